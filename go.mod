@@ -29,6 +29,10 @@ require (
 	// Powers autospec's command structure (init, config, workflow, etc.)
 	github.com/spf13/cobra v1.10.1
 
+	// Unified diff generation (36K)
+	// Used by commands diff to compare template overrides with embedded versions
+	github.com/pmezard/go-difflib v1.0.0
+
 	// ============================================================================
 	// TEST-ONLY DEPENDENCIES (NOT included in binary - only in *_test.go files)
 	// ============================================================================
@@ -62,7 +66,6 @@ require (
 	// Reflection and struct utilities
 	github.com/mitchellh/copystructure v1.2.0 // indirect; indirect - Deep copying of Go structures (32K)
 	github.com/mitchellh/reflectwalk v1.0.2 // indirect; indirect - Reflection-based struct walking (36K)
-	github.com/pmezard/go-difflib v1.0.0 // indirect; indirect - Diff library (36K source, 0 KB in binary)
 	github.com/spf13/pflag v1.0.9 // indirect; indirect - POSIX/GNU-style flags (312K)
 	golang.org/x/sys v0.39.0 // indirect - Low-level OS primitives (9.0M) ⚠️ LARGEST DEPENDENCY
 )
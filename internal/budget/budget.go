@@ -0,0 +1,149 @@
+// Package budget provides spending limits for agent token usage and cost.
+// Limits apply to a single stage execution, to a spec across all of its
+// stages, and to a whole run (a workflow invocation or a DAG run).
+// A zero limit means unlimited.
+package budget
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+)
+
+// Scope identifies which budget limit was exceeded.
+type Scope string
+
+// Budget scope constants.
+const (
+	// ScopeStage limits a single stage execution including its retries.
+	ScopeStage Scope = "stage"
+	// ScopeSpec limits all stages of a spec combined.
+	ScopeSpec Scope = "spec"
+	// ScopeRun limits a whole workflow invocation or DAG run.
+	ScopeRun Scope = "run"
+)
+
+// Config holds spending limits. Zero values disable the corresponding limit.
+type Config struct {
+	// MaxStageCostUSD caps the cost of a single stage execution including retries.
+	MaxStageCostUSD float64 `koanf:"max_stage_cost_usd" yaml:"max_stage_cost_usd,omitempty"`
+	// MaxStageTokens caps the tokens of a single stage execution including retries.
+	MaxStageTokens int `koanf:"max_stage_tokens" yaml:"max_stage_tokens,omitempty"`
+	// MaxSpecCostUSD caps the total cost recorded for a spec across all stages.
+	MaxSpecCostUSD float64 `koanf:"max_spec_cost_usd" yaml:"max_spec_cost_usd,omitempty"`
+	// MaxSpecTokens caps the total tokens recorded for a spec across all stages.
+	MaxSpecTokens int `koanf:"max_spec_tokens" yaml:"max_spec_tokens,omitempty"`
+	// MaxRunCostUSD caps the cost of a workflow invocation or DAG run.
+	MaxRunCostUSD float64 `koanf:"max_run_cost_usd" yaml:"max_run_cost_usd,omitempty"`
+	// MaxRunTokens caps the tokens of a workflow invocation or DAG run.
+	MaxRunTokens int `koanf:"max_run_tokens" yaml:"max_run_tokens,omitempty"`
+}
+
+// Enabled returns true if any limit is set.
+func (c Config) Enabled() bool {
+	return c.HasStageLimit() || c.HasSpecLimit() || c.HasRunLimit()
+}
+
+// HasStageLimit returns true if a per-stage limit is set.
+func (c Config) HasStageLimit() bool {
+	return c.MaxStageCostUSD > 0 || c.MaxStageTokens > 0
+}
+
+// HasSpecLimit returns true if a per-spec limit is set.
+func (c Config) HasSpecLimit() bool {
+	return c.MaxSpecCostUSD > 0 || c.MaxSpecTokens > 0
+}
+
+// HasRunLimit returns true if a per-run limit is set.
+func (c Config) HasRunLimit() bool {
+	return c.MaxRunCostUSD > 0 || c.MaxRunTokens > 0
+}
+
+// CheckStage returns an ExceededError if stage usage has reached a per-stage limit.
+func (c Config) CheckStage(stage string, spent cliagent.Usage) error {
+	return check(ScopeStage, stage, spent, c.MaxStageCostUSD, c.MaxStageTokens)
+}
+
+// CheckSpec returns an ExceededError if spec usage has reached a per-spec limit.
+func (c Config) CheckSpec(specName string, spent cliagent.Usage) error {
+	return check(ScopeSpec, specName, spent, c.MaxSpecCostUSD, c.MaxSpecTokens)
+}
+
+// CheckRun returns an ExceededError if run usage has reached a per-run limit.
+func (c Config) CheckRun(run string, spent cliagent.Usage) error {
+	return check(ScopeRun, run, spent, c.MaxRunCostUSD, c.MaxRunTokens)
+}
+
+// check compares spent usage against limits. A limit is reached once spending
+// is at or above it, so the next agent session is never started.
+func check(scope Scope, subject string, spent cliagent.Usage, maxCost float64, maxTokens int) error {
+	if maxCost > 0 && spent.CostUSD >= maxCost {
+		return &ExceededError{
+			Scope:   scope,
+			Subject: subject,
+			Metric:  MetricCost,
+			Limit:   maxCost,
+			Spent:   spent.CostUSD,
+		}
+	}
+	if maxTokens > 0 && spent.TotalTokens() >= maxTokens {
+		return &ExceededError{
+			Scope:   scope,
+			Subject: subject,
+			Metric:  MetricTokens,
+			Limit:   float64(maxTokens),
+			Spent:   float64(spent.TotalTokens()),
+		}
+	}
+	return nil
+}
+
+// Metric constants identify which counter reached its limit.
+const (
+	MetricCost   = "cost"
+	MetricTokens = "tokens"
+)
+
+// ExceededError is returned when spending reaches a configured limit.
+type ExceededError struct {
+	// Scope is the limit that was reached (stage, spec, or run).
+	Scope Scope
+	// Subject names what was limited: a stage name, spec name, or run identifier.
+	Subject string
+	// Metric is MetricCost or MetricTokens.
+	Metric string
+	// Limit is the configured limit.
+	Limit float64
+	// Spent is the amount spent when the limit was detected.
+	Spent float64
+}
+
+// Error implements the error interface.
+func (e *ExceededError) Error() string {
+	subject := ""
+	if e.Subject != "" {
+		subject = " for " + e.Subject
+	}
+	if e.Metric == MetricCost {
+		return fmt.Sprintf("%s budget exceeded%s: spent $%.2f of $%.2f limit", e.Scope, subject, e.Spent, e.Limit)
+	}
+	return fmt.Sprintf("%s budget exceeded%s: used %d of %d token limit", e.Scope, subject, int(e.Spent), int(e.Limit))
+}
+
+// ConfigKey returns the config key controlling the exceeded limit.
+func (e *ExceededError) ConfigKey() string {
+	if e.Metric == MetricCost {
+		return fmt.Sprintf("budget.max_%s_cost_usd", e.Scope)
+	}
+	return fmt.Sprintf("budget.max_%s_tokens", e.Scope)
+}
+
+// AsExceeded returns the ExceededError in err's chain, if any.
+func AsExceeded(err error) (*ExceededError, bool) {
+	var exceeded *ExceededError
+	if errors.As(err, &exceeded) {
+		return exceeded, true
+	}
+	return nil, false
+}
@@ -0,0 +1,107 @@
+package budget
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		cfg        Config
+		check      func(Config, cliagent.Usage) error
+		spent      cliagent.Usage
+		wantErr    bool
+		wantScope  Scope
+		wantMetric string
+		wantKey    string
+	}{
+		"no limits never exceeded": {
+			check: func(c Config, u cliagent.Usage) error { return c.CheckRun("run", u) },
+			spent: cliagent.Usage{InputTokens: 1_000_000, CostUSD: 100},
+		},
+		"stage cost below limit": {
+			cfg:   Config{MaxStageCostUSD: 1},
+			check: func(c Config, u cliagent.Usage) error { return c.CheckStage("plan", u) },
+			spent: cliagent.Usage{CostUSD: 0.99},
+		},
+		"stage cost at limit": {
+			cfg:        Config{MaxStageCostUSD: 1},
+			check:      func(c Config, u cliagent.Usage) error { return c.CheckStage("plan", u) },
+			spent:      cliagent.Usage{CostUSD: 1},
+			wantErr:    true,
+			wantScope:  ScopeStage,
+			wantMetric: MetricCost,
+			wantKey:    "budget.max_stage_cost_usd",
+		},
+		"spec tokens over limit": {
+			cfg:        Config{MaxSpecTokens: 100},
+			check:      func(c Config, u cliagent.Usage) error { return c.CheckSpec("001-feature", u) },
+			spent:      cliagent.Usage{InputTokens: 80, OutputTokens: 30},
+			wantErr:    true,
+			wantScope:  ScopeSpec,
+			wantMetric: MetricTokens,
+			wantKey:    "budget.max_spec_tokens",
+		},
+		"run cost checked before tokens": {
+			cfg:        Config{MaxRunCostUSD: 5, MaxRunTokens: 10},
+			check:      func(c Config, u cliagent.Usage) error { return c.CheckRun("dag.yaml", u) },
+			spent:      cliagent.Usage{OutputTokens: 50, CostUSD: 6},
+			wantErr:    true,
+			wantScope:  ScopeRun,
+			wantMetric: MetricCost,
+			wantKey:    "budget.max_run_cost_usd",
+		},
+		"spec limit ignored by stage check": {
+			cfg:   Config{MaxSpecCostUSD: 1},
+			check: func(c Config, u cliagent.Usage) error { return c.CheckStage("plan", u) },
+			spent: cliagent.Usage{CostUSD: 5},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.check(tt.cfg, tt.spent)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			exceeded, ok := AsExceeded(err)
+			require.True(t, ok, "expected ExceededError, got %v", err)
+			assert.Equal(t, tt.wantScope, exceeded.Scope)
+			assert.Equal(t, tt.wantMetric, exceeded.Metric)
+			assert.Equal(t, tt.wantKey, exceeded.ConfigKey())
+		})
+	}
+}
+
+func TestExceededError_Error(t *testing.T) {
+	t.Parallel()
+
+	costErr := &ExceededError{Scope: ScopeRun, Subject: "dag.yaml", Metric: MetricCost, Limit: 10, Spent: 12.5}
+	assert.Equal(t, "run budget exceeded for dag.yaml: spent $12.50 of $10.00 limit", costErr.Error())
+
+	tokenErr := &ExceededError{Scope: ScopeStage, Metric: MetricTokens, Limit: 1000, Spent: 1200}
+	assert.Equal(t, "stage budget exceeded: used 1200 of 1000 token limit", tokenErr.Error())
+
+	wrapped := fmt.Errorf("executing plan stage: %w", costErr)
+	got, ok := AsExceeded(wrapped)
+	require.True(t, ok)
+	assert.Same(t, costErr, got)
+}
+
+func TestConfig_Enabled(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, Config{}.Enabled())
+	assert.True(t, Config{MaxStageTokens: 1}.Enabled())
+	assert.True(t, Config{MaxSpecCostUSD: 0.5}.Enabled())
+	assert.True(t, Config{MaxRunTokens: 1}.HasRunLimit())
+	assert.False(t, Config{MaxRunTokens: 1}.HasStageLimit())
+}
@@ -5,9 +5,12 @@ package admin
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/ariel-frischer/autospec/internal/commands"
 	"github.com/ariel-frischer/autospec/internal/uninstall"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -70,6 +73,10 @@ func TestCommandsCmd_Subcommands(t *testing.T) {
 			subcommand: "info",
 			shouldHave: true,
 		},
+		"has diff subcommand": {
+			subcommand: "diff",
+			shouldHave: true,
+		},
 		"does not have invalid subcommand": {
 			subcommand: "nonexistent",
 			shouldHave: false,
@@ -209,6 +216,67 @@ func TestCommandsCheckCmd_RunE(t *testing.T) {
 	assert.NotNil(t, commandsCheckCmd.RunE, "RunE should be defined")
 }
 
+// =============================================================================
+// commands_diff.go Tests
+// =============================================================================
+
+func TestDiffCommand(t *testing.T) {
+	embedded, err := commands.GetTemplate("autospec.analyze")
+	require.NoError(t, err)
+	override := strings.Replace(string(embedded), `version: "1.0.0"`, `version: "0.9.0"`, 1) + "Also check naming.\n"
+
+	tests := map[string]struct {
+		name     string
+		files    map[string]string
+		contains []string
+		wantErr  bool
+	}{
+		"no overrides": {
+			name:     "autospec.analyze",
+			contains: []string{"No overrides for autospec.analyze"},
+		},
+		"override differs from embedded": {
+			name:  "autospec.analyze",
+			files: map[string]string{"autospec.analyze.md": override},
+			contains: []string{
+				"project override: ",
+				"Based on v0.9.0; embedded template is v1.0.0",
+				"+Also check naming.",
+			},
+		},
+		"identical override": {
+			name:     "autospec.analyze",
+			files:    map[string]string{"autospec.analyze.md": string(embedded)},
+			contains: []string{"Identical to the embedded template."},
+		},
+		"unknown command": {
+			name:    "autospec.missing",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			for file, content := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0o644))
+			}
+			layers := []commands.TemplateLayer{{Source: commands.SourceProject, Dir: dir}}
+
+			var buf bytes.Buffer
+			err := diffCommand(&buf, tt.name, layers)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for _, want := range tt.contains {
+				assert.Contains(t, buf.String(), want)
+			}
+		})
+	}
+}
+
 // =============================================================================
 // commands_info.go Tests
 // =============================================================================
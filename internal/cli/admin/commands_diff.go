@@ -0,0 +1,70 @@
+package admin
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ariel-frischer/autospec/internal/commands"
+	"github.com/spf13/cobra"
+)
+
+var commandsDiffCmd = &cobra.Command{
+	Use:   "diff <command-name>",
+	Short: "Compare a command template override with the embedded version",
+	Long: `Show a unified diff from the embedded command template to each override of it.
+
+Overrides are read from the user config directory (e.g. ~/.config/autospec/commands/)
+and the project .autospec/commands/ directory. Run this after upgrading autospec
+to see how your overrides differ from the new upstream template.
+
+Example:
+  autospec commands diff autospec.implement`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCommandsDiff,
+}
+
+func init() {
+	commandsCmd.AddCommand(commandsDiffCmd)
+}
+
+func runCommandsDiff(cmd *cobra.Command, args []string) error {
+	return diffCommand(cmd.OutOrStdout(), args[0], commands.TemplateLayers())
+}
+
+func diffCommand(out io.Writer, name string, layers []commands.TemplateLayer) error {
+	tpl, err := commands.GetTemplateInfo(name)
+	if err != nil {
+		return fmt.Errorf("command not found: %s", name)
+	}
+
+	overrides, err := commands.FindOverrides(name, layers)
+	if err != nil {
+		return fmt.Errorf("finding overrides: %w", err)
+	}
+	if len(overrides) == 0 {
+		fmt.Fprintf(out, "No overrides for %s; the embedded template (v%s) is used.\n", name, tpl.Version)
+		return nil
+	}
+
+	for i, override := range overrides {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		fmt.Fprintf(out, "%s override: %s\n", override.Source, override.Path)
+		if _, version, err := commands.ParseTemplateFrontmatter(override.Content); err == nil && version != "" && version != tpl.Version {
+			fmt.Fprintf(out, "Based on v%s; embedded template is v%s\n", version, tpl.Version)
+		}
+
+		diff, err := commands.DiffOverride(name, override)
+		if err != nil {
+			return err
+		}
+		if diff == "" {
+			fmt.Fprintln(out, "Identical to the embedded template.")
+			continue
+		}
+		fmt.Fprintln(out)
+		fmt.Fprint(out, diff)
+	}
+	return nil
+}
@@ -81,6 +81,9 @@ func showCommandDetail(cmd *cobra.Command, name string) error {
 	fmt.Fprintf(cmd.OutOrStdout(), "Description: %s\n", tpl.Description)
 	fmt.Fprintf(cmd.OutOrStdout(), "Version: %s\n", tpl.Version)
 	fmt.Fprintf(cmd.OutOrStdout(), "Size: %d bytes\n", len(tpl.Content))
+	if source, path, err := commands.TemplateSource(name, commands.TemplateLayers()); err == nil && path != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "Source: %s override (%s)\n", source, path)
+	}
 	fmt.Fprintln(cmd.OutOrStdout())
 
 	fmt.Fprintln(cmd.OutOrStdout(), "Usage:")
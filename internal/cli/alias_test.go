@@ -53,6 +53,10 @@ func TestCommandAliases(t *testing.T) {
 			commandName:   "analyze",
 			expectedAlias: []string{"az"},
 		},
+		"review command has alias rv": {
+			commandName:   "review",
+			expectedAlias: []string{"rv"},
+		},
 		"version command has alias v": {
 			commandName:   "version",
 			expectedAlias: []string{"v"},
@@ -100,6 +104,7 @@ func TestAliasResolution(t *testing.T) {
 		"cl resolves to clarify":         {alias: "cl", commandName: "clarify"},
 		"chk resolves to checklist":      {alias: "chk", commandName: "checklist"},
 		"az resolves to analyze":         {alias: "az", commandName: "analyze"},
+		"rv resolves to review":          {alias: "rv", commandName: "review"},
 		"v resolves to version":          {alias: "v", commandName: "version"},
 	}
 
@@ -10,6 +10,7 @@ import (
 	"github.com/ariel-frischer/autospec/internal/config"
 	"github.com/ariel-frischer/autospec/internal/spec"
 	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/ariel-frischer/autospec/internal/workflow"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
@@ -36,12 +37,17 @@ Types:
   analysis     - Cross-artifact analysis (analysis.yaml)
   checklist    - Feature quality checklist (checklists/*.yaml)
   constitution - Project constitution (.autospec/memory/constitution.yaml)
+  review       - Implementation review findings (review.yaml)
 
 Validates:
   - Valid YAML syntax
   - Required fields present for artifact type
   - Field types correct (strings, lists, enums)
   - Cross-references valid (e.g. task dependencies exist)
+  - Tasks: each EARS requirement whose test kind is enabled by the
+    verification config has a test task (requirement_id)
+  - Project extensions in .autospec/schemas/<type>.yaml (extra required
+    fields, enums, patterns)
 
 Output:
   - Shows which spec is being used (with fallback indicator if applicable)
@@ -68,7 +74,10 @@ Exit Codes:
 
   # Show schema for an artifact type
   autospec artifact spec --schema
-  autospec artifact constitution --schema
+  autospec artifact constitution --schema   # Includes .autospec/schemas/constitution.yaml
+
+  # JSON Schema for editors (also: dag, config)
+  autospec artifact schema --format jsonschema spec
 
   # Auto-fix common issues
   autospec artifact specs/001-feature/plan.yaml --fix`,
@@ -228,6 +237,12 @@ func runArtifactCommand(args []string, configPath string, out, errOut io.Writer)
 	// Run validation
 	result := validator.Validate(parsed.filePath)
 
+	// Tasks must trace each enabled EARS requirement to a test task
+	if parsed.artType == validation.ArtifactTypeTasks && result.Valid {
+		specPath := validation.GetSpecFilePath(filepath.Dir(parsed.filePath))
+		validation.CheckEarsTraceability(parsed.filePath, specPath, workflow.EarsTestKinds(&cfg.Verification), result)
+	}
+
 	// Format and display results
 	return formatValidationResult(result, parsed.filePath, parsed.artType, out, errOut)
 }
@@ -241,9 +256,10 @@ func printSpecIdentification(parsed *artifactArgs, out io.Writer) {
 	fmt.Fprintln(out, parsed.specMetadata.FormatInfo())
 }
 
-// printSchema prints the schema for an artifact type.
+// printSchema prints the schema for an artifact type, including the project
+// extension from .autospec/schemas/<type>.yaml if present.
 func printSchema(artType validation.ArtifactType, out io.Writer) error {
-	schema, err := validation.GetSchema(artType)
+	schema, err := validation.GetEffectiveSchema(artType)
 	if err != nil {
 		return fmt.Errorf("getting schema for %s: %w", artType, err)
 	}
@@ -251,6 +267,9 @@ func printSchema(artType validation.ArtifactType, out io.Writer) error {
 	fmt.Fprintf(out, "Schema for %s artifacts\n", artType)
 	fmt.Fprintf(out, "%s\n\n", strings.Repeat("=", 40))
 	fmt.Fprintf(out, "%s\n\n", schema.Description)
+	if _, statErr := os.Stat(validation.SchemaExtensionPath(artType)); statErr == nil {
+		fmt.Fprintf(out, "Extended by %s\n\n", validation.SchemaExtensionPath(artType))
+	}
 
 	fmt.Fprintf(out, "Fields:\n")
 	fmt.Fprintf(out, "%s\n", strings.Repeat("-", 40))
@@ -264,9 +283,16 @@ func printSchema(artType validation.ArtifactType, out io.Writer) error {
 
 // printSchemaField prints a single schema field with indentation.
 func printSchemaField(field validation.SchemaField, indent string, out io.Writer) {
-	required := ""
+	var notes []string
 	if field.Required {
-		required = " (required)"
+		notes = append(notes, "required")
+	}
+	if field.Pattern != "" {
+		notes = append(notes, "pattern "+field.Pattern)
+	}
+	suffix := ""
+	if len(notes) > 0 {
+		suffix = " (" + strings.Join(notes, ", ") + ")"
 	}
 
 	typeStr := string(field.Type)
@@ -274,7 +300,7 @@ func printSchemaField(field validation.SchemaField, indent string, out io.Writer
 		typeStr = fmt.Sprintf("enum[%s]", strings.Join(field.Enum, ", "))
 	}
 
-	fmt.Fprintf(out, "%s%s: %s%s\n", indent, field.Name, typeStr, required)
+	fmt.Fprintf(out, "%s%s: %s%s\n", indent, field.Name, typeStr, suffix)
 
 	if field.Description != "" {
 		fmt.Fprintf(out, "%s  # %s\n", indent, field.Description)
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/dag"
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/ariel-frischer/autospec/internal/yaml"
+	"github.com/spf13/cobra"
+)
+
+const (
+	schemaFormatText       = "text"
+	schemaFormatJSONSchema = "jsonschema"
+)
+
+var artifactSchemaCmd = &cobra.Command{
+	Use:   "schema <type>",
+	Short: "Print the schema of an artifact type, dag.yaml, or config",
+	Long: `Print the schema of an artifact type, dag.yaml, or the autospec config.
+
+Types:
+  spec, plan, tasks, analysis, checklist, constitution, review
+  dag    - DAG files (dag.yaml), generated from the DAG file format
+  config - .autospec/config.yml and the user config, generated from 'autospec config keys'
+
+Formats:
+  text       - Field outline (artifact types only, same as 'autospec artifact <type> --schema')
+  jsonschema - JSON Schema (draft-07) for editors and other tools
+
+Artifact schemas include project extensions from .autospec/schemas/<type>.yaml.
+
+With --write, the JSON Schema is saved to .autospec/schemas/json/<type>.schema.json,
+the file yaml-language-server modelines in generated artifacts point to.`,
+	Example: `  # JSON Schema for spec.yaml on stdout
+  autospec artifact schema --format jsonschema spec
+
+  # Save the config schema for editors
+  autospec artifact schema --format jsonschema --write config
+
+  # Field outline
+  autospec artifact schema plan`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		write, _ := cmd.Flags().GetBool("write")
+		return runArtifactSchema(args[0], format, write, cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	artifactCmd.AddCommand(artifactSchemaCmd)
+	artifactSchemaCmd.Flags().String("format", schemaFormatText, "Output format: text, jsonschema")
+	artifactSchemaCmd.Flags().Bool("write", false, "Write the JSON Schema to .autospec/schemas/json/ instead of stdout")
+}
+
+// runArtifactSchema prints or writes the schema called name in format.
+func runArtifactSchema(name, format string, write bool, out io.Writer) error {
+	if format != schemaFormatText && format != schemaFormatJSONSchema {
+		return fmt.Errorf("invalid format %q: must be one of: %s, %s", format, schemaFormatText, schemaFormatJSONSchema)
+	}
+	if write && format != schemaFormatJSONSchema {
+		return fmt.Errorf("--write requires --format %s", schemaFormatJSONSchema)
+	}
+
+	if format == schemaFormatText {
+		if name == "dag" || name == "config" {
+			return fmt.Errorf("the %s schema is only available with --format %s", name, schemaFormatJSONSchema)
+		}
+		artType, err := parseSchemaArtifactType(name)
+		if err != nil {
+			return err
+		}
+		return printSchema(artType, out)
+	}
+
+	doc, err := jsonSchemaFor(name)
+	if err != nil {
+		return err
+	}
+	if write {
+		path := validation.JSONSchemaPath(name)
+		if err := validation.WriteJSONSchemaFile(path, doc); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Wrote %s\n", path)
+		fmt.Fprintf(out, "Reference it from a YAML file with:\n  %s<path to %s>\n", yaml.SchemaModelinePrefix, path)
+		return nil
+	}
+	data, err := validation.MarshalJSONSchema(doc)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(data)
+	return err
+}
+
+// jsonSchemaFor returns the JSON Schema called name.
+func jsonSchemaFor(name string) (map[string]any, error) {
+	switch name {
+	case "dag":
+		return dag.JSONSchema(), nil
+	case "config":
+		return config.JSONSchema(), nil
+	}
+	artType, err := parseSchemaArtifactType(name)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := validation.GetEffectiveSchema(artType)
+	if err != nil {
+		return nil, fmt.Errorf("getting schema for %s: %w", name, err)
+	}
+	return validation.ArtifactJSONSchema(schema), nil
+}
+
+// parseSchemaArtifactType parses an artifact type, listing dag and config
+// among the valid types when name is unknown.
+func parseSchemaArtifactType(name string) (validation.ArtifactType, error) {
+	if _, err := validation.ParseArtifactType(name); err != nil {
+		return "", fmt.Errorf("unknown schema type %q: valid types are %s, dag, config",
+			name, strings.Join(validation.ValidArtifactTypes(), ", "))
+	}
+	return validation.ArtifactType(name), nil
+}
@@ -0,0 +1,92 @@
+// Package cli_test tests the artifact schema command for text and JSON Schema output.
+// Related: internal/cli/artifact_schema.go
+// Tags: cli, artifact, schema, json-schema, dag, config
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+)
+
+func TestRunArtifactSchema(t *testing.T) {
+	tests := map[string]struct {
+		name        string
+		format      string
+		wantTitle   string
+		wantOutput  string
+		errContains string
+	}{
+		"artifact JSON Schema": {name: "spec", format: "jsonschema", wantTitle: "autospec spec artifact"},
+		"dag JSON Schema":      {name: "dag", format: "jsonschema", wantTitle: "autospec DAG file"},
+		"config JSON Schema":   {name: "config", format: "jsonschema", wantTitle: "autospec configuration"},
+		"artifact text":        {name: "plan", format: "text", wantOutput: "Schema for plan artifacts"},
+		"dag text": {
+			name: "dag", format: "text",
+			errContains: "only available with --format jsonschema",
+		},
+		"unknown type": {
+			name: "roadmap", format: "jsonschema",
+			errContains: `unknown schema type "roadmap": valid types are spec, plan, tasks, analysis, checklist, constitution, review, dag, config`,
+		},
+		"invalid format": {
+			name: "spec", format: "yaml",
+			errContains: `invalid format "yaml"`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := runArtifactSchema(tt.name, tt.format, false, &out)
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Fatalf("runArtifactSchema() error = %v, want containing %q", err, tt.errContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runArtifactSchema() unexpected error: %v", err)
+			}
+
+			if tt.wantOutput != "" && !strings.Contains(out.String(), tt.wantOutput) {
+				t.Errorf("output should contain %q, got: %s", tt.wantOutput, out.String())
+			}
+			if tt.wantTitle != "" {
+				var doc map[string]any
+				if err := json.Unmarshal(out.Bytes(), &doc); err != nil {
+					t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+				}
+				if doc["$schema"] != validation.JSONSchemaDraft || doc["title"] != tt.wantTitle {
+					t.Errorf("$schema = %v, title = %v, want %s", doc["$schema"], doc["title"], tt.wantTitle)
+				}
+			}
+		})
+	}
+}
+
+func TestRunArtifactSchema_Write(t *testing.T) {
+	tmpDir := t.TempDir()
+	origWd, _ := os.Getwd()
+	defer os.Chdir(origWd)
+	os.Chdir(tmpDir)
+
+	var out bytes.Buffer
+	if err := runArtifactSchema("tasks", "text", true, &out); err == nil {
+		t.Error("expected error for --write without --format jsonschema")
+	}
+
+	if err := runArtifactSchema("tasks", "jsonschema", true, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Wrote .autospec/schemas/json/tasks.schema.json") {
+		t.Errorf("output should name the written file, got: %s", out.String())
+	}
+	if _, err := os.Stat(validation.JSONSchemaPath("tasks")); err != nil {
+		t.Errorf("schema file not written: %v", err)
+	}
+}
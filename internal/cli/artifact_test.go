@@ -236,6 +236,37 @@ func TestArtifactCommand_SchemaTasks(t *testing.T) {
 	}
 }
 
+func TestPrintSchema_ProjectExtension(t *testing.T) {
+	tmpDir := t.TempDir()
+	origWd, _ := os.Getwd()
+	defer os.Chdir(origWd)
+	os.Chdir(tmpDir)
+
+	if err := os.MkdirAll(".autospec/schemas", 0o755); err != nil {
+		t.Fatalf("failed to create schemas dir: %v", err)
+	}
+	extension := "fields:\n  - name: feature\n    children:\n      - name: ticket\n        type: string\n        required: true\n        pattern: \"^PROJ-\\\\d+$\"\n"
+	if err := os.WriteFile(".autospec/schemas/spec.yaml", []byte(extension), 0o644); err != nil {
+		t.Fatalf("failed to write extension: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := printSchema(validation.ArtifactTypeSpec, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := out.String()
+	if !strings.Contains(output, "Extended by .autospec/schemas/spec.yaml") {
+		t.Errorf("output should mention the extension file, got: %s", output)
+	}
+	if !strings.Contains(output, "  ticket: string (required, pattern ^PROJ-\\d+$)") {
+		t.Errorf("output should contain the extension field, got: %s", output)
+	}
+	if !strings.Contains(output, "user_stories") {
+		t.Errorf("output should still contain built-in fields, got: %s", output)
+	}
+}
+
 func TestArtifactCommand_CircularDependency(t *testing.T) {
 	var stdout, stderr bytes.Buffer
 	testFile := filepath.Join("..", "validation", "testdata", "tasks", "invalid_dep_circular.yaml")
@@ -454,3 +485,71 @@ technical_context:
 		t.Errorf("stdout should contain 'is valid', got: %s", stdout.String())
 	}
 }
+
+func TestArtifactCommand_TasksEarsTraceability(t *testing.T) {
+	tmpDir := t.TempDir()
+	specDir := filepath.Join(tmpDir, "specs", "001-ears")
+	if err := os.MkdirAll(specDir, 0o755); err != nil {
+		t.Fatalf("failed to create spec dir: %v", err)
+	}
+	specContent := `ears_requirements:
+  - id: "EARS-001"
+    pattern: "ubiquitous"
+    text: "The system shall validate all inputs"
+    test_type: "invariant"
+`
+	if err := os.WriteFile(filepath.Join(specDir, "spec.yaml"), []byte(specContent), 0o644); err != nil {
+		t.Fatalf("failed to create spec.yaml: %v", err)
+	}
+	tasksPath := filepath.Join(specDir, "tasks.yaml")
+	tasksContent := `tasks:
+  branch: "001-ears"
+summary:
+  total_tasks: 1
+phases:
+  - number: 1
+    title: "Core"
+    tasks:
+      - id: "T001"
+        title: "Implement validation"
+        status: "Pending"
+        type: "implementation"
+`
+	if err := os.WriteFile(tasksPath, []byte(tasksContent), 0o644); err != nil {
+		t.Fatalf("failed to create tasks.yaml: %v", err)
+	}
+
+	tests := map[string]struct {
+		level   string
+		wantErr bool
+	}{
+		"basic level skips traceability":   {level: "basic", wantErr: false},
+		"enhanced level requires contract": {level: "enhanced", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			configFile := filepath.Join(t.TempDir(), "config.yml")
+			configContent := fmt.Sprintf("verification:\n  level: %s\n", tc.level)
+			if err := os.WriteFile(configFile, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("failed to create config: %v", err)
+			}
+
+			var stdout, stderr bytes.Buffer
+			err := runArtifactCommand([]string{"tasks", tasksPath}, configFile, &stdout, &stderr)
+
+			if !tc.wantErr {
+				if err != nil {
+					t.Errorf("unexpected error: %v\nstderr: %s", err, stderr.String())
+				}
+				return
+			}
+			if code := ExitCode(err); code != ExitValidationFailed {
+				t.Errorf("exit code = %d, want %d", code, ExitValidationFailed)
+			}
+			if !strings.Contains(stderr.String(), "EARS requirement EARS-001 (invariant) has no contract test task") {
+				t.Errorf("stderr should report the untested requirement, got: %s", stderr.String())
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/ariel-frischer/autospec/internal/cli/shared"
@@ -80,6 +81,7 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 	fmt.Fprintf(out, "# User config:    %s\n", userPath)
 	fmt.Fprintf(out, "# Project config: %s\n", projectPath)
 	fmt.Fprintf(out, "\n")
+	printStageAgents(out, cfg)
 
 	if useJSON {
 		data, err := json.MarshalIndent(configMap, "", "  ")
@@ -98,6 +100,21 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// printStageAgents writes the effective agent for each stage as comment lines,
+// marking stages that override the top-level agent via stages.<name>.agent.
+func printStageAgents(out io.Writer, cfg *config.Configuration) {
+	agents := cfg.EffectiveStageAgents()
+	fmt.Fprintf(out, "# Effective Stage Agents\n")
+	for _, stage := range append(append([]string{}, config.StageNames...), cfg.CustomStageNames()...) {
+		suffix := ""
+		if cfg.StageConfigFor(stage).Agent != "" {
+			suffix = " (stages." + stage + ".agent)"
+		}
+		fmt.Fprintf(out, "# %-13s %s%s\n", stage+":", agents[stage], suffix)
+	}
+	fmt.Fprintf(out, "\n")
+}
+
 // fileExistsCheck returns true if the file exists
 func fileExistsCheck(path string) bool {
 	_, err := os.Stat(path)
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/ariel-frischer/autospec/internal/config"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
@@ -223,3 +224,20 @@ func TestConfigShow_VerificationJSONStructure(t *testing.T) {
 	assert.Contains(t, output, `"CoverageThreshold"`, "JSON output should contain CoverageThreshold")
 	assert.Contains(t, output, `"ComplexityMax"`, "JSON output should contain ComplexityMax")
 }
+
+func TestPrintStageAgents(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Configuration{
+		AgentPreset: "claude",
+		Stages:      map[string]config.StageConfig{"tasks": {Agent: "gemini"}},
+	}
+
+	var buf bytes.Buffer
+	printStageAgents(&buf, cfg)
+
+	output := buf.String()
+	assert.Contains(t, output, "# Effective Stage Agents")
+	assert.Contains(t, output, "# tasks:        gemini (stages.tasks.agent)")
+	assert.Contains(t, output, "# plan:         claude\n")
+}
@@ -40,6 +40,20 @@ Merge behavior:
 - Dependencies are merged before their dependents
 - Conflicts pause the merge for resolution
 
+Merge strategies (--strategy, default from dag.merge_strategy):
+  no-ff   Merge commit for each merge (default)
+  squash  One commit per spec with a generated conventional message; with
+          layer staging the per-spec staging commits are rebased onto the
+          target branch
+  rebase  Rebase onto the target branch, then fast-forward (linear history)
+
+Pull request mode (--pr):
+- Pushes each spec branch, or the final staging branch with layer staging,
+  to dag.remote and opens a pull request against the target branch
+- Providers: github, gitlab, gitea (dag.pr_provider, detected from the remote URL)
+- PR URLs and review state are stored in the spec merge state; run again to
+  push new commits and refresh review state shown by dag status
+
 Exit codes:
   0 - All specs merged successfully
   1 - One or more specs failed to merge or verification failed
@@ -50,6 +64,9 @@ Exit codes:
   # Merge to a specific branch
   autospec dag merge .autospec/dags/my-workflow.yaml --branch develop
 
+  # Land one commit per spec on the target branch
+  autospec dag merge .autospec/dags/my-workflow.yaml --strategy squash
+
   # Skip specs with no commits ahead of target
   autospec dag merge .autospec/dags/my-workflow.yaml --skip-no-commits
 
@@ -66,19 +83,24 @@ Exit codes:
   autospec dag merge .autospec/dags/my-workflow.yaml --cleanup
 
   # Reset merge status and re-merge all specs
-  autospec dag merge .autospec/dags/my-workflow.yaml --reset`,
+  autospec dag merge .autospec/dags/my-workflow.yaml --reset
+
+  # Open pull requests instead of merging locally
+  autospec dag merge .autospec/dags/my-workflow.yaml --pr`,
 	Args: cobra.ExactArgs(1),
 	RunE: runDagMerge,
 }
 
 func init() {
 	mergeCmd.Flags().String("branch", "", "Target branch for merging (default: main)")
+	mergeCmd.Flags().String("strategy", "", "Merge strategy: no-ff, squash, rebase (overrides dag.merge_strategy)")
 	mergeCmd.Flags().Bool("continue", false, "Continue merge after manual conflict resolution")
 	mergeCmd.Flags().Bool("skip-failed", false, "Skip specs that failed to merge and continue")
 	mergeCmd.Flags().Bool("skip-no-commits", false, "Skip specs with no commits ahead of target branch")
 	mergeCmd.Flags().Bool("force", false, "Bypass pre-flight verification (not recommended)")
 	mergeCmd.Flags().Bool("cleanup", false, "Remove worktrees after successful merge")
 	mergeCmd.Flags().Bool("reset", false, "Reset all merge status markers before merging")
+	mergeCmd.Flags().Bool("pr", false, "Push branches and open pull requests instead of merging locally")
 	DagCmd.AddCommand(mergeCmd)
 }
 
@@ -87,12 +109,14 @@ func runDagMerge(cmd *cobra.Command, args []string) error {
 
 	workflowPath := args[0]
 	targetBranch, _ := cmd.Flags().GetString("branch")
+	strategy, _ := cmd.Flags().GetString("strategy")
 	continueMode, _ := cmd.Flags().GetBool("continue")
 	skipFailed, _ := cmd.Flags().GetBool("skip-failed")
 	skipNoCommits, _ := cmd.Flags().GetBool("skip-no-commits")
 	force, _ := cmd.Flags().GetBool("force")
 	cleanup, _ := cmd.Flags().GetBool("cleanup")
 	reset, _ := cmd.Flags().GetBool("reset")
+	pr, _ := cmd.Flags().GetBool("pr")
 
 	if workflowPath == "" {
 		cliErr := clierrors.NewArgumentError("workflow-file is required")
@@ -105,11 +129,20 @@ func runDagMerge(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("loading config: %w", err)
 	}
 
+	if strategy == "" {
+		strategy = dag.LoadDAGConfig(cfg.DAG).MergeStrategy
+	}
+	if !dag.IsValidMergeStrategy(strategy) {
+		cliErr := clierrors.NewArgumentError(fmt.Sprintf("invalid merge strategy %q: must be one of: no-ff, squash, rebase", strategy))
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+
 	notifHandler := notify.NewHandler(cfg.Notifications)
 	historyLogger := history.NewWriter(cfg.StateDir, cfg.MaxHistoryEntries)
 
 	return lifecycle.RunWithHistoryContext(cmd.Context(), notifHandler, historyLogger, "dag-merge", workflowPath, func(ctx context.Context) error {
-		return executeDagMerge(ctx, cfg, workflowPath, targetBranch, continueMode, skipFailed, skipNoCommits, force, cleanup, reset)
+		return executeDagMerge(ctx, cfg, workflowPath, targetBranch, dag.MergeStrategy(strategy), continueMode, skipFailed, skipNoCommits, force, cleanup, reset, pr)
 	})
 }
 
@@ -117,7 +150,8 @@ func executeDagMerge(
 	ctx context.Context,
 	cfg *config.Configuration,
 	workflowPath, targetBranch string,
-	continueMode, skipFailed, skipNoCommits, force, cleanup, reset bool,
+	strategy dag.MergeStrategy,
+	continueMode, skipFailed, skipNoCommits, force, cleanup, reset, pr bool,
 ) error {
 	stateDir := dag.GetStateDir()
 
@@ -154,7 +188,16 @@ func executeDagMerge(
 
 	printMergeHeader(run, targetBranch)
 
-	mergeExec := buildMergeExecutor(stateDir, manager, repoRoot, targetBranch, continueMode, skipFailed, skipNoCommits, force, cleanup)
+	opts := conflictResolutionOptions(cfg)
+	if pr {
+		prOpt, err := pullRequestOption(ctx, cfg, repoRoot)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, prOpt)
+	}
+
+	mergeExec := buildMergeExecutor(stateDir, manager, repoRoot, targetBranch, strategy, continueMode, skipFailed, skipNoCommits, force, cleanup, opts...)
 	if err := mergeExec.Merge(ctx, run, dagConfig); err != nil {
 		// Save state on error too (partial merge state should be persisted)
 		if usingInlineState {
@@ -231,20 +274,57 @@ func buildMergeExecutor(
 	stateDir string,
 	manager worktree.Manager,
 	repoRoot, targetBranch string,
+	strategy dag.MergeStrategy,
 	continueMode, skipFailed, skipNoCommits, force, cleanup bool,
+	extra ...dag.MergeExecutorOption,
 ) *dag.MergeExecutor {
-	return dag.NewMergeExecutor(
-		stateDir,
-		manager,
-		repoRoot,
+	opts := []dag.MergeExecutorOption{
 		dag.WithMergeStdout(os.Stdout),
 		dag.WithMergeTargetBranch(targetBranch),
+		dag.WithMergeStrategy(strategy),
 		dag.WithMergeContinue(continueMode),
 		dag.WithMergeSkipFailed(skipFailed),
 		dag.WithMergeSkipNoCommits(skipNoCommits),
 		dag.WithMergeForce(force),
 		dag.WithMergeCleanup(cleanup),
-	)
+	}
+	return dag.NewMergeExecutor(stateDir, manager, repoRoot, append(opts, extra...)...)
+}
+
+// conflictResolutionOptions returns the merge options for dag.on_conflict and
+// dag.conflict_verify_cmd. Agent resolution falls back to manual when the
+// configured agent is unavailable.
+func conflictResolutionOptions(cfg *config.Configuration) []dag.MergeExecutorOption {
+	dagCfg := dag.LoadDAGConfig(cfg.DAG)
+	opts := []dag.MergeExecutorOption{
+		dag.WithMergeConflictVerifyCmd(dagCfg.ConflictVerifyCmd),
+		dag.WithMergeLogBase(dagCfg.LogDir),
+	}
+	if dag.OnConflict(dagCfg.OnConflict) != dag.OnConflictAgent {
+		return opts
+	}
+
+	agent, err := cfg.GetAgent()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: agent conflict resolution unavailable, using manual: %v\n", err)
+		return opts
+	}
+	return append(opts, dag.WithMergeOnConflict(dag.OnConflictAgent), dag.WithMergeAgent(agent))
+}
+
+// pullRequestOption configures the pull request provider for --pr from the
+// dag.remote and dag.pr_* settings.
+func pullRequestOption(ctx context.Context, cfg *config.Configuration, repoRoot string) (dag.MergeExecutorOption, error) {
+	dagCfg := dag.LoadDAGConfig(cfg.DAG)
+	prCfg, err := dag.ResolvePRProviderConfig(ctx, dagCfg, repoRoot)
+	if err != nil {
+		return nil, fmt.Errorf("configuring pull requests: %w", err)
+	}
+	provider, err := dag.NewPRProvider(prCfg)
+	if err != nil {
+		return nil, fmt.Errorf("configuring pull requests: %w", err)
+	}
+	return dag.WithMergePullRequests(provider, dagCfg.Remote), nil
 }
 
 func setupMergeSignalHandler(ctx context.Context) (context.Context, context.CancelFunc) {
@@ -0,0 +1,192 @@
+package dag
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/dag"
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/lifecycle"
+	"github.com/ariel-frischer/autospec/internal/notify"
+	"github.com/ariel-frischer/autospec/internal/workflow"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// defaultDAGsDir is where dag plan writes DAG files when --output is not set.
+const defaultDAGsDir = ".autospec/dags"
+
+var planCmd = &cobra.Command{
+	Use:   "plan <roadmap or file>",
+	Short: "Generate a DAG file from a roadmap",
+	Long: `Generate a DAG file from a roadmap using an agent session.
+
+The argument is either the roadmap text or the path to a file containing it.
+The agent splits the roadmap into features with IDs, descriptions, and
+depends_on edges, grouped into layers, and writes the DAG file.
+
+After each attempt the file is checked for:
+- Structural validity (same checks as dag validate)
+- A resolved DAG ID that is unique among the other files in its directory
+- Feature IDs that collide with existing spec folders by name or number
+
+Failures are fed back to the agent as retry context until the file passes or
+max retries are exhausted.
+
+The output defaults to .autospec/dags/<name>.yaml, where <name> comes from the
+roadmap file name or the first line of the roadmap text.`,
+	Example: `  # Plan from a roadmap file
+  autospec dag plan docs/roadmap.md
+
+  # Plan from inline text
+  autospec dag plan "Add user accounts: auth, profiles, and admin dashboard"
+
+  # Write to a specific file, replacing it if it exists
+  autospec dag plan docs/roadmap.md --output .autospec/dags/q3.yaml --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPlan,
+}
+
+func init() {
+	planCmd.Flags().StringP("output", "o", "", "DAG file to write (default: .autospec/dags/<name>.yaml)")
+	planCmd.Flags().Bool("force", false, "Replace the output file if it already exists")
+	planCmd.Flags().IntP("max-retries", "r", 0, "Override max retry attempts (overrides config when set)")
+	planCmd.Flags().String("specs-dir", "", "Directory containing spec folders (default: from config)")
+	DagCmd.AddCommand(planCmd)
+}
+
+func runPlan(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	output, _ := cmd.Flags().GetString("output")
+	force, _ := cmd.Flags().GetBool("force")
+
+	cfg, err := config.Load("")
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	if cmd.Flags().Changed("max-retries") {
+		cfg.MaxRetries, _ = cmd.Flags().GetInt("max-retries")
+	}
+	if specsDir, _ := cmd.Flags().GetString("specs-dir"); specsDir != "" {
+		cfg.SpecsDir = specsDir
+	}
+
+	roadmap, name, err := readRoadmap(args[0])
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		output = filepath.Join(defaultDAGsDir, name+".yaml")
+	}
+	if err := prepareOutput(output, force); err != nil {
+		return err
+	}
+
+	notifHandler := notify.NewHandler(cfg.Notifications)
+	historyLogger := history.NewWriter(cfg.StateDir, cfg.MaxHistoryEntries)
+
+	return lifecycle.RunWithHistoryContext(cmd.Context(), notifHandler, historyLogger, "dag-plan", output, func(_ context.Context) error {
+		return executePlan(cmd, cfg, roadmap, output)
+	})
+}
+
+func executePlan(cmd *cobra.Command, cfg *config.Configuration, roadmap, output string) error {
+	existing, err := dag.ExistingSpecIDs(cfg.SpecsDir)
+	if err != nil {
+		return err
+	}
+
+	orch := workflow.NewWorkflowOrchestrator(cfg)
+	shared.ApplyOutputStyle(cmd, orch)
+
+	planName := "dag-" + strings.TrimSuffix(filepath.Base(output), filepath.Ext(output))
+	input := buildPlanInput(roadmap, output, existing)
+	validate := func() error {
+		return dag.ValidatePlannedDAG(output, cfg.SpecsDir)
+	}
+	if err := orch.ExecuteDAGPlan(planName, input, validate); err != nil {
+		return fmt.Errorf("dag plan failed: %w", err)
+	}
+	if cfg.SchemaModeline {
+		if err := dag.StampSchemaModeline(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to add schema modeline to %s: %v\n", output, err)
+		}
+	}
+
+	result, err := dag.ParseDAGFile(output)
+	if err != nil {
+		return fmt.Errorf("parsing planned DAG: %w", err)
+	}
+	printPlanSummary(result.Config, output)
+	return nil
+}
+
+// readRoadmap returns the roadmap text and a name for the DAG file. An
+// argument naming an existing file is read; anything else is the roadmap itself.
+func readRoadmap(arg string) (roadmap, name string, err error) {
+	if info, statErr := os.Stat(arg); statErr == nil && !info.IsDir() {
+		data, err := os.ReadFile(arg)
+		if err != nil {
+			return "", "", fmt.Errorf("reading roadmap file: %w", err)
+		}
+		roadmap = strings.TrimSpace(string(data))
+		name = dag.Slugify(strings.TrimSuffix(filepath.Base(arg), filepath.Ext(arg)))
+	} else {
+		roadmap = strings.TrimSpace(arg)
+		firstLine, _, _ := strings.Cut(roadmap, "\n")
+		name = dag.Slugify(firstLine)
+	}
+
+	if roadmap == "" {
+		return "", "", fmt.Errorf("roadmap is empty")
+	}
+	if name == "" {
+		name = "roadmap"
+	}
+	return roadmap, name, nil
+}
+
+// prepareOutput ensures the output directory exists and the output file is
+// absent, so validation never passes on a stale file the agent did not write.
+func prepareOutput(output string, force bool) error {
+	if _, err := os.Stat(output); err == nil {
+		if !force {
+			return fmt.Errorf("output file already exists: %s (use --force to replace it)", output)
+		}
+		if err := os.Remove(output); err != nil {
+			return fmt.Errorf("removing existing output file: %w", err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(output), 0o755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+	return nil
+}
+
+// buildPlanInput builds the user input for the dag-plan command.
+func buildPlanInput(roadmap, output string, existing []string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Output file: %s\n", output)
+	if len(existing) == 0 {
+		sb.WriteString("Existing spec folders: none\n")
+	} else {
+		fmt.Fprintf(&sb, "Existing spec folders (do not reuse these IDs or numbers): %s\n", strings.Join(existing, ", "))
+	}
+	fmt.Fprintf(&sb, "\nRoadmap:\n\n%s\n", roadmap)
+	return sb.String()
+}
+
+func printPlanSummary(cfg *dag.DAGConfig, output string) {
+	green := color.New(color.FgGreen, color.Bold)
+	green.Print("✓ DAG planned")
+	fmt.Printf(" - %q written to %s\n", cfg.DAG.Name, output)
+	fmt.Printf("  %d layer(s), %d feature(s)\n", len(cfg.Layers), countFeatures(cfg))
+	fmt.Printf("\nNext: autospec dag visualize %s\n", output)
+	fmt.Printf("      autospec dag run %s --parallel\n", output)
+}
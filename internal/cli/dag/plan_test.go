@@ -0,0 +1,106 @@
+package dag
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanCmd_Structure(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "plan <roadmap or file>", planCmd.Use)
+	for _, name := range []string{"output", "force", "max-retries", "specs-dir"} {
+		assert.NotNil(t, planCmd.Flags().Lookup(name), "flag %s should be registered", name)
+	}
+	assert.Equal(t, "o", planCmd.Flags().Lookup("output").Shorthand)
+}
+
+func TestReadRoadmap(t *testing.T) {
+	t.Parallel()
+
+	roadmapFile := filepath.Join(t.TempDir(), "Q3 Roadmap.md")
+	require.NoError(t, os.WriteFile(roadmapFile, []byte("\n# Q3\n- auth\n- billing\n"), 0o644))
+
+	tests := map[string]struct {
+		arg         string
+		wantRoadmap string
+		wantName    string
+		wantErr     string
+	}{
+		"file argument": {
+			arg:         roadmapFile,
+			wantRoadmap: "# Q3\n- auth\n- billing",
+			wantName:    "q3-roadmap",
+		},
+		"inline text": {
+			arg:         "Add user accounts: auth & profiles\nthen admin",
+			wantRoadmap: "Add user accounts: auth & profiles\nthen admin",
+			wantName:    "add-user-accounts-auth-profiles",
+		},
+		"text without slug characters": {
+			arg:         "!!!",
+			wantRoadmap: "!!!",
+			wantName:    "roadmap",
+		},
+		"empty roadmap": {
+			arg:     "   ",
+			wantErr: "roadmap is empty",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			roadmap, planName, err := readRoadmap(tt.arg)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRoadmap, roadmap)
+			assert.Equal(t, tt.wantName, planName)
+		})
+	}
+}
+
+func TestPrepareOutput(t *testing.T) {
+	t.Parallel()
+
+	t.Run("creates output directory", func(t *testing.T) {
+		t.Parallel()
+		output := filepath.Join(t.TempDir(), "dags", "q3.yaml")
+		require.NoError(t, prepareOutput(output, false))
+		assert.DirExists(t, filepath.Dir(output))
+	})
+
+	t.Run("refuses existing file without force", func(t *testing.T) {
+		t.Parallel()
+		output := filepath.Join(t.TempDir(), "q3.yaml")
+		require.NoError(t, os.WriteFile(output, []byte("old"), 0o644))
+		assert.ErrorContains(t, prepareOutput(output, false), "use --force")
+		assert.FileExists(t, output)
+	})
+
+	t.Run("removes existing file with force", func(t *testing.T) {
+		t.Parallel()
+		output := filepath.Join(t.TempDir(), "q3.yaml")
+		require.NoError(t, os.WriteFile(output, []byte("old"), 0o644))
+		require.NoError(t, prepareOutput(output, true))
+		assert.NoFileExists(t, output)
+	})
+}
+
+func TestBuildPlanInput(t *testing.T) {
+	t.Parallel()
+
+	input := buildPlanInput("Add auth", ".autospec/dags/q3.yaml", []string{"001-setup", "002-cli"})
+	assert.Contains(t, input, "Output file: .autospec/dags/q3.yaml\n")
+	assert.Contains(t, input, "(do not reuse these IDs or numbers): 001-setup, 002-cli\n")
+	assert.Contains(t, input, "Roadmap:\n\nAdd auth\n")
+
+	assert.Contains(t, buildPlanInput("Add auth", "q3.yaml", nil), "Existing spec folders: none\n")
+}
@@ -34,7 +34,10 @@ The dag run command:
 - Parses and validates the DAG file
 - Checks for existing state (embedded in dag.yaml) and resumes if found
 - Creates worktrees for each spec on-demand
-- Executes specs in layer-dependency order (sequential or parallel)
+- Executes specs in layer-dependency order (sequential), or with --parallel
+  starts each spec as soon as its depends_on specs complete
+- With --parallel, warns about concurrent specs predicted to touch the same
+  files (see dag validate --conflicts)
 - Tracks run state directly in the dag.yaml file (inline state)
 
 Exit codes:
@@ -59,6 +62,9 @@ Exit codes:
   # Execute specs concurrently with custom parallelism
   autospec dag run .autospec/dags/my-workflow.yaml --parallel --max-parallel 2
 
+  # Start specs on the longest dependency chain first
+  autospec dag run .autospec/dags/my-workflow.yaml --parallel --priority critical-path
+
   # Preview execution plan without running
   autospec dag run .autospec/dags/my-workflow.yaml --dry-run
 
@@ -83,6 +89,9 @@ Exit codes:
   # Auto-merge after successful run (for CI)
   autospec dag run .autospec/dags/my-workflow.yaml --merge
 
+  # Squash each spec into one commit on the staging branches
+  autospec dag run .autospec/dags/my-workflow.yaml --strategy squash
+
   # Skip the post-run merge prompt
   autospec dag run .autospec/dags/my-workflow.yaml --no-merge-prompt`,
 	Args: cobra.ExactArgs(1),
@@ -98,6 +107,8 @@ func init() {
 	runCmd.Flags().Bool("parallel", false, "Execute specs concurrently instead of sequentially")
 	runCmd.Flags().Int("max-parallel", 4, "Maximum concurrent spec count (default 4, requires --parallel)")
 	runCmd.Flags().Bool("fail-fast", false, "Stop all running specs on first failure (requires --parallel)")
+	runCmd.Flags().String("priority", "", "Start order for ready specs: dag-order, critical-path (overrides dag.schedule_priority, requires --parallel)")
+	runCmd.Flags().String("strategy", "", "Merge strategy for staging and post-run merges: no-ff, squash, rebase (overrides dag.merge_strategy)")
 	runCmd.Flags().Bool("autocommit", false, "Force enable autocommit verification after spec execution")
 	runCmd.Flags().Bool("no-autocommit", false, "Force disable autocommit verification")
 	runCmd.Flags().Bool("automerge", false, "Force enable automerge into staging branches after spec completion")
@@ -120,6 +131,8 @@ func runDagRun(cmd *cobra.Command, args []string) error {
 	parallel, _ := cmd.Flags().GetBool("parallel")
 	maxParallel, _ := cmd.Flags().GetInt("max-parallel")
 	failFast, _ := cmd.Flags().GetBool("fail-fast")
+	priority, _ := cmd.Flags().GetString("priority")
+	strategy, _ := cmd.Flags().GetString("strategy")
 	autocommit, _ := cmd.Flags().GetBool("autocommit")
 	noAutocommit, _ := cmd.Flags().GetBool("no-autocommit")
 	automerge, _ := cmd.Flags().GetBool("automerge")
@@ -147,6 +160,13 @@ func runDagRun(cmd *cobra.Command, args []string) error {
 		return cliErr
 	}
 
+	// Validate priority requires parallel mode
+	if priority != "" && !parallel {
+		cliErr := clierrors.NewArgumentError("--priority requires --parallel flag")
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+
 	// Validate --clean requires --only
 	if clean && onlyStr == "" {
 		cliErr := clierrors.NewArgumentError("--clean requires --only to specify which specs to clean")
@@ -194,7 +214,7 @@ func runDagRun(cmd *cobra.Command, args []string) error {
 	automergeOverride := buildAutomergeOverride(automerge, noAutomerge)
 
 	return lifecycle.RunWithHistoryContext(cmd.Context(), notifHandler, historyLogger, "dag-run", filePath, func(ctx context.Context) error {
-		return executeDagRun(ctx, cfg, filePath, dryRun, force, fresh, parallel, maxParallel, failFast, onlySpecs, clean, autocommitOverride, automergeOverride, noLayerStaging, merge, noMergePrompt)
+		return executeDagRun(ctx, cfg, filePath, dryRun, force, fresh, parallel, maxParallel, failFast, priority, strategy, onlySpecs, clean, autocommitOverride, automergeOverride, noLayerStaging, merge, noMergePrompt)
 	})
 }
 
@@ -233,6 +253,7 @@ func handlePostRunMerge(
 	filePath, stateDir string,
 	manager worktree.Manager,
 	repoRoot string,
+	strategy dag.MergeStrategy,
 	autoMerge, noMergePrompt bool,
 ) error {
 	// Skip prompt if explicitly disabled
@@ -258,7 +279,7 @@ func handlePostRunMerge(
 		return nil
 	}
 
-	return executeMergeAfterRun(ctx, filePath, stateDir, manager, repoRoot, hasFailures)
+	return executeMergeAfterRun(ctx, filePath, stateDir, manager, repoRoot, strategy, hasFailures)
 }
 
 // analyzeMergeState checks the run state for merge candidates.
@@ -326,6 +347,7 @@ func executeMergeAfterRun(
 	filePath, stateDir string,
 	manager worktree.Manager,
 	repoRoot string,
+	strategy dag.MergeStrategy,
 	hasFailures bool,
 ) error {
 	fmt.Println("\nRunning merge...")
@@ -336,6 +358,7 @@ func executeMergeAfterRun(
 		repoRoot,
 		dag.WithMergeStdout(os.Stdout),
 		dag.WithMergeSkipFailed(hasFailures), // Auto-skip failed if there are failures
+		dag.WithMergeStrategy(strategy),
 	)
 
 	run, err := loadExistingState(filePath, stateDir)
@@ -369,7 +392,7 @@ func parseOnlySpecs(onlyStr string) []string {
 	return specs
 }
 
-func executeDagRun(ctx context.Context, cfg *config.Configuration, filePath string, dryRun, force, fresh, parallel bool, maxParallel int, failFast bool, onlySpecs []string, clean bool, autocommitOverride, automergeOverride *bool, noLayerStaging, autoMerge, noMergePrompt bool) error {
+func executeDagRun(ctx context.Context, cfg *config.Configuration, filePath string, dryRun, force, fresh, parallel bool, maxParallel int, failFast bool, priority, strategy string, onlySpecs []string, clean bool, autocommitOverride, automergeOverride *bool, noLayerStaging, autoMerge, noMergePrompt bool) error {
 	result, err := dag.ParseDAGFile(filePath)
 	if err != nil {
 		return formatDagParseError(filePath, err)
@@ -399,6 +422,12 @@ func executeDagRun(ctx context.Context, cfg *config.Configuration, filePath stri
 	if automergeOverride != nil {
 		dagConfig.Automerge = automergeOverride
 	}
+	if priority != "" {
+		dagConfig.SchedulePriority = priority
+	}
+	if strategy != "" {
+		dagConfig.MergeStrategy = strategy
+	}
 	// Validate config after applying overrides
 	if err := dagConfig.Validate(); err != nil {
 		cliErr := clierrors.NewArgumentError(err.Error())
@@ -459,21 +488,41 @@ func executeDagRun(ctx context.Context, cfg *config.Configuration, filePath stri
 	ctx, cancel := setupSignalHandler(ctx)
 	defer cancel()
 
+	// Read per-spec token and cost usage recorded by autospec subprocesses
+	// and stop the run once a budget limit is reached
+	extraOpts := []dag.ExecutorOption{
+		dag.WithUsageStateDir(cfg.StateDir),
+		dag.WithBudget(cfg.Budget),
+	}
+
 	var runErr error
 	if parallel {
-		runErr = executeParallelRun(ctx, result.Config, filePath, manager, stateDir, repoRoot, dagConfig, worktreeConfig, dryRun, force, maxParallel, failFast, existingState, onlySpecs, noLayerStaging)
+		warnPredictedOverlaps(result.Config, cfg.SpecsDir)
+		runErr = executeParallelRun(ctx, result.Config, filePath, manager, stateDir, repoRoot, dagConfig, worktreeConfig, dryRun, force, maxParallel, failFast, existingState, onlySpecs, noLayerStaging, extraOpts...)
 	} else {
-		runErr = executeSequentialRun(ctx, result.Config, filePath, manager, stateDir, repoRoot, dagConfig, worktreeConfig, dryRun, force, existingState, onlySpecs, noLayerStaging)
+		runErr = executeSequentialRun(ctx, result.Config, filePath, manager, stateDir, repoRoot, dagConfig, worktreeConfig, dryRun, force, existingState, onlySpecs, noLayerStaging, extraOpts...)
 	}
 
 	// Handle post-run merge prompt
 	if !dryRun && runErr == nil {
-		return handlePostRunMerge(ctx, filePath, stateDir, manager, repoRoot, autoMerge, noMergePrompt)
+		return handlePostRunMerge(ctx, filePath, stateDir, manager, repoRoot, dag.MergeStrategy(dagConfig.MergeStrategy), autoMerge, noMergePrompt)
 	}
 
 	return runErr
 }
 
+// warnPredictedOverlaps warns before a parallel run when specs that can run
+// concurrently are expected to touch the same files. The run still proceeds.
+func warnPredictedOverlaps(cfg *dag.DAGConfig, specsDir string) {
+	report := dag.PredictFileOverlaps(cfg, specsDir)
+	if !report.HasOverlaps() {
+		return
+	}
+	printOverlapReport(os.Stderr, report)
+	color.New(color.FgYellow).Fprintln(os.Stderr, "Continuing; these specs may conflict at merge time.")
+	fmt.Fprintln(os.Stderr)
+}
+
 func executeSequentialRun(
 	ctx context.Context,
 	dagCfg *dag.DAGConfig,
@@ -486,11 +535,21 @@ func executeSequentialRun(
 	existingState *dag.DAGRun,
 	onlySpecs []string,
 	noLayerStaging bool,
+	extraOpts ...dag.ExecutorOption,
 ) error {
 	// Print resume/new run status
 	isResume := existingState != nil
 	printRunStatus(filePath, isResume, existingState)
 
+	opts := append([]dag.ExecutorOption{
+		dag.WithExecutorStdout(os.Stdout),
+		dag.WithDryRun(dryRun),
+		dag.WithForce(force),
+		dag.WithExistingState(existingState),
+		dag.WithOnlySpecs(onlySpecs),
+		dag.WithDisableLayerStaging(noLayerStaging),
+	}, extraOpts...)
+
 	executor := dag.NewExecutor(
 		dagCfg,
 		filePath,
@@ -499,12 +558,7 @@ func executeSequentialRun(
 		repoRoot,
 		dagConfig,
 		worktreeConfig,
-		dag.WithExecutorStdout(os.Stdout),
-		dag.WithDryRun(dryRun),
-		dag.WithForce(force),
-		dag.WithExistingState(existingState),
-		dag.WithOnlySpecs(onlySpecs),
-		dag.WithDisableLayerStaging(noLayerStaging),
+		opts...,
 	)
 
 	_, err := executor.Execute(ctx)
@@ -533,11 +587,20 @@ func executeParallelRun(
 	existingState *dag.DAGRun,
 	onlySpecs []string,
 	noLayerStaging bool,
+	extraOpts ...dag.ExecutorOption,
 ) error {
 	// Print resume/new run status
 	isResume := existingState != nil
 	printRunStatus(filePath, isResume, existingState)
 
+	opts := append([]dag.ExecutorOption{
+		dag.WithDryRun(dryRun),
+		dag.WithForce(force),
+		dag.WithExistingState(existingState),
+		dag.WithOnlySpecs(onlySpecs),
+		dag.WithDisableLayerStaging(noLayerStaging),
+	}, extraOpts...)
+
 	parallelExec := dag.CreateParallelExecutorFromConfig(
 		dagCfg,
 		filePath,
@@ -549,11 +612,7 @@ func executeParallelRun(
 		maxParallel,
 		failFast,
 		os.Stdout,
-		dag.WithDryRun(dryRun),
-		dag.WithForce(force),
-		dag.WithExistingState(existingState),
-		dag.WithOnlySpecs(onlySpecs),
-		dag.WithDisableLayerStaging(noLayerStaging),
+		opts...,
 	)
 
 	_, err := parallelExec.Execute(ctx)
@@ -621,6 +680,9 @@ func printRunFailure(filePath string, err error) error {
 	} else {
 		fmt.Fprintln(os.Stderr)
 	}
+	if cliErr := clierrors.AsCLIError(err); cliErr != nil {
+		clierrors.PrintError(cliErr)
+	}
 	return err
 }
 
@@ -933,6 +995,8 @@ func cleanSpecs(
 		specState.CurrentStage = ""
 		specState.CurrentTask = ""
 		specState.FailureReason = ""
+		specState.FailureClass = ""
+		specState.Attempts = nil
 		specState.ExitCode = nil
 		specState.BlockedBy = nil
 		fmt.Printf("  Reset state for %s\n", specID)
@@ -968,6 +1032,8 @@ func saveCleanedState(filePath string, run *dag.DAGRun) error {
 			CommitSHA:     spec.CommitSHA,
 			CommitStatus:  spec.CommitStatus,
 			FailureReason: spec.FailureReason,
+			FailureClass:  spec.FailureClass,
+			Attempts:      spec.Attempts,
 			ExitCode:      spec.ExitCode,
 			Merge:         spec.Merge,
 		}
@@ -1152,8 +1218,11 @@ func convertInlineSpecToSpecState(specID string, inline *dag.InlineSpecState, co
 		CommitSHA:     inline.CommitSHA,
 		CommitStatus:  inline.CommitStatus,
 		FailureReason: inline.FailureReason,
+		FailureClass:  inline.FailureClass,
+		Attempts:      inline.Attempts,
 		ExitCode:      inline.ExitCode,
 		Merge:         inline.Merge,
+		Usage:         inline.Usage,
 	}
 
 	// Find layer ID from DAG definition
@@ -276,6 +276,10 @@ func TestRunCmd_Flags(t *testing.T) {
 			flagName: "force",
 			flagType: "bool",
 		},
+		"strategy flag": {
+			flagName: "strategy",
+			flagType: "string",
+		},
 	}
 
 	for name, tt := range tests {
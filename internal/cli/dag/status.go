@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
 	"github.com/ariel-frischer/autospec/internal/dag"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -222,7 +223,7 @@ func printInlineCompletedSpecs(specs []inlineSpecEntry) {
 			d := spec.State.CompletedAt.Sub(*spec.State.StartedAt)
 			duration = fmt.Sprintf(" (%s)", formatDuration(d))
 		}
-		green.Fprintf(os.Stdout, "  ✓ %s%s\n", spec.ID, duration)
+		green.Fprintf(os.Stdout, "  ✓ %s%s%s%s\n", spec.ID, duration, formatInlineSpecUsage(spec.State), formatInlineMergeInfo(spec.State))
 	}
 	fmt.Println()
 }
@@ -237,7 +238,7 @@ func printInlineRunningSpecs(specs []inlineSpecEntry) {
 	fmt.Println("Running:")
 	for _, spec := range specs {
 		info := buildInlineRunningInfo(spec.State)
-		yellow.Fprintf(os.Stdout, "  ● %s%s\n", spec.ID, info)
+		yellow.Fprintf(os.Stdout, "  ● %s%s%s\n", spec.ID, info, formatInlineSpecUsage(spec.State))
 	}
 	fmt.Println()
 }
@@ -250,6 +251,31 @@ func buildInlineRunningInfo(spec *dag.InlineSpecState) string {
 	return fmt.Sprintf(" [%s]", spec.CurrentStage)
 }
 
+// formatInlineSpecUsage returns the token and cost suffix for a spec, or "" if none recorded.
+func formatInlineSpecUsage(spec *dag.InlineSpecState) string {
+	if spec == nil || spec.Usage == nil {
+		return ""
+	}
+	usage := shared.FormatUsage(spec.Usage.TotalTokens(), spec.Usage.CostUSD)
+	if usage == "" {
+		return ""
+	}
+	return fmt.Sprintf(" {%s}", usage)
+}
+
+// formatInlineMergeInfo returns the pull request suffix for a spec opened by
+// dag merge --pr, or "" if it has no pull request.
+func formatInlineMergeInfo(spec *dag.InlineSpecState) string {
+	if spec == nil || spec.Merge == nil || spec.Merge.PRNumber == 0 {
+		return ""
+	}
+	state := spec.Merge.PRState
+	if state == "" {
+		state = dag.PRStateOpen
+	}
+	return fmt.Sprintf(" [PR #%d %s: %s]", spec.Merge.PRNumber, state, spec.Merge.PRURL)
+}
+
 // printInlinePendingSpecs displays pending specs from inline state.
 // Dependencies are derived from the DAG definition (Layers/Features).
 func printInlinePendingSpecs(specs []inlineSpecEntry, config *dag.DAGConfig) {
@@ -314,7 +340,7 @@ func printInlineFailedSpecs(specs []inlineSpecEntry) {
 	red := color.New(color.FgRed, color.Bold)
 	fmt.Println("Failed:")
 	for _, spec := range specs {
-		red.Fprintf(os.Stdout, "  ✗ %s\n", spec.ID)
+		red.Fprintf(os.Stdout, "  ✗ %s%s\n", spec.ID, formatInlineSpecUsage(spec.State))
 		if spec.State.FailureReason != "" {
 			fmt.Printf("    Error: %s\n", spec.State.FailureReason)
 		}
@@ -332,6 +358,10 @@ func printInlineSummary(config *dag.DAGConfig) {
 		fmt.Printf("  Completed: %d, Failed: %d, Blocked: %d, Pending: %d\n",
 			stats.Completed, stats.Failed, stats.Blocked, stats.Pending)
 	}
+	total := dag.InlineUsageTotal(config.Specs)
+	if usage := shared.FormatUsage(total.TotalTokens(), total.CostUSD); usage != "" {
+		fmt.Printf("Usage: %s\n", usage)
+	}
 }
 
 // inlineProgressStats holds progress statistics from inline state.
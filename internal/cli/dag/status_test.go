@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ariel-frischer/autospec/internal/cliagent"
 	"github.com/ariel-frischer/autospec/internal/dag"
 )
 
@@ -317,6 +318,39 @@ func TestBuildInlineRunningInfo(t *testing.T) {
 	}
 }
 
+func TestFormatInlineSpecUsage(t *testing.T) {
+	tests := map[string]struct {
+		spec     *dag.InlineSpecState
+		expected string
+	}{
+		"nil spec": {
+			spec:     nil,
+			expected: "",
+		},
+		"no usage": {
+			spec:     &dag.InlineSpecState{},
+			expected: "",
+		},
+		"zero usage": {
+			spec:     &dag.InlineSpecState{Usage: &cliagent.Usage{}},
+			expected: "",
+		},
+		"with usage": {
+			spec:     &dag.InlineSpecState{Usage: &cliagent.Usage{InputTokens: 1000, OutputTokens: 500, CostUSD: 0.3}},
+			expected: " {1.5k tokens, $0.30}",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := formatInlineSpecUsage(tt.spec)
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
 func TestPrintInlineCompletedSpecs(t *testing.T) {
 	now := time.Now()
 	startTime := now.Add(-5 * time.Minute)
@@ -749,3 +783,40 @@ specs:
 		t.Errorf("expected most recent DAG to be newer.yaml, got %s", result)
 	}
 }
+
+func TestFormatInlineMergeInfo(t *testing.T) {
+	tests := map[string]struct {
+		spec *dag.InlineSpecState
+		want string
+	}{
+		"nil spec":       {spec: nil, want: ""},
+		"no merge state": {spec: &dag.InlineSpecState{}, want: ""},
+		"merged locally": {spec: &dag.InlineSpecState{Merge: &dag.MergeState{Status: dag.MergeStatusMerged}}, want: ""},
+		"open pull request": {
+			spec: &dag.InlineSpecState{Merge: &dag.MergeState{
+				Status:   dag.MergeStatusPROpen,
+				PRNumber: 12,
+				PRURL:    "https://github.com/o/r/pull/12",
+				PRState:  dag.PRStateOpen,
+			}},
+			want: " [PR #12 open: https://github.com/o/r/pull/12]",
+		},
+		"merged pull request": {
+			spec: &dag.InlineSpecState{Merge: &dag.MergeState{
+				Status:   dag.MergeStatusMerged,
+				PRNumber: 7,
+				PRURL:    "https://gitlab.com/o/r/-/merge_requests/7",
+				PRState:  dag.PRStateMerged,
+			}},
+			want: " [PR #7 merged: https://gitlab.com/o/r/-/merge_requests/7]",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := formatInlineMergeInfo(tt.spec); got != tt.want {
+				t.Errorf("formatInlineMergeInfo() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
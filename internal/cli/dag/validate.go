@@ -2,8 +2,10 @@ package dag
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/ariel-frischer/autospec/internal/dag"
 	"github.com/fatih/color"
@@ -24,11 +26,20 @@ Checks for:
 Note: Missing spec folders are NOT errors. They will be created dynamically
 when dag run executes using each feature's description field.
 
+With --conflicts, also predicts merge conflicts: specs that can run at the
+same time (neither depends on the other) whose tasks.yaml file_path entries
+or plan.yaml project_structure paths and deliverables name the same files.
+Each overlap comes with a suggested depends_on edge that serialises the pair.
+Predicted conflicts are warnings and do not change the exit code.
+
 Exit codes:
   0 - Valid DAG file
   1 - Invalid DAG file or validation errors`,
 	Example: `  # Validate a DAG file
-  autospec dag validate .autospec/dags/my-workflow.yaml`,
+  autospec dag validate .autospec/dags/my-workflow.yaml
+
+  # Also predict file conflicts between specs that can run concurrently
+  autospec dag validate .autospec/dags/my-workflow.yaml --conflicts`,
 	Args: cobra.ExactArgs(1),
 	RunE: runValidate,
 }
@@ -56,6 +67,11 @@ func runValidate(cmd *cobra.Command, args []string) error {
 	}
 
 	printValidMessage(result.Config, vr.MissingSpecs)
+
+	if conflicts, _ := cmd.Flags().GetBool("conflicts"); conflicts {
+		fmt.Println()
+		printOverlapReport(os.Stdout, dag.PredictFileOverlaps(result.Config, specsDir))
+	}
 	return nil
 }
 
@@ -124,6 +140,28 @@ func printValidMessage(cfg *dag.DAGConfig, missingSpecs []*dag.MissingSpecError)
 	}
 }
 
+// printOverlapReport prints predicted file conflicts with suggested fixes,
+// and the specs whose files could not be predicted.
+func printOverlapReport(w io.Writer, report *dag.OverlapReport) {
+	yellow := color.New(color.FgYellow, color.Bold)
+	if !report.HasOverlaps() {
+		color.New(color.FgGreen).Fprintln(w, "No file conflicts predicted between concurrent specs")
+	} else {
+		yellow.Fprintf(w, "Predicted file conflicts (%d):\n", len(report.Overlaps))
+		for _, overlap := range report.Overlaps {
+			fmt.Fprintf(w, "  %s <-> %s\n", overlap.SpecA, overlap.SpecB)
+			for _, file := range overlap.Files {
+				fmt.Fprintf(w, "    - %s\n", file)
+			}
+			fmt.Fprintf(w, "    Suggestion: %s\n", overlap.Suggestion())
+		}
+	}
+
+	if len(report.Unanalyzed) > 0 {
+		fmt.Fprintf(w, "  Not analyzed (no tasks.yaml or plan.yaml yet): %s\n", strings.Join(report.Unanalyzed, ", "))
+	}
+}
+
 func countFeatures(cfg *dag.DAGConfig) int {
 	count := 0
 	for _, layer := range cfg.Layers {
@@ -133,5 +171,6 @@ func countFeatures(cfg *dag.DAGConfig) int {
 }
 
 func init() {
+	validateCmd.Flags().Bool("conflicts", false, "Predict file conflicts between specs that can run concurrently")
 	DagCmd.AddCommand(validateCmd)
 }
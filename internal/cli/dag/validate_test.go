@@ -1,6 +1,7 @@
 package dag
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"testing"
@@ -134,3 +135,57 @@ layers: []
 		})
 	}
 }
+
+func TestValidateCmd_ConflictsFlagRegistered(t *testing.T) {
+	t.Parallel()
+
+	flag := validateCmd.Flags().Lookup("conflicts")
+	require.NotNil(t, flag)
+	assert.Equal(t, "false", flag.DefValue)
+}
+
+func TestPrintOverlapReport(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		report      *dag.OverlapReport
+		wantContain []string
+		wantAbsent  []string
+	}{
+		"no overlaps": {
+			report:      &dag.OverlapReport{},
+			wantContain: []string{"No file conflicts predicted"},
+			wantAbsent:  []string{"Not analyzed"},
+		},
+		"overlaps with suggestion": {
+			report: &dag.OverlapReport{
+				Overlaps: []dag.FileOverlap{
+					{SpecA: "001-auth", SpecB: "002-billing", Files: []string{"internal/router.go", "go.mod"}},
+				},
+				Unanalyzed: []string{"003-reports", "004-admin"},
+			},
+			wantContain: []string{
+				"Predicted file conflicts (1):",
+				"001-auth <-> 002-billing",
+				"    - internal/router.go\n",
+				"    - go.mod\n",
+				`Suggestion: add "001-auth" to depends_on of "002-billing"`,
+				"Not analyzed (no tasks.yaml or plan.yaml yet): 003-reports, 004-admin",
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			printOverlapReport(&buf, tt.report)
+			for _, want := range tt.wantContain {
+				assert.Contains(t, buf.String(), want)
+			}
+			for _, absent := range tt.wantAbsent {
+				assert.NotContains(t, buf.String(), absent)
+			}
+		})
+	}
+}
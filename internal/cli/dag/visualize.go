@@ -12,14 +12,24 @@ import (
 
 var visualizeCmd = &cobra.Command{
 	Use:   "visualize <file>",
-	Short: "Generate ASCII visualization of DAG structure",
-	Long: `Generate an ASCII visualization of a DAG configuration file.
+	Short: "Visualize DAG structure as ASCII, Mermaid, DOT, or JSON",
+	Long: `Generate a visualization of a DAG configuration file.
 
 The visualization shows:
 - Layers with their features
 - Dependency relationships between features
 - Summary statistics (layer count, feature count)
 
+Formats (--format):
+  ascii    Terminal diagram (default)
+  mermaid  Mermaid flowchart for markdown (GitHub PRs, docs sites)
+  dot      Graphviz DOT digraph (render with: dot -Tsvg)
+  json     Layers, features, edges, and status as JSON
+
+Mermaid, DOT, and JSON output include each spec's runtime status from the
+inline specs state in the DAG file, with running, completed, failed, and
+blocked specs coloured.
+
 The DAG is validated before visualization. If validation fails,
 errors are displayed instead of the diagram.
 
@@ -30,16 +40,29 @@ Exit codes:
   autospec dag visualize .autospec/dags/my-workflow.yaml
 
   # Visualize with compact output
-  autospec dag visualize --compact .autospec/dags/my-workflow.yaml`,
+  autospec dag visualize --compact .autospec/dags/my-workflow.yaml
+
+  # Embed a live Mermaid diagram in a PR description
+  autospec dag visualize --format mermaid .autospec/dags/my-workflow.yaml
+
+  # Render an SVG with Graphviz
+  autospec dag visualize --format dot .autospec/dags/my-workflow.yaml | dot -Tsvg > dag.svg`,
 	Args: cobra.ExactArgs(1),
 	RunE: runVisualize,
 }
 
-var compactFlag bool
+var (
+	compactFlag bool
+	formatFlag  string
+)
 
 func runVisualize(cmd *cobra.Command, args []string) error {
 	filePath := args[0]
 
+	if err := validateFormatFlags(formatFlag, compactFlag); err != nil {
+		return err
+	}
+
 	if err := validateFileArg(filePath); err != nil {
 		return err
 	}
@@ -59,12 +82,40 @@ func runVisualize(cmd *cobra.Command, args []string) error {
 		return formatVisualizationErrors(filePath, vr.Errors)
 	}
 
-	output := renderVisualization(result.Config, compactFlag)
+	output, err := renderFormat(result.Config, formatFlag, compactFlag)
+	if err != nil {
+		return err
+	}
 	fmt.Print(output)
 
 	return nil
 }
 
+// validateFormatFlags checks --format and that --compact is only used with ASCII.
+func validateFormatFlags(format string, compact bool) error {
+	if !dag.IsValidExportFormat(format) {
+		return fmt.Errorf("invalid format %q: must be one of: ascii, mermaid, dot, json", format)
+	}
+	if compact && format != string(dag.FormatASCII) {
+		return fmt.Errorf("--compact can only be used with --format ascii")
+	}
+	return nil
+}
+
+// renderFormat renders the DAG in the requested format.
+func renderFormat(cfg *dag.DAGConfig, format string, compact bool) (string, error) {
+	switch dag.ExportFormat(format) {
+	case dag.FormatMermaid:
+		return dag.RenderMermaid(cfg), nil
+	case dag.FormatDOT:
+		return dag.RenderDOT(cfg), nil
+	case dag.FormatJSON:
+		return dag.RenderJSON(cfg)
+	default:
+		return renderVisualization(cfg, compact), nil
+	}
+}
+
 // renderVisualization generates the visualization output.
 func renderVisualization(cfg *dag.DAGConfig, compact bool) string {
 	if compact {
@@ -100,6 +151,7 @@ func formatVisualizationErrors(filePath string, errs []error) error {
 
 func init() {
 	visualizeCmd.Flags().BoolVar(&compactFlag, "compact", false, "Use compact single-line output")
+	visualizeCmd.Flags().StringVar(&formatFlag, "format", string(dag.FormatASCII), "Output format: ascii, mermaid, dot, json")
 	visualizeCmd.Flags().String("specs-dir", "", "Directory containing spec folders (default: specs)")
 	DagCmd.AddCommand(visualizeCmd)
 }
@@ -146,3 +146,69 @@ func TestVisualizeCmd_SpecsDirFlagRegistered(t *testing.T) {
 	flag := visualizeCmd.Flags().Lookup("specs-dir")
 	assert.NotNil(t, flag)
 }
+
+func TestVisualizeCmd_FormatFlagRegistered(t *testing.T) {
+	t.Parallel()
+
+	flag := visualizeCmd.Flags().Lookup("format")
+	assert.NotNil(t, flag)
+	assert.Equal(t, "ascii", flag.DefValue)
+}
+
+func TestValidateFormatFlags(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		format  string
+		compact bool
+		wantErr string
+	}{
+		"ascii":                {format: "ascii"},
+		"ascii compact":        {format: "ascii", compact: true},
+		"mermaid":              {format: "mermaid"},
+		"dot":                  {format: "dot"},
+		"json":                 {format: "json"},
+		"unknown format":       {format: "svg", wantErr: `invalid format "svg"`},
+		"compact with mermaid": {format: "mermaid", compact: true, wantErr: "--compact can only be used with --format ascii"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := validateFormatFlags(tt.format, tt.compact)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRenderFormat(t *testing.T) {
+	t.Parallel()
+
+	cfg := &internaldag.DAGConfig{
+		DAG:    internaldag.DAGMetadata{Name: "Formats"},
+		Layers: []internaldag.Layer{{ID: "L0", Features: []internaldag.Feature{{ID: "spec-a"}}}},
+		Specs: map[string]*internaldag.InlineSpecState{
+			"spec-a": {Status: internaldag.InlineSpecStatusFailed},
+		},
+	}
+
+	tests := map[string]string{
+		"ascii":   "spec-a",
+		"mermaid": "class spec_spec_2d_a failed",
+		"dot":     `fillcolor="#f8d7da"`,
+		"json":    `"status": "failed"`,
+	}
+
+	for format, want := range tests {
+		t.Run(format, func(t *testing.T) {
+			t.Parallel()
+			out, err := renderFormat(cfg, format, false)
+			assert.NoError(t, err)
+			assert.Contains(t, out, want)
+		})
+	}
+}
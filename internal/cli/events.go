@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/events"
+	"github.com/spf13/cobra"
+)
+
+// eventSink is the JSONL sink attached for this process, if any.
+var (
+	eventSink            *events.JSONLSink
+	unsubscribeEventSink func()
+)
+
+// setupEventSink attaches the JSONL event sink set by --events-file or
+// AUTOSPEC_EVENTS_SINK, falling back to events.sink from the configuration.
+// Config errors are ignored here; commands report them when they load config.
+func setupEventSink(cmd *cobra.Command) error {
+	path, _ := cmd.Flags().GetString("events-file")
+	if path == "" {
+		path = os.Getenv(events.SinkEnvVar)
+	}
+	if path != "" {
+		return attachEventSink(path)
+	}
+
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.LoadWithOptions(config.LoadOptions{ProjectConfigPath: configPath, SkipWarnings: true})
+	if err != nil {
+		return nil
+	}
+	attachConfiguredEventSink(cmd.ErrOrStderr(), cfg)
+	return nil
+}
+
+// attachConfiguredEventSink attaches the events.sink of cfg. A sink that
+// cannot be opened is reported to w as a warning so it never fails a command.
+func attachConfiguredEventSink(w io.Writer, cfg *config.Configuration) {
+	if cfg.Events.Sink == "" {
+		return
+	}
+	if err := attachEventSink(cfg.Events.Sink); err != nil {
+		fmt.Fprintf(w, "Warning: events.sink %s: %v\n", cfg.Events.Sink, err)
+	}
+}
+
+// attachEventSink opens path as the process event sink, replacing any previous one.
+// The absolute path is exported as AUTOSPEC_EVENTS_SINK so child autospec
+// processes (DAG spec runs in worktrees, agent-invoked update-task) append to
+// the same file.
+func attachEventSink(path string) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("resolving events file path: %w", err)
+	}
+	sink, err := events.OpenJSONLFile(absPath)
+	if err != nil {
+		return err
+	}
+
+	detachEventSink()
+	eventSink = sink
+	unsubscribeEventSink = events.Subscribe(sink.Handle)
+	return os.Setenv(events.SinkEnvVar, absPath)
+}
+
+// detachEventSink unsubscribes and closes the current event sink.
+func detachEventSink() {
+	if unsubscribeEventSink != nil {
+		unsubscribeEventSink()
+		unsubscribeEventSink = nil
+	}
+	if eventSink != nil {
+		_ = eventSink.Close()
+		eventSink = nil
+	}
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAttachEventSink is not parallel: it replaces the process event sink
+// and sets AUTOSPEC_EVENTS_SINK.
+func TestAttachEventSink(t *testing.T) {
+	t.Setenv(events.SinkEnvVar, "")
+	path := filepath.Join(t.TempDir(), "out", "events.jsonl")
+
+	require.NoError(t, attachEventSink(path))
+	t.Cleanup(detachEventSink)
+
+	assert.Equal(t, path, os.Getenv(events.SinkEnvVar), "child processes should inherit the sink")
+
+	events.Publish(events.Event{Type: events.TaskStatusChanged, Spec: "cli-events-test", TaskID: "T001"})
+	detachEventSink()
+	events.Publish(events.Event{Type: events.TaskStatusChanged, Spec: "cli-events-test", TaskID: "T002"})
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var taskIDs []string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var e events.Event
+		require.NoError(t, json.Unmarshal([]byte(line), &e))
+		if e.Spec == "cli-events-test" {
+			taskIDs = append(taskIDs, e.TaskID)
+		}
+	}
+	assert.Equal(t, []string{"T001"}, taskIDs, "events after detach should not be written")
+}
+
+// TestAttachConfiguredEventSink is not parallel: it replaces the process event
+// sink and sets AUTOSPEC_EVENTS_SINK.
+func TestAttachConfiguredEventSink(t *testing.T) {
+	t.Setenv(events.SinkEnvVar, "")
+	t.Cleanup(detachEventSink)
+	detachEventSink()
+	dir := t.TempDir()
+	var stderr bytes.Buffer
+
+	attachConfiguredEventSink(&stderr, &config.Configuration{})
+	assert.Nil(t, eventSink, "no sink without events.sink")
+
+	path := filepath.Join(dir, "events.jsonl")
+	attachConfiguredEventSink(&stderr, &config.Configuration{Events: events.Config{Sink: path}})
+	assert.NotNil(t, eventSink)
+	assert.Equal(t, path, os.Getenv(events.SinkEnvVar))
+	assert.Empty(t, stderr.String())
+
+	// An unwritable sink is a warning and keeps the attached sink
+	blocker := filepath.Join(dir, "blocker")
+	require.NoError(t, os.WriteFile(blocker, nil, 0o644))
+	unwritable := filepath.Join(blocker, "events.jsonl")
+	attachConfiguredEventSink(&stderr, &config.Configuration{Events: events.Config{Sink: unwritable}})
+	assert.Contains(t, stderr.String(), "Warning: events.sink "+unwritable)
+	assert.Equal(t, path, os.Getenv(events.SinkEnvVar))
+}
@@ -48,11 +48,6 @@ func runRenderCommand(cmd *cobra.Command, args []string) error {
 		specsDir = "./specs"
 	}
 
-	content, err := commands.GetTemplate(commandName)
-	if err != nil {
-		return fmt.Errorf("loading template %s: %w", commandName, err)
-	}
-
 	opts := getOptionsForCommand(commandName, specsDir)
 
 	ctx, err := prereqs.ComputeContext(opts)
@@ -60,7 +55,7 @@ func runRenderCommand(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("computing prereqs context: %w", err)
 	}
 
-	rendered, err := commands.RenderAndValidate(commandName, content, ctx)
+	rendered, err := commands.RenderCommand(commandName, ctx)
 	if err != nil {
 		return fmt.Errorf("rendering template: %w", err)
 	}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/lifecycle"
+	"github.com/ariel-frischer/autospec/internal/notify"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/workflow"
+	"github.com/spf13/cobra"
+)
+
+var reviewCmd = &cobra.Command{
+	Use:     "review [optional-prompt]",
+	Aliases: []string{"rv"},
+	Short:   "Adversarially review the implementation against the spec (rv)",
+	Long: `Execute the /autospec.review command for the current specification.
+
+The review command will:
+- Auto-detect the current spec from git branch or most recent spec
+- Run a separate agent session over the implementation diff
+- Check the changes against spec.yaml acceptance criteria and tasks.yaml
+- Write review.yaml with findings (severity, category, file, line)
+
+With verification.review_blocking enabled, the command fails while review.yaml
+has open CRITICAL findings. The review also runs automatically after implement
+when verification.adversarial_review is enabled (level: full).
+
+Prerequisites:
+- spec.yaml must exist (run 'autospec specify' first)
+- tasks.yaml must exist (run 'autospec tasks' first)`,
+	Example: `  # Review the current implementation
+  autospec review
+
+  # Focus the review on a specific concern
+  autospec review "Focus on error handling in the storage layer"
+
+  # Fail while critical findings remain open
+  AUTOSPEC_VERIFICATION_REVIEW_BLOCKING=true autospec review`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true // Don't show help for execution errors
+		// Get optional prompt from args
+		var prompt string
+		if len(args) > 0 {
+			prompt = strings.Join(args, " ")
+		}
+
+		// Get flags
+		configPath, _ := cmd.Flags().GetString("config")
+		skipPreflight, _ := cmd.Flags().GetBool("skip-preflight")
+		maxRetries, _ := cmd.Flags().GetInt("max-retries")
+
+		// Load configuration
+		cfg, err := config.Load(configPath)
+		if err != nil {
+			cliErr := clierrors.ConfigParseError(configPath, err)
+			clierrors.PrintError(cliErr)
+			return cliErr
+		}
+
+		// Override skip-preflight from flag if set
+		if cmd.Flags().Changed("skip-preflight") {
+			cfg.SkipPreflight = skipPreflight
+		}
+
+		// Override max-retries from flag if set
+		if cmd.Flags().Changed("max-retries") {
+			cfg.MaxRetries = maxRetries
+		}
+
+		// Auto-detect current spec and verify required artifacts exist
+		metadata, err := spec.DetectCurrentSpec(cfg.SpecsDir)
+		if err != nil {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("failed to detect current spec: %w\n\nRun 'autospec specify' to create a new spec first", err)
+		}
+		PrintSpecInfo(metadata)
+
+		// Validate required artifacts exist (spec.yaml, tasks.yaml)
+		prereqResult := workflow.ValidateStagePrerequisites(workflow.StageReview, metadata.Directory)
+		if !prereqResult.Valid {
+			fmt.Fprint(os.Stderr, prereqResult.ErrorMessage)
+			cmd.SilenceUsage = true
+			return NewExitError(ExitInvalidArguments)
+		}
+
+		// Create notification handler and history logger
+		notifHandler := notify.NewHandler(cfg.Notifications)
+		historyLogger := history.NewWriter(cfg.StateDir, cfg.MaxHistoryEntries)
+		specName := fmt.Sprintf("%s-%s", metadata.Number, metadata.Name)
+
+		// Wrap command execution with lifecycle for timing, notification, and history
+		return lifecycle.RunWithHistory(notifHandler, historyLogger, "review", specName, func() error {
+			// Create workflow orchestrator
+			orch := workflow.NewWorkflowOrchestrator(cfg)
+			orch.Executor.NotificationHandler = notifHandler
+
+			// Apply output style from CLI flag (overrides config)
+			shared.ApplyOutputStyle(cmd, orch)
+
+			// Execute review stage
+			if err := orch.ExecuteReview(specName, prompt); err != nil {
+				return fmt.Errorf("review stage failed: %w", err)
+			}
+
+			return nil
+		})
+	},
+}
+
+func init() {
+	reviewCmd.GroupID = GroupOptionalStages
+	reviewCmd.Flags().IntP("max-retries", "r", 0, "Override max retry attempts (overrides config when set)")
+	rootCmd.AddCommand(reviewCmd)
+}
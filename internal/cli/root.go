@@ -9,11 +9,16 @@
 package cli
 
 import (
+	"errors"
+	"io"
+	"os"
+
 	"github.com/ariel-frischer/autospec/internal/cli/admin"
 	"github.com/ariel-frischer/autospec/internal/cli/config"
 	"github.com/ariel-frischer/autospec/internal/cli/shared"
 	"github.com/ariel-frischer/autospec/internal/cli/stages"
 	"github.com/ariel-frischer/autospec/internal/cli/util"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -54,11 +59,27 @@ Source: https://github.com/ariel-frischer/autospec`,
   autospec plan
   autospec tasks
   autospec implement`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return setupEventSink(cmd)
+	},
 }
 
 // Execute runs the root command
 func Execute() error {
-	return rootCmd.Execute()
+	err := rootCmd.Execute()
+	printWrappedCLIError(os.Stderr, err)
+	return err
+}
+
+// printWrappedCLIError prints a CLIError that reached the root wrapped in other
+// errors, such as a budget stop returned by a workflow stage. Commands print
+// the CLIErrors they create themselves and return them unwrapped.
+func printWrappedCLIError(w io.Writer, err error) {
+	var cliErr *clierrors.CLIError
+	if err == nil || clierrors.AsCLIError(err) != nil || !errors.As(err, &cliErr) {
+		return
+	}
+	clierrors.FprintError(w, cliErr)
 }
 
 func init() {
@@ -81,6 +102,7 @@ func init() {
 	rootCmd.PersistentFlags().BoolP("debug", "d", false, "Enable debug logging")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
 	rootCmd.PersistentFlags().String("output-style", "", "Output formatting style: default, compact, minimal, plain, raw")
+	rootCmd.PersistentFlags().String("events-file", "", "Append lifecycle events as JSON Lines to this file (overrides events.sink)")
 
 	// Register commands from subpackages
 	stages.Register(rootCmd)
@@ -6,8 +6,11 @@ package cli
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"testing"
 
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -49,6 +52,10 @@ func TestRootCmd_PersistentFlags(t *testing.T) {
 			flagName: "verbose",
 			wantFlag: true,
 		},
+		"events-file flag exists": {
+			flagName: "events-file",
+			wantFlag: true,
+		},
 	}
 
 	for name, tt := range tests {
@@ -263,3 +270,35 @@ func TestRootCmd_SubcommandCategories(t *testing.T) {
 	assert.True(t, commandNames["commands"], "Should have commands command")
 	assert.True(t, commandNames["uninstall"], "Should have uninstall command")
 }
+
+func TestPrintWrappedCLIError(t *testing.T) {
+	t.Parallel()
+
+	budgetErr := clierrors.BudgetExceeded("spec budget exceeded", "budget.max_spec_cost_usd", "autospec plan")
+
+	tests := map[string]struct {
+		err       error
+		wantPrint bool
+	}{
+		"nil error":            {err: nil},
+		"plain error":          {err: errors.New("plan failed")},
+		"unwrapped CLIError":   {err: budgetErr},
+		"wrapped CLIError":     {err: fmt.Errorf("plan stage failed: %w", budgetErr), wantPrint: true},
+		"wrapped plain errors": {err: fmt.Errorf("plan stage failed: %w", errors.New("boom"))},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			printWrappedCLIError(&buf, tt.err)
+			if !tt.wantPrint {
+				assert.Empty(t, buf.String())
+				return
+			}
+			assert.Contains(t, buf.String(), "spec budget exceeded")
+			assert.Contains(t, buf.String(), "budget.max_spec_cost_usd")
+		})
+	}
+}
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/cli/stages"
 	"github.com/ariel-frischer/autospec/internal/config"
 	clierrors "github.com/ariel-frischer/autospec/internal/errors"
 	"github.com/ariel-frischer/autospec/internal/history"
@@ -36,8 +39,13 @@ Optional stage flags:
   -l, --checklist     Include checklist stage (note: -c is used for --config)
   -z, --analyze       Include analyze stage
 
+Stage list:
+  --stages a,b,...    Include stages by name, including custom stages defined
+                      under stages.<name> in config (combines with the flags above)
+
 Stages are always executed in canonical order:
-  constitution -> specify -> clarify -> plan -> tasks -> checklist -> analyze -> implement`,
+  constitution -> specify -> clarify -> plan -> tasks -> checklist -> analyze -> implement
+Custom stages run after the stage named in their after setting (default: implement).`,
 	Example: `  # Run all core stages for a new feature
   autospec run -a "Add user authentication"
 
@@ -50,8 +58,17 @@ Stages are always executed in canonical order:
   # Preview what stages would run (dry run mode)
   autospec run -ti --dry-run
 
+  # Run the core stages plus a custom threat-model stage defined in config
+  autospec run -a --stages threat-model "Add user authentication"
+
+  # Select stages by name
+  autospec run --stages plan,threat-model,tasks
+
   # Skip confirmation prompts for CI/CD
-  autospec run -ti -y`,
+  autospec run -ti -y
+
+  # With implement_method: parallel, run implement in per-task worktrees
+  autospec run -ti --worktrees --max-parallel 3`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true // Don't show help for execution errors
 		// Get core stage flags
@@ -66,6 +83,7 @@ Stages are always executed in canonical order:
 		clarify, _ := cmd.Flags().GetBool("clarify")
 		checklist, _ := cmd.Flags().GetBool("checklist")
 		analyze, _ := cmd.Flags().GetBool("analyze")
+		stageNames, _ := cmd.Flags().GetStringSlice("stages")
 
 		// Get other flags
 		specName, _ := cmd.Flags().GetString("spec")
@@ -76,6 +94,14 @@ Stages are always executed in canonical order:
 		resume, _ := cmd.Flags().GetBool("resume")
 		debug, _ := cmd.Flags().GetBool("debug")
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		maxParallel, _ := cmd.Flags().GetInt("max-parallel")
+		useWorktrees, _ := cmd.Flags().GetBool("worktrees")
+
+		if maxParallel <= 0 {
+			cliErr := clierrors.NewArgumentError("--max-parallel must be a positive integer")
+			clierrors.PrintError(cliErr)
+			return cliErr
+		}
 
 		// Build StageConfig from flags
 		stageConfig := workflow.NewStageConfig()
@@ -93,10 +119,12 @@ Stages are always executed in canonical order:
 		stageConfig.Clarify = clarify
 		stageConfig.Checklist = checklist
 		stageConfig.Analyze = analyze
+		// Built-in names in --stages are selected now; custom names need the config
+		customStageNames := applyStagesFlag(stageConfig, stageNames)
 
 		// Validate at least one stage is selected
-		if !stageConfig.HasAnyStage() {
-			return fmt.Errorf("no stages selected. Use -s/-p/-t/-i flags or -a for all stages\n\nRun 'autospec run --help' for usage")
+		if !stageConfig.HasAnyStage() && len(customStageNames) == 0 {
+			return fmt.Errorf("no stages selected. Use -s/-p/-t/-i flags, -a for all stages, or --stages\n\nRun 'autospec run --help' for usage")
 		}
 
 		// Get feature description from args if specify stage is selected
@@ -119,6 +147,17 @@ Stages are always executed in canonical order:
 			return cliErr
 		}
 
+		if err := selectCustomStages(stageConfig, customStageNames, workflow.CustomStagesFromConfig(cfg)); err != nil {
+			return err
+		}
+
+		// Parallel flags only apply to implement_method: parallel
+		if useWorktrees && cfg.ImplementMethod != "parallel" {
+			cliErr := clierrors.NewArgumentError("--worktrees requires implement_method: parallel")
+			clierrors.PrintError(cliErr)
+			return cliErr
+		}
+
 		// Override settings from flags
 		if cmd.Flags().Changed("skip-preflight") {
 			cfg.SkipPreflight = skipPreflight
@@ -221,7 +260,8 @@ Stages are always executed in canonical order:
 
 		// Execute stages in canonical order with context for cancellation support
 		// Pass 'all' flag as isFullWorkflow to control description propagation
-		return executeStages(cmd.Context(), orchestrator, stageConfig, featureDescription, specMetadata, resume, debug, cfg.ImplementMethod, all, historyLogger)
+		implementOpts := runImplementOptions(cfg, maxParallel, useWorktrees)
+		return executeStages(cmd.Context(), orchestrator, stageConfig, featureDescription, specMetadata, resume, debug, implementOpts, all, historyLogger)
 	},
 }
 
@@ -237,6 +277,10 @@ func printDryRunPreview(stageConfig *workflow.StageConfig, featureDescription st
 
 	fmt.Println("Execution order:")
 	for i, stage := range stages {
+		if custom, ok := stageConfig.CustomStage(stage); ok && custom.Description != "" {
+			fmt.Printf("  %d. %s - %s\n", i+1, stage, custom.Description)
+			continue
+		}
 		fmt.Printf("  %d. %s\n", i+1, stage)
 	}
 	fmt.Println()
@@ -268,6 +312,14 @@ func printDryRunPreview(stageConfig *workflow.StageConfig, featureDescription st
 			fmt.Println("  - (analysis output, no file changes)")
 		case workflow.StageImplement:
 			fmt.Println("  - (implementation changes to codebase)")
+		default:
+			custom, _ := stageConfig.CustomStage(stage)
+			if len(custom.Produces) == 0 {
+				fmt.Printf("  - (%s: no declared artifacts)\n", stage)
+			}
+			for _, artifact := range custom.Produces {
+				fmt.Printf("  - specs/*/%s\n", artifact)
+			}
 		}
 	}
 	fmt.Println()
@@ -276,20 +328,72 @@ func printDryRunPreview(stageConfig *workflow.StageConfig, featureDescription st
 	return nil
 }
 
+// applyStagesFlag selects the built-in stages named in --stages and returns
+// the remaining names, which must be custom stages defined in config.
+func applyStagesFlag(stageConfig *workflow.StageConfig, names []string) []string {
+	var custom []string
+	for _, name := range names {
+		switch workflow.Stage(strings.TrimSpace(name)) {
+		case workflow.StageConstitution:
+			stageConfig.Constitution = true
+		case workflow.StageSpecify:
+			stageConfig.Specify = true
+		case workflow.StageClarify:
+			stageConfig.Clarify = true
+		case workflow.StagePlan:
+			stageConfig.Plan = true
+		case workflow.StageTasks:
+			stageConfig.Tasks = true
+		case workflow.StageChecklist:
+			stageConfig.Checklist = true
+		case workflow.StageAnalyze:
+			stageConfig.Analyze = true
+		case workflow.StageImplement:
+			stageConfig.Implement = true
+		default:
+			custom = append(custom, strings.TrimSpace(name))
+		}
+	}
+	return custom
+}
+
+// selectCustomStages adds the custom stages named in --stages to stageConfig.
+// Names must be defined under stages.<name> in config.
+func selectCustomStages(stageConfig *workflow.StageConfig, names []string, defined map[workflow.Stage]workflow.CustomStage) error {
+	for _, name := range names {
+		custom, ok := defined[workflow.Stage(name)]
+		if !ok {
+			valid := append([]string{}, config.StageNames...)
+			for definedName := range defined {
+				valid = append(valid, string(definedName))
+			}
+			sort.Strings(valid[len(config.StageNames):])
+			return fmt.Errorf("unknown stage %q in --stages; valid stages: %s\n\nDefine custom stages under stages.<name> with a command in your config",
+				name, strings.Join(valid, ", "))
+		}
+		if _, selected := stageConfig.CustomStage(custom.Name); !selected {
+			stageConfig.Custom = append(stageConfig.Custom, custom)
+		}
+	}
+	return nil
+}
+
 // stageExecutionContext holds state during stage execution
 type stageExecutionContext struct {
 	orchestrator        *workflow.WorkflowOrchestrator
+	stageConfig         *workflow.StageConfig
 	notificationHandler *notify.Handler
 	featureDescription  string
 	// isFullWorkflow is true when -a flag was used, indicating description should only
 	// go to specify stage. When true, plan/tasks/implement receive empty prompts to
 	// ensure they work from structured artifacts rather than raw feature descriptions.
-	isFullWorkflow  bool
-	resume          bool
-	implementMethod string
-	specName        string
-	specDir         string
-	ranImplement    bool
+	isFullWorkflow bool
+	resume         bool
+	// implementOpts are the implement stage options from runImplementOptions.
+	implementOpts workflow.PhaseExecutionOptions
+	specName      string
+	specDir       string
+	ranImplement  bool
 	// hadAutomatedStage tracks whether any automated (non-interactive) stage has run.
 	// Used to decide whether to send notification before interactive stages.
 	hadAutomatedStage bool
@@ -298,9 +402,13 @@ type stageExecutionContext struct {
 // executeStages executes the selected stages in order
 // isFullWorkflow indicates whether -a flag was used (all core stages), which affects
 // how featureDescription is propagated: only to specify when true, to all stages when false.
-func executeStages(cmdCtx context.Context, orchestrator *workflow.WorkflowOrchestrator, stageConfig *workflow.StageConfig, featureDescription string, specMetadata *spec.Metadata, resume, debug bool, implementMethod string, isFullWorkflow bool, historyLogger *history.Writer) error {
+func executeStages(cmdCtx context.Context, orchestrator *workflow.WorkflowOrchestrator, stageConfig *workflow.StageConfig, featureDescription string, specMetadata *spec.Metadata, resume, debug bool, implementOpts workflow.PhaseExecutionOptions, isFullWorkflow bool, historyLogger *history.Writer) error {
 	stages := stageConfig.GetCanonicalOrder()
 	orchestrator.Executor.TotalStages = len(stages)
+	orchestrator.Executor.StageNumbers = make(map[workflow.Stage]int, len(stages))
+	for i, stage := range stages {
+		orchestrator.Executor.StageNumbers[stage] = i + 1
+	}
 
 	// Create notification handler from config
 	notifHandler := notify.NewHandler(orchestrator.Config.Notifications)
@@ -308,11 +416,12 @@ func executeStages(cmdCtx context.Context, orchestrator *workflow.WorkflowOrches
 
 	ctx := &stageExecutionContext{
 		orchestrator:        orchestrator,
+		stageConfig:         stageConfig,
 		notificationHandler: notifHandler,
 		featureDescription:  featureDescription,
 		isFullWorkflow:      isFullWorkflow,
 		resume:              resume,
-		implementMethod:     implementMethod,
+		implementOpts:       implementOpts,
 	}
 
 	if specMetadata != nil {
@@ -366,6 +475,9 @@ func (ctx *stageExecutionContext) executeStage(stage workflow.Stage) error {
 	case workflow.StageAnalyze:
 		return ctx.executeAnalyze()
 	default:
+		if custom, ok := ctx.stageConfig.CustomStage(stage); ok {
+			return ctx.executeCustom(custom)
+		}
 		return fmt.Errorf("unknown stage: %s", stage)
 	}
 }
@@ -406,17 +518,32 @@ func (ctx *stageExecutionContext) executeTasks() error {
 	return nil
 }
 
-func (ctx *stageExecutionContext) executeImplement() error {
-	// Build phase options from config's implement_method setting
-	phaseOpts := workflow.PhaseExecutionOptions{}
-	switch ctx.implementMethod {
-	case "phases":
-		phaseOpts.RunAllPhases = true
-	case "tasks":
-		phaseOpts.TaskMode = true
-	case "single-session":
-		// Legacy behavior: no phase/task mode (default state)
+// runImplementOptions builds the implement stage options for run from the
+// implement_method config, resolved the same way as autospec implement
+// without mode flags. maxParallel and useWorktrees apply to the parallel
+// method, and skip_confirmations (set by -y) bypasses its prompts.
+func runImplementOptions(cfg *config.Configuration, maxParallel int, useWorktrees bool) workflow.PhaseExecutionOptions {
+	mode := stages.ResolveExecutionMode(
+		stages.ExecutionModeFlags{
+			MaxParallelFlag: maxParallel,
+			WorktreesFlag:   useWorktrees,
+			YesFlag:         cfg.SkipConfirmations,
+		},
+		false,
+		cfg.ImplementMethod,
+	)
+	return workflow.PhaseExecutionOptions{
+		RunAllPhases:     mode.RunAllPhases,
+		TaskMode:         mode.TaskMode,
+		ParallelMode:     mode.ParallelMode,
+		MaxParallel:      mode.MaxParallel,
+		UseWorktrees:     mode.UseWorktrees && mode.ParallelMode,
+		SkipConfirmation: mode.SkipConfirmation,
 	}
+}
+
+func (ctx *stageExecutionContext) executeImplement() error {
+	phaseOpts := ctx.implementOpts
 	// When running full workflow (-a), pass empty prompt so implement works from tasks.yaml artifacts.
 	// When running individual stages, pass the user's hint/description to the stage.
 	prompt := ctx.featureDescription
@@ -458,6 +585,18 @@ func (ctx *stageExecutionContext) executeAnalyze() error {
 	return nil
 }
 
+func (ctx *stageExecutionContext) executeCustom(stage workflow.CustomStage) error {
+	// Like plan and tasks, custom stages work from artifacts in a full workflow (-a)
+	prompt := ctx.featureDescription
+	if ctx.isFullWorkflow {
+		prompt = ""
+	}
+	if err := ctx.orchestrator.ExecuteCustomStage(ctx.specName, stage, prompt); err != nil {
+		return fmt.Errorf("%s stage failed: %w", stage.Name, err)
+	}
+	return nil
+}
+
 // printWorkflowSummary prints a comprehensive summary after workflow completion
 func printWorkflowSummary(stages []workflow.Stage, specName, specDir string, ranImplement bool) {
 	fmt.Println()
@@ -519,6 +658,7 @@ func init() {
 	runCmd.Flags().BoolP("clarify", "r", false, "Include clarify stage")
 	runCmd.Flags().BoolP("checklist", "l", false, "Include checklist stage")
 	runCmd.Flags().BoolP("analyze", "z", false, "Include analyze stage")
+	runCmd.Flags().StringSlice("stages", nil, "Include stages by name, including custom stages from config (e.g. plan,threat-model)")
 
 	// Spec selection
 	runCmd.Flags().String("spec", "", "Specify which spec to work with (overrides branch detection)")
@@ -531,6 +671,10 @@ func init() {
 	runCmd.Flags().Bool("resume", false, "Resume implementation from where it left off")
 	runCmd.Flags().Bool("dry-run", false, "Preview what stages would run without executing")
 
+	// Parallel implement flags (apply with implement_method: parallel)
+	runCmd.Flags().Int("max-parallel", workflow.DefaultMaxParallel, "Maximum concurrent sessions when implement_method is parallel")
+	runCmd.Flags().Bool("worktrees", false, "Use git worktrees for isolation when implement_method is parallel")
+
 	// Agent override flag
 	shared.AddAgentFlag(runCmd)
 
@@ -4,8 +4,10 @@
 package cli
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/ariel-frischer/autospec/internal/config"
 	"github.com/ariel-frischer/autospec/internal/workflow"
 )
 
@@ -42,17 +44,8 @@ func TestRunImplementMethodConfig(t *testing.T) {
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			// Replicate the logic from run.go executeStages() StageImplement case
-			// This is the exact logic that was fixed to respect implement_method
-			phaseOpts := workflow.PhaseExecutionOptions{}
-			switch tt.implementMethod {
-			case "phases":
-				phaseOpts.RunAllPhases = true
-			case "tasks":
-				phaseOpts.TaskMode = true
-			case "single-session":
-				// Legacy behavior: no phase/task mode (default state)
-			}
+			cfg := &config.Configuration{ImplementMethod: tt.implementMethod}
+			phaseOpts := runImplementOptions(cfg, workflow.DefaultMaxParallel, false)
 
 			if phaseOpts.RunAllPhases != tt.wantRunAllPhases {
 				t.Errorf("RunAllPhases = %v, want %v", phaseOpts.RunAllPhases, tt.wantRunAllPhases)
@@ -86,16 +79,7 @@ func TestRunAndImplementConsistency(t *testing.T) {
 				}
 			}
 
-			// Simulate run.go logic (lines 307-314 after fix)
-			runPhaseOpts := workflow.PhaseExecutionOptions{}
-			switch method {
-			case "phases":
-				runPhaseOpts.RunAllPhases = true
-			case "tasks":
-				runPhaseOpts.TaskMode = true
-			case "single-session":
-				// Legacy behavior
-			}
+			runPhaseOpts := runImplementOptions(&config.Configuration{ImplementMethod: method}, workflow.DefaultMaxParallel, false)
 
 			// Both should produce identical results
 			if implRunAllPhases != runPhaseOpts.RunAllPhases {
@@ -110,6 +94,53 @@ func TestRunAndImplementConsistency(t *testing.T) {
 	}
 }
 
+// TestRunImplementOptions_Parallel verifies that run applies its parallel
+// flags and -y to implement_method: parallel.
+func TestRunImplementOptions_Parallel(t *testing.T) {
+	tests := map[string]struct {
+		cfg          *config.Configuration
+		maxParallel  int
+		useWorktrees bool
+		want         workflow.PhaseExecutionOptions
+	}{
+		"parallel uses shared default": {
+			cfg:         &config.Configuration{ImplementMethod: "parallel"},
+			maxParallel: workflow.DefaultMaxParallel,
+			want:        workflow.PhaseExecutionOptions{ParallelMode: true, MaxParallel: workflow.DefaultMaxParallel},
+		},
+		"parallel with flags": {
+			cfg:          &config.Configuration{ImplementMethod: "parallel"},
+			maxParallel:  2,
+			useWorktrees: true,
+			want:         workflow.PhaseExecutionOptions{ParallelMode: true, MaxParallel: 2, UseWorktrees: true},
+		},
+		"skip confirmations bypasses prompts": {
+			cfg:         &config.Configuration{ImplementMethod: "parallel", SkipConfirmations: true},
+			maxParallel: workflow.DefaultMaxParallel,
+			want: workflow.PhaseExecutionOptions{
+				ParallelMode:     true,
+				MaxParallel:      workflow.DefaultMaxParallel,
+				SkipConfirmation: true,
+			},
+		},
+		"worktrees ignored outside parallel": {
+			cfg:          &config.Configuration{ImplementMethod: "phases"},
+			maxParallel:  workflow.DefaultMaxParallel,
+			useWorktrees: true,
+			want:         workflow.PhaseExecutionOptions{RunAllPhases: true, MaxParallel: workflow.DefaultMaxParallel},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := runImplementOptions(tt.cfg, tt.maxParallel, tt.useWorktrees)
+			if got != tt.want {
+				t.Errorf("runImplementOptions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestStageConfigFromFlags(t *testing.T) {
 	tests := map[string]struct {
 		config   *workflow.StageConfig
@@ -836,3 +867,64 @@ func TestRunCommandModeTransitions(t *testing.T) {
 		})
 	}
 }
+
+func TestStagesFlag(t *testing.T) {
+	defined := map[workflow.Stage]workflow.CustomStage{
+		"threat-model": {Name: "threat-model", After: []workflow.Stage{workflow.StagePlan}},
+		"api-contract": {Name: "api-contract", After: []workflow.Stage{workflow.StageTasks}},
+	}
+
+	tests := map[string]struct {
+		names       []string
+		expected    []workflow.Stage
+		errContains string
+	}{
+		"built-in names": {
+			names:    []string{"implement", "plan", "tasks"},
+			expected: []workflow.Stage{workflow.StagePlan, workflow.StageTasks, workflow.StageImplement},
+		},
+		"built-in and custom names": {
+			names:    []string{"api-contract", "plan", " threat-model", "tasks"},
+			expected: []workflow.Stage{workflow.StagePlan, "threat-model", workflow.StageTasks, "api-contract"},
+		},
+		"duplicate custom name": {
+			names:    []string{"threat-model", "threat-model"},
+			expected: []workflow.Stage{"threat-model"},
+		},
+		"unknown name": {
+			names:       []string{"plan", "deploy"},
+			errContains: `unknown stage "deploy" in --stages; valid stages: constitution, specify, clarify, plan, tasks, checklist, analyze, implement, api-contract, threat-model`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			stageConfig := workflow.NewStageConfig()
+			custom := applyStagesFlag(stageConfig, tt.names)
+			err := selectCustomStages(stageConfig, custom, defined)
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Fatalf("selectCustomStages() error = %v, want containing %q", err, tt.errContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectCustomStages() unexpected error: %v", err)
+			}
+
+			got := stageConfig.GetCanonicalOrder()
+			if joinStages(got) != joinStages(tt.expected) {
+				t.Errorf("stages = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// joinStages joins stage names for comparison.
+func joinStages(stages []workflow.Stage) string {
+	names := make([]string, len(stages))
+	for i, stage := range stages {
+		names[i] = string(stage)
+	}
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,24 @@
+package shared
+
+import "fmt"
+
+// FormatUsage returns a compact token and cost summary (e.g., "12.3k tokens, $0.42").
+// Returns an empty string when no usage was recorded.
+func FormatUsage(tokens int, costUSD float64) string {
+	if tokens == 0 && costUSD == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s tokens, $%.2f", FormatTokenCount(tokens), costUSD)
+}
+
+// FormatTokenCount abbreviates large token counts (e.g., 1234 -> "1.2k", 2500000 -> "2.5M").
+func FormatTokenCount(tokens int) string {
+	switch {
+	case tokens >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(tokens)/1_000_000)
+	case tokens >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(tokens)/1_000)
+	default:
+		return fmt.Sprintf("%d", tokens)
+	}
+}
@@ -0,0 +1,30 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatUsage(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		tokens int
+		cost   float64
+		want   string
+	}{
+		"no usage":            {want: ""},
+		"small count":         {tokens: 950, cost: 0.011, want: "950 tokens, $0.01"},
+		"thousands":           {tokens: 12345, cost: 0.42, want: "12.3k tokens, $0.42"},
+		"millions":            {tokens: 2_500_000, cost: 12.5, want: "2.5M tokens, $12.50"},
+		"cost without tokens": {cost: 1, want: "0 tokens, $1.00"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, FormatUsage(tt.tokens, tt.cost))
+		})
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/ariel-frischer/autospec/internal/cli/shared"
-	"github.com/ariel-frischer/autospec/internal/cli/util"
 	"github.com/ariel-frischer/autospec/internal/config"
 	clierrors "github.com/ariel-frischer/autospec/internal/errors"
 	"github.com/ariel-frischer/autospec/internal/history"
@@ -39,11 +38,13 @@ Execution Modes:
 - --tasks: Run each task in a separate Claude session (finest granularity)
 - --from-task T003: Start task-level execution from a specific task ID
 - --single-session: Run all tasks in one Claude session (legacy mode)
+- --parallel: Run independent tasks concurrently in dependency waves
 
 The default execution mode can be configured in config.yml:
   implement_method: phases     # Each phase in separate session (default)
   implement_method: tasks      # Each task in separate session
   implement_method: single-session  # All tasks in one session (legacy)
+  implement_method: parallel   # Independent tasks concurrently
 
 CLI flags always override the config setting. Environment variable
 AUTOSPEC_IMPLEMENT_METHOD can also be used to set the default.
@@ -58,7 +59,16 @@ The --tasks mode provides maximum context isolation:
 - Each task gets a completely fresh Claude session
 - Ideal for complex or long-running tasks
 - Finest-grained recovery points
-- Can combine with --from-task to resume from specific task`,
+- Can combine with --from-task to resume from specific task
+
+The --parallel mode groups tasks into waves from their dependencies and
+runs each wave concurrently (up to --max-parallel sessions). With
+--worktrees every task runs in its own git worktree; after each wave the
+task branches are merged back in task order and the task statuses from
+each worktree are reconciled into tasks.yaml. A merge conflict outside
+tasks.yaml stops execution and leaves the task worktree in place for
+manual resolution. Wave progress is saved so an interrupted run can be
+resumed, and 'autospec status' shows it.`,
 	Example: `  # Auto-detect spec and implement
   autospec implement
 
@@ -87,7 +97,13 @@ The --tasks mode provides maximum context isolation:
   autospec implement --tasks --from-task T003
 
   # Run all tasks in a single Claude session (legacy mode)
-  autospec implement --single-session`,
+  autospec implement --single-session
+
+  # Run independent tasks concurrently, each in its own worktree
+  autospec implement --parallel --worktrees --max-parallel 3
+
+  # Preview the wave plan without running anything
+  autospec implement --parallel --dry-run`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true // Don't show help for execution errors
 		// Parse args to distinguish between spec-name and prompt
@@ -111,39 +127,35 @@ The --tasks mode provides maximum context isolation:
 		// Get single-session flag
 		singleSession, _ := cmd.Flags().GetBool("single-session")
 
-		// Get parallel execution flags (dev builds only)
-		var parallelMode, useWorktrees, dryRun, skipConfirmation bool
-		var maxParallel int
-		if util.IsDevBuild() {
-			parallelMode, _ = cmd.Flags().GetBool("parallel")
-			maxParallel, _ = cmd.Flags().GetInt("max-parallel")
-			useWorktrees, _ = cmd.Flags().GetBool("worktrees")
-			dryRun, _ = cmd.Flags().GetBool("dry-run")
-			skipConfirmation, _ = cmd.Flags().GetBool("yes")
-
-			// Validate parallel flag values
-			if maxParallel <= 0 {
-				cliErr := clierrors.NewArgumentError("--max-parallel must be a positive integer")
-				clierrors.PrintError(cliErr)
-				return cliErr
-			}
-			if maxParallel > 8 {
-				fmt.Fprintf(os.Stderr, "Warning: --max-parallel=%d may cause resource contention; recommended max is 8\n", maxParallel)
-			}
+		// Get parallel execution flags
+		parallelMode, _ := cmd.Flags().GetBool("parallel")
+		maxParallel, _ := cmd.Flags().GetInt("max-parallel")
+		useWorktrees, _ := cmd.Flags().GetBool("worktrees")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		skipConfirmation, _ := cmd.Flags().GetBool("yes")
 
-			// Validate --dry-run requires --parallel
-			if dryRun && !parallelMode {
-				cliErr := clierrors.NewArgumentError("--dry-run requires --parallel flag")
-				clierrors.PrintError(cliErr)
-				return cliErr
-			}
+		// Validate parallel flag values
+		if maxParallel <= 0 {
+			cliErr := clierrors.NewArgumentError("--max-parallel must be a positive integer")
+			clierrors.PrintError(cliErr)
+			return cliErr
+		}
+		if maxParallel > 8 {
+			fmt.Fprintf(os.Stderr, "Warning: --max-parallel=%d may cause resource contention; recommended max is 8\n", maxParallel)
+		}
 
-			// Validate --worktrees requires --parallel
-			if useWorktrees && !parallelMode {
-				cliErr := clierrors.NewArgumentError("--worktrees requires --parallel flag")
-				clierrors.PrintError(cliErr)
-				return cliErr
-			}
+		// Validate --dry-run requires --parallel
+		if dryRun && !parallelMode {
+			cliErr := clierrors.NewArgumentError("--dry-run requires --parallel flag")
+			clierrors.PrintError(cliErr)
+			return cliErr
+		}
+
+		// Validate --worktrees requires --parallel
+		if useWorktrees && !parallelMode {
+			cliErr := clierrors.NewArgumentError("--worktrees requires --parallel flag")
+			clierrors.PrintError(cliErr)
+			return cliErr
 		}
 
 		// Validate phase flag values
@@ -200,7 +212,7 @@ The --tasks mode provides maximum context isolation:
 			cmd.Flags().Changed("from-phase") ||
 			cmd.Flags().Changed("from-task") ||
 			cmd.Flags().Changed("single-session") ||
-			(util.IsDevBuild() && cmd.Flags().Changed("parallel"))
+			cmd.Flags().Changed("parallel")
 
 		execMode := ResolveExecutionMode(
 			ExecutionModeFlags{
@@ -357,9 +369,9 @@ func ResolveExecutionMode(flags ExecutionModeFlags, flagsChanged bool, configMet
 		SkipConfirmation: flags.YesFlag,
 	}
 
-	// Default max-parallel to 4 if not set
+	// Default max-parallel if not set
 	if result.MaxParallel == 0 {
-		result.MaxParallel = 4
+		result.MaxParallel = workflow.DefaultMaxParallel
 	}
 
 	// If --parallel flag is set, it takes precedence over other modes
@@ -427,21 +439,19 @@ func init() {
 	implementCmd.MarkFlagsMutuallyExclusive("single-session", "from-phase")
 	implementCmd.MarkFlagsMutuallyExclusive("single-session", "tasks")
 
-	// Experimental: Parallel execution flags (dev builds only)
-	if util.IsDevBuild() {
-		implementCmd.Flags().Bool("parallel", false, "Execute independent tasks concurrently using DAG-based wave scheduling")
-		implementCmd.Flags().Int("max-parallel", 4, "Maximum concurrent Claude sessions when using --parallel")
-		implementCmd.Flags().Bool("worktrees", false, "Use git worktrees for isolation when running in parallel")
-		implementCmd.Flags().Bool("dry-run", false, "Preview execution plan without running (requires --parallel)")
-		implementCmd.Flags().Bool("yes", false, "Bypass confirmation prompts (e.g., worktree isolation warning)")
-
-		// Mark parallel as mutually exclusive with other execution modes
-		implementCmd.MarkFlagsMutuallyExclusive("parallel", "tasks")
-		implementCmd.MarkFlagsMutuallyExclusive("parallel", "phases")
-		implementCmd.MarkFlagsMutuallyExclusive("parallel", "phase")
-		implementCmd.MarkFlagsMutuallyExclusive("parallel", "from-phase")
-		implementCmd.MarkFlagsMutuallyExclusive("parallel", "single-session")
-	}
+	// Parallel execution flags
+	implementCmd.Flags().Bool("parallel", false, "Execute independent tasks concurrently using DAG-based wave scheduling")
+	implementCmd.Flags().Int("max-parallel", workflow.DefaultMaxParallel, "Maximum concurrent Claude sessions when using --parallel")
+	implementCmd.Flags().Bool("worktrees", false, "Use git worktrees for isolation when running in parallel")
+	implementCmd.Flags().Bool("dry-run", false, "Preview execution plan without running (requires --parallel)")
+	implementCmd.Flags().Bool("yes", false, "Bypass confirmation prompts (e.g., worktree isolation warning)")
+
+	// Mark parallel as mutually exclusive with other execution modes
+	implementCmd.MarkFlagsMutuallyExclusive("parallel", "tasks")
+	implementCmd.MarkFlagsMutuallyExclusive("parallel", "phases")
+	implementCmd.MarkFlagsMutuallyExclusive("parallel", "phase")
+	implementCmd.MarkFlagsMutuallyExclusive("parallel", "from-phase")
+	implementCmd.MarkFlagsMutuallyExclusive("parallel", "single-session")
 
 	// Agent override flag
 	shared.AddAgentFlag(implementCmd)
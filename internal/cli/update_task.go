@@ -8,6 +8,7 @@ import (
 
 	"github.com/ariel-frischer/autospec/internal/config"
 	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/events"
 	"github.com/ariel-frischer/autospec/internal/spec"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -121,6 +122,15 @@ func runUpdateTask(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to write tasks.yaml: %w", err)
 	}
 
+	events.Publish(events.Event{
+		Type:           events.TaskStatusChanged,
+		Spec:           fmt.Sprintf("%s-%s", metadata.Number, metadata.Name),
+		TaskID:         taskID,
+		Status:         newStatus,
+		PreviousStatus: previousStatus,
+		Path:           tasksPath,
+	})
+
 	fmt.Printf("✓ Task %s: %s -> %s\n", taskID, previousStatus, newStatus)
 	return nil
 }
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/ariel-frischer/autospec/internal/cli/shared"
 	"github.com/ariel-frischer/autospec/internal/history"
@@ -159,7 +161,7 @@ func displayEntries(cmd *cobra.Command, entries []history.HistoryEntry) {
 		// Format ID (truncate or show "-" if empty)
 		id := formatID(entry.ID)
 
-		fmt.Fprintf(out, "%s  %-30s  %-10s  %s  %-15s  exit=%s  %s\n",
+		line := fmt.Sprintf("%s  %-30s  %-10s  %s  %-15s  exit=%s  %s",
 			cyan(timestamp),
 			id,
 			statusStr,
@@ -168,7 +170,40 @@ func displayEntries(cmd *cobra.Command, entries []history.HistoryEntry) {
 			exitCodeStr,
 			entry.Duration,
 		)
+		if usage := shared.FormatUsage(entry.Tokens, entry.CostUSD); usage != "" {
+			line += "  " + usage
+		}
+		if agents := formatAgents(entry.Agents); agents != "" {
+			line += "  " + agents
+		}
+		fmt.Fprintln(out, line)
+	}
+}
+
+// formatAgents returns the agents that produced a command's artifacts.
+// A single agent is shown as "agent=claude"; mixed agents (after a fallback)
+// are listed per stage: "agents=plan:codex,tasks:claude".
+func formatAgents(agents map[string]string) string {
+	if len(agents) == 0 {
+		return ""
+	}
+
+	stages := make([]string, 0, len(agents))
+	distinct := make(map[string]bool)
+	for stage, agent := range agents {
+		stages = append(stages, stage)
+		distinct[agent] = true
+	}
+	sort.Strings(stages)
+	if len(distinct) == 1 {
+		return "agent=" + agents[stages[0]]
+	}
+
+	parts := make([]string, len(stages))
+	for i, stage := range stages {
+		parts[i] = stage + ":" + agents[stage]
 	}
+	return "agents=" + strings.Join(parts, ",")
 }
 
 // formatStatus returns a color-coded status string.
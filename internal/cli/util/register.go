@@ -1,5 +1,5 @@
 // Package util provides utility CLI commands for autospec.
-// Includes: status, history, version, clean, worktree, dag
+// Includes: status, history, version, clean, serve, worktree, dag
 package util
 
 import (
@@ -19,6 +19,7 @@ func Register(rootCmd *cobra.Command) {
 	rootCmd.AddCommand(cleanCmd)
 	rootCmd.AddCommand(viewCmd)
 	rootCmd.AddCommand(ckCmd)
+	rootCmd.AddCommand(serveCmd)
 	rootCmd.AddCommand(worktree.WorktreeCmd)
 
 	// Experimental: dag and waves commands only available in dev builds
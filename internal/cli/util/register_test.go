@@ -33,7 +33,7 @@ func TestRegister(t *testing.T) {
 		commandNames[cmd.Name()] = true
 	}
 
-	// Should have status, history, version, sauce, clean, view, worktree, ck commands
+	// Should have status, history, version, sauce, clean, view, worktree, ck, serve commands
 	assert.True(t, commandNames["status"], "Should have 'status' command")
 	assert.True(t, commandNames["history"], "Should have 'history' command")
 	assert.True(t, commandNames["version"], "Should have 'version' command")
@@ -42,6 +42,7 @@ func TestRegister(t *testing.T) {
 	assert.True(t, commandNames["view"], "Should have 'view' command")
 	assert.True(t, commandNames["worktree"], "Should have 'worktree' command")
 	assert.True(t, commandNames["ck"], "Should have 'ck' command")
+	assert.True(t, commandNames["serve"], "Should have 'serve' command")
 }
 
 func TestRegister_CommandAnnotations(t *testing.T) {
@@ -111,9 +112,9 @@ func TestRegister_CommandCount(t *testing.T) {
 
 	Register(rootCmd)
 
-	// Should register exactly 11 commands (status, history, version, update, sauce, clean, view, ck, worktree, dag, waves)
+	// Should register exactly 12 commands (status, history, version, update, sauce, clean, view, ck, serve, worktree, dag, waves)
 	// Note: waves is only registered in dev builds, dag is the new DAG validation command group
-	assert.Equal(t, 11, len(rootCmd.Commands()))
+	assert.Equal(t, 12, len(rootCmd.Commands()))
 }
 
 func TestStatusCmd_Structure(t *testing.T) {
@@ -0,0 +1,154 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/serve"
+	"github.com/spf13/cobra"
+)
+
+// defaultServeAddr binds to loopback only, where no bearer token is required.
+const defaultServeAddr = "127.0.0.1:8765"
+
+// serveShutdownTimeout bounds how long shutdown waits for jobs to stop.
+const serveShutdownTimeout = 30 * time.Second
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run a local HTTP/JSON API for triggering and observing workflows",
+	Long: `Run a headless HTTP/JSON API server for dashboards and other tools.
+
+Workflow operations run as autospec subprocesses in the current directory.
+Each job streams its output and stage transitions as server-sent events.
+Only one workflow may work on a spec at a time: jobs hold the same spec locks
+as 'autospec dag run' and requests for a locked spec return 409 Conflict.
+
+Endpoints:
+  GET  /api/v1/health
+  POST /api/v1/workflows/{specify|plan|tasks|implement|run}
+  GET  /api/v1/jobs
+  GET  /api/v1/jobs/{id}
+  POST /api/v1/jobs/{id}/cancel
+  GET  /api/v1/jobs/{id}/events   (server-sent events)
+  GET  /api/v1/events             (server-sent events for all jobs)
+  GET  /api/v1/history?spec=&limit=
+  POST /api/v1/artifacts/validate
+  POST /api/v1/dag/run            (dev builds only)
+  POST /api/v1/dag/merge          (dev builds only)
+  GET  /api/v1/dag/status?file=   (dev builds only)
+
+POST requests must use Content-Type: application/json. Requests must address
+the server by its listen address or a loopback name, and browser requests are
+rejected unless their Origin is allowed with --allow-origin. The API listens on
+127.0.0.1 by default; when --addr is not a loopback address, a bearer token is
+generated at startup and required in the Authorization header.`,
+	Example: `  # Start the API server on the default address
+  autospec serve
+
+  # Listen on a different port
+  autospec serve --addr 127.0.0.1:9000
+
+  # Allow a local dashboard to call the API from the browser
+  autospec serve --allow-origin http://localhost:3000
+
+  # Plan a spec and follow its progress
+  curl -X POST localhost:8765/api/v1/workflows/plan \
+    -H 'Content-Type: application/json' -d '{"spec": "003-auth"}'
+  curl -N localhost:8765/api/v1/jobs/job-1/events`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runServe,
+}
+
+func init() {
+	serveCmd.GroupID = shared.GroupWorkflows
+	serveCmd.Flags().String("addr", defaultServeAddr, "Address to listen on (host:port)")
+	serveCmd.Flags().StringSlice("allow-origin", nil, "Browser origin allowed to call the API (repeatable, e.g. http://localhost:3000)")
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	addr, _ := cmd.Flags().GetString("addr")
+	configPath, _ := cmd.Flags().GetString("config")
+	allowedOrigins, _ := cmd.Flags().GetStringSlice("allow-origin")
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locating autospec executable: %w", err)
+	}
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting working directory: %w", err)
+	}
+
+	// Off loopback, other machines can reach the API, so require a token
+	var token string
+	if !serve.IsLoopbackAddr(addr) {
+		if token, err = serve.GenerateToken(); err != nil {
+			return err
+		}
+	}
+
+	srv := serve.NewServer(serve.Options{
+		Executable:     executable,
+		WorkDir:        workDir,
+		ConfigPath:     configPath,
+		SpecsDir:       cfg.SpecsDir,
+		HistoryDir:     getDefaultStateDir(),
+		EnableDAG:      IsDevBuild(),
+		Addr:           addr,
+		Token:          token,
+		AllowedOrigins: allowedOrigins,
+	})
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", addr, err)
+	}
+	httpServer := &http.Server{Handler: srv.Handler(), ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.Serve(listener)
+	}()
+	fmt.Fprintf(cmd.OutOrStdout(), "autospec API listening on http://%s\n", listener.Addr())
+	if token != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "API token (send as 'Authorization: Bearer <token>'): %s\n", token)
+	}
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("serving API: %w", err)
+		}
+	case <-ctx.Done():
+		fmt.Fprintln(cmd.OutOrStdout(), "Shutting down; cancelling running jobs...")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("stopping jobs: %w", err)
+	}
+	// Event streams are long-lived; Close ends them once jobs have stopped
+	if err := httpServer.Close(); err != nil {
+		return fmt.Errorf("closing server: %w", err)
+	}
+	return nil
+}
@@ -2,15 +2,19 @@ package util
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/ariel-frischer/autospec/internal/cli/shared"
 	"github.com/ariel-frischer/autospec/internal/config"
 	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/retry"
 	"github.com/ariel-frischer/autospec/internal/spec"
 	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/ariel-frischer/autospec/internal/workflow"
 	"github.com/spf13/cobra"
 )
 
@@ -85,6 +89,13 @@ var statusCmd = &cobra.Command{
 			displayBlockedTasks(tasksPath)
 		}
 
+		// Show token and cost usage recorded for this spec
+		specName := fmt.Sprintf("%s-%s", metadata.Number, metadata.Name)
+		displayUsage(os.Stdout, cfg.StateDir, specName, verbose)
+
+		// Show per-wave progress of the last parallel implement run
+		displayParallelProgress(os.Stdout, cfg.StateDir, specName)
+
 		// Show phase details in verbose mode
 		if verbose && stats != nil {
 			fmt.Println()
@@ -109,6 +120,103 @@ func init() {
 	statusCmd.Flags().BoolP("verbose", "v", false, "Show all tasks, not just unchecked")
 }
 
+// displayUsage shows accumulated token and cost usage for a spec.
+// In verbose mode, lists each stage, phase, and task scope separately.
+func displayUsage(out io.Writer, stateDir, specName string, verbose bool) {
+	total, err := retry.SpecUsageTotal(stateDir, specName)
+	if err != nil || total == nil {
+		return
+	}
+	summary := shared.FormatUsage(total.TotalTokens(), total.CostUSD)
+	if summary == "" {
+		return
+	}
+	fmt.Fprintf(out, "  usage: %s\n", summary)
+
+	if !verbose {
+		return
+	}
+	entries, _ := retry.LoadUsage(stateDir, specName)
+	for _, u := range entries {
+		fmt.Fprintf(out, "    %-20s %s\n", u.Scope, shared.FormatUsage(u.TotalTokens(), u.CostUSD))
+	}
+}
+
+// displayParallelProgress shows per-wave progress from the persisted state of
+// the last 'implement --parallel' run. Completed runs get a single line;
+// unfinished runs list every wave and the failed tasks.
+func displayParallelProgress(out io.Writer, stateDir, specName string) {
+	state, err := workflow.LoadParallelState(stateDir, specName)
+	if err != nil || state == nil {
+		return
+	}
+
+	completedWaves := 0
+	for _, info := range state.WaveResults {
+		if info.Status == "completed" {
+			completedWaves++
+		}
+	}
+	if state.IsComplete() {
+		fmt.Fprintf(out, "  parallel: %d/%d waves completed\n", completedWaves, state.TotalWaves)
+		return
+	}
+
+	summary := fmt.Sprintf("wave %d/%d", state.CurrentWave, state.TotalWaves)
+	if state.Interrupted {
+		summary += ", interrupted"
+	}
+	fmt.Fprintf(out, "  parallel: %s\n", summary)
+
+	for num := 1; num <= state.TotalWaves; num++ {
+		info, ok := state.WaveResults[num]
+		if !ok {
+			fmt.Fprintf(out, "    [ ] Wave %d: pending\n", num)
+			continue
+		}
+		fmt.Fprintf(out, "    %s Wave %d: %s\n", waveStatusIcon(info.Status), num, formatWaveCounts(info))
+	}
+
+	failed := make([]string, 0, len(state.FailedTasks))
+	for taskID := range state.FailedTasks {
+		failed = append(failed, taskID)
+	}
+	sort.Strings(failed)
+	for _, taskID := range failed {
+		fmt.Fprintf(out, "    %s failed: %s\n", taskID, truncateStatusReason(state.FailedTasks[taskID], 80))
+		if path := state.WorktreePaths[taskID]; path != "" {
+			fmt.Fprintf(out, "       Worktree: %s\n", path)
+		}
+	}
+}
+
+// waveStatusIcon returns the checkbox icon for a persisted wave status.
+func waveStatusIcon(status string) string {
+	switch status {
+	case "completed":
+		return "[✓]"
+	case "partial_failed":
+		return "[✗]"
+	default:
+		return "[~]"
+	}
+}
+
+// formatWaveCounts describes the task counts of a wave.
+func formatWaveCounts(info workflow.ParallelWaveStateInfo) string {
+	if info.Status == "running" {
+		return fmt.Sprintf("running (%d tasks)", info.TaskCount)
+	}
+	counts := fmt.Sprintf("%d/%d tasks completed", info.Completed, info.TaskCount)
+	if info.Failed > 0 {
+		counts += fmt.Sprintf(", %d failed", info.Failed)
+	}
+	if info.Skipped > 0 {
+		counts += fmt.Sprintf(", %d skipped", info.Skipped)
+	}
+	return counts
+}
+
 // displayBlockedTasks shows blocked tasks with their reasons
 func displayBlockedTasks(tasksPath string) {
 	tasks, err := validation.GetAllTasks(tasksPath)
@@ -7,8 +7,12 @@ package util
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/ariel-frischer/autospec/internal/workflow"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -91,3 +95,129 @@ func TestDisplayBlockedTasks_EmptyBlockedReason(t *testing.T) {
 	// Should handle empty blocked_reason gracefully
 	displayBlockedTasks(tasksPath)
 }
+
+func TestDisplayUsage(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		records  []*retry.UsageState
+		verbose  bool
+		contains []string
+		excludes []string
+	}{
+		"no usage prints nothing": {},
+		"summary only": {
+			records: []*retry.UsageState{
+				{SpecName: "001-test", Scope: retry.StageUsageScope("plan"), InputTokens: 1500, CostUSD: 0.25},
+				{SpecName: "001-test", Scope: retry.PhaseUsageScope(1), InputTokens: 700, CostUSD: 0.1},
+			},
+			contains: []string{"usage: 1.5k tokens, $0.25"},
+			excludes: []string{"phase:1"},
+		},
+		"verbose lists scopes": {
+			records: []*retry.UsageState{
+				{SpecName: "001-test", Scope: retry.StageUsageScope("implement"), OutputTokens: 2000, CostUSD: 1},
+				{SpecName: "001-test", Scope: retry.TaskUsageScope("T001"), OutputTokens: 2000, CostUSD: 1},
+			},
+			verbose:  true,
+			contains: []string{"usage: 2.0k tokens, $1.00", "stage:implement", "task:T001"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			stateDir := t.TempDir()
+			for _, r := range tt.records {
+				require.NoError(t, retry.RecordUsage(stateDir, r))
+			}
+
+			var out strings.Builder
+			displayUsage(&out, stateDir, "001-test", tt.verbose)
+
+			if len(tt.contains) == 0 {
+				assert.Empty(t, out.String())
+			}
+			for _, want := range tt.contains {
+				assert.Contains(t, out.String(), want)
+			}
+			for _, exclude := range tt.excludes {
+				assert.NotContains(t, out.String(), exclude)
+			}
+		})
+	}
+}
+
+func TestDisplayParallelProgress(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		setup    func(state *workflow.ParallelExecutionState)
+		contains []string
+		excludes []string
+	}{
+		"no state prints nothing": {},
+		"unfinished run lists waves and failures": {
+			setup: func(state *workflow.ParallelExecutionState) {
+				state.StartWave(1, 3)
+				state.CompleteWave(1, 3, 0, 0)
+				state.StartWave(2, 2)
+				state.RecordTaskFailure("T004", "merge conflict in shared.txt merging task T004")
+				state.WorktreePaths["T004"] = "/repo/.worktrees/T004"
+				state.CompleteWave(2, 1, 1, 0)
+				state.MarkInterrupted()
+			},
+			contains: []string{
+				"parallel: wave 2/3, interrupted",
+				"[✓] Wave 1: 3/3 tasks completed",
+				"[✗] Wave 2: 1/2 tasks completed, 1 failed",
+				"[ ] Wave 3: pending",
+				"T004 failed: merge conflict in shared.txt",
+				"Worktree: /repo/.worktrees/T004",
+			},
+		},
+		"running wave": {
+			setup: func(state *workflow.ParallelExecutionState) {
+				state.StartWave(1, 4)
+			},
+			contains: []string{"parallel: wave 1/3", "[~] Wave 1: running (4 tasks)"},
+			excludes: []string{"interrupted"},
+		},
+		"completed run is one line": {
+			setup: func(state *workflow.ParallelExecutionState) {
+				for wave := 1; wave <= 3; wave++ {
+					state.StartWave(wave, 1)
+					state.CompleteWave(wave, 1, 0, 0)
+				}
+				state.MarkCompleted()
+			},
+			contains: []string{"parallel: 3/3 waves completed"},
+			excludes: []string{"Wave 1"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			stateDir := t.TempDir()
+			if tt.setup != nil {
+				state := workflow.NewParallelExecutionState("001-test", 3, 4, true)
+				tt.setup(state)
+				require.NoError(t, workflow.SaveParallelState(stateDir, "001-test", state))
+			}
+
+			var out strings.Builder
+			displayParallelProgress(&out, stateDir, "001-test")
+
+			if len(tt.contains) == 0 {
+				assert.Empty(t, out.String())
+			}
+			for _, want := range tt.contains {
+				assert.Contains(t, out.String(), want)
+			}
+			for _, exclude := range tt.excludes {
+				assert.NotContains(t, out.String(), exclude)
+			}
+		})
+	}
+}
@@ -170,6 +170,10 @@ func TestUtilCommands_GroupIDs(t *testing.T) {
 			cmd:         versionCmd,
 			wantGroupID: "getting-started",
 		},
+		"serve group": {
+			cmd:         serveCmd,
+			wantGroupID: "workflows",
+		},
 	}
 
 	for name, tt := range tests {
@@ -1061,6 +1065,54 @@ func TestDisplayEntries_OutputFormat(t *testing.T) {
 	assert.Contains(t, output, "test_id_123")
 }
 
+func TestDisplayEntries_ShowsUsage(t *testing.T) {
+	t.Parallel()
+
+	entries := []history.HistoryEntry{
+		{ID: "with_usage", Command: "plan", Status: "completed", Tokens: 12345, CostUSD: 0.42},
+		{ID: "without_usage", Command: "tasks", Status: "completed"},
+	}
+
+	cmd := &cobra.Command{}
+	var outBuf strings.Builder
+	cmd.SetOut(&outBuf)
+
+	displayEntries(cmd, entries)
+
+	lines := strings.Split(strings.TrimSpace(outBuf.String()), "\n")
+	if !assert.Len(t, lines, 2) {
+		return
+	}
+	assert.Contains(t, lines[0], "12.3k tokens, $0.42")
+	assert.NotContains(t, lines[1], "tokens")
+}
+
+func TestFormatAgents(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		agents map[string]string
+		want   string
+	}{
+		"no agents": {},
+		"single agent across stages": {
+			agents: map[string]string{"plan": "claude", "tasks": "claude"},
+			want:   "agent=claude",
+		},
+		"mixed agents sorted by stage": {
+			agents: map[string]string{"tasks": "claude", "plan": "codex"},
+			want:   "agents=plan:codex,tasks:claude",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, formatAgents(tt.agents))
+		})
+	}
+}
+
 // Test history command with temp directory
 
 func TestRunHistoryWithStateDir_InvalidLimit(t *testing.T) {
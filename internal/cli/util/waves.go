@@ -13,9 +13,25 @@ import (
 )
 
 var wavesCmd = &cobra.Command{
-	Use:          "waves [spec-name]",
-	Short:        "Visualize task execution waves and dependency graph",
-	Long:         `Display an ASCII visualization of task execution waves showing which tasks can run in parallel and the execution order.`,
+	Use:   "waves [spec-name]",
+	Short: "Visualize task execution waves and dependency graph",
+	Long: `Display an ASCII visualization of task execution waves showing which tasks can run in parallel and the execution order.
+
+Use --format to export the task graph instead:
+  mermaid  Mermaid flowchart for markdown (GitHub PRs, docs sites)
+  dot      Graphviz DOT digraph (render with: dot -Tsvg)
+  json     Waves, tasks, edges, and status as JSON
+
+Exported graphs colour each task by its status in tasks.yaml
+(InProgress, Completed).`,
+	Example: `  # Show waves for the current spec
+  autospec waves
+
+  # Mermaid diagram for a PR description
+  autospec waves 001-auth --format mermaid
+
+  # Render an SVG with Graphviz
+  autospec waves --format dot | dot -Tsvg > waves.svg`,
 	Args:         cobra.MaximumNArgs(1),
 	SilenceUsage: true,
 	RunE:         runWavesCmd,
@@ -25,14 +41,20 @@ func init() {
 	wavesCmd.Flags().Bool("compact", false, "Show compact single-line output")
 	wavesCmd.Flags().Bool("detailed", false, "Show detailed task information")
 	wavesCmd.Flags().Bool("stats", false, "Show only wave statistics")
+	wavesCmd.Flags().String("format", "ascii", "Output format: ascii, mermaid, dot, json")
 }
 
 func runWavesCmd(cmd *cobra.Command, args []string) error {
 	compact, _ := cmd.Flags().GetBool("compact")
 	detailed, _ := cmd.Flags().GetBool("detailed")
 	stats, _ := cmd.Flags().GetBool("stats")
+	format, _ := cmd.Flags().GetString("format")
 	configPath, _ := cmd.Flags().GetString("config")
 
+	if err := validateWavesFormat(format, compact || detailed || stats); err != nil {
+		return err
+	}
+
 	// Load configuration
 	cfg, err := config.Load(configPath)
 	if err != nil {
@@ -46,7 +68,10 @@ func runWavesCmd(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	shared.PrintSpecInfo(metadata)
+	// Keep export output machine-readable
+	if format == "ascii" {
+		shared.PrintSpecInfo(metadata)
+	}
 
 	// Load tasks
 	tasksPath := validation.GetTasksFilePath(metadata.Directory)
@@ -72,10 +97,48 @@ func runWavesCmd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("computing execution waves: %w", err)
 	}
 
+	if format != "ascii" {
+		graph.ApplyTaskStatuses()
+		return renderExport(graph, format)
+	}
+
 	// Render output based on flags
 	return renderOutput(graph, compact, detailed, stats)
 }
 
+// validateWavesFormat checks --format and that the ASCII view flags
+// (--compact, --detailed, --stats) are not combined with an export format.
+func validateWavesFormat(format string, asciiView bool) error {
+	switch format {
+	case "ascii":
+		return nil
+	case "mermaid", "dot", "json":
+		if asciiView {
+			return fmt.Errorf("--compact, --detailed, and --stats can only be used with --format ascii")
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid format %q: must be one of: ascii, mermaid, dot, json", format)
+	}
+}
+
+// renderExport prints the graph in an export format.
+func renderExport(graph *taskgraph.DependencyGraph, format string) error {
+	switch format {
+	case "mermaid":
+		fmt.Print(graph.RenderMermaid())
+	case "dot":
+		fmt.Print(graph.RenderDOT())
+	case "json":
+		out, err := graph.RenderJSON()
+		if err != nil {
+			return err
+		}
+		fmt.Print(out)
+	}
+	return nil
+}
+
 // detectSpec determines which spec to use from args or auto-detection.
 func detectSpec(specsDir string, args []string) (*spec.Metadata, error) {
 	var metadata *spec.Metadata
@@ -137,3 +137,41 @@ func TestDetectSpec(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestValidateWavesFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		format    string
+		asciiView bool
+		wantErr   string
+	}{
+		"ascii":                {format: "ascii"},
+		"ascii with view flag": {format: "ascii", asciiView: true},
+		"mermaid":              {format: "mermaid"},
+		"dot":                  {format: "dot"},
+		"json":                 {format: "json"},
+		"unknown format":       {format: "png", wantErr: `invalid format "png"`},
+		"view flag with json":  {format: "json", asciiView: true, wantErr: "can only be used with --format ascii"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := validateWavesFormat(tt.format, tt.asciiView)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestWavesCmd_FormatFlagRegistered(t *testing.T) {
+	t.Parallel()
+
+	flag := wavesCmd.Flags().Lookup("format")
+	require.NotNil(t, flag)
+	assert.Equal(t, "ascii", flag.DefValue)
+}
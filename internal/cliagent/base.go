@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
@@ -349,13 +350,22 @@ func (b *BaseAgent) runCommand(ctx context.Context, cmd *exec.Cmd, opts ExecOpti
 	defer cancel()
 
 	var stdoutBuf, stderrBuf bytes.Buffer
-	cmd.Stdout = opts.Stdout
-	if cmd.Stdout == nil {
-		cmd.Stdout = &stdoutBuf
+	var stdout io.Writer = &stdoutBuf
+	if opts.Stdout != nil {
+		stdout = opts.Stdout
 	}
-	cmd.Stderr = opts.Stderr
-	if cmd.Stderr == nil {
-		cmd.Stderr = &stderrBuf
+	usage := &usageCollector{}
+	tail := &outputTail{}
+	errorLines := &stdoutErrorLines{tail: tail}
+	cmd.Stdout = io.MultiWriter(stdout, usage, errorLines)
+	var stderr io.Writer = &stderrBuf
+	if opts.Stderr != nil {
+		stderr = opts.Stderr
+	}
+	cmd.Stderr = stderr
+	if !opts.Interactive {
+		// Interactive sessions keep a direct stderr; fallbacks only apply headless
+		cmd.Stderr = io.MultiWriter(stderr, tail)
 	}
 
 	if err := cmd.Start(); err != nil {
@@ -382,11 +392,14 @@ func (b *BaseAgent) runCommand(ctx context.Context, cmd *exec.Cmd, opts ExecOpti
 		Duration: duration,
 		Stdout:   stdoutBuf.String(),
 		Stderr:   stderrBuf.String(),
+		Usage:    usage.Usage(),
 	}
 
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			result.ExitCode = exitErr.ExitCode()
+			errorLines.Flush()
+			result.RateLimited = IsRateLimited(b.AgentCaps, tail.String())
 		} else {
 			return nil, fmt.Errorf("executing %s: %w", b.AgentName, err)
 		}
@@ -92,4 +92,9 @@ type Caps struct {
 	// Added after prompt delivery args but before AutonomousFlag and ExtraArgs.
 	// Example: ["--verbose", "--output-format", "stream-json"]
 	DefaultArgs []string
+
+	// RateLimitPatterns are agent-specific output fragments (case-insensitive) that
+	// identify a rate-limit or quota failure, checked in addition to DefaultRateLimitPatterns.
+	// Example: ["RESOURCE_EXHAUSTED"]
+	RateLimitPatterns []string
 }
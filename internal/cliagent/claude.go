@@ -34,6 +34,8 @@ func NewClaude() *Claude {
 				// DefaultArgs enables stream-json output for better terminal parsing.
 				// --verbose is required with stream-json or Claude will error.
 				DefaultArgs: []string{"--verbose", "--output-format", "stream-json"},
+				// Subscription usage caps and API 429/529 responses
+				RateLimitPatterns: []string{"usage limit reached", "rate_limit_error", "credit balance is too low"},
 			},
 		},
 	}
@@ -25,6 +25,8 @@ func NewCodex() *Codex {
 				// Ref: https://developers.openai.com/codex/cli/reference/#codex-login
 				RequiredEnv: []string{},
 				OptionalEnv: []string{"OPENAI_API_KEY", "CODEX_API_KEY"},
+				// OpenAI quota errors and ChatGPT plan usage caps
+				RateLimitPatterns: []string{"insufficient_quota", "you've hit your usage limit"},
 			},
 		},
 	}
@@ -217,8 +217,11 @@ func (c *CustomAgent) runCommand(ctx context.Context, cmd *exec.Cmd, opts ExecOp
 	if opts.Stderr != nil {
 		stderr = io.MultiWriter(opts.Stderr, &stderrBuf)
 	}
-	cmd.Stdout = stdout
-	cmd.Stderr = stderr
+	usage := &usageCollector{}
+	tail := &outputTail{}
+	errorLines := &stdoutErrorLines{tail: tail}
+	cmd.Stdout = io.MultiWriter(stdout, usage, errorLines)
+	cmd.Stderr = io.MultiWriter(stderr, tail)
 
 	if err := cmd.Start(); err != nil {
 		return nil, fmt.Errorf("starting custom agent: %w", err)
@@ -244,11 +247,14 @@ func (c *CustomAgent) runCommand(ctx context.Context, cmd *exec.Cmd, opts ExecOp
 		Duration: duration,
 		Stdout:   stdoutBuf.String(),
 		Stderr:   stderrBuf.String(),
+		Usage:    usage.Usage(),
 	}
 
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			result.ExitCode = exitErr.ExitCode()
+			errorLines.Flush()
+			result.RateLimited = IsRateLimited(c.caps, tail.String())
 		} else {
 			return nil, fmt.Errorf("executing custom agent: %w", err)
 		}
@@ -22,6 +22,8 @@ func NewGemini() *Gemini {
 				AutonomousFlag: "--yolo",
 				RequiredEnv:    []string{"GEMINI_API_KEY"},
 				OptionalEnv:    []string{},
+				// Google API quota errors
+				RateLimitPatterns: []string{"RESOURCE_EXHAUSTED", "exceeded your current quota"},
 			},
 		},
 	}
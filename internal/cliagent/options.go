@@ -69,4 +69,12 @@ type Result struct {
 
 	// Duration is the execution time from command start to completion.
 	Duration time.Duration
+
+	// Usage contains token and cost accounting parsed from stream-json output.
+	// Zero when the agent does not report usage.
+	Usage Usage
+
+	// RateLimited is true when the agent exited non-zero and its output matched
+	// a rate-limit or quota signature (see IsRateLimited).
+	RateLimited bool
 }
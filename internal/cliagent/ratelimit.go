@@ -0,0 +1,141 @@
+package cliagent
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// ErrRateLimited indicates an agent exited because of a rate limit or exhausted quota.
+// Callers use errors.Is to decide whether to retry the prompt on a fallback agent.
+var ErrRateLimited = errors.New("agent rate limited")
+
+// DefaultRateLimitPatterns are case-insensitive output fragments that signal a
+// rate limit or exhausted quota for any agent. Agent-specific signatures are
+// added via Caps.RateLimitPatterns.
+var DefaultRateLimitPatterns = []string{
+	"rate limit",
+	"rate_limit",
+	"ratelimit",
+	"usage limit",
+	"quota exceeded",
+	"too many requests",
+}
+
+// rateLimitTailSize bounds how much trailing output is scanned for rate-limit signatures.
+// Rate-limit errors are reported at the end of a session, so the tail is enough.
+const rateLimitTailSize = 16 * 1024
+
+// IsRateLimited reports whether output contains a rate-limit signature from
+// DefaultRateLimitPatterns or the agent-specific patterns in caps. Agents pass
+// stderr and the structured error lines of stdout (see stdoutErrorLines), not
+// the model's streamed text, which mentions rate limits whenever the agent
+// works on rate-limit code.
+func IsRateLimited(caps Caps, output string) bool {
+	lower := strings.ToLower(output)
+	for _, pattern := range DefaultRateLimitPatterns {
+		if strings.Contains(lower, pattern) {
+			return true
+		}
+	}
+	for _, pattern := range caps.RateLimitPatterns {
+		if pattern != "" && strings.Contains(lower, strings.ToLower(pattern)) {
+			return true
+		}
+	}
+	return false
+}
+
+// outputTail is an io.Writer that retains the last rateLimitTailSize bytes written.
+// Stderr and the stdout error lines share one tail so signatures are found
+// regardless of stream.
+type outputTail struct {
+	mu  sync.Mutex
+	buf []byte
+}
+
+// Write appends p and discards the oldest bytes beyond the tail size.
+func (t *outputTail) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.buf = append(t.buf, p...)
+	if over := len(t.buf) - rateLimitTailSize; over > 0 {
+		t.buf = t.buf[over:]
+	}
+	return len(p), nil
+}
+
+// String returns the retained output.
+func (t *outputTail) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return string(t.buf)
+}
+
+// stdoutErrorLines is an io.Writer that forwards only the agent CLI's own
+// structured error lines from stdout to tail. Other lines, including the
+// model's streamed text, are dropped.
+type stdoutErrorLines struct {
+	mu   sync.Mutex
+	buf  []byte
+	tail *outputTail
+}
+
+// Write buffers partial lines and forwards complete error lines.
+func (w *stdoutErrorLines) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+		if idx < 0 {
+			break
+		}
+		if isStructuredErrorLine(w.buf[:idx]) {
+			_, _ = w.tail.Write(w.buf[:idx+1])
+		}
+		w.buf = w.buf[idx+1:]
+	}
+	if len(w.buf) > rateLimitTailSize {
+		w.buf = nil // Error lines are short; drop overlong streamed text
+	}
+	return len(p), nil
+}
+
+// Flush forwards an unterminated final error line.
+func (w *stdoutErrorLines) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if isStructuredErrorLine(w.buf) {
+		_, _ = w.tail.Write(w.buf)
+	}
+	w.buf = nil
+}
+
+// structuredErrorLine is the subset of a JSON output line that marks an error
+// reported by the agent CLI itself, such as a stream-json result with
+// is_error set or a {"type":"error"} event.
+type structuredErrorLine struct {
+	Type    string          `json:"type"`
+	IsError bool            `json:"is_error"`
+	Error   json.RawMessage `json:"error"`
+}
+
+// isStructuredErrorLine reports whether line is a JSON error line from the agent CLI.
+func isStructuredErrorLine(line []byte) bool {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 || line[0] != '{' {
+		return false
+	}
+	var msg structuredErrorLine
+	if err := json.Unmarshal(line, &msg); err != nil {
+		return false
+	}
+	hasError := len(msg.Error) > 0 && string(msg.Error) != "null"
+	return msg.Type == "error" || msg.IsError || hasError
+}
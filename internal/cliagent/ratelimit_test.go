@@ -0,0 +1,179 @@
+package cliagent
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestIsRateLimited(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		caps   Caps
+		output string
+		want   bool
+	}{
+		"generic rate limit message": {
+			output: "Error: Rate limit exceeded, retry later",
+			want:   true,
+		},
+		"too many requests": {
+			output: "HTTP 429 Too Many Requests",
+			want:   true,
+		},
+		"agent-specific pattern": {
+			caps:   Caps{RateLimitPatterns: []string{"RESOURCE_EXHAUSTED"}},
+			output: `{"error": {"status": "resource_exhausted"}}`,
+			want:   true,
+		},
+		"agent-specific pattern not configured": {
+			output: `{"error": {"status": "RESOURCE_EXHAUSTED"}}`,
+			want:   false,
+		},
+		"ordinary failure": {
+			output: "Error: file not found",
+			want:   false,
+		},
+		"empty output": {
+			want: false,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if got := IsRateLimited(tt.caps, tt.output); got != tt.want {
+				t.Errorf("IsRateLimited() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuiltinAgents_RateLimitPatterns(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		agent  Agent
+		output string
+	}{
+		"claude usage cap": {
+			agent:  NewClaude(),
+			output: `{"type":"result","is_error":true,"result":"Claude AI usage limit reached|1767225600"}`,
+		},
+		"gemini quota": {
+			agent:  NewGemini(),
+			output: "[API Error: RESOURCE_EXHAUSTED]",
+		},
+		"codex quota": {
+			agent:  NewCodex(),
+			output: "ERROR: insufficient_quota",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if !IsRateLimited(tt.agent.Capabilities(), tt.output) {
+				t.Errorf("%s: output %q not detected as rate limited", tt.agent.Name(), tt.output)
+			}
+		})
+	}
+}
+
+func TestOutputTail(t *testing.T) {
+	t.Parallel()
+
+	tail := &outputTail{}
+	_, _ = tail.Write([]byte(strings.Repeat("x", rateLimitTailSize)))
+	_, _ = tail.Write([]byte("rate limit"))
+
+	got := tail.String()
+	if len(got) != rateLimitTailSize {
+		t.Errorf("tail length = %d, want %d", len(got), rateLimitTailSize)
+	}
+	if !strings.HasSuffix(got, "rate limit") {
+		t.Errorf("tail should keep the most recent output, got suffix %q", got[len(got)-20:])
+	}
+}
+
+func TestBaseAgent_Execute_RateLimited(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		script          string
+		wantRateLimited bool
+	}{
+		"rate limit on stderr": {
+			script:          "echo 'Error: 429 Too Many Requests' >&2; exit 1",
+			wantRateLimited: true,
+		},
+		"structured error on stdout": {
+			script:          `echo '{"type":"result","is_error":true,"result":"usage limit reached"}'; exit 1`,
+			wantRateLimited: true,
+		},
+		"unterminated structured error on stdout": {
+			script:          `printf '{"type":"error","error":{"type":"rate_limit_error"}}'; exit 1`,
+			wantRateLimited: true,
+		},
+		"failure while streaming rate limit code": {
+			script:          `echo 'Adding rate limit middleware (429 Too Many Requests)'; echo '{"type":"assistant","message":"usage limit"}'; exit 1`,
+			wantRateLimited: false,
+		},
+		"ordinary failure": {
+			script:          "echo 'syntax error' >&2; exit 1",
+			wantRateLimited: false,
+		},
+		"success mentioning rate limits": {
+			script:          "echo 'added rate limit middleware'",
+			wantRateLimited: false,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			agent := &BaseAgent{
+				AgentName: "test",
+				Cmd:       "sh",
+				AgentCaps: Caps{PromptDelivery: PromptDelivery{Method: PromptMethodArg, Flag: "-c"}},
+			}
+			var stdout, stderr bytes.Buffer
+			result, err := agent.Execute(context.Background(), tt.script, ExecOptions{Stdout: &stdout, Stderr: &stderr})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if result.RateLimited != tt.wantRateLimited {
+				t.Errorf("RateLimited = %v, want %v", result.RateLimited, tt.wantRateLimited)
+			}
+		})
+	}
+}
+
+func TestIsStructuredErrorLine(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		line string
+		want bool
+	}{
+		"result with is_error": {line: `{"type":"result","is_error":true,"result":"limit"}`, want: true},
+		"error event":          {line: `{"type":"error","message":"overloaded"}`, want: true},
+		"error field":          {line: `  {"error":{"code":429}}`, want: true},
+		"successful result":    {line: `{"type":"result","is_error":false}`},
+		"null error field":     {line: `{"type":"assistant","error":null}`},
+		"assistant text":       {line: `{"type":"assistant","message":"handle rate limit errors"}`},
+		"plain text":           {line: "Error: rate limit exceeded"},
+		"invalid json":         {line: `{"type":"error"`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if got := isStructuredErrorLine([]byte(tt.line)); got != tt.want {
+				t.Errorf("isStructuredErrorLine(%q) = %v, want %v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package cliagent
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+)
+
+// Usage contains token and cost accounting reported by an agent session.
+// Agents that emit Claude-style stream-json report a final "result" message
+// carrying total_cost_usd and a usage object; other agents leave Usage zero.
+type Usage struct {
+	InputTokens              int     `json:"input_tokens,omitempty" yaml:"input_tokens,omitempty"`
+	OutputTokens             int     `json:"output_tokens,omitempty" yaml:"output_tokens,omitempty"`
+	CacheCreationInputTokens int     `json:"cache_creation_input_tokens,omitempty" yaml:"cache_creation_input_tokens,omitempty"`
+	CacheReadInputTokens     int     `json:"cache_read_input_tokens,omitempty" yaml:"cache_read_input_tokens,omitempty"`
+	CostUSD                  float64 `json:"cost_usd,omitempty" yaml:"cost_usd,omitempty"`
+}
+
+// Add accumulates other into u.
+func (u *Usage) Add(other Usage) {
+	u.InputTokens += other.InputTokens
+	u.OutputTokens += other.OutputTokens
+	u.CacheCreationInputTokens += other.CacheCreationInputTokens
+	u.CacheReadInputTokens += other.CacheReadInputTokens
+	u.CostUSD += other.CostUSD
+}
+
+// TotalTokens returns the sum of input, output, and cache tokens.
+func (u Usage) TotalTokens() int {
+	return u.InputTokens + u.OutputTokens + u.CacheCreationInputTokens + u.CacheReadInputTokens
+}
+
+// IsZero reports whether no usage was recorded.
+func (u Usage) IsZero() bool {
+	return u.TotalTokens() == 0 && u.CostUSD == 0
+}
+
+// streamResultMessage is the subset of a stream-json "result" message we need.
+type streamResultMessage struct {
+	Type         string  `json:"type"`
+	TotalCostUSD float64 `json:"total_cost_usd"`
+	Usage        *struct {
+		InputTokens              int `json:"input_tokens"`
+		OutputTokens             int `json:"output_tokens"`
+		CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+		CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+	} `json:"usage"`
+}
+
+// ParseUsageLine extracts usage from a single stream-json line.
+// Returns false if the line is not a "result" message.
+func ParseUsageLine(line []byte) (Usage, bool) {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 || line[0] != '{' {
+		return Usage{}, false
+	}
+
+	var msg streamResultMessage
+	if err := json.Unmarshal(line, &msg); err != nil || msg.Type != "result" {
+		return Usage{}, false
+	}
+
+	u := Usage{CostUSD: msg.TotalCostUSD}
+	if msg.Usage != nil {
+		u.InputTokens = msg.Usage.InputTokens
+		u.OutputTokens = msg.Usage.OutputTokens
+		u.CacheCreationInputTokens = msg.Usage.CacheCreationInputTokens
+		u.CacheReadInputTokens = msg.Usage.CacheReadInputTokens
+	}
+	return u, true
+}
+
+// usageCollector is an io.Writer that scans stdout for stream-json result
+// messages and accumulates their usage. It never fails writes so it can be
+// teed alongside the caller's stdout writer.
+type usageCollector struct {
+	mu    sync.Mutex
+	buf   []byte
+	usage Usage
+}
+
+// Write buffers partial lines and parses each complete line.
+func (c *usageCollector) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.buf = append(c.buf, p...)
+	for {
+		idx := bytes.IndexByte(c.buf, '\n')
+		if idx < 0 {
+			break
+		}
+		c.collect(c.buf[:idx])
+		c.buf = c.buf[idx+1:]
+	}
+	return len(p), nil
+}
+
+// Usage returns the accumulated usage, including any unterminated final line.
+func (c *usageCollector) Usage() Usage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.buf) > 0 {
+		c.collect(c.buf)
+		c.buf = nil
+	}
+	return c.usage
+}
+
+// collect parses a line and adds its usage if it is a result message.
+func (c *usageCollector) collect(line []byte) {
+	if u, ok := ParseUsageLine(line); ok {
+		c.usage.Add(u)
+	}
+}
@@ -0,0 +1,135 @@
+package cliagent
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParseUsageLine(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		line   string
+		want   Usage
+		wantOK bool
+	}{
+		"result message with usage": {
+			line: `{"type":"result","subtype":"success","total_cost_usd":0.125,"usage":{"input_tokens":100,"output_tokens":50,"cache_creation_input_tokens":10,"cache_read_input_tokens":5}}`,
+			want: Usage{
+				InputTokens:              100,
+				OutputTokens:             50,
+				CacheCreationInputTokens: 10,
+				CacheReadInputTokens:     5,
+				CostUSD:                  0.125,
+			},
+			wantOK: true,
+		},
+		"result message without usage": {
+			line:   `{"type":"result","total_cost_usd":0.5}`,
+			want:   Usage{CostUSD: 0.5},
+			wantOK: true,
+		},
+		"assistant message": {
+			line:   `{"type":"assistant","message":{"usage":{"input_tokens":100}}}`,
+			wantOK: false,
+		},
+		"plain text": {
+			line:   "hello world",
+			wantOK: false,
+		},
+		"invalid json": {
+			line:   `{"type":"result",`,
+			wantOK: false,
+		},
+		"empty line": {
+			line:   "",
+			wantOK: false,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := ParseUsageLine([]byte(tt.line))
+			if ok != tt.wantOK {
+				t.Fatalf("ParseUsageLine() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("ParseUsageLine() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUsage_AddAndTotals(t *testing.T) {
+	t.Parallel()
+
+	var u Usage
+	if !u.IsZero() {
+		t.Error("zero Usage should report IsZero")
+	}
+
+	u.Add(Usage{InputTokens: 10, OutputTokens: 5, CostUSD: 0.1})
+	u.Add(Usage{CacheReadInputTokens: 3, CacheCreationInputTokens: 2, CostUSD: 0.2})
+
+	if got := u.TotalTokens(); got != 20 {
+		t.Errorf("TotalTokens() = %d, want 20", got)
+	}
+	if u.CostUSD < 0.299 || u.CostUSD > 0.301 {
+		t.Errorf("CostUSD = %v, want 0.3", u.CostUSD)
+	}
+	if u.IsZero() {
+		t.Error("non-zero Usage should not report IsZero")
+	}
+}
+
+func TestUsageCollector_SplitWrites(t *testing.T) {
+	t.Parallel()
+
+	stream := `{"type":"system"}` + "\n" +
+		`{"type":"result","total_cost_usd":1.5,"usage":{"input_tokens":7,"output_tokens":3}}`
+
+	c := &usageCollector{}
+	// Write in small chunks to exercise line buffering across writes
+	for i := 0; i < len(stream); i += 8 {
+		end := i + 8
+		if end > len(stream) {
+			end = len(stream)
+		}
+		n, err := c.Write([]byte(stream[i:end]))
+		if err != nil || n != end-i {
+			t.Fatalf("Write() = (%d, %v)", n, err)
+		}
+	}
+
+	want := Usage{InputTokens: 7, OutputTokens: 3, CostUSD: 1.5}
+	if got := c.Usage(); got != want {
+		t.Errorf("Usage() = %+v, want %+v", got, want)
+	}
+}
+
+func TestBaseAgent_Execute_CapturesUsage(t *testing.T) {
+	t.Parallel()
+	agent := &BaseAgent{
+		AgentName: "test",
+		Cmd:       "echo",
+		AgentCaps: Caps{
+			PromptDelivery: PromptDelivery{Method: PromptMethodPositional},
+		},
+	}
+
+	line := `{"type":"result","total_cost_usd":0.25,"usage":{"input_tokens":11,"output_tokens":22}}`
+	var buf strings.Builder
+	result, err := agent.Execute(context.Background(), line, ExecOptions{Stdout: &buf})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	want := Usage{InputTokens: 11, OutputTokens: 22, CostUSD: 0.25}
+	if result.Usage != want {
+		t.Errorf("result.Usage = %+v, want %+v", result.Usage, want)
+	}
+	if !strings.Contains(buf.String(), "total_cost_usd") {
+		t.Errorf("custom writer should still receive output, got %q", buf.String())
+	}
+}
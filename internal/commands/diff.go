@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// DiffOverride returns a unified diff from the embedded template to an
+// override, or an empty string when they are identical.
+func DiffOverride(name string, override TemplateOverride) (string, error) {
+	embedded, err := GetTemplate(name)
+	if err != nil {
+		return "", fmt.Errorf("template not found: %s", name)
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(embedded)),
+		B:        difflib.SplitLines(string(override.Content)),
+		FromFile: "embedded/" + name + ".md",
+		ToFile:   override.Path,
+		Context:  3,
+	})
+	if err != nil {
+		return "", fmt.Errorf("diffing %s: %w", name, err)
+	}
+	return diff, nil
+}
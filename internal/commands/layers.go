@@ -0,0 +1,192 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/ariel-frischer/autospec/internal/prereqs"
+)
+
+// Template sources, from lowest to highest precedence.
+const (
+	SourceEmbedded = "embedded"
+	SourceUser     = "user"
+	SourceProject  = "project"
+)
+
+// partialPrefix marks partial templates in override directories. A partial
+// such as _rules.md is available to every command as {{template "_rules" .}}.
+const partialPrefix = "_"
+
+// TemplateLayer is a directory of command template overrides.
+type TemplateLayer struct {
+	Source string // SourceUser or SourceProject
+	Dir    string // Directory containing <command-name>.md and _<partial>.md files
+}
+
+// TemplateOverride is an override of a command template found in a layer.
+type TemplateOverride struct {
+	Source  string // Layer the override was found in
+	Path    string // Path to the override file
+	Content []byte // Raw override content
+}
+
+// ProjectTemplateDir returns the project-level command template directory.
+// This is always .autospec/commands relative to the current directory.
+func ProjectTemplateDir() string {
+	return filepath.Join(".autospec", "commands")
+}
+
+// UserTemplateDir returns the user-level command template directory,
+// next to the user config file (e.g. ~/.config/autospec/commands).
+func UserTemplateDir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "autospec", "commands"), nil
+}
+
+// TemplateLayers returns the override layers searched on top of the embedded
+// templates, lowest precedence first: user config dir, then project.
+func TemplateLayers() []TemplateLayer {
+	var layers []TemplateLayer
+	if dir, err := UserTemplateDir(); err == nil {
+		layers = append(layers, TemplateLayer{Source: SourceUser, Dir: dir})
+	}
+	return append(layers, TemplateLayer{Source: SourceProject, Dir: ProjectTemplateDir()})
+}
+
+// FindOverrides returns the overrides of a command template in the given
+// layers, in layer order.
+func FindOverrides(name string, layers []TemplateLayer) ([]TemplateOverride, error) {
+	var overrides []TemplateOverride
+	for _, layer := range layers {
+		path := filepath.Join(layer.Dir, name+".md")
+		content, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s override: %w", layer.Source, err)
+		}
+		overrides = append(overrides, TemplateOverride{Source: layer.Source, Path: path, Content: content})
+	}
+	return overrides, nil
+}
+
+// TemplateSource reports which layer a command template resolves from: the
+// highest-precedence override, or SourceEmbedded. The path is empty for
+// embedded templates.
+func TemplateSource(name string, layers []TemplateLayer) (source, path string, err error) {
+	overrides, err := FindOverrides(name, layers)
+	if err != nil {
+		return "", "", err
+	}
+	if len(overrides) == 0 {
+		if _, err := GetTemplate(name); err != nil {
+			return "", "", fmt.Errorf("template not found: %s", name)
+		}
+		return SourceEmbedded, "", nil
+	}
+	last := overrides[len(overrides)-1]
+	return last.Source, last.Path, nil
+}
+
+// ParseLayeredTemplate parses a command template from the embedded version
+// and its overrides into one template set, so later layers can redefine
+// {{block}}s of earlier ones.
+//
+// Each layer is parsed on top of the previous one with text/template
+// semantics: an override whose body is only {{define}}s (and whitespace)
+// keeps the body below it and replaces just the named blocks, while an
+// override with other content replaces the whole body. Frontmatter in
+// overrides is ignored. Partials (_<name>.md) from every layer are added as
+// templates named after the file.
+func ParseLayeredTemplate(name string, layers []TemplateLayer) (*template.Template, error) {
+	tmpl := template.New(name)
+	found := false
+
+	if content, err := GetTemplate(name); err == nil {
+		if _, err := tmpl.Parse(string(content)); err != nil {
+			return nil, fmt.Errorf("parsing embedded template %s: %w", name, err)
+		}
+		found = true
+	}
+
+	for _, layer := range layers {
+		if err := parsePartials(tmpl, layer); err != nil {
+			return nil, err
+		}
+		path := filepath.Join(layer.Dir, name+".md")
+		content, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s override: %w", layer.Source, err)
+		}
+		if _, err := tmpl.Parse(string(StripFrontmatter(content))); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		found = true
+	}
+
+	if !found {
+		return nil, fmt.Errorf("template not found: %s", name)
+	}
+	return tmpl, nil
+}
+
+// parsePartials adds the partial templates of a layer to tmpl.
+func parsePartials(tmpl *template.Template, layer TemplateLayer) error {
+	paths, err := filepath.Glob(filepath.Join(layer.Dir, partialPrefix+"*.md"))
+	if err != nil {
+		return fmt.Errorf("listing %s partials: %w", layer.Source, err)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading partial %s: %w", path, err)
+		}
+		partial := strings.TrimSuffix(filepath.Base(path), ".md")
+		if _, err := tmpl.New(partial).Parse(string(StripFrontmatter(content))); err != nil {
+			return fmt.Errorf("parsing partial %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// RenderCommand validates requirements and renders a command template
+// resolved through the override layers (user config dir, then project
+// .autospec/commands) on top of the embedded version.
+func RenderCommand(commandName string, ctx *prereqs.Context) ([]byte, error) {
+	return RenderCommandWithLayers(commandName, ctx, TemplateLayers())
+}
+
+// RenderCommandWithLayers is RenderCommand with explicit override layers.
+func RenderCommandWithLayers(commandName string, ctx *prereqs.Context, layers []TemplateLayer) ([]byte, error) {
+	if err := ValidateRequirements(commandName, ctx); err != nil {
+		return nil, err
+	}
+
+	tmpl, err := ParseLayeredTemplate(commandName, layers)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, ctx); err != nil {
+		return nil, fmt.Errorf("executing template: %w", err)
+	}
+	return StripFrontmatter(buf.Bytes()), nil
+}
@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/prereqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderCommandWithLayers(t *testing.T) {
+	ctx := &prereqs.Context{
+		FeatureDir:      "specs/001-test",
+		FeatureSpec:     "specs/001-test/spec.yaml",
+		TasksFile:       "specs/001-test/tasks.yaml",
+		AutospecVersion: "autospec 0.10.4",
+		CreatedDate:     "2026-01-30T00:00:00Z",
+	}
+
+	tests := map[string]struct {
+		command     string
+		user        map[string]string
+		project     map[string]string
+		contains    []string
+		excludes    []string
+		errContains string
+	}{
+		"embedded template without overrides": {
+			command:  "autospec.implement",
+			contains: []string{"## Outline", "`specs/001-test`"},
+			excludes: []string{"description:", "{{"},
+		},
+		"define-only override keeps the embedded body": {
+			command: "autospec.implement",
+			project: map[string]string{
+				"autospec.implement.md": "---\nversion: \"1.0.0\"\n---\n{{define \"extra_instructions\"}}Run make lint before each commit.\n{{end}}",
+			},
+			contains: []string{"## Outline", "Run make lint before each commit.", "`specs/001-test`"},
+		},
+		"block override replaces only that block": {
+			command: "autospec.plan",
+			project: map[string]string{
+				"autospec.plan.md": "{{define \"key_rules\"}}## Key Rules\n\n- Keep plans short\n{{end}}",
+			},
+			contains: []string{"## Outline", "- Keep plans short"},
+			excludes: []string{"All YAML arrays use list syntax"},
+		},
+		"override with body replaces the template": {
+			command: "autospec.implement",
+			user: map[string]string{
+				"autospec.implement.md": "Implement {{.TasksFile}} carefully.",
+			},
+			contains: []string{"Implement specs/001-test/tasks.yaml carefully."},
+			excludes: []string{"## Outline"},
+		},
+		"project override extends user override": {
+			command: "autospec.implement",
+			user: map[string]string{
+				"autospec.implement.md": "Team rules.\n{{block \"extra_instructions\" .}}{{end}}",
+			},
+			project: map[string]string{
+				"autospec.implement.md": "{{define \"extra_instructions\"}}Project rules.{{end}}",
+			},
+			contains: []string{"Team rules.\nProject rules."},
+		},
+		"partials are shared across layers": {
+			command: "autospec.implement",
+			user: map[string]string{
+				"_conventions.md": "Use table-driven tests.",
+			},
+			project: map[string]string{
+				"autospec.implement.md": "{{define \"extra_instructions\"}}{{template \"_conventions\" .}}{{end}}",
+			},
+			contains: []string{"## Outline", "Use table-driven tests."},
+		},
+		"project-only template": {
+			command: "autospec.lint",
+			project: map[string]string{
+				"autospec.lint.md": "Lint {{.FeatureDir}}.",
+			},
+			contains: []string{"Lint specs/001-test."},
+		},
+		"unknown template": {
+			command:     "autospec.missing",
+			errContains: "template not found: autospec.missing",
+		},
+		"invalid override": {
+			command: "autospec.implement",
+			project: map[string]string{
+				"autospec.implement.md": "{{define \"outline\"}}unclosed",
+			},
+			errContains: "parsing",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			layers := []TemplateLayer{
+				{Source: SourceUser, Dir: writeLayer(t, tt.user)},
+				{Source: SourceProject, Dir: writeLayer(t, tt.project)},
+			}
+
+			output, err := RenderCommandWithLayers(tt.command, ctx, layers)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			for _, want := range tt.contains {
+				assert.Contains(t, string(output), want)
+			}
+			for _, unwanted := range tt.excludes {
+				assert.NotContains(t, string(output), unwanted)
+			}
+		})
+	}
+}
+
+func TestRenderCommandWithLayers_ValidatesRequirements(t *testing.T) {
+	_, err := RenderCommandWithLayers("autospec.implement", &prereqs.Context{}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing required context")
+}
+
+func TestTemplateSource(t *testing.T) {
+	userDir := writeLayer(t, map[string]string{"autospec.plan.md": "user", "autospec.tasks.md": "user"})
+	projectDir := writeLayer(t, map[string]string{"autospec.plan.md": "project"})
+	layers := []TemplateLayer{
+		{Source: SourceUser, Dir: userDir},
+		{Source: SourceProject, Dir: projectDir},
+	}
+
+	tests := map[string]struct {
+		command    string
+		wantSource string
+		wantPath   string
+		wantErr    bool
+	}{
+		"project wins":     {command: "autospec.plan", wantSource: SourceProject, wantPath: filepath.Join(projectDir, "autospec.plan.md")},
+		"user override":    {command: "autospec.tasks", wantSource: SourceUser, wantPath: filepath.Join(userDir, "autospec.tasks.md")},
+		"embedded":         {command: "autospec.specify", wantSource: SourceEmbedded},
+		"unknown template": {command: "autospec.missing", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			source, path, err := TemplateSource(tt.command, layers)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSource, source)
+			assert.Equal(t, tt.wantPath, path)
+		})
+	}
+}
+
+func TestDiffOverride(t *testing.T) {
+	embedded, err := GetTemplate("autospec.analyze")
+	require.NoError(t, err)
+
+	diff, err := DiffOverride("autospec.analyze", TemplateOverride{Path: "override.md", Content: embedded})
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+
+	changed := append(append([]byte{}, embedded...), []byte("Also check naming.\n")...)
+	diff, err = DiffOverride("autospec.analyze", TemplateOverride{Path: "override.md", Content: changed})
+	require.NoError(t, err)
+	assert.Contains(t, diff, "--- embedded/autospec.analyze.md")
+	assert.Contains(t, diff, "+++ override.md")
+	assert.Contains(t, diff, "+Also check naming.")
+
+	_, err = DiffOverride("autospec.missing", TemplateOverride{})
+	assert.Error(t, err)
+}
+
+// writeLayer writes files into a new template layer directory.
+func writeLayer(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	return dir
+}
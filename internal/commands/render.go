@@ -12,14 +12,16 @@ import (
 // RequiredVars defines which prereqs context fields are required by each command.
 // Commands not listed here require no specific prereqs context.
 var RequiredVars = map[string][]string{
-	"autospec.specify":      {},                                                                          // No prereqs required
-	"autospec.plan":         {"FeatureDir", "FeatureSpec", "AutospecVersion", "CreatedDate"},             // Needs spec
-	"autospec.tasks":        {"FeatureDir", "FeatureSpec", "ImplPlan", "AutospecVersion", "CreatedDate"}, // Needs plan
-	"autospec.implement":    {"FeatureDir", "TasksFile"},                                                 // Needs tasks
-	"autospec.checklist":    {"FeatureDir", "FeatureSpec"},                                               // Needs spec
-	"autospec.clarify":      {"FeatureDir", "FeatureSpec"},                                               // Needs spec
-	"autospec.analyze":      {"FeatureDir", "FeatureSpec"},                                               // Needs spec
-	"autospec.constitution": {"AutospecVersion", "CreatedDate"},                                          // Minimal context
+	"autospec.specify":      {},                                                                           // No prereqs required
+	"autospec.plan":         {"FeatureDir", "FeatureSpec", "AutospecVersion", "CreatedDate"},              // Needs spec
+	"autospec.tasks":        {"FeatureDir", "FeatureSpec", "ImplPlan", "AutospecVersion", "CreatedDate"},  // Needs plan
+	"autospec.implement":    {"FeatureDir", "TasksFile"},                                                  // Needs tasks
+	"autospec.checklist":    {"FeatureDir", "FeatureSpec"},                                                // Needs spec
+	"autospec.clarify":      {"FeatureDir", "FeatureSpec"},                                                // Needs spec
+	"autospec.analyze":      {"FeatureDir", "FeatureSpec"},                                                // Needs spec
+	"autospec.constitution": {"AutospecVersion", "CreatedDate"},                                           // Minimal context
+	"autospec.review":       {"FeatureDir", "FeatureSpec", "TasksFile", "AutospecVersion", "CreatedDate"}, // Needs spec and tasks
+	"autospec.dag-plan":     {},                                                                           // No prereqs required
 }
 
 // RenderTemplate renders a command template using the provided prereqs context.
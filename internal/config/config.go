@@ -16,8 +16,10 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/ariel-frischer/autospec/internal/budget"
 	"github.com/ariel-frischer/autospec/internal/cliagent"
 	"github.com/ariel-frischer/autospec/internal/dag"
+	"github.com/ariel-frischer/autospec/internal/events"
 	"github.com/ariel-frischer/autospec/internal/notify"
 	"github.com/ariel-frischer/autospec/internal/verification"
 	"github.com/ariel-frischer/autospec/internal/worktree"
@@ -57,6 +59,11 @@ type Configuration struct {
 	//     post_processor: "cclean"
 	CustomAgent *cliagent.CustomAgentConfig `koanf:"custom_agent"`
 
+	// AgentFallbacks lists built-in agents tried in order when the selected agent
+	// exits with a rate-limit or quota failure. The same prompt is re-run on the
+	// next agent. Can be set via AUTOSPEC_AGENT_FALLBACKS env var (comma-separated).
+	AgentFallbacks []string `koanf:"agent_fallbacks"`
+
 	// UseSubscription forces Claude to use subscription (Pro/Max) instead of API credits.
 	// When true, ANTHROPIC_API_KEY is set to empty string at execution time,
 	// and validation is skipped for this environment variable.
@@ -130,6 +137,13 @@ type Configuration struct {
 	// Default: false. Can be set via AUTOSPEC_ENABLE_RISK_ASSESSMENT env var.
 	EnableRiskAssessment bool `koanf:"enable_risk_assessment"`
 
+	// SchemaModeline controls whether artifacts produced by workflow stages get a
+	// yaml-language-server modeline pointing at their JSON Schema, which is
+	// written to .autospec/schemas/json/ for editors to pick up.
+	// Default: false (opt-in, since the schema files become part of the project).
+	// Can be set via AUTOSPEC_SCHEMA_MODELINE env var.
+	SchemaModeline bool `koanf:"schema_modeline"`
+
 	// Cclean configures cclean (claude-clean) output formatting options.
 	// Controls verbose mode, line numbers, and output style for stream-json display.
 	// Environment variable support via AUTOSPEC_CCLEAN_* prefix.
@@ -145,6 +159,23 @@ type Configuration struct {
 	// Controls conflict handling, base branch, retry limits, and log size limits.
 	// Environment variable support via AUTOSPEC_DAG_* prefix.
 	DAG *dag.DAGExecutionConfig `koanf:"dag"`
+
+	// Budget configures spending limits per stage, per spec, and per run.
+	// When a limit is reached the workflow or DAG run stops and can be resumed.
+	// Environment variable support via AUTOSPEC_BUDGET_* prefix.
+	Budget budget.Config `koanf:"budget"`
+
+	// Stages overrides agent, extra_args, and timeout per workflow stage, keyed by
+	// stage name (e.g., "plan", "implement"). Stages without an entry use the
+	// top-level agent settings. Entries with other names define custom stages.
+	// See StageConfig.
+	// Environment variable support via AUTOSPEC_STAGES_<STAGE>_* prefix.
+	Stages map[string]StageConfig `koanf:"stages"`
+
+	// Events configures the JSONL lifecycle event sink.
+	// The --events-file flag overrides events.sink.
+	// Environment variable: AUTOSPEC_EVENTS_SINK
+	Events events.Config `koanf:"events"`
 }
 
 // LoadOptions configures how configuration is loaded
@@ -313,7 +344,7 @@ func warnLegacyExists(warningWriter io.Writer, legacyPath, yamlPath string, lega
 
 // loadEnvironmentConfig loads environment variable overrides
 func loadEnvironmentConfig(k *koanf.Koanf) error {
-	if err := k.Load(env.Provider("AUTOSPEC_", ".", envTransform), nil); err != nil {
+	if err := k.Load(env.ProviderWithValue("AUTOSPEC_", ".", envTransformValue), nil); err != nil {
 		return fmt.Errorf("failed to load environment config: %w", err)
 	}
 	return nil
@@ -389,6 +420,7 @@ func finalizeConfigWithWarnings(k *koanf.Koanf, warningWriter io.Writer, skipWar
 
 	cfg.StateDir = expandHomePath(cfg.StateDir)
 	cfg.SpecsDir = expandHomePath(cfg.SpecsDir)
+	cfg.Events.Sink = expandHomePath(cfg.Events.Sink)
 
 	if os.Getenv("AUTOSPEC_YES") != "" {
 		cfg.SkipConfirmations = true
@@ -416,12 +448,22 @@ func fileExists(path string) bool {
 //   - AUTOSPEC_NOTIFICATIONS_ENABLED -> notifications.enabled
 //   - AUTOSPEC_WORKTREE_BASE_DIR -> worktree.base_dir
 //   - AUTOSPEC_CUSTOM_AGENT_COMMAND -> custom_agent.command
+//   - AUTOSPEC_BUDGET_MAX_RUN_COST_USD -> budget.max_run_cost_usd
+//   - AUTOSPEC_STAGES_PLAN_EXTRA_ARGS -> stages.plan.extra_args
+//   - AUTOSPEC_EVENTS_SINK -> events.sink
 func envTransform(s string) string {
 	key := strings.ToLower(strings.TrimPrefix(s, "AUTOSPEC_"))
 
+	// stages.<stage>.<field>: stage names contain no underscores
+	if rest, ok := strings.CutPrefix(key, "stages_"); ok {
+		if stage, field, found := strings.Cut(rest, "_"); found {
+			return "stages." + stage + "." + field
+		}
+	}
+
 	// Known nested config prefixes that need dot notation.
 	// Order matters: longer prefixes must come first to avoid partial matches.
-	nestedPrefixes := []string{"custom_agent_", "notifications_", "verification_", "worktree_", "cclean_", "dag_"}
+	nestedPrefixes := []string{"custom_agent_", "notifications_", "verification_", "worktree_", "cclean_", "dag_", "budget_", "events_"}
 	for _, prefix := range nestedPrefixes {
 		if strings.HasPrefix(key, prefix) {
 			// Replace the trailing underscore of the prefix with a dot
@@ -432,6 +474,34 @@ func envTransform(s string) string {
 	return key
 }
 
+// envListKeys are list-valued config keys whose environment values are comma-separated.
+// Example: AUTOSPEC_AGENT_FALLBACKS=codex,opencode -> agent_fallbacks: [codex, opencode]
+var envListKeys = map[string]bool{
+	"agent_fallbacks": true,
+	"default_agents":  true,
+}
+
+// isEnvListKey returns true if key holds a list, including stages.<stage>.extra_args.
+func isEnvListKey(key string) bool {
+	return envListKeys[key] || (strings.HasPrefix(key, "stages.") && strings.HasSuffix(key, ".extra_args"))
+}
+
+// envTransformValue maps an environment variable to its config key and value,
+// splitting comma-separated values for list-valued keys.
+func envTransformValue(name, value string) (string, interface{}) {
+	key := envTransform(name)
+	if !isEnvListKey(key) {
+		return key, value
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return key, items
+}
+
 // expandHomePath expands ~ to the user's home directory
 func expandHomePath(path string) string {
 	if strings.HasPrefix(path, "~/") {
@@ -469,6 +539,20 @@ func (c *Configuration) GetAgent() (cliagent.Agent, error) {
 	return agent, nil
 }
 
+// GetFallbackAgents returns the agents listed in agent_fallbacks, in order.
+// Returns error if any name is not a registered agent.
+func (c *Configuration) GetFallbackAgents() ([]cliagent.Agent, error) {
+	agents := make([]cliagent.Agent, 0, len(c.AgentFallbacks))
+	for _, name := range c.AgentFallbacks {
+		agent := cliagent.Get(name)
+		if agent == nil {
+			return nil, fmt.Errorf("unknown fallback agent %q; available: %v", name, cliagent.List())
+		}
+		agents = append(agents, agent)
+	}
+	return agents, nil
+}
+
 // ToMap converts Configuration to a map[string]interface{} using koanf struct tags.
 // Fields with koanf:"-" are excluded. This ensures config show automatically
 // includes all Configuration fields without manual maintenance.
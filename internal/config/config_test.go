@@ -12,6 +12,8 @@ import (
 	"testing"
 
 	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/ariel-frischer/autospec/internal/notify"
+	"github.com/ariel-frischer/autospec/internal/verification"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -797,6 +799,10 @@ func TestEnvTransform(t *testing.T) {
 			input:    "AUTOSPEC_CCLEAN_STYLE",
 			expected: "cclean.style",
 		},
+		"nested events sink": {
+			input:    "AUTOSPEC_EVENTS_SINK",
+			expected: "events.sink",
+		},
 		"nested cclean line_numbers": {
 			input:    "AUTOSPEC_CCLEAN_LINE_NUMBERS",
 			expected: "cclean.line_numbers",
@@ -809,6 +815,14 @@ func TestEnvTransform(t *testing.T) {
 			input:    "AUTOSPEC_CUSTOM_AGENT_COMMAND",
 			expected: "custom_agent.command",
 		},
+		"stage agent": {
+			input:    "AUTOSPEC_STAGES_PLAN_AGENT",
+			expected: "stages.plan.agent",
+		},
+		"stage extra_args": {
+			input:    "AUTOSPEC_STAGES_IMPLEMENT_EXTRA_ARGS",
+			expected: "stages.implement.extra_args",
+		},
 	}
 
 	for name, tt := range tests {
@@ -990,6 +1004,17 @@ func TestLoad_AgentPresetFromEnv(t *testing.T) {
 	assert.Equal(t, "cline", cfg.AgentPreset)
 }
 
+func TestLoad_SchemaModelineDefaults(t *testing.T) {
+	// Cannot use t.Parallel() due to environment modification
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(tmpDir, ".config"))
+
+	cfg, err := Load("")
+	require.NoError(t, err)
+	assert.False(t, cfg.SchemaModeline, "SchemaModeline should be opt-in")
+}
+
 // EnableRiskAssessment Configuration Tests
 
 func TestLoad_EnableRiskAssessmentDefaults(t *testing.T) {
@@ -1916,3 +1941,161 @@ func TestLoad_VerificationFeatureToggleOverride_EnvOverridesLevel(t *testing.T)
 	assert.True(t, cfg.Verification.IsEnabled("property_tests"))
 	assert.True(t, cfg.Verification.IsEnabled("metamorphic_tests"))
 }
+
+func TestLoad_BudgetConfig(t *testing.T) {
+	// Cannot use t.Parallel() due to environment modification
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yml")
+
+	configContent := `budget:
+  max_stage_cost_usd: 2.5
+  max_spec_tokens: 500000
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0o644))
+	t.Setenv("AUTOSPEC_BUDGET_MAX_RUN_COST_USD", "25")
+
+	cfg, err := LoadWithOptions(LoadOptions{
+		ProjectConfigPath: configPath,
+		SkipWarnings:      true,
+	})
+	require.NoError(t, err)
+
+	assert.InDelta(t, 2.5, cfg.Budget.MaxStageCostUSD, 1e-9)
+	assert.Equal(t, 500000, cfg.Budget.MaxSpecTokens)
+	assert.InDelta(t, 25.0, cfg.Budget.MaxRunCostUSD, 1e-9)
+	assert.Zero(t, cfg.Budget.MaxRunTokens)
+}
+
+func TestLoad_NotificationChannels(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yml")
+	yamlContent := `notifications:
+  enabled: true
+  channels:
+    - type: ntfy
+      url: https://ntfy.sh/autospec-test
+    - type: webhook
+      url: https://example.com/hook
+      template: '{"text": {{json .Message}}}'
+      headers:
+        X-Team: platform
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(yamlContent), 0o644))
+
+	cfg, err := LoadWithOptions(LoadOptions{ProjectConfigPath: configPath, SkipWarnings: true})
+	require.NoError(t, err)
+
+	require.Len(t, cfg.Notifications.Channels, 2)
+	assert.Equal(t, notify.ChannelNtfy, cfg.Notifications.Channels[0].Type)
+	assert.Equal(t, "https://ntfy.sh/autospec-test", cfg.Notifications.Channels[0].URL)
+	assert.Equal(t, notify.ChannelWebhook, cfg.Notifications.Channels[1].Type)
+	assert.Equal(t, `{"text": {{json .Message}}}`, cfg.Notifications.Channels[1].Template)
+	assert.Equal(t, map[string]string{"X-Team": "platform"}, cfg.Notifications.Channels[1].Headers)
+}
+
+func TestLoad_VerificationGateCommands(t *testing.T) {
+	// Cannot use t.Parallel() due to environment modification
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yml")
+	yamlContent := `verification:
+  coverage_threshold: 0.9
+  coverage_cmd: go test -cover ./...
+  complexity_cmd: gocyclo -over 10 .
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(yamlContent), 0o644))
+
+	cfg, err := LoadWithOptions(LoadOptions{ProjectConfigPath: configPath, SkipWarnings: true})
+	require.NoError(t, err)
+	assert.Equal(t, "go test -cover ./...", cfg.Verification.CoverageCmd)
+	assert.Equal(t, "gocyclo -over 10 .", cfg.Verification.ComplexityCmd)
+	assert.Empty(t, cfg.Verification.MutationCmd)
+	assert.True(t, cfg.Verification.HasGates())
+
+	t.Setenv("AUTOSPEC_VERIFICATION_MUTATION_CMD", "make mutate")
+	cfg, err = LoadWithOptions(LoadOptions{ProjectConfigPath: configPath, SkipWarnings: true})
+	require.NoError(t, err)
+	assert.Equal(t, "make mutate", cfg.Verification.MutationCmd)
+}
+
+func TestLoad_VerificationReviewBlocking(t *testing.T) {
+	// Cannot use t.Parallel() due to environment modification
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yml")
+	require.NoError(t, os.WriteFile(configPath, []byte("verification:\n  level: full\n"), 0o644))
+
+	cfg, err := LoadWithOptions(LoadOptions{ProjectConfigPath: configPath, SkipWarnings: true})
+	require.NoError(t, err)
+	assert.False(t, cfg.Verification.ReviewBlocking)
+	assert.True(t, cfg.Verification.IsEnabled(verification.FeatureAdversarialReview))
+
+	t.Setenv("AUTOSPEC_VERIFICATION_REVIEW_BLOCKING", "true")
+	cfg, err = LoadWithOptions(LoadOptions{ProjectConfigPath: configPath, SkipWarnings: true})
+	require.NoError(t, err)
+	assert.True(t, cfg.Verification.ReviewBlocking)
+}
+
+func TestLoad_AgentFallbacks(t *testing.T) {
+	// Cannot use t.Parallel() due to environment modification
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yml")
+	require.NoError(t, os.WriteFile(configPath, []byte("agent_fallbacks: [gemini]\n"), 0o644))
+
+	cfg, err := LoadWithOptions(LoadOptions{ProjectConfigPath: configPath, SkipWarnings: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gemini"}, cfg.AgentFallbacks)
+
+	t.Setenv("AUTOSPEC_AGENT_FALLBACKS", "codex,opencode")
+	cfg, err = LoadWithOptions(LoadOptions{ProjectConfigPath: configPath, SkipWarnings: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"codex", "opencode"}, cfg.AgentFallbacks)
+
+	agents, err := cfg.GetFallbackAgents()
+	require.NoError(t, err)
+	require.Len(t, agents, 2)
+	assert.Equal(t, "codex", agents[0].Name())
+	assert.Equal(t, "opencode", agents[1].Name())
+}
+
+func TestLoad_StagesConfig(t *testing.T) {
+	// Cannot use t.Parallel() due to environment modification
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yml")
+
+	configContent := `agent_preset: claude
+stages:
+  tasks:
+    agent: gemini
+  implement:
+    extra_args: ["--model", "opus"]
+    timeout: 3600
+  threat-model:
+    command: autospec.threat-model
+    requires: [plan.yaml]
+    produces: [threat-model.yaml]
+    after: plan
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0o644))
+	t.Setenv("AUTOSPEC_STAGES_PLAN_AGENT", "codex")
+	t.Setenv("AUTOSPEC_STAGES_IMPLEMENT_EXTRA_ARGS", "--model,sonnet")
+
+	cfg, err := LoadWithOptions(LoadOptions{
+		ProjectConfigPath: configPath,
+		SkipWarnings:      true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "gemini", cfg.StageConfigFor("tasks").Agent)
+	assert.Equal(t, "codex", cfg.StageConfigFor("plan").Agent)
+	assert.Equal(t, []string{"--model", "sonnet"}, cfg.StageConfigFor("implement").ExtraArgs)
+	assert.Equal(t, 3600, cfg.StageConfigFor("implement").Timeout)
+	assert.True(t, cfg.StageConfigFor("specify").IsZero())
+
+	threat := cfg.StageConfigFor("threat-model")
+	assert.Equal(t, "autospec.threat-model", threat.Command)
+	assert.Equal(t, []string{"plan.yaml"}, threat.Requires)
+	assert.Equal(t, []string{"threat-model.yaml"}, threat.Produces)
+	assert.Equal(t, "plan", threat.After)
+	assert.Equal(t, []string{"threat-model"}, cfg.CustomStageNames())
+}
@@ -10,6 +10,7 @@ func GetDefaultConfigTemplate() string {
 
 # Agent settings
 agent_preset: ""                      # Built-in agent: claude | opencode | codex
+agent_fallbacks: []                   # Agents tried in order when the agent is rate limited (e.g., [codex, opencode])
 use_subscription: true                # Force subscription mode (no API charges); set false to use API key
 
 # Workflow settings
@@ -19,7 +20,7 @@ state_dir: ~/.autospec/state          # Directory for state files
 skip_preflight: false                 # Skip preflight checks
 timeout: 2400                         # Timeout in seconds (40 min default, 0 = no timeout)
 skip_confirmations: false             # Skip confirmation prompts
-implement_method: phases              # Default: phases | tasks | single-session
+implement_method: phases              # Default: phases | tasks | single-session | parallel
 auto_commit: false                    # Auto-create git commit after workflow (disabled by default)
 
 # History settings
@@ -53,6 +54,12 @@ notifications:
   on_error: true                      # Notify on failures
   on_long_running: false              # Enable duration-based notifications
   long_running_threshold: 2m          # Threshold for long-running notification
+  channels: []                        # Remote channels: webhook | slack | ntfy | gotify
+  # channels:
+  #   - type: ntfy
+  #     url: https://ntfy.sh/my-autospec-topic
+  #   - type: slack
+  #     url: ${SLACK_WEBHOOK_URL}     # Env vars are expanded in url, token, headers
 
 # Cclean (claude-clean) output formatting
 cclean:
@@ -73,18 +80,61 @@ verification:
   mutation_threshold: 0.8             # Minimum mutation score (0.0-1.0)
   coverage_threshold: 0.85            # Minimum code coverage (0.0-1.0)
   complexity_max: 10                  # Maximum cyclomatic complexity
+  # Quality gate commands run after implement; failures trigger another implement attempt
+  coverage_cmd: ""                    # e.g., go test -coverprofile=c.out ./... && go tool cover -func=c.out | tail -1
+  complexity_cmd: ""                  # e.g., gocyclo -over 10 .
+  mutation_cmd: ""                    # Command printing a mutation score (e.g., "score: 82%")
+  review_blocking: false              # Fail review/implement while review.yaml has open CRITICAL findings
 
 # DAG execution settings
 dag:
   on_conflict: manual                 # Merge conflict handling: manual | agent
+  # conflict_verify_cmd: ""           # Check after agent conflict resolution, e.g. "go build ./..."
   base_branch: ""                     # Target branch for merging (empty = repo default)
-  max_spec_retries: 0                 # Max auto-retry per spec (0 = manual only)
+  max_spec_retries: 0                 # Auto-retry transient spec failures (0 = manual only)
   max_log_size: "50MB"                # Max log file size per spec (e.g., 50MB, 100MB)
   # log_dir: ""                       # Custom log directory (empty = XDG cache default)
   autocommit: true                    # Enable post-execution commit verification
   # autocommit_cmd: ""                # Custom commit command (empty = agent session)
   autocommit_retries: 1               # Commit retry attempts (0-10)
   automerge: true                     # Auto-merge specs into staging branch after commit
+  # pre_merge_cmd: ""                 # Gate for staging merges, e.g. "make test" (empty = disabled)
+  schedule_priority: dag-order        # Parallel start order for ready specs: dag-order | critical-path
+  merge_strategy: no-ff               # Staging and final merges: no-ff | squash | rebase
+  remote: origin                      # Remote that dag merge --pr pushes to
+  # pr_provider: ""                   # github | gitlab | gitea (empty = detect from remote URL)
+  # pr_repo: ""                       # Repository path, e.g. owner/name (empty = from remote URL)
+  # pr_api_url: ""                    # Provider API base URL (empty = derived from remote host)
+  # pr_token: ""                      # API token or "${ENV_VAR}" (empty = GITHUB_TOKEN/GITLAB_TOKEN/GITEA_TOKEN)
+
+# Spending limits (0 = unlimited); runs stop and can be resumed when reached
+budget:
+  max_stage_cost_usd: 0               # Max cost of one stage execution incl. retries
+  max_stage_tokens: 0                 # Max tokens of one stage execution incl. retries
+  max_spec_cost_usd: 0                # Max total cost recorded for a spec
+  max_spec_tokens: 0                  # Max total tokens recorded for a spec
+  max_run_cost_usd: 0                 # Max cost of a workflow invocation or DAG run
+  max_run_tokens: 0                   # Max tokens of a workflow invocation or DAG run
+
+# Per-stage agent overrides (stages without an entry use the agent settings above)
+stages: {}
+# stages:
+#   tasks:
+#     agent: gemini                   # Built-in agent for this stage
+#   implement:
+#     agent: claude
+#     extra_args: ["--model", "opus"] # Extra CLI args for this stage
+#     timeout: 3600                   # Seconds (0 = use top-level timeout)
+#   threat-model:                     # Custom stage (run with --stages threat-model)
+#     command: autospec.threat-model  # Template in .autospec/commands/
+#     requires: [plan.yaml]           # Artifacts that must exist first
+#     produces: [threat-model.yaml]   # Artifacts the stage must create
+#     schema: .autospec/schemas/threat-model.yaml  # Validates the first produced artifact
+#     after: plan                     # Ordering among selected stages
+
+# Lifecycle event stream (JSON Lines) for CI and tooling
+events:
+  sink: ""                            # File receiving one JSON event per line (empty = disabled)
 `
 }
 
@@ -93,6 +143,7 @@ func GetDefaults() map[string]interface{} {
 	return map[string]interface{}{
 		// Agent configuration
 		"agent_preset":       "",
+		"agent_fallbacks":    []string{},
 		"use_subscription":   true, // Protect users from accidental API charges
 		"max_retries":        0,
 		"specs_dir":          "./specs",
@@ -102,7 +153,7 @@ func GetDefaults() map[string]interface{} {
 		"skip_confirmations": false, // Confirmation prompts enabled by default
 		// implement_method: Default to "phases" for cost-efficient execution with context isolation.
 		// This changes the legacy behavior (single-session) to run each phase in a separate Claude session.
-		// Valid values: "single-session", "phases", "tasks"
+		// Valid values: "single-session", "phases", "tasks", "parallel"
 		"implement_method": "phases",
 		// notifications: Notification settings for command and stage completion.
 		// Disabled by default (opt-in). When enabled, defaults to both sound and visual notifications.
@@ -115,6 +166,7 @@ func GetDefaults() map[string]interface{} {
 			"on_error":               true,                       // Notify on failures (default when enabled)
 			"on_long_running":        false,                      // Don't use duration threshold by default
 			"long_running_threshold": (2 * time.Minute).String(), // 2 minutes threshold
+			"channels":               []interface{}{},            // No remote channels by default
 		},
 		// max_history_entries: Maximum number of command history entries to retain.
 		// Oldest entries are pruned when this limit is exceeded.
@@ -144,6 +196,11 @@ func GetDefaults() map[string]interface{} {
 		// into the plan stage prompt. When enabled, generated plan.yaml includes a risks section.
 		// Default: false (opt-in feature to reduce cognitive overhead for simple features).
 		"enable_risk_assessment": false,
+		// schema_modeline: Adds a yaml-language-server modeline to spec.yaml, plan.yaml,
+		// tasks.yaml, and review.yaml after they are generated, pointing at JSON Schemas
+		// written to .autospec/schemas/json/ (editor completion and validation).
+		// Default: false (opt-in, since the schemas are written into the project).
+		"schema_modeline": false,
 		// skip_permissions_notice_shown: Tracks whether the security notice about
 		// --dangerously-skip-permissions has been shown. Set to true after first display.
 		// User-level config only (not shown in project config).
@@ -168,20 +225,50 @@ func GetDefaults() map[string]interface{} {
 			"mutation_threshold": 0.8,     // 80% mutation score threshold
 			"coverage_threshold": 0.85,    // 85% code coverage threshold
 			"complexity_max":     10,      // Max cyclomatic complexity
+			"coverage_cmd":       "",      // Empty disables the coverage gate
+			"complexity_cmd":     "",      // Empty disables the complexity gate
+			"mutation_cmd":       "",      // Empty disables the mutation gate
+			"review_blocking":    false,   // Review findings are advisory by default
 		},
 		// dag: Configuration for DAG execution settings.
 		// Controls conflict handling, base branch, retry limits, and log size limits.
 		// Environment variable support via AUTOSPEC_DAG_* prefix.
 		"dag": map[string]interface{}{
-			"on_conflict":        "manual", // Default to manual conflict resolution
-			"base_branch":        "",       // Empty means use repo default branch
-			"max_spec_retries":   0,        // 0 means manual retry only
-			"max_log_size":       "50MB",   // Default 50MB max log size per spec
-			"log_dir":            "",       // Empty means XDG cache default
-			"autocommit":         true,     // Enable post-execution commit verification
-			"autocommit_cmd":     "",       // Empty means agent session
-			"autocommit_retries": 1,        // Default 1 retry attempt
-			"automerge":          true,     // Auto-merge specs into staging after commit
+			"on_conflict":         "manual",    // Default to manual conflict resolution
+			"conflict_verify_cmd": "",          // Empty means only check for conflict markers
+			"base_branch":         "",          // Empty means use repo default branch
+			"max_spec_retries":    0,           // 0 means manual retry only
+			"max_log_size":        "50MB",      // Default 50MB max log size per spec
+			"log_dir":             "",          // Empty means XDG cache default
+			"autocommit":          true,        // Enable post-execution commit verification
+			"autocommit_cmd":      "",          // Empty means agent session
+			"autocommit_retries":  1,           // Default 1 retry attempt
+			"automerge":           true,        // Auto-merge specs into staging after commit
+			"pre_merge_cmd":       "",          // Empty means no staging merge gate
+			"schedule_priority":   "dag-order", // Start ready specs in DAG declaration order
+			"merge_strategy":      "no-ff",     // Merge commit for each staging and final merge
+			"remote":              "origin",    // Remote for dag merge --pr
+			"pr_provider":         "",          // Empty means detect from remote URL
+			"pr_repo":             "",          // Empty means derive from remote URL
+			"pr_api_url":          "",          // Empty means derive from remote host
+			"pr_token":            "",          // Empty means provider token env var
+		},
+		// budget: Spending limits for agent token usage and cost.
+		// All limits default to 0 (unlimited). Environment variable support via AUTOSPEC_BUDGET_* prefix.
+		"budget": map[string]interface{}{
+			"max_stage_cost_usd": 0.0,
+			"max_stage_tokens":   0,
+			"max_spec_cost_usd":  0.0,
+			"max_spec_tokens":    0,
+			"max_run_cost_usd":   0.0,
+			"max_run_tokens":     0,
+		},
+		// stages: Per-stage agent overrides keyed by stage name (e.g., "plan").
+		// Empty by default; stages inherit the top-level agent settings.
+		"stages": map[string]interface{}{},
+		// events: JSONL lifecycle event sink. Empty sink disables it.
+		"events": map[string]interface{}{
+			"sink": "",
 		},
 	}
 }
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+)
+
+// jsonSchemaListItems gives the item schema of keys registered as TypeString
+// that hold lists in the config file.
+var jsonSchemaListItems = map[string]map[string]any{
+	"agent_fallbacks":        {"type": "string"},
+	"default_agents":         {"type": "string"},
+	"worktree.copy_dirs":     {"type": "string"},
+	"notifications.channels": {"type": "object"},
+}
+
+// JSONSchema returns a JSON Schema for .autospec/config.yml and the user
+// config file, generated from KnownKeys. Every stages.<name> entry accepts the
+// per-stage keys. Keys not in the registry are allowed, so the schema never
+// rejects a config the loader accepts for lack of an entry.
+func JSONSchema() map[string]any {
+	doc := map[string]any{
+		"$schema":     validation.JSONSchemaDraft,
+		"title":       "autospec configuration",
+		"description": "autospec project (.autospec/config.yml) and user configuration",
+		"type":        "object",
+		"properties":  map[string]any{},
+	}
+
+	paths := make([]string, 0, len(KnownKeys))
+	for path := range KnownKeys {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		addJSONSchemaKey(doc, strings.Split(path, "."), keyJSONSchema(path, KnownKeys[path]))
+	}
+
+	stage := map[string]any{"type": "object", "properties": map[string]any{}}
+	for field, schema := range stageKeySchemas {
+		stage["properties"].(map[string]any)[field] = keyJSONSchema("", schema)
+	}
+	doc["properties"].(map[string]any)["stages"] = map[string]any{
+		"type":                 "object",
+		"description":          "Per-stage overrides and custom stages, keyed by stage name",
+		"additionalProperties": stage,
+	}
+	return doc
+}
+
+// addJSONSchemaKey adds the schema of a dotted key to doc, creating the
+// parent objects.
+func addJSONSchemaKey(doc map[string]any, parts []string, schema map[string]any) {
+	properties := doc["properties"].(map[string]any)
+	if len(parts) == 1 {
+		properties[parts[0]] = schema
+		return
+	}
+	parent, ok := properties[parts[0]].(map[string]any)
+	if !ok {
+		parent = map[string]any{"type": "object", "properties": map[string]any{}}
+		properties[parts[0]] = parent
+	}
+	addJSONSchemaKey(parent, parts[1:], schema)
+}
+
+// keyJSONSchema returns the JSON Schema of a single configuration key.
+func keyJSONSchema(path string, schema ConfigKeySchema) map[string]any {
+	if items, ok := jsonSchemaListItems[path]; ok {
+		return map[string]any{"type": "array", "items": items, "description": schema.Description}
+	}
+
+	doc := map[string]any{"description": schema.Description}
+	switch schema.Type {
+	case TypeBool:
+		doc["type"] = "boolean"
+	case TypeInt:
+		doc["type"] = "integer"
+	case TypeFloat:
+		doc["type"] = "number"
+	case TypeEnum:
+		doc["type"] = "string"
+		doc["enum"] = schema.AllowedValues
+	default:
+		doc["type"] = "string" // Durations are written as strings like "5m"
+	}
+	if schema.Default != nil {
+		doc["default"] = schema.Default
+	}
+	return doc
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	doc := JSONSchema()
+	_, err := validation.MarshalJSONSchema(doc)
+	require.NoError(t, err)
+
+	// Every registered key is described at its dotted path
+	for path, schema := range KnownKeys {
+		key := jsonSchemaProperty(t, doc, path)
+		assert.Equal(t, schema.Description, key["description"], path)
+	}
+
+	tests := map[string]struct {
+		path string
+		want map[string]any
+	}{
+		"bool with default": {
+			path: "auto_commit",
+			want: map[string]any{"type": "boolean", "description": KnownKeys["auto_commit"].Description, "default": KnownKeys["auto_commit"].Default},
+		},
+		"enum": {
+			path: "dag.merge_strategy",
+			want: map[string]any{
+				"type": "string", "enum": KnownKeys["dag.merge_strategy"].AllowedValues,
+				"description": KnownKeys["dag.merge_strategy"].Description, "default": KnownKeys["dag.merge_strategy"].Default,
+			},
+		},
+		"float": {
+			path: "budget.max_run_cost_usd",
+			want: map[string]any{"type": "number", "description": KnownKeys["budget.max_run_cost_usd"].Description, "default": 0.0},
+		},
+		"list registered as string": {
+			path: "worktree.copy_dirs",
+			want: map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": KnownKeys["worktree.copy_dirs"].Description},
+		},
+		"no default": {
+			path: "verification.ears_requirements",
+			want: map[string]any{"type": "boolean", "description": KnownKeys["verification.ears_requirements"].Description},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, jsonSchemaProperty(t, doc, tt.path))
+		})
+	}
+
+	stages := jsonSchemaProperty(t, doc, "stages")
+	stage, ok := stages["additionalProperties"].(map[string]any)
+	require.True(t, ok, "stages accepts any stage name")
+	agent := stage["properties"].(map[string]any)["agent"].(map[string]any)
+	assert.Equal(t, stageKeySchemas["agent"].AllowedValues, agent["enum"])
+}
+
+// jsonSchemaProperty returns the schema at a dotted path of nested properties.
+func jsonSchemaProperty(t *testing.T, doc map[string]any, path string) map[string]any {
+	t.Helper()
+	for _, part := range strings.Split(path, ".") {
+		properties, _ := doc["properties"].(map[string]any)
+		next, ok := properties[part].(map[string]any)
+		require.True(t, ok, "schema has no property %q", path)
+		doc = next
+	}
+	return doc
+}
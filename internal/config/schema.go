@@ -57,6 +57,12 @@ var KnownKeys = map[string]ConfigKeySchema{
 		Description:   "Built-in agent preset to use",
 		Default:       "",
 	},
+	"agent_fallbacks": {
+		Path:        "agent_fallbacks",
+		Type:        TypeString, // Actually a list, but we handle as string for simplicity
+		Description: "Agents tried in order when the agent is rate limited",
+		Default:     "",
+	},
 	"use_subscription": {
 		Path:        "use_subscription",
 		Type:        TypeBool,
@@ -102,7 +108,7 @@ var KnownKeys = map[string]ConfigKeySchema{
 	"implement_method": {
 		Path:          "implement_method",
 		Type:          TypeEnum,
-		AllowedValues: []string{"single-session", "phases", "tasks"},
+		AllowedValues: []string{"single-session", "phases", "tasks", "parallel"},
 		Description:   "Default execution mode for implement command",
 		Default:       "phases",
 	},
@@ -130,6 +136,12 @@ var KnownKeys = map[string]ConfigKeySchema{
 		Description: "Enable risk assessment in plan generation",
 		Default:     false,
 	},
+	"schema_modeline": {
+		Path:        "schema_modeline",
+		Type:        TypeBool,
+		Description: "Add a yaml-language-server schema modeline to generated artifacts",
+		Default:     false,
+	},
 	"notifications.enabled": {
 		Path:        "notifications.enabled",
 		Type:        TypeBool,
@@ -179,6 +191,12 @@ var KnownKeys = map[string]ConfigKeySchema{
 		Description: "Threshold for long-running notifications (e.g., 2m, 1h30m)",
 		Default:     "2m",
 	},
+	"notifications.channels": {
+		Path:        "notifications.channels",
+		Type:        TypeString, // Actually a list of channel objects; edit in the config file
+		Description: "Remote notification channels (webhook, slack, ntfy, gotify)",
+		Default:     "",
+	},
 	"auto_commit": {
 		Path:        "auto_commit",
 		Type:        TypeBool,
@@ -307,6 +325,30 @@ var KnownKeys = map[string]ConfigKeySchema{
 		Description: "Maximum cyclomatic complexity allowed (positive integer)",
 		Default:     10,
 	},
+	"verification.coverage_cmd": {
+		Path:        "verification.coverage_cmd",
+		Type:        TypeString,
+		Description: "Command whose output reports coverage, checked against coverage_threshold after implement (empty = disabled)",
+		Default:     "",
+	},
+	"verification.complexity_cmd": {
+		Path:        "verification.complexity_cmd",
+		Type:        TypeString,
+		Description: "Command printing one complexity score per line (gocyclo format), checked against complexity_max after implement (empty = disabled)",
+		Default:     "",
+	},
+	"verification.mutation_cmd": {
+		Path:        "verification.mutation_cmd",
+		Type:        TypeString,
+		Description: "Command whose output reports a mutation score, checked against mutation_threshold after implement (empty = disabled)",
+		Default:     "",
+	},
+	"verification.review_blocking": {
+		Path:        "verification.review_blocking",
+		Type:        TypeBool,
+		Description: "Fail the review stage while review.yaml has open CRITICAL findings",
+		Default:     false,
+	},
 	"verification.ears_requirements": {
 		Path:        "verification.ears_requirements",
 		Type:        TypeBool,
@@ -329,7 +371,7 @@ var KnownKeys = map[string]ConfigKeySchema{
 	"dag.max_spec_retries": {
 		Path:        "dag.max_spec_retries",
 		Type:        TypeInt,
-		Description: "Max auto-retry attempts per spec (0 = manual only)",
+		Description: "Max auto-retry attempts per spec for transient failures (0 = manual only)",
 		Default:     0,
 	},
 	"dag.max_log_size": {
@@ -368,6 +410,105 @@ var KnownKeys = map[string]ConfigKeySchema{
 		Description: "Enable automatic merge into staging branch after spec commits",
 		Default:     true,
 	},
+	"dag.conflict_verify_cmd": {
+		Path:        "dag.conflict_verify_cmd",
+		Type:        TypeString,
+		Description: "Command that must pass after agent conflict resolution (empty = marker check only). Supports: {{.SpecID}}, {{.Worktree}}, {{.Branch}}, {{.BaseBranch}}",
+		Default:     "",
+	},
+	"dag.pre_merge_cmd": {
+		Path:        "dag.pre_merge_cmd",
+		Type:        TypeString,
+		Description: "Command that must pass in the worktree and on staging for each staging merge (empty = disabled). Supports: {{.SpecID}}, {{.Worktree}}, {{.Branch}}, {{.BaseBranch}}, {{.DagID}}",
+		Default:     "",
+	},
+	"dag.schedule_priority": {
+		Path:          "dag.schedule_priority",
+		Type:          TypeEnum,
+		AllowedValues: []string{"dag-order", "critical-path"},
+		Description:   "Order in which ready specs start in parallel runs (dag-order or critical-path)",
+		Default:       "dag-order",
+	},
+	"dag.merge_strategy": {
+		Path:          "dag.merge_strategy",
+		Type:          TypeEnum,
+		AllowedValues: []string{"no-ff", "squash", "rebase"},
+		Description:   "How specs merge into staging and staging into the target branch (no-ff, squash, or rebase)",
+		Default:       "no-ff",
+	},
+	"dag.remote": {
+		Path:        "dag.remote",
+		Type:        TypeString,
+		Description: "Git remote that dag merge --pr pushes branches to",
+		Default:     "origin",
+	},
+	"dag.pr_provider": {
+		Path:          "dag.pr_provider",
+		Type:          TypeEnum,
+		AllowedValues: []string{"", "github", "gitlab", "gitea"},
+		Description:   "Pull request provider for dag merge --pr (empty = detect from remote URL)",
+		Default:       "",
+	},
+	"dag.pr_repo": {
+		Path:        "dag.pr_repo",
+		Type:        TypeString,
+		Description: "Repository path on the provider, e.g. owner/name (empty = from remote URL)",
+		Default:     "",
+	},
+	"dag.pr_api_url": {
+		Path:        "dag.pr_api_url",
+		Type:        TypeString,
+		Description: "Provider REST API base URL (empty = derived from the remote host)",
+		Default:     "",
+	},
+	"dag.pr_token": {
+		Path:        "dag.pr_token",
+		Type:        TypeString,
+		Description: "Provider API token, may reference env vars like ${GH_TOKEN} (empty = GITHUB_TOKEN, GITLAB_TOKEN, or GITEA_TOKEN)",
+		Default:     "",
+	},
+	"budget.max_stage_cost_usd": {
+		Path:        "budget.max_stage_cost_usd",
+		Type:        TypeFloat,
+		Description: "Max cost in USD of one stage execution including retries (0 = unlimited)",
+		Default:     0.0,
+	},
+	"budget.max_stage_tokens": {
+		Path:        "budget.max_stage_tokens",
+		Type:        TypeInt,
+		Description: "Max tokens of one stage execution including retries (0 = unlimited)",
+		Default:     0,
+	},
+	"budget.max_spec_cost_usd": {
+		Path:        "budget.max_spec_cost_usd",
+		Type:        TypeFloat,
+		Description: "Max total cost in USD recorded for a spec (0 = unlimited)",
+		Default:     0.0,
+	},
+	"budget.max_spec_tokens": {
+		Path:        "budget.max_spec_tokens",
+		Type:        TypeInt,
+		Description: "Max total tokens recorded for a spec (0 = unlimited)",
+		Default:     0,
+	},
+	"budget.max_run_cost_usd": {
+		Path:        "budget.max_run_cost_usd",
+		Type:        TypeFloat,
+		Description: "Max cost in USD of a workflow invocation or DAG run (0 = unlimited)",
+		Default:     0.0,
+	},
+	"budget.max_run_tokens": {
+		Path:        "budget.max_run_tokens",
+		Type:        TypeInt,
+		Description: "Max tokens of a workflow invocation or DAG run (0 = unlimited)",
+		Default:     0,
+	},
+	"events.sink": {
+		Path:        "events.sink",
+		Type:        TypeString,
+		Description: "File path receiving lifecycle events as JSON Lines (empty = disabled)",
+		Default:     "",
+	},
 }
 
 // ErrUnknownKey is returned when trying to access an unknown configuration key.
@@ -379,14 +520,37 @@ func (e ErrUnknownKey) Error() string {
 	return "unknown configuration key: " + e.Key
 }
 
+// stageKeySchemas holds the schema for each field of stages.<stage>.
+// Stage keys are resolved dynamically since stages has no entries by default.
+var stageKeySchemas = map[string]ConfigKeySchema{
+	"agent": {
+		Type:          TypeEnum,
+		AllowedValues: []string{"", "claude", "gemini", "cline", "codex", "opencode", "goose"},
+		Description:   "Built-in agent for this stage (empty = top-level agent)",
+		Default:       "",
+	},
+	"timeout": {
+		Type:        TypeInt,
+		Description: "Timeout in seconds for this stage (0 = top-level timeout)",
+		Default:     0,
+	},
+}
+
 // GetKeySchema returns the schema for a known configuration key.
+// Keys of the form stages.<stage>.<field> are resolved for every stage in StageNames.
 // Returns ErrUnknownKey if the key is not in the registry.
 func GetKeySchema(path string) (ConfigKeySchema, error) {
-	schema, ok := KnownKeys[path]
-	if !ok {
-		return ConfigKeySchema{}, ErrUnknownKey{Key: path}
+	if schema, ok := KnownKeys[path]; ok {
+		return schema, nil
+	}
+	if rest, ok := strings.CutPrefix(path, "stages."); ok {
+		stage, field, _ := strings.Cut(rest, ".")
+		if schema, ok := stageKeySchemas[field]; ok && isStageName(stage) {
+			schema.Path = path
+			return schema, nil
+		}
 	}
-	return schema, nil
+	return ConfigKeySchema{}, ErrUnknownKey{Key: path}
 }
 
 // InferType determines the ConfigValueType from a string value.
@@ -49,6 +49,24 @@ func TestGetKeySchema(t *testing.T) {
 			wantErr:   true,
 			errString: "unknown configuration key: notifications",
 		},
+		"stage agent key": {
+			key:      "stages.plan.agent",
+			wantType: TypeEnum,
+		},
+		"stage timeout key": {
+			key:      "stages.implement.timeout",
+			wantType: TypeInt,
+		},
+		"unknown stage": {
+			key:       "stages.deploy.agent",
+			wantErr:   true,
+			errString: "unknown configuration key: stages.deploy.agent",
+		},
+		"unknown stage field": {
+			key:       "stages.plan.model",
+			wantErr:   true,
+			errString: "unknown configuration key: stages.plan.model",
+		},
 	}
 
 	for name, tt := range tests {
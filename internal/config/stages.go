@@ -0,0 +1,159 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+)
+
+// StageNames lists the workflow stages that accept per-stage overrides under stages.<name>.
+var StageNames = []string{
+	"constitution",
+	"specify",
+	"clarify",
+	"plan",
+	"tasks",
+	"checklist",
+	"analyze",
+	"implement",
+}
+
+// reservedStageNames are internal workflow stages run by other commands;
+// they cannot be used as custom stage names.
+var reservedStageNames = []string{"verify", "review", "dag-plan"}
+
+// customStageNamePattern restricts custom stage names to lowercase words
+// joined by hyphens, so they are safe as flag values and file names.
+var customStageNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// StageConfig overrides agent settings for a single workflow stage.
+// Unset fields inherit the top-level configuration.
+//
+// An entry whose name is not in StageNames defines a custom stage. Custom
+// stages must set command and may declare the artifacts they require and
+// produce, a schema for the produced artifact, and the stage they run after.
+//
+// Example YAML configuration:
+//
+//	stages:
+//	  tasks:
+//	    agent: gemini          # Cheaper agent for task breakdown
+//	  implement:
+//	    agent: claude
+//	    extra_args: ["--model", "opus"]
+//	    timeout: 3600          # Seconds; overrides top-level timeout
+//	  threat-model:
+//	    command: autospec.threat-model   # .autospec/commands/autospec.threat-model.md
+//	    requires: [plan.yaml]
+//	    produces: [threat-model.yaml]
+//	    schema: .autospec/schemas/threat-model.yaml
+//	    after: plan
+type StageConfig struct {
+	// Agent is the built-in agent name for this stage (e.g., "codex").
+	// Empty inherits custom_agent / agent_preset.
+	// Environment variable: AUTOSPEC_STAGES_<STAGE>_AGENT
+	Agent string `koanf:"agent"`
+
+	// ExtraArgs are additional CLI arguments appended to the agent command for this stage.
+	// Environment variable: AUTOSPEC_STAGES_<STAGE>_EXTRA_ARGS (comma-separated)
+	ExtraArgs []string `koanf:"extra_args"`
+
+	// Timeout in seconds for this stage's agent sessions (0 = inherit top-level timeout).
+	// Environment variable: AUTOSPEC_STAGES_<STAGE>_TIMEOUT
+	Timeout int `koanf:"timeout"`
+
+	// Command is the command template rendered for a custom stage (e.g.,
+	// "autospec.threat-model"). Templates are resolved like built-in ones, so a
+	// project-only template in .autospec/commands/ works. Custom stages only.
+	Command string `koanf:"command"`
+
+	// Description is shown in dry-run previews. Custom stages only.
+	Description string `koanf:"description"`
+
+	// Requires lists artifacts (relative to the spec directory) that must exist
+	// before a custom stage runs. Custom stages only.
+	Requires []string `koanf:"requires"`
+
+	// Produces lists artifacts (relative to the spec directory) a custom stage
+	// must create. Custom stages only.
+	Produces []string `koanf:"produces"`
+
+	// Schema is the path to a YAML schema file that the first produced artifact
+	// is validated against. Requires produces. Custom stages only.
+	Schema string `koanf:"schema"`
+
+	// After is the stage a custom stage runs after when selected with other
+	// stages (empty = after implement). Custom stages only.
+	After string `koanf:"after"`
+}
+
+// IsZero returns true if the stage config overrides no agent settings.
+func (s StageConfig) IsZero() bool {
+	return s.Agent == "" && len(s.ExtraArgs) == 0 && s.Timeout == 0
+}
+
+// hasCustomFields returns true if any custom-stage-only field is set.
+func (s StageConfig) hasCustomFields() bool {
+	return s.Command != "" || s.Description != "" || len(s.Requires) > 0 ||
+		len(s.Produces) > 0 || s.Schema != "" || s.After != ""
+}
+
+// isStageName returns true if name is a stage that accepts overrides.
+func isStageName(name string) bool {
+	for _, stage := range StageNames {
+		if stage == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CustomStageNames returns the names of the custom stages defined under
+// stages, sorted alphabetically.
+func (c *Configuration) CustomStageNames() []string {
+	var names []string
+	for name := range c.Stages {
+		if !isStageName(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// StageConfigFor returns the overrides configured for stage (zero value if none).
+func (c *Configuration) StageConfigFor(stage string) StageConfig {
+	return c.Stages[stage]
+}
+
+// GetStageAgent returns the agent for stage: stages.<stage>.agent when set,
+// otherwise the agent from GetAgent.
+func (c *Configuration) GetStageAgent(stage string) (cliagent.Agent, error) {
+	name := c.StageConfigFor(stage).Agent
+	if name == "" {
+		return c.GetAgent()
+	}
+	agent := cliagent.Get(name)
+	if agent == nil {
+		return nil, fmt.Errorf("unknown agent %q for stage %s; available: %v", name, stage, cliagent.List())
+	}
+	return agent, nil
+}
+
+// EffectiveStageAgents returns the agent name used by each stage in StageNames
+// and each custom stage. Stages whose agent cannot be resolved are reported
+// with an "<error: ...>" value.
+func (c *Configuration) EffectiveStageAgents() map[string]string {
+	agents := make(map[string]string, len(StageNames))
+	for _, stage := range append(append([]string{}, StageNames...), c.CustomStageNames()...) {
+		agent, err := c.GetStageAgent(stage)
+		if err != nil {
+			agents[stage] = fmt.Sprintf("<error: %v>", err)
+			continue
+		}
+		agents[stage] = agent.Name()
+	}
+	return agents
+}
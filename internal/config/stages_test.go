@@ -0,0 +1,96 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStageAgent(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		cfg       Configuration
+		stage     string
+		wantAgent string
+		wantErr   bool
+	}{
+		"no override uses agent_preset": {
+			cfg:       Configuration{AgentPreset: "gemini"},
+			stage:     "plan",
+			wantAgent: "gemini",
+		},
+		"stage override": {
+			cfg: Configuration{
+				AgentPreset: "claude",
+				Stages:      map[string]StageConfig{"tasks": {Agent: "codex"}},
+			},
+			stage:     "tasks",
+			wantAgent: "codex",
+		},
+		"override for other stage": {
+			cfg: Configuration{
+				AgentPreset: "claude",
+				Stages:      map[string]StageConfig{"tasks": {Agent: "codex"}},
+			},
+			stage:     "implement",
+			wantAgent: "claude",
+		},
+		"unknown stage agent": {
+			cfg:     Configuration{Stages: map[string]StageConfig{"plan": {Agent: "nope"}}},
+			stage:   "plan",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			agent, err := tt.cfg.GetStageAgent(tt.stage)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAgent, agent.Name())
+		})
+	}
+}
+
+func TestEffectiveStageAgents(t *testing.T) {
+	t.Parallel()
+
+	cfg := Configuration{
+		AgentPreset: "claude",
+		Stages: map[string]StageConfig{
+			"tasks":     {Agent: "gemini"},
+			"implement": {Timeout: 600},
+		},
+	}
+
+	agents := cfg.EffectiveStageAgents()
+	assert.Len(t, agents, len(StageNames))
+	assert.Equal(t, "gemini", agents["tasks"])
+	assert.Equal(t, "claude", agents["implement"])
+	assert.Equal(t, "claude", agents["plan"])
+
+	cfg.Stages["threat-model"] = StageConfig{Command: "autospec.threat-model", Agent: "codex"}
+	agents = cfg.EffectiveStageAgents()
+	assert.Len(t, agents, len(StageNames)+1)
+	assert.Equal(t, "codex", agents["threat-model"])
+}
+
+func TestCustomStageNames(t *testing.T) {
+	t.Parallel()
+
+	cfg := Configuration{
+		Stages: map[string]StageConfig{
+			"plan":         {Agent: "codex"},
+			"threat-model": {Command: "autospec.threat-model"},
+			"api-contract": {Command: "autospec.api-contract"},
+		},
+	}
+	assert.Equal(t, []string{"api-contract", "threat-model"}, cfg.CustomStageNames())
+	assert.Empty(t, (&Configuration{}).CustomStageNames())
+}
@@ -110,9 +110,13 @@ func findMissingKeys(userKeys []string, schemaKeys map[string]interface{}) []str
 }
 
 // findDeprecatedKeys returns keys that exist in user config but not in schema.
+// Keys under stages are user-defined per stage and never deprecated.
 func findDeprecatedKeys(userKeys []string, schemaKeys map[string]interface{}) []string {
 	var deprecated []string
 	for _, userKey := range userKeys {
+		if strings.HasPrefix(userKey, "stages.") {
+			continue
+		}
 		if _, exists := schemaKeys[userKey]; !exists {
 			deprecated = append(deprecated, userKey)
 		}
@@ -131,6 +131,10 @@ func TestFindDeprecatedKeys(t *testing.T) {
 			userKeys:       []string{"old_field", "legacy_setting"},
 			wantDeprecated: []string{"legacy_setting", "old_field"},
 		},
+		"stage overrides are kept": {
+			userKeys:       []string{"max_retries", "stages.plan.agent", "stages.implement.extra_args"},
+			wantDeprecated: []string{},
+		},
 	}
 
 	for name, tt := range tests {
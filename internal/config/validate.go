@@ -4,8 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/ariel-frischer/autospec/internal/budget"
+	"github.com/ariel-frischer/autospec/internal/cliagent"
 	"github.com/ariel-frischer/autospec/internal/dag"
 	"github.com/ariel-frischer/autospec/internal/notify"
 	"github.com/ariel-frischer/autospec/internal/verification"
@@ -140,9 +144,9 @@ func ValidateConfigValues(cfg *Configuration, filePath string) error {
 		}
 	}
 
-	// ImplementMethod: must be one of "single-session", "phases", "tasks", or empty (uses default)
+	// ImplementMethod: must be one of "single-session", "phases", "tasks", "parallel", or empty (uses default)
 	if cfg.ImplementMethod != "" {
-		validMethods := []string{"single-session", "phases", "tasks"}
+		validMethods := []string{"single-session", "phases", "tasks", "parallel"}
 		isValid := false
 		for _, m := range validMethods {
 			if cfg.ImplementMethod == m {
@@ -154,7 +158,7 @@ func ValidateConfigValues(cfg *Configuration, filePath string) error {
 			return &ValidationError{
 				FilePath: filePath,
 				Field:    "implement_method",
-				Message:  "must be one of: single-session, phases, tasks",
+				Message:  "must be one of: single-session, phases, tasks, parallel",
 			}
 		}
 	}
@@ -180,6 +184,21 @@ func ValidateConfigValues(cfg *Configuration, filePath string) error {
 		return err
 	}
 
+	// Validate agent fallback chain
+	if err := validateAgentFallbacks(cfg.AgentFallbacks, filePath); err != nil {
+		return err
+	}
+
+	// Validate per-stage overrides
+	if err := validateStagesConfig(cfg.Stages, filePath); err != nil {
+		return err
+	}
+
+	// Validate budget limits
+	if err := validateBudgetConfig(&cfg.Budget, filePath); err != nil {
+		return err
+	}
+
 	// Validate DAG config if present
 	if cfg.DAG != nil {
 		if err := validateDAGConfig(cfg.DAG, filePath); err != nil {
@@ -224,6 +243,53 @@ func validateNotificationConfig(nc *notify.NotificationConfig, filePath string)
 	// Note: LongRunningThreshold of 0 or negative is valid and means "always notify"
 	// This is documented behavior per the spec, so no validation error is needed.
 
+	for i, ch := range nc.Channels {
+		if err := validateNotificationChannel(ch, i, filePath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateNotificationChannel validates a notifications.channels entry.
+func validateNotificationChannel(ch notify.ChannelConfig, index int, filePath string) error {
+	field := fmt.Sprintf("notifications.channels[%d]", index)
+	if !notify.ValidChannelType(string(ch.Type)) {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    field + ".type",
+			Message:  "must be one of: webhook, slack, ntfy, gotify",
+		}
+	}
+	if ch.URL == "" {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    field + ".url",
+			Message:  "is required",
+		}
+	}
+	if ch.Type == notify.ChannelGotify && ch.Token == "" {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    field + ".token",
+			Message:  "is required for gotify channels",
+		}
+	}
+	if ch.Template != "" && ch.Type != notify.ChannelWebhook {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    field + ".template",
+			Message:  "is only supported for webhook channels",
+		}
+	}
+	if err := notify.ParseWebhookTemplate(ch.Template); err != nil {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    field + ".template",
+			Message:  err.Error(),
+		}
+	}
 	return nil
 }
 
@@ -264,6 +330,33 @@ func validateDAGConfig(dc *dag.DAGExecutionConfig, filePath string) error {
 		}
 	}
 
+	// Validate SchedulePriority: must be a known priority
+	if dc.SchedulePriority != "" && !dag.IsValidSchedulePriority(dc.SchedulePriority) {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "dag.schedule_priority",
+			Message:  "must be one of: dag-order, critical-path",
+		}
+	}
+
+	// Validate MergeStrategy: must be a known strategy
+	if dc.MergeStrategy != "" && !dag.IsValidMergeStrategy(dc.MergeStrategy) {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "dag.merge_strategy",
+			Message:  "must be one of: no-ff, squash, rebase",
+		}
+	}
+
+	// Validate PRProvider: must be a supported provider
+	if dc.PRProvider != "" && !dag.IsValidPRProvider(dc.PRProvider) {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "dag.pr_provider",
+			Message:  "must be one of: github, gitlab, gitea",
+		}
+	}
+
 	// Validate MaxSpecRetries: must be non-negative
 	if dc.MaxSpecRetries < 0 {
 		return &ValidationError{
@@ -287,6 +380,171 @@ func validateDAGConfig(dc *dag.DAGExecutionConfig, filePath string) error {
 	return nil
 }
 
+// validateAgentFallbacks validates that every fallback names a registered agent.
+func validateAgentFallbacks(fallbacks []string, filePath string) error {
+	for i, name := range fallbacks {
+		if cliagent.Get(name) == nil {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    fmt.Sprintf("agent_fallbacks[%d]", i),
+				Message:  fmt.Sprintf("unknown agent %q; available: %s", name, strings.Join(cliagent.List(), ", ")),
+			}
+		}
+	}
+	return nil
+}
+
+// validateStagesConfig validates stage names, agents, and timeouts under stages,
+// and the definitions of custom stages.
+func validateStagesConfig(stages map[string]StageConfig, filePath string) error {
+	names := make([]string, 0, len(stages))
+	for name := range stages {
+		names = append(names, name)
+	}
+	sort.Strings(names) // Deterministic error reporting
+
+	for _, name := range names {
+		sc := stages[name]
+		if isStageName(name) && sc.hasCustomFields() {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    "stages." + name,
+				Message:  "command, description, requires, produces, schema, and after are only valid for custom stages",
+			}
+		}
+		if !isStageName(name) && sc.Command == "" {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    "stages." + name,
+				Message: fmt.Sprintf("unknown stage %q; valid stages: %s (custom stages must set command)",
+					name, strings.Join(StageNames, ", ")),
+			}
+		}
+		if !isStageName(name) {
+			if err := validateCustomStage(name, sc, stages, filePath); err != nil {
+				return err
+			}
+		}
+		if sc.Agent != "" && cliagent.Get(sc.Agent) == nil {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    "stages." + name + ".agent",
+				Message:  fmt.Sprintf("unknown agent %q; available: %s", sc.Agent, strings.Join(cliagent.List(), ", ")),
+			}
+		}
+		if sc.Timeout < 0 || sc.Timeout > 604800 {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    "stages." + name + ".timeout",
+				Message:  "must be between 1 and 604800 (or 0 to use the top-level timeout)",
+			}
+		}
+	}
+	return nil
+}
+
+// validateCustomStage validates the definition of the custom stage name.
+func validateCustomStage(name string, sc StageConfig, stages map[string]StageConfig, filePath string) error {
+	field := "stages." + name
+	if !customStageNamePattern.MatchString(name) {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    field,
+			Message:  "custom stage names must be lowercase letters, digits, and hyphens (e.g. threat-model)",
+		}
+	}
+	for _, reserved := range reservedStageNames {
+		if name == reserved {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    field,
+				Message:  fmt.Sprintf("%q is reserved for a built-in workflow stage", name),
+			}
+		}
+	}
+
+	for _, list := range []struct {
+		key   string
+		paths []string
+	}{{"requires", sc.Requires}, {"produces", sc.Produces}} {
+		for _, path := range list.paths {
+			if path == "" || filepath.IsAbs(path) || strings.HasPrefix(filepath.Clean(path), "..") {
+				return &ValidationError{
+					FilePath: filePath,
+					Field:    field + "." + list.key,
+					Message:  fmt.Sprintf("invalid artifact %q: must be a path relative to the spec directory", path),
+				}
+			}
+		}
+	}
+
+	if sc.Schema != "" {
+		if len(sc.Produces) == 0 {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    field + ".schema",
+				Message:  "requires produces; the schema validates the first produced artifact",
+			}
+		}
+		if ext := filepath.Ext(sc.Produces[0]); ext != ".yaml" && ext != ".yml" {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    field + ".schema",
+				Message:  fmt.Sprintf("can only validate YAML artifacts, got %q", sc.Produces[0]),
+			}
+		}
+	}
+
+	// Follow the after chain to reject unknown anchors and cycles
+	seen := map[string]bool{name: true}
+	for current := sc.After; current != ""; current = stages[current].After {
+		if isStageName(current) {
+			break
+		}
+		if _, ok := stages[current]; !ok {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    field + ".after",
+				Message:  fmt.Sprintf("unknown stage %q; must be a built-in or custom stage", current),
+			}
+		}
+		if seen[current] {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    field + ".after",
+				Message:  fmt.Sprintf("cycle through stage %q", current),
+			}
+		}
+		seen[current] = true
+	}
+	return nil
+}
+
+// validateBudgetConfig validates that budget limits are non-negative.
+func validateBudgetConfig(bc *budget.Config, filePath string) error {
+	limits := []struct {
+		field string
+		value float64
+	}{
+		{"budget.max_stage_cost_usd", bc.MaxStageCostUSD},
+		{"budget.max_stage_tokens", float64(bc.MaxStageTokens)},
+		{"budget.max_spec_cost_usd", bc.MaxSpecCostUSD},
+		{"budget.max_spec_tokens", float64(bc.MaxSpecTokens)},
+		{"budget.max_run_cost_usd", bc.MaxRunCostUSD},
+		{"budget.max_run_tokens", float64(bc.MaxRunTokens)},
+	}
+	for _, l := range limits {
+		if l.value < 0 {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    l.field,
+				Message:  "must be >= 0 (0 = unlimited)",
+			}
+		}
+	}
+	return nil
+}
+
 // validateVerificationConfig validates verification configuration values.
 func validateVerificationConfig(vc *verification.VerificationConfig, filePath string) error {
 	if vc.Level != "" && !vc.Level.IsValid() {
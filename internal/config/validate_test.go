@@ -9,6 +9,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ariel-frischer/autospec/internal/budget"
+	"github.com/ariel-frischer/autospec/internal/notify"
 	"github.com/ariel-frischer/autospec/internal/verification"
 )
 
@@ -226,6 +228,10 @@ func TestValidateConfigValues_ImplementMethod(t *testing.T) {
 			implementMethod: "tasks",
 			wantErr:         false,
 		},
+		"valid parallel": {
+			implementMethod: "parallel",
+			wantErr:         false,
+		},
 		"empty string is valid (uses default)": {
 			implementMethod: "",
 			wantErr:         false,
@@ -403,6 +409,72 @@ func TestValidateNotificationConfig_ValidSoundFile(t *testing.T) {
 	}
 }
 
+func TestValidateNotificationConfig_Channels(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		channel   notify.ChannelConfig
+		wantField string
+	}{
+		"valid ntfy": {
+			channel: notify.ChannelConfig{Type: notify.ChannelNtfy, URL: "https://ntfy.sh/topic"},
+		},
+		"valid webhook template": {
+			channel: notify.ChannelConfig{Type: notify.ChannelWebhook, URL: "https://example.com/hook", Template: `{"text": {{json .Message}}}`},
+		},
+		"unknown type": {
+			channel:   notify.ChannelConfig{Type: "email", URL: "https://example.com"},
+			wantField: "notifications.channels[0].type",
+		},
+		"missing url": {
+			channel:   notify.ChannelConfig{Type: notify.ChannelSlack},
+			wantField: "notifications.channels[0].url",
+		},
+		"gotify without token": {
+			channel:   notify.ChannelConfig{Type: notify.ChannelGotify, URL: "https://gotify.example.com"},
+			wantField: "notifications.channels[0].token",
+		},
+		"template on slack": {
+			channel:   notify.ChannelConfig{Type: notify.ChannelSlack, URL: "https://hooks.slack.com/x", Template: "{}"},
+			wantField: "notifications.channels[0].template",
+		},
+		"invalid template": {
+			channel:   notify.ChannelConfig{Type: notify.ChannelWebhook, URL: "https://example.com", Template: "{{.Message"},
+			wantField: "notifications.channels[0].template",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &Configuration{
+				AgentPreset: "claude",
+				MaxRetries:  3,
+				SpecsDir:    "./specs",
+				StateDir:    "~/.autospec/state",
+			}
+			cfg.Notifications.Channels = []notify.ChannelConfig{tt.channel}
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateConfigValues() returned error for valid channel: %v", err)
+				}
+				return
+			}
+
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+		})
+	}
+}
+
 func TestExtractLineColumn(t *testing.T) {
 	t.Parallel()
 
@@ -928,3 +1000,176 @@ func TestValidateVerificationConfig_ValidFullConfig(t *testing.T) {
 		t.Errorf("ValidateConfigValues() returned error for valid verification config: %v", err)
 	}
 }
+
+func TestValidateBudgetConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		budget    budget.Config
+		wantField string
+	}{
+		"zero limits are valid": {},
+		"positive limits are valid": {
+			budget: budget.Config{MaxStageCostUSD: 1.5, MaxSpecTokens: 100000, MaxRunCostUSD: 20},
+		},
+		"negative stage cost": {
+			budget:    budget.Config{MaxStageCostUSD: -1},
+			wantField: "budget.max_stage_cost_usd",
+		},
+		"negative run tokens": {
+			budget:    budget.Config{MaxRunTokens: -10},
+			wantField: "budget.max_run_tokens",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateBudgetConfig(&tt.budget, "test.yml")
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("validateBudgetConfig() unexpected error: %v", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T", err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestValidateAgentFallbacks(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		fallbacks []string
+		wantField string
+	}{
+		"empty list is valid": {},
+		"registered agents are valid": {
+			fallbacks: []string{"codex", "opencode"},
+		},
+		"unknown agent": {
+			fallbacks: []string{"codex", "nope"},
+			wantField: "agent_fallbacks[1]",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateAgentFallbacks(tt.fallbacks, "test.yml")
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("validateAgentFallbacks() unexpected error: %v", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T", err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestValidateStagesConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		stages    map[string]StageConfig
+		wantField string
+	}{
+		"no stages is valid": {},
+		"valid overrides": {
+			stages: map[string]StageConfig{
+				"plan":      {Agent: "codex"},
+				"implement": {ExtraArgs: []string{"--model", "opus"}, Timeout: 3600},
+			},
+		},
+		"unknown stage": {
+			stages:    map[string]StageConfig{"deploy": {Agent: "codex"}},
+			wantField: "stages.deploy",
+		},
+		"unknown agent": {
+			stages:    map[string]StageConfig{"tasks": {Agent: "nope"}},
+			wantField: "stages.tasks.agent",
+		},
+		"negative timeout": {
+			stages:    map[string]StageConfig{"plan": {Timeout: -1}},
+			wantField: "stages.plan.timeout",
+		},
+		"valid custom stages": {
+			stages: map[string]StageConfig{
+				"threat-model": {Command: "autospec.threat-model", Requires: []string{"plan.yaml"}, Produces: []string{"threat-model.yaml"}, Schema: "threat.yaml", After: "plan"},
+				"api-contract": {Command: "autospec.api-contract", After: "threat-model", Agent: "codex"},
+			},
+		},
+		"custom field on built-in stage": {
+			stages:    map[string]StageConfig{"plan": {Command: "autospec.other"}},
+			wantField: "stages.plan",
+		},
+		"invalid custom stage name": {
+			stages:    map[string]StageConfig{"Threat_Model": {Command: "autospec.threat"}},
+			wantField: "stages.Threat_Model",
+		},
+		"reserved custom stage name": {
+			stages:    map[string]StageConfig{"review": {Command: "autospec.review"}},
+			wantField: "stages.review",
+		},
+		"artifact outside spec directory": {
+			stages:    map[string]StageConfig{"threat-model": {Command: "autospec.threat-model", Produces: []string{"../threat.yaml"}}},
+			wantField: "stages.threat-model.produces",
+		},
+		"schema without produces": {
+			stages:    map[string]StageConfig{"threat-model": {Command: "autospec.threat-model", Schema: "threat.yaml"}},
+			wantField: "stages.threat-model.schema",
+		},
+		"schema for non-YAML artifact": {
+			stages:    map[string]StageConfig{"threat-model": {Command: "autospec.threat-model", Produces: []string{"threats.md"}, Schema: "threat.yaml"}},
+			wantField: "stages.threat-model.schema",
+		},
+		"unknown after stage": {
+			stages:    map[string]StageConfig{"threat-model": {Command: "autospec.threat-model", After: "deploy"}},
+			wantField: "stages.threat-model.after",
+		},
+		"after cycle": {
+			stages: map[string]StageConfig{
+				"api-contract": {Command: "autospec.api-contract", After: "threat-model"},
+				"threat-model": {Command: "autospec.threat-model", After: "api-contract"},
+			},
+			wantField: "stages.api-contract.after",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateStagesConfig(tt.stages, "test.yml")
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("validateStagesConfig() unexpected error: %v", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T", err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package dag
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/budget"
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/retry"
+)
+
+// DefaultBudgetPollInterval is how often spending is checked while specs run.
+const DefaultBudgetPollInterval = 5 * time.Second
+
+// WithBudget sets spending limits enforced per spec and per DAG run.
+// Requires WithUsageStateDir so usage recorded by spec subprocesses can be read.
+func WithBudget(cfg budget.Config) ExecutorOption {
+	return func(e *Executor) {
+		e.budget = cfg
+	}
+}
+
+// WithBudgetPollInterval sets how often spending is checked while specs run.
+func WithBudgetPollInterval(d time.Duration) ExecutorOption {
+	return func(e *Executor) {
+		e.budgetPollInterval = d
+	}
+}
+
+// startBudgetMonitor derives a context that is cancelled once a spec or run
+// budget is reached, which stops in-flight autospec subprocesses.
+// Spending is checked immediately (a resumed run may already be over budget)
+// and then every budgetPollInterval. The returned stop function must be called
+// when the run finishes.
+func (e *Executor) startBudgetMonitor(ctx context.Context) (context.Context, context.CancelFunc) {
+	if e.usageStateDir == "" || e.state == nil || (!e.budget.HasSpecLimit() && !e.budget.HasRunLimit()) {
+		return ctx, func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	e.budgetTracker = e.newBudgetTracker()
+	e.budgetCancel = cancel
+
+	if e.enforceBudget() {
+		return ctx, cancel
+	}
+
+	interval := e.budgetPollInterval
+	if interval <= 0 {
+		interval = DefaultBudgetPollInterval
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if e.enforceBudget() {
+					return
+				}
+			}
+		}
+	}()
+
+	return ctx, cancel
+}
+
+// enforceBudget checks spending and cancels the run context when a limit is
+// reached. Returns true if the run has been stopped by a budget limit.
+// Safe to call concurrently and before startBudgetMonitor (no-op).
+func (e *Executor) enforceBudget() bool {
+	if e.BudgetExceeded() != nil {
+		return true
+	}
+	if e.budgetTracker == nil {
+		return false
+	}
+
+	exceeded := e.budgetTracker.check()
+	if exceeded == nil {
+		return false
+	}
+
+	e.budgetMu.Lock()
+	first := e.budgetExceeded == nil
+	if first {
+		e.budgetExceeded = exceeded
+	}
+	e.budgetMu.Unlock()
+
+	if first {
+		fmt.Fprintf(e.stdout, "\nBudget limit reached: %v. Cancelling running specs...\n", exceeded)
+		e.budgetCancel()
+	}
+	return true
+}
+
+// budgetTracker computes DAG spending from usage persisted by spec subprocesses.
+// It reads only the usage store, never the shared run state, so it can run
+// concurrently with spec execution.
+type budgetTracker struct {
+	cfg      budget.Config
+	stateDir string
+	runName  string
+	// baselines holds each spec's persisted usage when the run started.
+	baselines map[string]*retry.UsageState
+	// prior holds usage attributed to each spec by earlier attempts of this run.
+	prior map[string]cliagent.Usage
+	// order keeps spec checks deterministic.
+	order []string
+}
+
+// newBudgetTracker snapshots current usage for every spec in the DAG.
+func (e *Executor) newBudgetTracker() *budgetTracker {
+	t := &budgetTracker{
+		cfg:       e.budget,
+		stateDir:  e.usageStateDir,
+		runName:   e.dagFile,
+		baselines: make(map[string]*retry.UsageState),
+		prior:     make(map[string]cliagent.Usage),
+	}
+	for _, specID := range e.collectSpecIDs() {
+		t.order = append(t.order, specID)
+		t.baselines[specID] = t.snapshot(specID)
+		if specState := e.state.Specs[specID]; specState != nil && specState.Usage != nil {
+			t.prior[specID] = *specState.Usage
+		}
+	}
+	return t
+}
+
+// snapshot returns persisted usage for a spec, or an empty total on error.
+func (t *budgetTracker) snapshot(specID string) *retry.UsageState {
+	total, err := retry.SpecUsageTotal(t.stateDir, specID)
+	if err != nil || total == nil {
+		return &retry.UsageState{SpecName: specID}
+	}
+	return total
+}
+
+// check returns the first spec or run limit reached, or nil.
+func (t *budgetTracker) check() *budget.ExceededError {
+	var run cliagent.Usage
+	for _, specID := range t.order {
+		spent := t.prior[specID]
+		spent.Add(usageDelta(t.baselines[specID], t.snapshot(specID)))
+		if err := t.cfg.CheckSpec(specID, spent); err != nil {
+			exceeded, _ := budget.AsExceeded(err)
+			return exceeded
+		}
+		run.Add(spent)
+	}
+	if err := t.cfg.CheckRun(t.runName, run); err != nil {
+		exceeded, _ := budget.AsExceeded(err)
+		return exceeded
+	}
+	return nil
+}
+
+// BudgetExceeded returns the limit that stopped the run, or nil.
+func (e *Executor) BudgetExceeded() *budget.ExceededError {
+	e.budgetMu.Lock()
+	defer e.budgetMu.Unlock()
+	return e.budgetExceeded
+}
+
+// budgetStopError returns a CLIError describing the exceeded budget and how to resume.
+func (e *Executor) budgetStopError() error {
+	exceeded := e.BudgetExceeded()
+	return clierrors.BudgetExceeded(
+		fmt.Sprintf("DAG run stopped: %v", exceeded),
+		exceeded.ConfigKey(),
+		"autospec dag run "+e.dagFile,
+	)
+}
+
+// interruptionError returns the error reported when the run context is cancelled:
+// a budget CLIError when a limit stopped the run, otherwise the context error.
+func (e *Executor) interruptionError(ctx context.Context) error {
+	if e.BudgetExceeded() != nil {
+		return e.budgetStopError()
+	}
+	return ctx.Err()
+}
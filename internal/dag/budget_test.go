@@ -0,0 +1,231 @@
+package dag
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/budget"
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/ariel-frischer/autospec/internal/worktree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// usageRecordingRunner simulates spec subprocesses that record agent usage
+// into the shared state directory. When block is set, each run waits for
+// context cancellation after recording usage.
+type usageRecordingRunner struct {
+	stateDir string
+	specIDs  []string
+	costUSD  float64
+	block    bool
+	runs     []string
+}
+
+func (r *usageRecordingRunner) Run(ctx context.Context, dir string, _, _ io.Writer, _ string, _ ...string) (int, error) {
+	for _, specID := range r.specIDs {
+		if !strings.HasSuffix(dir, specID) {
+			continue
+		}
+		r.runs = append(r.runs, specID)
+		if err := retry.RecordUsage(r.stateDir, &retry.UsageState{
+			SpecName: specID,
+			Scope:    retry.StageUsageScope("implement"),
+			CostUSD:  r.costUSD,
+			Sessions: 1,
+		}); err != nil {
+			return 1, err
+		}
+	}
+	if r.block {
+		<-ctx.Done()
+		return 1, ctx.Err()
+	}
+	return 0, nil
+}
+
+func TestBudgetTracker_Check(t *testing.T) {
+	tests := map[string]struct {
+		cfg       budget.Config
+		prior     map[string]cliagent.Usage
+		recorded  map[string]float64
+		wantScope budget.Scope
+		wantSubj  string
+	}{
+		"under all limits": {
+			cfg:      budget.Config{MaxSpecCostUSD: 5, MaxRunCostUSD: 10},
+			recorded: map[string]float64{"spec-a": 1, "spec-b": 2},
+		},
+		"spec limit reached": {
+			cfg:       budget.Config{MaxSpecCostUSD: 2},
+			recorded:  map[string]float64{"spec-a": 1, "spec-b": 2},
+			wantScope: budget.ScopeSpec,
+			wantSubj:  "spec-b",
+		},
+		"run limit reached across specs": {
+			cfg:       budget.Config{MaxRunCostUSD: 3},
+			recorded:  map[string]float64{"spec-a": 1.5, "spec-b": 1.5},
+			wantScope: budget.ScopeRun,
+			wantSubj:  "dag.yaml",
+		},
+		"prior attempts count toward the spec": {
+			cfg:       budget.Config{MaxSpecCostUSD: 2},
+			prior:     map[string]cliagent.Usage{"spec-a": {CostUSD: 1.5}},
+			recorded:  map[string]float64{"spec-a": 0.5},
+			wantScope: budget.ScopeSpec,
+			wantSubj:  "spec-a",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			stateDir := t.TempDir()
+			// Usage recorded before the run is part of the baseline and ignored
+			require.NoError(t, retry.RecordUsage(stateDir, &retry.UsageState{
+				SpecName: "spec-a", Scope: retry.StageUsageScope("specify"), CostUSD: 100,
+			}))
+
+			tracker := &budgetTracker{
+				cfg:       tt.cfg,
+				stateDir:  stateDir,
+				runName:   "dag.yaml",
+				baselines: make(map[string]*retry.UsageState),
+				prior:     tt.prior,
+				order:     []string{"spec-a", "spec-b"},
+			}
+			for _, specID := range tracker.order {
+				tracker.baselines[specID] = tracker.snapshot(specID)
+			}
+			for _, specID := range tracker.order {
+				if cost, ok := tt.recorded[specID]; ok {
+					require.NoError(t, retry.RecordUsage(stateDir, &retry.UsageState{
+						SpecName: specID, Scope: retry.StageUsageScope("implement"), CostUSD: cost,
+					}))
+				}
+			}
+
+			exceeded := tracker.check()
+			if tt.wantScope == "" {
+				assert.Nil(t, exceeded)
+				return
+			}
+			require.NotNil(t, exceeded)
+			assert.Equal(t, tt.wantScope, exceeded.Scope)
+			assert.Equal(t, tt.wantSubj, exceeded.Subject)
+		})
+	}
+}
+
+func newBudgetTestExecutor(t *testing.T, runner CommandRunner, usageDir string, cfg budget.Config) (*Executor, string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+
+	dagConfig := &DAGConfig{
+		SchemaVersion: "1.0",
+		DAG:           DAGMetadata{Name: "Budget DAG"},
+		Layers: []Layer{
+			{
+				ID: "L0",
+				Features: []Feature{
+					{ID: "spec-a", Description: "First spec"},
+					{ID: "spec-b", Description: "Second spec"},
+					{ID: "spec-c", Description: "Third spec"},
+				},
+			},
+		},
+	}
+	dagFile := filepath.Join(tmpDir, "dag.yaml")
+	require.NoError(t, SaveDAGWithState(dagFile, dagConfig))
+
+	exec := NewExecutor(
+		dagConfig,
+		dagFile,
+		newMockWorktreeManager(),
+		filepath.Join(tmpDir, "state"),
+		tmpDir,
+		DefaultDAGConfig(),
+		worktree.DefaultConfig(),
+		WithExecutorStdout(io.Discard),
+		WithCommandRunner(runner),
+		WithUsageStateDir(usageDir),
+		WithBudget(cfg),
+		WithBudgetPollInterval(time.Hour),
+	)
+	return exec, dagFile
+}
+
+func TestExecute_RunBudgetStopsBeforeNextSpec(t *testing.T) {
+	usageDir := t.TempDir()
+	runner := &usageRecordingRunner{
+		stateDir: usageDir,
+		specIDs:  []string{"spec-a", "spec-b", "spec-c"},
+		costUSD:  1,
+	}
+	exec, dagFile := newBudgetTestExecutor(t, runner, usageDir, budget.Config{MaxRunCostUSD: 1.5})
+
+	_, err := exec.Execute(context.Background())
+	require.Error(t, err)
+
+	cliErr := clierrors.AsCLIError(err)
+	require.NotNil(t, cliErr, "expected CLIError, got %T: %v", err, err)
+	assert.Contains(t, cliErr.Message, "run budget exceeded")
+	assert.Contains(t, strings.Join(cliErr.Remediation, "\n"), "budget.max_run_cost_usd")
+	assert.Contains(t, strings.Join(cliErr.Remediation, "\n"), "autospec dag run "+dagFile)
+
+	assert.Equal(t, []string{"spec-a", "spec-b"}, runner.runs)
+	state := exec.State()
+	assert.Equal(t, RunStatusInterrupted, state.Status)
+	assert.Equal(t, SpecStatusCompleted, state.Specs["spec-b"].Status)
+	assert.Equal(t, SpecStatusPending, state.Specs["spec-c"].Status)
+}
+
+func TestExecute_BudgetCancelsRunningSpec(t *testing.T) {
+	usageDir := t.TempDir()
+	runner := &usageRecordingRunner{
+		stateDir: usageDir,
+		specIDs:  []string{"spec-a", "spec-b", "spec-c"},
+		costUSD:  2,
+		block:    true,
+	}
+	exec, _ := newBudgetTestExecutor(t, runner, usageDir, budget.Config{MaxSpecCostUSD: 1})
+	WithBudgetPollInterval(5 * time.Millisecond)(exec)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := exec.Execute(context.Background())
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		require.NotNil(t, clierrors.AsCLIError(err), "expected CLIError, got %T: %v", err, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("budget monitor did not cancel the running spec")
+	}
+
+	require.NotNil(t, exec.BudgetExceeded())
+	assert.Equal(t, budget.ScopeSpec, exec.BudgetExceeded().Scope)
+	state := exec.State()
+	assert.Equal(t, RunStatusInterrupted, state.Status)
+	// The cancelled spec stays running so a plain re-run resumes it
+	assert.Equal(t, SpecStatusRunning, state.Specs["spec-a"].Status)
+}
+
+func TestExecute_ResumedRunAlreadyOverBudget(t *testing.T) {
+	usageDir := t.TempDir()
+	runner := &usageRecordingRunner{stateDir: usageDir, specIDs: []string{"spec-a"}}
+	exec, _ := newBudgetTestExecutor(t, runner, usageDir, budget.Config{MaxRunCostUSD: 1})
+	resumed := NewDAGRun(exec.dagFile, exec.dag, 0)
+	resumed.Specs["spec-a"].Usage = &cliagent.Usage{CostUSD: 1.25}
+	WithExistingState(resumed)(exec)
+
+	_, err := exec.Execute(context.Background())
+	require.NotNil(t, clierrors.AsCLIError(err), "expected CLIError, got %T: %v", err, err)
+	assert.Empty(t, runner.runs)
+}
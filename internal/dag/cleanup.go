@@ -159,6 +159,8 @@ func (ce *CleanupExecutor) reportKept(specID string, specState *SpecState) {
 			reason = "merge failed"
 		case MergeStatusSkipped:
 			reason = "merge skipped"
+		case MergeStatusPROpen:
+			reason = "pull request open"
 		case MergeStatusPending:
 			reason = "merge pending"
 		}
@@ -276,6 +278,8 @@ func (ce *CleanupExecutor) reportInlineKept(specID string, specState *InlineSpec
 			reason = "merge failed"
 		case MergeStatusSkipped:
 			reason = "merge skipped"
+		case MergeStatusPROpen:
+			reason = "pull request open"
 		case MergeStatusPending:
 			reason = "merge pending"
 		}
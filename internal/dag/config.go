@@ -16,11 +16,19 @@ type DAGExecutionConfig struct {
 	// OnConflict specifies default merge conflict handling.
 	// Valid values: "manual" (default), "agent"
 	OnConflict string `yaml:"on_conflict,omitempty" koanf:"on_conflict"`
+	// ConflictVerifyCmd is a command that must pass after the agent resolves
+	// merge conflicts, such as the project build. A failure is fed back to the
+	// agent for another attempt; when all attempts fail the merge falls back to
+	// manual resolution with a conflict report in the DAG log directory.
+	// Empty checks only that conflict markers are gone.
+	// Supports template variables: {{.SpecID}}, {{.Worktree}}, {{.Branch}}, {{.BaseBranch}}
+	ConflictVerifyCmd string `yaml:"conflict_verify_cmd,omitempty" koanf:"conflict_verify_cmd"`
 	// BaseBranch is the default target branch for merging completed specs.
 	// If empty, defaults to the repository's default branch (usually "main" or "master").
 	BaseBranch string `yaml:"base_branch,omitempty" koanf:"base_branch"`
 	// MaxSpecRetries is the max auto-retry attempts per spec.
-	// 0 means manual retry only (default).
+	// Only transient failures (agent crash, timeout, commit verification) are
+	// retried, with exponential backoff. 0 means manual retry only (default).
 	MaxSpecRetries int `yaml:"max_spec_retries,omitempty" koanf:"max_spec_retries"`
 	// MaxLogSize is the max log file size per spec (e.g., "50MB").
 	// Default: "50MB"
@@ -45,6 +53,37 @@ type DAGExecutionConfig struct {
 	// Requires Autocommit to be enabled (validated by Validate method).
 	// Default: true
 	Automerge *bool `yaml:"automerge,omitempty" koanf:"automerge"`
+	// PreMergeCmd is a command that gates each staging merge, such as the
+	// project test suite. It runs in the spec worktree before the merge and on
+	// the staging branch after it; a failure reverts the merge and marks the
+	// spec merge_failed. Empty disables the gate.
+	// Supports template variables: {{.SpecID}}, {{.Worktree}}, {{.Branch}}, {{.BaseBranch}}, {{.DagID}}
+	PreMergeCmd string `yaml:"pre_merge_cmd,omitempty" koanf:"pre_merge_cmd"`
+	// Remote is the git remote that dag merge --pr pushes branches to.
+	// Default: "origin"
+	Remote string `yaml:"remote,omitempty" koanf:"remote"`
+	// PRProvider is the hosting service for dag merge --pr.
+	// Valid values: "github", "gitlab", "gitea". Empty detects it from the remote URL.
+	PRProvider string `yaml:"pr_provider,omitempty" koanf:"pr_provider"`
+	// PRRepo is the repository path on the host, e.g. "owner/name".
+	// Empty derives it from the remote URL.
+	PRRepo string `yaml:"pr_repo,omitempty" koanf:"pr_repo"`
+	// PRAPIURL is the provider REST API base URL, e.g. "https://gitea.example.com/api/v1".
+	// Empty derives it from the remote host: the public API for github.com and
+	// gitlab.com, otherwise /api/v3 (GitHub Enterprise), /api/v4 (GitLab), or /api/v1 (Gitea).
+	PRAPIURL string `yaml:"pr_api_url,omitempty" koanf:"pr_api_url"`
+	// PRToken authenticates provider API requests and may reference
+	// environment variables (e.g. "${GH_TOKEN}"). Empty uses GITHUB_TOKEN,
+	// GITLAB_TOKEN, or GITEA_TOKEN.
+	PRToken string `yaml:"pr_token,omitempty" koanf:"pr_token"`
+	// SchedulePriority orders ready specs in parallel runs when more are ready
+	// than there are free slots.
+	// Valid values: "dag-order" (default), "critical-path"
+	SchedulePriority string `yaml:"schedule_priority,omitempty" koanf:"schedule_priority"`
+	// MergeStrategy controls how specs are merged into layer staging branches
+	// and how the final staging branch is merged into the target branch.
+	// Valid values: "no-ff" (default), "squash", "rebase"
+	MergeStrategy string `yaml:"merge_strategy,omitempty" koanf:"merge_strategy"`
 }
 
 // DefaultDAGConfig returns a DAGExecutionConfig with default values.
@@ -58,6 +97,9 @@ func DefaultDAGConfig() *DAGExecutionConfig {
 		Autocommit:        &autocommitDefault,
 		AutocommitRetries: 1,
 		Automerge:         &automergeDefault,
+		SchedulePriority:  string(PriorityDAGOrder),
+		MergeStrategy:     string(MergeStrategyNoFF),
+		Remote:            "origin",
 	}
 }
 
@@ -106,6 +148,33 @@ func applyProvidedConfig(result, cfg *DAGExecutionConfig) {
 	if cfg.Automerge != nil {
 		result.Automerge = cfg.Automerge
 	}
+	if cfg.PreMergeCmd != "" {
+		result.PreMergeCmd = cfg.PreMergeCmd
+	}
+	if cfg.ConflictVerifyCmd != "" {
+		result.ConflictVerifyCmd = cfg.ConflictVerifyCmd
+	}
+	if cfg.Remote != "" {
+		result.Remote = cfg.Remote
+	}
+	if cfg.PRProvider != "" {
+		result.PRProvider = cfg.PRProvider
+	}
+	if cfg.PRRepo != "" {
+		result.PRRepo = cfg.PRRepo
+	}
+	if cfg.PRAPIURL != "" {
+		result.PRAPIURL = cfg.PRAPIURL
+	}
+	if cfg.PRToken != "" {
+		result.PRToken = cfg.PRToken
+	}
+	if cfg.SchedulePriority != "" {
+		result.SchedulePriority = cfg.SchedulePriority
+	}
+	if cfg.MergeStrategy != "" {
+		result.MergeStrategy = cfg.MergeStrategy
+	}
 }
 
 // applyEnvOverrides applies environment variable overrides to the config.
@@ -133,6 +202,12 @@ func (c *DAGExecutionConfig) applyBaseEnvOverrides() {
 	if val := os.Getenv("AUTOSPEC_DAG_LOG_DIR"); val != "" {
 		c.LogDir = val
 	}
+	if val := os.Getenv("AUTOSPEC_DAG_SCHEDULE_PRIORITY"); val != "" {
+		c.SchedulePriority = val
+	}
+	if val := os.Getenv("AUTOSPEC_DAG_MERGE_STRATEGY"); val != "" {
+		c.MergeStrategy = val
+	}
 }
 
 // applyAutocommitEnvOverrides applies autocommit and automerge related env overrides.
@@ -153,6 +228,21 @@ func (c *DAGExecutionConfig) applyAutocommitEnvOverrides() {
 		enabled := val == "true" || val == "1"
 		c.Automerge = &enabled
 	}
+	if val := os.Getenv("AUTOSPEC_DAG_PRE_MERGE_CMD"); val != "" {
+		c.PreMergeCmd = val
+	}
+	if val := os.Getenv("AUTOSPEC_DAG_CONFLICT_VERIFY_CMD"); val != "" {
+		c.ConflictVerifyCmd = val
+	}
+	if val := os.Getenv("AUTOSPEC_DAG_REMOTE"); val != "" {
+		c.Remote = val
+	}
+	if val := os.Getenv("AUTOSPEC_DAG_PR_PROVIDER"); val != "" {
+		c.PRProvider = val
+	}
+	if val := os.Getenv("AUTOSPEC_DAG_PR_TOKEN"); val != "" {
+		c.PRToken = val
+	}
 }
 
 // LoadWorktreeConfig loads worktree configuration with hierarchy:
@@ -298,5 +388,14 @@ func (c *DAGExecutionConfig) Validate() error {
 	if c.IsAutomergeEnabled() && !c.IsAutocommitEnabled() {
 		return fmt.Errorf("automerge requires autocommit to be enabled")
 	}
+	if c.SchedulePriority != "" && !IsValidSchedulePriority(c.SchedulePriority) {
+		return fmt.Errorf("invalid schedule_priority %q: must be one of: dag-order, critical-path", c.SchedulePriority)
+	}
+	if c.MergeStrategy != "" && !IsValidMergeStrategy(c.MergeStrategy) {
+		return fmt.Errorf("invalid merge_strategy %q: must be one of: no-ff, squash, rebase", c.MergeStrategy)
+	}
+	if c.PRProvider != "" && !IsValidPRProvider(c.PRProvider) {
+		return fmt.Errorf("invalid pr_provider %q: must be one of: github, gitlab, gitea", c.PRProvider)
+	}
 	return nil
 }
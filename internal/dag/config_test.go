@@ -689,6 +689,24 @@ func TestValidate(t *testing.T) {
 			cfg:     &DAGExecutionConfig{Autocommit: nil, Automerge: nil},
 			wantErr: false,
 		},
+		"critical-path schedule priority - valid": {
+			cfg:     &DAGExecutionConfig{SchedulePriority: "critical-path"},
+			wantErr: false,
+		},
+		"unknown schedule priority - invalid": {
+			cfg:     &DAGExecutionConfig{SchedulePriority: "fastest"},
+			wantErr: true,
+			errMsg:  "invalid schedule_priority",
+		},
+		"squash merge strategy - valid": {
+			cfg:     &DAGExecutionConfig{MergeStrategy: "squash"},
+			wantErr: false,
+		},
+		"unknown merge strategy - invalid": {
+			cfg:     &DAGExecutionConfig{MergeStrategy: "octopus"},
+			wantErr: true,
+			errMsg:  "invalid merge_strategy",
+		},
 	}
 
 	for name, tt := range tests {
@@ -3,6 +3,7 @@ package dag
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -33,21 +34,37 @@ type ConflictContext struct {
 
 // ConflictResolver handles merge conflict resolution.
 type ConflictResolver struct {
-	repoRoot string
-	agent    cliagent.Agent
-	stdout   io.Writer
+	repoRoot  string
+	agent     cliagent.Agent
+	stdout    io.Writer
+	verifyCmd string
+}
+
+// ConflictResolverOption configures a ConflictResolver.
+type ConflictResolverOption func(*ConflictResolver)
+
+// WithConflictVerifyCmd sets the command that must pass after the agent
+// resolves conflicts. See DAGExecutionConfig.ConflictVerifyCmd.
+func WithConflictVerifyCmd(cmd string) ConflictResolverOption {
+	return func(cr *ConflictResolver) {
+		cr.verifyCmd = cmd
+	}
 }
 
 // NewConflictResolver creates a new ConflictResolver.
-func NewConflictResolver(repoRoot string, agent cliagent.Agent, stdout io.Writer) *ConflictResolver {
+func NewConflictResolver(repoRoot string, agent cliagent.Agent, stdout io.Writer, opts ...ConflictResolverOption) *ConflictResolver {
 	if stdout == nil {
 		stdout = os.Stdout
 	}
-	return &ConflictResolver{
+	cr := &ConflictResolver{
 		repoRoot: repoRoot,
 		agent:    agent,
 		stdout:   stdout,
 	}
+	for _, opt := range opts {
+		opt(cr)
+	}
+	return cr
 }
 
 // BuildConflictContext creates a ConflictContext from a conflicted file.
@@ -146,13 +163,24 @@ func lookupSpecInfo(dag *DAGConfig, specID string) (name, description string) {
 func (cr *ConflictResolver) ResolveWithAgent(
 	ctx context.Context,
 	conflicts []*ConflictContext,
+) error {
+	return cr.ResolveWithAgentFeedback(ctx, conflicts, "")
+}
+
+// ResolveWithAgentFeedback attempts to resolve conflicts using an AI agent.
+// A non-empty feedback describes why the previous resolution was rejected
+// and is appended to each prompt.
+func (cr *ConflictResolver) ResolveWithAgentFeedback(
+	ctx context.Context,
+	conflicts []*ConflictContext,
+	feedback string,
 ) error {
 	if cr.agent == nil {
 		return fmt.Errorf("no agent configured for conflict resolution")
 	}
 
 	for _, conflict := range conflicts {
-		prompt := buildAgentPrompt(conflict)
+		prompt := buildAgentPrompt(conflict) + buildFeedbackSection(feedback)
 
 		result, err := cr.agent.Execute(ctx, prompt, cliagent.ExecOptions{
 			Autonomous: true,
@@ -210,6 +238,22 @@ func buildAgentPrompt(conflict *ConflictContext) string {
 	return sb.String()
 }
 
+// buildFeedbackSection describes a rejected previous resolution for the
+// agent prompt. Returns an empty string when there is no feedback.
+func buildFeedbackSection(feedback string) string {
+	if feedback == "" {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n## Previous Attempt Failed\n\n")
+	sb.WriteString("A previous resolution of this merge was rejected and reverted. ")
+	sb.WriteString("Avoid repeating the problem below:\n\n```\n")
+	sb.WriteString(strings.TrimRight(feedback, "\n"))
+	sb.WriteString("\n```\n")
+	return sb.String()
+}
+
 // verifyConflictResolved checks that a file no longer contains conflict markers.
 func verifyConflictResolved(repoRoot, filePath string) error {
 	fullPath := filepath.Join(repoRoot, filePath)
@@ -225,6 +269,64 @@ func verifyConflictResolved(repoRoot, filePath string) error {
 	return nil
 }
 
+// ConflictVerifyError reports a failed dag.conflict_verify_cmd run after an
+// agent resolution.
+type ConflictVerifyError struct {
+	// ExitCode is the exit code of the command.
+	ExitCode int
+	// Output is the tail of the command's combined output.
+	Output string
+}
+
+// Error implements the error interface.
+func (e *ConflictVerifyError) Error() string {
+	return fmt.Sprintf("conflict verification command failed with exit code %d", e.ExitCode)
+}
+
+// VerifyResolution checks an agent resolution: every conflicted file must be
+// free of conflict markers, and the verification command, if configured,
+// must pass in the repository. {{.Worktree}} expands to the repository root,
+// {{.Branch}} to the source branch and {{.BaseBranch}} to the target branch.
+func (cr *ConflictResolver) VerifyResolution(ctx context.Context, conflicts []*ConflictContext) error {
+	for _, conflict := range conflicts {
+		if err := verifyConflictResolved(cr.repoRoot, conflict.FilePath); err != nil {
+			return fmt.Errorf("conflict not fully resolved in %s: %w", conflict.FilePath, err)
+		}
+	}
+
+	if cr.verifyCmd == "" || len(conflicts) == 0 {
+		return nil
+	}
+
+	cmd, err := ExpandTemplateVars(cr.verifyCmd, TemplateVars{
+		SpecID:     conflicts[0].SpecID,
+		Worktree:   cr.repoRoot,
+		Branch:     conflicts[0].SourceBranch,
+		BaseBranch: conflicts[0].TargetBranch,
+	})
+	if err != nil {
+		return fmt.Errorf("expanding conflict verification command: %w", err)
+	}
+
+	fmt.Fprintf(cr.stdout, "Verifying resolution: %s\n", cmd)
+	tail := newTailBuffer(failureTailSize)
+	verify := exec.CommandContext(ctx, "sh", "-c", cmd)
+	verify.Dir = cr.repoRoot
+	verify.Stdout = io.MultiWriter(cr.stdout, tail)
+	verify.Stderr = verify.Stdout
+	if err := verify.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return fmt.Errorf("running conflict verification command: %w", err)
+		}
+		return &ConflictVerifyError{
+			ExitCode: exitErr.ExitCode(),
+			Output:   strings.TrimSpace(tail.String()),
+		}
+	}
+	return nil
+}
+
 // hasConflictMarkers checks if content contains git conflict markers.
 func hasConflictMarkers(content string) bool {
 	return strings.Contains(content, "<<<<<<<") ||
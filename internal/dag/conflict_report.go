@@ -0,0 +1,127 @@
+package dag
+
+// conflict_report.go supports falling back from agent conflict resolution.
+//
+// The conflicted files are snapshotted before the agent runs so a rejected
+// resolution can be reverted to the original conflict markers, and the last
+// rejected attempt is saved as a per-file report in the DAG log directory for
+// whoever resolves the merge by hand.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// snapshotConflictFiles reads the current content of each conflicted file.
+func snapshotConflictFiles(repoRoot string, conflicts []*ConflictContext) (map[string][]byte, error) {
+	snapshot := make(map[string][]byte, len(conflicts))
+	for _, conflict := range conflicts {
+		content, err := os.ReadFile(filepath.Join(repoRoot, conflict.FilePath))
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", conflict.FilePath, err)
+		}
+		snapshot[conflict.FilePath] = content
+	}
+	return snapshot, nil
+}
+
+// restoreConflictFiles writes the snapshotted conflict content back and marks
+// the files unmerged again, undoing an agent resolution.
+func restoreConflictFiles(repoRoot string, snapshot map[string][]byte) error {
+	paths := make([]string, 0, len(snapshot))
+	for path, content := range snapshot {
+		if err := os.WriteFile(filepath.Join(repoRoot, path), content, 0o644); err != nil {
+			return fmt.Errorf("restoring %s: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+
+	// Best effort: files the agent did not stage are still unmerged
+	args := append([]string{"update-index", "--unresolve", "--"}, paths...)
+	_, _ = runGitOutput(context.Background(), repoRoot, args...)
+	return nil
+}
+
+// resolutionDiffs returns the diff of each conflicted file against HEAD,
+// which is the target branch during a merge.
+func resolutionDiffs(repoRoot string, conflicts []*ConflictContext) map[string]string {
+	diffs := make(map[string]string, len(conflicts))
+	for _, conflict := range conflicts {
+		cmd := exec.Command("git", "diff", "HEAD", "--", conflict.FilePath)
+		cmd.Dir = repoRoot
+		out, err := cmd.Output()
+		if err != nil {
+			continue
+		}
+		diffs[conflict.FilePath] = string(out)
+	}
+	return diffs
+}
+
+// conflictFailureText returns the error and, for a failed verification
+// command, its output.
+func conflictFailureText(err error) string {
+	text := err.Error()
+	var verifyErr *ConflictVerifyError
+	if errors.As(err, &verifyErr) && verifyErr.Output != "" {
+		text += "\n" + verifyErr.Output
+	}
+	return text
+}
+
+// writeConflictReport saves a markdown report of a failed agent resolution
+// to dir and returns its path. For each file it contains the original
+// conflict and the diff of the agent's last attempt against the target branch.
+func writeConflictReport(
+	dir, specID string,
+	conflicts []*ConflictContext,
+	diffs map[string]string,
+	attempts int,
+	failure error,
+) (string, error) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Conflict Resolution Report: %s\n\n", specID)
+	if len(conflicts) > 0 {
+		fmt.Fprintf(&sb, "- **Source branch**: %s\n", conflicts[0].SourceBranch)
+		fmt.Fprintf(&sb, "- **Target branch**: %s\n", conflicts[0].TargetBranch)
+	}
+	fmt.Fprintf(&sb, "- **Agent attempts**: %d\n", attempts)
+	fmt.Fprintf(&sb, "- **Generated**: %s\n\n", time.Now().Format(time.RFC3339))
+
+	if failure != nil {
+		sb.WriteString("## Last Failure\n\n```\n")
+		sb.WriteString(strings.TrimRight(conflictFailureText(failure), "\n"))
+		sb.WriteString("\n```\n\n")
+	}
+
+	for _, conflict := range conflicts {
+		fmt.Fprintf(&sb, "## File: %s\n\n", conflict.FilePath)
+		sb.WriteString("### Conflict\n\n```\n")
+		sb.WriteString(conflict.ConflictDiff)
+		sb.WriteString("```\n\n")
+		sb.WriteString("### Last Agent Resolution\n\n")
+		if diff := diffs[conflict.FilePath]; diff != "" {
+			sb.WriteString("```diff\n")
+			sb.WriteString(diff)
+			sb.WriteString("```\n\n")
+		} else {
+			sb.WriteString("No changes against the target branch.\n\n")
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("creating report directory: %w", err)
+	}
+	name := fmt.Sprintf("conflict-%s-%s.md", specID, time.Now().Format("20060102-150405"))
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+		return "", fmt.Errorf("writing conflict report: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,208 @@
+package dag
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+)
+
+// resolvingAgent implements cliagent.Agent by writing a fixed resolution to
+// shared.txt and recording the prompts it receives.
+type resolvingAgent struct {
+	resolution string
+	prompts    []string
+}
+
+func (a *resolvingAgent) Name() string             { return "resolving" }
+func (a *resolvingAgent) Version() (string, error) { return "1.0.0", nil }
+func (a *resolvingAgent) Validate() error          { return nil }
+func (a *resolvingAgent) BuildCommand(_ string, _ cliagent.ExecOptions) (*exec.Cmd, error) {
+	return exec.Command("echo", "resolving"), nil
+}
+func (a *resolvingAgent) Capabilities() cliagent.Caps { return cliagent.Caps{} }
+func (a *resolvingAgent) Execute(_ context.Context, prompt string, opts cliagent.ExecOptions) (*cliagent.Result, error) {
+	a.prompts = append(a.prompts, prompt)
+	if err := os.WriteFile(filepath.Join(opts.WorkDir, "shared.txt"), []byte(a.resolution), 0o644); err != nil {
+		return nil, err
+	}
+	return &cliagent.Result{ExitCode: 0}, nil
+}
+
+func TestTryAgentResolution_Verification(t *testing.T) {
+	tests := map[string]struct {
+		resolution   string
+		verifyCmd    string
+		wantResolved bool
+		wantAttempts int
+		wantReport   []string
+	}{
+		"no verify command accepts marker-free resolution": {
+			resolution:   "merged\n",
+			wantResolved: true,
+			wantAttempts: 1,
+		},
+		"passing verify command merges": {
+			resolution:   "merged\n",
+			verifyCmd:    "grep -q merged shared.txt",
+			wantResolved: true,
+			wantAttempts: 1,
+		},
+		"failing verify command falls back to manual": {
+			resolution:   "broken\n",
+			verifyCmd:    `echo "build broken for {{.SpecID}}"; exit 2`,
+			wantAttempts: MaxAgentRetries,
+			wantReport:   []string{"build broken for spec-a", "## File: shared.txt", "<<<<<<<", "+broken"},
+		},
+		"leftover markers fall back to manual": {
+			resolution:   "<<<<<<< HEAD\nmain\n",
+			wantAttempts: MaxAgentRetries,
+			wantReport:   []string{"conflict markers", "## File: shared.txt"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			repo := setupConflictedMerge(t)
+			logBase := t.TempDir()
+			agent := &resolvingAgent{resolution: tt.resolution}
+			me := NewMergeExecutor(t.TempDir(), nil, repo,
+				WithMergeStdout(io.Discard),
+				WithMergeOnConflict(OnConflictAgent),
+				WithMergeAgent(agent),
+				WithMergeConflictVerifyCmd(tt.verifyCmd),
+				WithMergeLogBase(logBase),
+			)
+			run := &DAGRun{DAGId: "d", ProjectID: "p", Specs: map[string]*SpecState{"spec-a": {}}}
+
+			resolver := NewConflictResolver(repo, agent, io.Discard, WithConflictVerifyCmd(tt.verifyCmd))
+			contexts, err := resolver.BuildAllConflictContexts([]string{"shared.txt"}, "spec-a", nil, "spec-a", "main")
+			if err != nil {
+				t.Fatalf("BuildAllConflictContexts() error: %v", err)
+			}
+
+			resolved, err := me.tryAgentResolution(context.Background(), run, "spec-a", resolver, contexts)
+			if resolved != tt.wantResolved {
+				t.Fatalf("resolved = %v (err %v), want %v", resolved, err, tt.wantResolved)
+			}
+			if len(agent.prompts) != tt.wantAttempts {
+				t.Errorf("agent ran %d time(s), want %d", len(agent.prompts), tt.wantAttempts)
+			}
+
+			if tt.wantResolved {
+				if err != nil {
+					t.Fatalf("tryAgentResolution() error: %v", err)
+				}
+				if got := run.Specs["spec-a"].Merge.ResolutionMethod; got != "agent" {
+					t.Errorf("ResolutionMethod = %q, want agent", got)
+				}
+				if parents := strings.Fields(gitOutput(t, repo, "log", "-1", "--format=%P")); len(parents) != 2 {
+					t.Errorf("HEAD has %d parent(s), want merge commit", len(parents))
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("expected error after failed resolution")
+			}
+			if !strings.Contains(agent.prompts[1], "Previous Attempt Failed") {
+				t.Errorf("retry prompt lacks failure feedback:\n%s", agent.prompts[1])
+			}
+			if conflicts := DetectConflictedFiles(repo); len(conflicts) != 1 || conflicts[0] != "shared.txt" {
+				t.Errorf("conflicted files after fallback = %v, want [shared.txt]", conflicts)
+			}
+			content, _ := os.ReadFile(filepath.Join(repo, "shared.txt"))
+			if !hasConflictMarkers(string(content)) {
+				t.Errorf("shared.txt not restored to the original conflict:\n%s", content)
+			}
+
+			reports, _ := filepath.Glob(filepath.Join(logBase, "p", "d", "conflict-spec-a-*.md"))
+			if len(reports) != 1 {
+				t.Fatalf("found %d conflict report(s), want 1", len(reports))
+			}
+			report, _ := os.ReadFile(reports[0])
+			for _, want := range tt.wantReport {
+				if !strings.Contains(string(report), want) {
+					t.Errorf("report missing %q:\n%s", want, report)
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyResolution(t *testing.T) {
+	tests := map[string]struct {
+		content    string
+		verifyCmd  string
+		wantErr    bool
+		wantOutput string
+	}{
+		"resolved without command": {content: "merged\n"},
+		"markers remain": {
+			content: "<<<<<<< HEAD\nmain\n=======\nspec\n>>>>>>> spec\n",
+			wantErr: true,
+		},
+		"command passes": {
+			content:   "merged\n",
+			verifyCmd: "test {{.BaseBranch}} = main",
+		},
+		"command fails": {
+			content:    "merged\n",
+			verifyCmd:  `echo "missing symbol in {{.Branch}}" >&2; exit 1`,
+			wantErr:    true,
+			wantOutput: "missing symbol in feature",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "file.go"), []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			resolver := NewConflictResolver(dir, nil, io.Discard, WithConflictVerifyCmd(tt.verifyCmd))
+			conflicts := []*ConflictContext{{FilePath: "file.go", SpecID: "spec-a", SourceBranch: "feature", TargetBranch: "main"}}
+
+			err := resolver.VerifyResolution(context.Background(), conflicts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifyResolution() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantOutput == "" {
+				return
+			}
+			var verifyErr *ConflictVerifyError
+			if !errors.As(err, &verifyErr) {
+				t.Fatalf("error = %v, want *ConflictVerifyError", err)
+			}
+			if verifyErr.Output != tt.wantOutput {
+				t.Errorf("Output = %q, want %q", verifyErr.Output, tt.wantOutput)
+			}
+		})
+	}
+}
+
+// setupConflictedMerge creates a repository with a no-ff merge of spec-a
+// into main in progress, conflicting in shared.txt.
+func setupConflictedMerge(t *testing.T) string {
+	t.Helper()
+	repo := setupDivergedRepo(t)
+	mustGit(t, repo, "checkout", "spec-a")
+	createFile(t, repo, "shared.txt", "spec-a\n")
+	mustGit(t, repo, "add", ".")
+	mustGit(t, repo, "commit", "-m", "a: shared")
+	mustGit(t, repo, "checkout", "main")
+	createFile(t, repo, "shared.txt", "main\n")
+	mustGit(t, repo, "add", ".")
+	mustGit(t, repo, "commit", "-m", "main: shared")
+
+	if _, err := mergeWithStrategy(context.Background(), repo, MergeStrategyNoFF, "main", "spec-a", "Merge spec-a"); err == nil {
+		t.Fatal("expected merge conflict")
+	}
+	return repo
+}
@@ -540,3 +540,16 @@ func TestDetectConflicts(t *testing.T) {
 		t.Errorf("expected nil for non-git directory, got: %v", result)
 	}
 }
+
+func TestBuildFeedbackSection(t *testing.T) {
+	if got := buildFeedbackSection(""); got != "" {
+		t.Errorf("buildFeedbackSection(\"\") = %q, want empty", got)
+	}
+
+	got := buildFeedbackSection("go build failed\nundefined: Auth\n")
+	for _, want := range []string{"## Previous Attempt Failed", "undefined: Auth\n```"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("feedback section missing %q:\n%s", want, got)
+		}
+	}
+}
@@ -162,3 +162,23 @@ func (w *DuplicateDAGNameWarning) Warning() string {
 	return fmt.Sprintf("duplicate DAG name %q: first in %s, also in %s (IDs may differ)",
 		w.Name, w.FirstFile, w.SecondFile)
 }
+
+// SpecCollisionError represents a planned feature whose ID collides with an
+// existing spec folder, either by name or by number prefix.
+type SpecCollisionError struct {
+	// FeatureID is the ID of the planned feature.
+	FeatureID string
+	// ExistingSpec is the name of the existing spec folder it collides with.
+	ExistingSpec string
+	// Line is the source line number where the feature is defined.
+	Line int
+}
+
+// Error implements the error interface.
+func (e *SpecCollisionError) Error() string {
+	if e.FeatureID == e.ExistingSpec {
+		return fmt.Sprintf("line %d: feature %q already exists as a spec folder", e.Line, e.FeatureID)
+	}
+	return fmt.Sprintf("line %d: feature %q reuses the number of existing spec %q",
+		e.Line, e.FeatureID, e.ExistingSpec)
+}
@@ -0,0 +1,47 @@
+package dag
+
+import (
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/events"
+)
+
+// publishSpecStarted publishes a spec.started event for a spec picked up by a run.
+func publishSpecStarted(specID, worktreePath string) {
+	events.Publish(events.Event{
+		Type: events.SpecStarted,
+		Spec: specID,
+		Path: worktreePath,
+	})
+}
+
+// publishSpecCompleted publishes a spec.completed event. stage names the
+// step that failed (empty on success).
+func publishSpecCompleted(specID, stage string, startedAt *time.Time, err error) {
+	evt := events.Event{
+		Type:   events.SpecCompleted,
+		Spec:   specID,
+		Stage:  stage,
+		Status: events.StatusCompleted,
+	}
+	if startedAt != nil {
+		evt.DurationMS = time.Since(*startedAt).Milliseconds()
+	}
+	if err != nil {
+		evt.Status = events.StatusFailed
+		evt.Error = err.Error()
+	}
+	events.Publish(evt)
+}
+
+// publishMergeConflict publishes a merge.conflict event for a merge into branch
+// that stopped on conflicting files. specID names what was being merged: the
+// spec, or the staging branch for merges between staging branches.
+func publishMergeConflict(specID, branch string, conflicts []string) {
+	events.Publish(events.Event{
+		Type:   events.MergeConflict,
+		Spec:   specID,
+		Branch: branch,
+		Files:  conflicts,
+	})
+}
@@ -12,8 +12,10 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/ariel-frischer/autospec/internal/budget"
 	"github.com/ariel-frischer/autospec/internal/git"
 	"github.com/ariel-frischer/autospec/internal/worktree"
 )
@@ -84,6 +86,32 @@ type Executor struct {
 	onlySpecs []string
 	// disableLayerStaging disables layer staging, making all worktrees branch from main.
 	disableLayerStaging bool
+	// usageStateDir is the autospec state directory holding retry.json usage records.
+	// When set, per-spec token and cost usage is captured after each run.
+	usageStateDir string
+	// budget holds spending limits enforced per spec and per run.
+	budget budget.Config
+	// budgetPollInterval controls how often spending is checked while specs run.
+	budgetPollInterval time.Duration
+	// budgetTracker computes spending during a run (nil when no limit applies).
+	budgetTracker *budgetTracker
+	// budgetCancel cancels the run context when a budget limit is reached.
+	budgetCancel context.CancelFunc
+	// budgetMu protects budgetExceeded.
+	budgetMu sync.Mutex
+	// budgetExceeded is set when a budget limit stopped the run.
+	budgetExceeded *budget.ExceededError
+	// syncStaging refreshes the staging branch chain before cutting a worktree.
+	// Set by the ready-queue scheduler, where specs from later layers can start
+	// before every spec in earlier layers has merged.
+	syncStaging bool
+	// stateMu serializes inline state saves from concurrently running specs.
+	stateMu sync.Mutex
+	// stagingMu serializes staging branch checkouts and merges in repoRoot.
+	stagingMu sync.Mutex
+	// retryBackoff is the delay before the first automatic retry of a
+	// transient spec failure. Later retries double the delay.
+	retryBackoff time.Duration
 }
 
 // ExecutorOption configures an Executor.
@@ -141,6 +169,22 @@ func WithDisableLayerStaging(disable bool) ExecutorOption {
 	}
 }
 
+// WithUsageStateDir sets the autospec state directory used to read token and
+// cost usage recorded by the autospec subprocess for each spec.
+func WithUsageStateDir(dir string) ExecutorOption {
+	return func(e *Executor) {
+		e.usageStateDir = dir
+	}
+}
+
+// WithRetryBackoff sets the delay before the first automatic retry of a
+// transient spec failure (default 30s). Later retries double the delay.
+func WithRetryBackoff(d time.Duration) ExecutorOption {
+	return func(e *Executor) {
+		e.retryBackoff = d
+	}
+}
+
 // NewExecutor creates a new Executor with dependency injection.
 func NewExecutor(
 	dag *DAGConfig,
@@ -162,6 +206,7 @@ func NewExecutor(
 		worktreeConfig:  worktreeConfig,
 		cmdRunner:       &defaultCommandRunner{},
 		repoRoot:        repoRoot,
+		retryBackoff:    defaultRetryBackoff,
 	}
 
 	for _, opt := range opts {
@@ -176,6 +221,20 @@ func NewExecutor(
 // If existingState is set, execution resumes from that state (idempotent behavior).
 // State is written directly to the dag.yaml file (inline state).
 func (e *Executor) Execute(ctx context.Context) (string, error) {
+	return e.run(ctx, 0, func(ctx context.Context) error {
+		// Cancel the run once a spec or run budget is reached
+		ctx, stopBudget := e.startBudgetMonitor(ctx)
+		defer stopBudget()
+
+		// Execute layers in order
+		return e.executeLayers(ctx)
+	})
+}
+
+// run initializes or resumes the run state, takes the DAG lock, and saves
+// the initial inline state before calling execute. maxParallel is recorded
+// in new run state (0 means sequential execution).
+func (e *Executor) run(ctx context.Context, maxParallel int, execute func(context.Context) error) (string, error) {
 	// Extract all spec IDs for locking
 	specIDs := e.collectSpecIDs()
 
@@ -196,8 +255,7 @@ func (e *Executor) Execute(ctx context.Context) (string, error) {
 			return "", err
 		}
 	} else {
-		// Create new run state (0 means sequential execution)
-		e.state = NewDAGRun(e.dagFile, e.dag, 0)
+		e.state = NewDAGRun(e.dagFile, e.dag, maxParallel)
 	}
 
 	if e.dryRun {
@@ -216,8 +274,7 @@ func (e *Executor) Execute(ctx context.Context) (string, error) {
 		return "", fmt.Errorf("saving initial state: %w", err)
 	}
 
-	// Execute layers in order
-	if err := e.executeLayers(ctx); err != nil {
+	if err := execute(ctx); err != nil {
 		return e.state.RunID, err
 	}
 
@@ -317,7 +374,7 @@ func (e *Executor) executeLayers(ctx context.Context) error {
 		}
 
 		// Batch merge unmerged specs when automerge is disabled
-		if err := e.completeLayer(layer.ID); err != nil {
+		if err := e.completeLayer(ctx, layer.ID); err != nil {
 			return fmt.Errorf("completing layer %s: %w", layer.ID, err)
 		}
 
@@ -341,11 +398,14 @@ func (e *Executor) executeLayers(ctx context.Context) error {
 // executeLayerSpecs processes specs within a single layer.
 func (e *Executor) executeLayerSpecs(ctx context.Context, layer Layer) error {
 	for _, feature := range layer.Features {
+		// Stop before starting another spec once a budget limit is reached
+		e.enforceBudget()
+
 		// Check if context is cancelled
 		select {
 		case <-ctx.Done():
 			e.handleInterruption()
-			return ctx.Err()
+			return e.interruptionError(ctx)
 		default:
 		}
 
@@ -355,6 +415,10 @@ func (e *Executor) executeLayerSpecs(ctx context.Context, layer Layer) error {
 		}
 
 		if err := e.executeSpec(ctx, feature, layer.ID); err != nil {
+			if e.BudgetExceeded() != nil {
+				e.handleInterruption()
+				return err
+			}
 			return e.handleSpecFailure(feature.ID, err)
 		}
 	}
@@ -431,40 +495,170 @@ func (e *Executor) executeSpec(ctx context.Context, feature Feature, _ string) e
 	}
 
 	// Update state to running
-	specState.Status = SpecStatusRunning
 	now := time.Now()
-	specState.StartedAt = &now
+	e.updateState(func() {
+		specState.Status = SpecStatusRunning
+		specState.StartedAt = &now
+	})
 	if err := e.saveInlineState(); err != nil {
 		return fmt.Errorf("saving state: %w", err)
 	}
 
 	fmt.Fprintf(e.stdout, "\n--- Spec: %s ---\n", specID)
 
+	// spec.started carries the worktree path, so it is published once the
+	// first attempt has a worktree (or with no path if none could be created)
+	var started sync.Once
+	publishStarted := func(worktreePath string) {
+		started.Do(func() { publishSpecStarted(specID, worktreePath) })
+	}
+
+	// Run attempts, retrying transient failures up to max_spec_retries
+	for retries := 0; ; retries++ {
+		err := e.runSpecAttempt(ctx, feature, publishStarted)
+		var failure *attemptError
+		if !errors.As(err, &failure) {
+			if err != nil {
+				return err
+			}
+			return e.markSpecCompleted(ctx, specID)
+		}
+
+		if !e.shouldRetrySpec(ctx, failure, retries) {
+			publishStarted("")
+			return e.markSpecFailed(specID, failure.stage, failure.err)
+		}
+		if err := e.waitForRetry(ctx, specID, failure, retries+1); err != nil {
+			publishStarted("")
+			return e.markSpecFailed(specID, failure.stage, failure.err)
+		}
+	}
+}
+
+// runSpecAttempt runs one attempt of a spec and records it in the spec's
+// attempt history. onWorktree is called with the worktree path once it
+// exists. Returns an *attemptError when a stage fails.
+func (e *Executor) runSpecAttempt(ctx context.Context, feature Feature, onWorktree func(string)) error {
+	specState := e.state.Specs[feature.ID]
+	now := time.Now()
+	var attempt *SpecAttempt
+	e.updateState(func() {
+		specState.Attempts = append(specState.Attempts, SpecAttempt{
+			Number:    len(specState.Attempts) + 1,
+			Stage:     e.resumeStage(specState),
+			StartedAt: &now,
+		})
+		attempt = &specState.Attempts[len(specState.Attempts)-1]
+	})
+	e.saveInlineState() //nolint:errcheck // non-critical attempt tracking
+
+	prevExitCode := specState.ExitCode
+	err := e.runSpecStages(ctx, feature, onWorktree)
+
+	completed := time.Now()
+	e.updateState(func() {
+		attempt.CompletedAt = &completed
+		if specState.ExitCode != prevExitCode {
+			attempt.ExitCode = specState.ExitCode
+		}
+		var failure *attemptError
+		if errors.As(err, &failure) {
+			attempt.FailureClass = failure.class
+			attempt.FailureReason = failure.Error()
+			specState.FailureClass = failure.class
+		} else if err != nil {
+			attempt.FailureReason = err.Error()
+		}
+	})
+	return err
+}
+
+// runSpecStages prepares the worktree, runs autospec, and verifies the commit.
+// A spec whose previous attempt reached commit verification resumes there
+// instead of re-running autospec.
+func (e *Executor) runSpecStages(ctx context.Context, feature Feature, onWorktree func(string)) error {
+	specID := feature.ID
+	specState := e.state.Specs[specID]
+
 	// Create or get worktree
 	worktreePath, err := e.ensureWorktree(specID)
 	if err != nil {
-		return e.markSpecFailed(specID, "worktree", err)
+		return newAttemptError("worktree", 0, "", err)
 	}
-	specState.WorktreePath = worktreePath
+	e.updateState(func() { specState.WorktreePath = worktreePath })
+	onWorktree(worktreePath)
 
-	// Run autospec in worktree
-	exitCode, err := e.runAutospecInWorktree(ctx, specID, worktreePath, feature.Description)
+	if e.resumeStage(specState) == "commit" {
+		fmt.Fprintf(e.stdout, "[%s] Resuming at commit verification\n", specID)
+	} else if err := e.runImplementStage(ctx, feature, worktreePath); err != nil {
+		return err
+	}
+
+	// Verify and handle commit status
+	e.updateState(func() { specState.CurrentStage = "commit" })
+	if err := e.verifyCommit(ctx, specID, specState); err != nil {
+		return newAttemptError("commit", 0, "", err)
+	}
+	return nil
+}
+
+// runImplementStage runs autospec in the worktree and checks its exit code.
+func (e *Executor) runImplementStage(ctx context.Context, feature Feature, worktreePath string) error {
+	specID := feature.ID
+	specState := e.state.Specs[specID]
+
+	usageBaseline := e.specUsageSnapshot(specID)
+	tail := newTailBuffer(failureTailSize)
+	exitCode, err := e.runAutospecInWorktree(ctx, specID, worktreePath, feature.Description, tail)
+	e.recordSpecUsage(specID, usageBaseline)
+	if e.BudgetExceeded() != nil {
+		// Leave the spec running so resume re-executes it in the same worktree
+		return e.budgetStopError()
+	}
 	if err != nil {
-		return e.markSpecFailed(specID, "execution", err)
+		return newAttemptError("execution", -1, tail.String(), err)
 	}
 
-	specState.ExitCode = &exitCode
+	e.updateState(func() { specState.ExitCode = &exitCode })
 	if exitCode != 0 {
-		return e.markSpecFailed(specID, "implement", fmt.Errorf("exit code %d", exitCode))
+		return newAttemptError("implement", exitCode, tail.String(), fmt.Errorf("exit code %d", exitCode))
 	}
+	return nil
+}
 
-	// Verify and handle commit status
-	if err := e.verifyCommit(ctx, specID, specState); err != nil {
-		return e.markSpecFailed(specID, "commit", err)
+// resumeStage returns the stage a spec attempt starts from.
+func (e *Executor) resumeStage(specState *SpecState) string {
+	if specState.CurrentStage == "commit" {
+		return "commit"
+	}
+	return "implement"
+}
+
+// shouldRetrySpec reports whether a failed attempt is retried automatically.
+// Only transient failures are retried, up to dag.max_spec_retries times.
+func (e *Executor) shouldRetrySpec(ctx context.Context, failure *attemptError, retries int) bool {
+	if ctx.Err() != nil || !failure.class.IsTransient() {
+		return false
 	}
+	return retries < e.config.MaxSpecRetries
+}
 
-	// Mark completed
-	return e.markSpecCompleted(specID)
+// waitForRetry waits out the exponential backoff before retry number n.
+// Returns the context error if the run is cancelled while waiting.
+func (e *Executor) waitForRetry(ctx context.Context, specID string, failure *attemptError, n int) error {
+	delay := retryBackoffDelay(e.retryBackoff, n)
+	fmt.Fprintf(e.stdout, "[%s] Attempt failed (%s): %v\n", specID, failure.class, failure.err)
+	fmt.Fprintf(e.stdout, "[%s] Retrying from %s in %s (retry %d/%d)\n",
+		specID, e.resumeStage(e.state.Specs[specID]), delay, n, e.config.MaxSpecRetries)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 // ensureWorktree creates or retrieves the worktree for a spec.
@@ -536,6 +730,9 @@ func (e *Executor) createWorktree(specID string) (string, error) {
 
 	// Determine start point based on layer (for layer staging)
 	startPoint := e.getStartPointForSpec(specID)
+	if err := e.syncStartPoint(specID); err != nil {
+		return "", fmt.Errorf("syncing staging branches: %w", err)
+	}
 
 	// Output which base branch is being used
 	if startPoint != "" {
@@ -552,9 +749,13 @@ func (e *Executor) createWorktree(specID string) (string, error) {
 		return "", fmt.Errorf("creating worktree: %w", err)
 	}
 
-	// Store branch name in state for resume idempotency
+	// Store branch name in state for resume idempotency. A fresh worktree
+	// has no progress, so stage tracking starts over.
 	if specState := e.state.Specs[specID]; specState != nil {
-		specState.Branch = branch
+		e.updateState(func() {
+			specState.Branch = branch
+			specState.CurrentStage = ""
+		})
 	}
 
 	return wt.Path, nil
@@ -631,9 +832,13 @@ func (e *Executor) findCollisionSafeBranch(specID string) (string, error) {
 }
 
 // runAutospecInWorktree executes autospec run -spti in the worktree.
+// When the spec is resumed in an existing worktree, stages whose artifacts
+// already validate are skipped and implement continues with --resume.
+// Output is also written to tail so failures can be classified.
 func (e *Executor) runAutospecInWorktree(
 	ctx context.Context,
 	specID, worktreePath, description string,
+	tail io.Writer,
 ) (int, error) {
 	// Create output writer with prefixed terminal, log file, and truncation support
 	output, cleanup, err := CreateSpecOutputWithConfig(e.stateDir, e.state.RunID, specID, e.stdout, e.config)
@@ -642,50 +847,74 @@ func (e *Executor) runAutospecInWorktree(
 	}
 	defer cleanup()
 
-	// Update stage tracking
+	// A stage left over from an earlier attempt means the worktree was reused
 	specState := e.state.Specs[specID]
-	specState.CurrentStage = "implement"
+	args, stage := e.autospecArgs(specID, worktreePath, description, specState.CurrentStage != "", output)
+
+	// Update stage tracking
+	e.updateState(func() { specState.CurrentStage = stage })
 	e.saveInlineState() //nolint:errcheck // non-critical stage tracking
 
-	// Build command args
+	fmt.Fprintf(output, "Running: autospec %v\n", args)
+
+	w := io.MultiWriter(output, tail)
+	return e.cmdRunner.Run(ctx, worktreePath, w, w, "autospec", args...)
+}
+
+// autospecArgs returns the autospec arguments for a spec and the stage it
+// starts from. Resumed specs are checked for valid artifacts in the worktree
+// first; otherwise the full pipeline runs.
+func (e *Executor) autospecArgs(specID, worktreePath, description string, resuming bool, output io.Writer) ([]string, string) {
+	if resuming {
+		progress := InspectArtifacts(filepath.Join(worktreePath, "specs", specID))
+		fmt.Fprintf(output, "Resuming: %s\n", progress.Summary())
+		if args := progress.ResumeArgs(specID); args != nil {
+			return args, progress.NextStage()
+		}
+	}
+
 	args := []string{"run", "-spti"}
 	if description != "" && !e.specExists(specID) {
 		args = append(args, "-a", description)
 	}
-
-	fmt.Fprintf(output, "Running: autospec %v\n", args)
-
-	return e.cmdRunner.Run(ctx, worktreePath, output, output, "autospec", args...)
+	return args, "implement"
 }
 
 // markSpecFailed marks a spec as failed with error details.
 func (e *Executor) markSpecFailed(specID, stage string, err error) error {
 	specState := e.state.Specs[specID]
-	specState.Status = SpecStatusFailed
 	now := time.Now()
-	specState.CompletedAt = &now
-	specState.FailureReason = fmt.Sprintf("[%s] %v", stage, err)
+	e.updateState(func() {
+		specState.Status = SpecStatusFailed
+		specState.CompletedAt = &now
+		specState.FailureReason = fmt.Sprintf("[%s] %v", stage, err)
+	})
 
 	e.saveInlineState() //nolint:errcheck // best-effort save during failure
+	publishSpecCompleted(specID, stage, specState.StartedAt, err)
 	return fmt.Errorf("%s: %w", stage, err)
 }
 
 // markSpecCompleted marks a spec as successfully completed.
-func (e *Executor) markSpecCompleted(specID string) error {
+func (e *Executor) markSpecCompleted(ctx context.Context, specID string) error {
 	specState := e.state.Specs[specID]
-	specState.Status = SpecStatusCompleted
 	now := time.Now()
-	specState.CompletedAt = &now
-	specState.CurrentStage = ""
+	e.updateState(func() {
+		specState.Status = SpecStatusCompleted
+		specState.CompletedAt = &now
+		specState.CurrentStage = ""
+		specState.FailureClass = ""
+	})
 
 	if err := e.saveInlineState(); err != nil {
 		return fmt.Errorf("saving state: %w", err)
 	}
 
 	fmt.Fprintf(e.stdout, "[%s] Completed successfully\n", specID)
+	publishSpecCompleted(specID, "", specState.StartedAt, nil)
 
 	// Run post-completion automerge flow
-	if err := e.postSpecCompletion(specID); err != nil {
+	if err := e.postSpecCompletion(ctx, specID); err != nil {
 		return fmt.Errorf("post-completion automerge: %w", err)
 	}
 
@@ -695,7 +924,7 @@ func (e *Executor) markSpecCompleted(specID string) error {
 // postSpecCompletion handles automerge flow after a spec successfully completes.
 // When automerge is enabled and the spec has a verified commit, the spec branch
 // is merged into the layer's staging branch immediately.
-func (e *Executor) postSpecCompletion(specID string) error {
+func (e *Executor) postSpecCompletion(ctx context.Context, specID string) error {
 	// Skip if layer staging is disabled (legacy mode)
 	if e.disableLayerStaging {
 		return nil
@@ -723,37 +952,65 @@ func (e *Executor) postSpecCompletion(specID string) error {
 		return nil
 	}
 
-	return e.mergeSpecToStaging(specID, specState)
+	return e.mergeSpecToStaging(ctx, specID, specState)
 }
 
 // mergeSpecToStaging performs the actual merge of a spec into its layer's staging branch.
-func (e *Executor) mergeSpecToStaging(specID string, specState *SpecState) error {
+// When dag.pre_merge_cmd is set, it must pass in the spec worktree before the
+// merge and on the staging branch after it; otherwise the merge is reverted.
+func (e *Executor) mergeSpecToStaging(ctx context.Context, specID string, specState *SpecState) error {
+	// Get the spec's branch name
+	specBranch := specState.Branch
+	if specBranch == "" {
+		specBranch = e.branchName(specID)
+	}
+
+	// Check the spec in its own worktree first, outside the staging lock
+	stagingName := stageBranchName(e.state.DAGId, specState.LayerID)
+	if err := e.runPreMergeCmd(ctx, specID, "worktree", specState.WorktreePath, specBranch, stagingName); err != nil {
+		return e.failPreMergeCheck(specID, specState, err)
+	}
+
+	e.stagingMu.Lock()
+	defer e.stagingMu.Unlock()
+
 	// Ensure staging branch exists for this layer
 	stagingBranch, err := e.ensureStagingBranch(specState.LayerID)
 	if err != nil {
 		return fmt.Errorf("ensuring staging branch: %w", err)
 	}
 
-	// Get the spec's branch name
-	specBranch := specState.Branch
-	if specBranch == "" {
-		specBranch = e.branchName(specID)
+	preMergeHead, err := branchHead(e.repoRoot, stagingBranch)
+	if err != nil {
+		return fmt.Errorf("recording staging head: %w", err)
 	}
 
 	fmt.Fprintf(e.stdout, "[%s] Merging to staging branch %s\n", specID, stagingBranch)
 
 	// Perform the merge and handle conflicts with detailed output
-	if err := mergeIntoStaging(e.repoRoot, stagingBranch, specBranch, specID); err != nil {
+	if err := mergeIntoStaging(e.repoRoot, stagingBranch, specBranch, specID, e.mergeStrategy(), featureDescription(e.dag, specID)); err != nil {
 		var conflictErr *MergeConflictError
 		if errors.As(err, &conflictErr) {
+			e.updateState(func() { specState.FailureClass = FailureMergeConflict })
 			return e.handleStagingConflict(specID, conflictErr)
 		}
 		return fmt.Errorf("merging to staging: %w", err)
 	}
 
+	// Re-run the check on the merged result, which may differ from either side
+	if err := e.runPreMergeCmd(ctx, specID, "staging", e.repoRoot, stagingBranch, stagingBranch); err != nil {
+		if resetErr := resetStagingBranch(e.repoRoot, preMergeHead); resetErr != nil {
+			return fmt.Errorf("reverting staging merge after %v: %w", err, resetErr)
+		}
+		fmt.Fprintf(e.stdout, "[%s] Reverted staging branch %s to %s\n", specID, stagingBranch, preMergeHead[:7])
+		return e.failPreMergeCheck(specID, specState, err)
+	}
+
 	// Update state
-	specState.MergedToStaging = true
-	e.updateStagingBranchState(specState.LayerID, stagingBranch, specID)
+	e.updateState(func() {
+		specState.MergedToStaging = true
+		e.updateStagingBranchState(specState.LayerID, stagingBranch, specID)
+	})
 
 	// Persist state to dag.yaml
 	if err := e.saveInlineState(); err != nil {
@@ -830,7 +1087,11 @@ func (e *Executor) handleStagingConflict(specID string, conflictErr *MergeConfli
 		fmt.Fprintf(e.stdout, "  - %s\n", file)
 	}
 
-	e.printConflictResolutionSteps(conflictErr.StageBranch)
+	if e.mergeStrategy() == MergeStrategyRebase {
+		e.printRebaseResolutionSteps(conflictErr.StageBranch)
+	} else {
+		e.printConflictResolutionSteps(conflictErr.StageBranch)
+	}
 
 	return fmt.Errorf("staging merge conflict for spec %s: %w", specID, conflictErr)
 }
@@ -851,6 +1112,29 @@ func (e *Executor) printConflictResolutionSteps(stagingBranch string) {
 		stagingBranch)
 }
 
+// printRebaseResolutionSteps outputs instructions for resolving a conflicted
+// rebase. The rebase was aborted, so the spec branch must be rebased onto the
+// staging branch in its worktree before resuming.
+func (e *Executor) printRebaseResolutionSteps(stagingBranch string) {
+	fmt.Fprintf(e.stdout, "\n"+
+		"Resolution Steps (rebase strategy):\n"+
+		"-----------------------------------\n"+
+		"1. In the spec worktree, rebase onto staging: git rebase %s\n"+
+		"2. Resolve the conflicts and continue:        git rebase --continue\n"+
+		"3. Resume the DAG run:                        autospec dag run <workflow>\n\n"+
+		"The rebase was aborted; the staging branch is unchanged.\n"+
+		"============================================================\n",
+		stagingBranch)
+}
+
+// mergeStrategy returns the configured merge strategy, defaulting to no-ff.
+func (e *Executor) mergeStrategy() MergeStrategy {
+	if e.config != nil && IsValidMergeStrategy(e.config.MergeStrategy) {
+		return MergeStrategy(e.config.MergeStrategy)
+	}
+	return MergeStrategyNoFF
+}
+
 // validateResumeState checks if the DAG can resume after an interruption.
 // Validates that any interrupted staging merge has been resolved.
 func (e *Executor) validateResumeState() error {
@@ -897,13 +1181,13 @@ func (e *Executor) handleInterruptedMerge(resolutionErr error) error {
 func (e *Executor) verifyCommit(ctx context.Context, specID string, specState *SpecState) error {
 	// Skip verification if worktree path is not valid
 	if specState.WorktreePath == "" {
-		specState.CommitStatus = CommitStatusPending
+		e.updateState(func() { specState.CommitStatus = CommitStatusPending })
 		return nil
 	}
 
 	// Check if worktree path exists before attempting verification
 	if _, err := os.Stat(specState.WorktreePath); os.IsNotExist(err) {
-		specState.CommitStatus = CommitStatusPending
+		e.updateState(func() { specState.CommitStatus = CommitStatusPending })
 		fmt.Fprintf(e.stdout, "[%s] Worktree path does not exist, skipping commit verification\n", specID)
 		return nil
 	}
@@ -930,9 +1214,11 @@ func (e *Executor) runCommitVerification(ctx context.Context, specID string, spe
 	)
 
 	// Update spec state with commit information
-	specState.CommitStatus = result.Status
-	specState.CommitSHA = result.CommitSHA
-	specState.CommitAttempts = result.Attempts
+	e.updateState(func() {
+		specState.CommitStatus = result.Status
+		specState.CommitSHA = result.CommitSHA
+		specState.CommitAttempts = result.Attempts
+	})
 
 	// Save state after commit verification
 	if err := e.saveInlineState(); err != nil {
@@ -1017,7 +1303,7 @@ func (e *Executor) getUnmergedSpecsInLayer(layerID string) []string {
 // completeLayer performs batch merge of all unmerged specs when automerge is disabled.
 // Skips if automerge is enabled (specs already merged individually).
 // Skips if layer staging is disabled (legacy mode).
-func (e *Executor) completeLayer(layerID string) error {
+func (e *Executor) completeLayer(ctx context.Context, layerID string) error {
 	// Skip if layer staging is disabled (legacy mode)
 	if e.disableLayerStaging {
 		return nil
@@ -1041,7 +1327,7 @@ func (e *Executor) completeLayer(layerID string) error {
 		if specState == nil {
 			continue
 		}
-		if err := e.mergeSpecToStaging(specID, specState); err != nil {
+		if err := e.mergeSpecToStaging(ctx, specID, specState); err != nil {
 			return fmt.Errorf("batch merge spec %s: %w", specID, err)
 		}
 	}
@@ -1092,6 +1378,9 @@ func (e *Executor) saveInlineState() error {
 		return fmt.Errorf("dag or state is nil")
 	}
 
+	e.stateMu.Lock()
+	defer e.stateMu.Unlock()
+
 	SyncStateToDAGConfig(e.state, e.dag)
 
 	if err := SaveDAGWithState(e.dagFile, e.dag); err != nil {
@@ -1101,6 +1390,14 @@ func (e *Executor) saveInlineState() error {
 	return nil
 }
 
+// updateState applies fn to the run state while holding the state lock, so
+// concurrently running specs do not race with inline state saves.
+func (e *Executor) updateState(fn func()) {
+	e.stateMu.Lock()
+	defer e.stateMu.Unlock()
+	fn()
+}
+
 // SyncStateToDAGConfig synchronizes runtime state from DAGRun to DAGConfig inline fields.
 // This updates the Run, Specs, and Staging sections in the DAGConfig.
 // Exported for use by CLI commands that need to save DAGRun state to dag.yaml.
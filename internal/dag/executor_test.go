@@ -50,7 +50,9 @@ func (m *mockCommandRunner) Run(
 }
 
 // mockWorktreeManager implements worktree.Manager for testing.
+// It is safe for concurrent use by parallel executor tests.
 type mockWorktreeManager struct {
+	mu        sync.Mutex
 	worktrees map[string]*worktree.Worktree
 	creates   []createCall
 	removes   []string
@@ -72,6 +74,8 @@ func (m *mockWorktreeManager) Create(name, branch, customPath string) (*worktree
 }
 
 func (m *mockWorktreeManager) CreateWithOptions(name, branch, customPath string, _ worktree.CreateOptions) (*worktree.Worktree, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.creates = append(m.creates, createCall{name: name, branch: branch})
 	wt := &worktree.Worktree{
 		Name:   name,
@@ -83,6 +87,8 @@ func (m *mockWorktreeManager) CreateWithOptions(name, branch, customPath string,
 }
 
 func (m *mockWorktreeManager) List() ([]worktree.Worktree, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	var result []worktree.Worktree
 	for _, wt := range m.worktrees {
 		result = append(result, *wt)
@@ -91,6 +97,8 @@ func (m *mockWorktreeManager) List() ([]worktree.Worktree, error) {
 }
 
 func (m *mockWorktreeManager) Get(name string) (*worktree.Worktree, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if wt, ok := m.worktrees[name]; ok {
 		return wt, nil
 	}
@@ -98,6 +106,8 @@ func (m *mockWorktreeManager) Get(name string) (*worktree.Worktree, error) {
 }
 
 func (m *mockWorktreeManager) Remove(name string, _ bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.removes = append(m.removes, name)
 	delete(m.worktrees, name)
 	return nil
@@ -2582,7 +2592,7 @@ func TestPostSpecCompletionAutomergeDisabled(t *testing.T) {
 		stdout: io.Discard,
 	}
 
-	err := exec.postSpecCompletion("spec-1")
+	err := exec.postSpecCompletion(context.Background(), "spec-1")
 	if err != nil {
 		t.Errorf("expected no error when automerge disabled, got: %v", err)
 	}
@@ -2612,7 +2622,7 @@ func TestPostSpecCompletionAlreadyMerged(t *testing.T) {
 		stdout: &output,
 	}
 
-	err := exec.postSpecCompletion("spec-1")
+	err := exec.postSpecCompletion(context.Background(), "spec-1")
 	if err != nil {
 		t.Errorf("expected no error when already merged, got: %v", err)
 	}
@@ -2641,7 +2651,7 @@ func TestPostSpecCompletionNoCommit(t *testing.T) {
 		stdout: &output,
 	}
 
-	err := exec.postSpecCompletion("spec-1")
+	err := exec.postSpecCompletion(context.Background(), "spec-1")
 	if err != nil {
 		t.Errorf("expected no error when no commit, got: %v", err)
 	}
@@ -2668,7 +2678,7 @@ func TestPostSpecCompletionSpecNotFound(t *testing.T) {
 		stdout: io.Discard,
 	}
 
-	err := exec.postSpecCompletion("nonexistent-spec")
+	err := exec.postSpecCompletion(context.Background(), "nonexistent-spec")
 	if err == nil {
 		t.Error("expected error when spec not found")
 	}
@@ -2873,7 +2883,7 @@ func TestCompleteLayerAutomergeEnabled(t *testing.T) {
 		stdout: &output,
 	}
 
-	err := exec.completeLayer("L0")
+	err := exec.completeLayer(context.Background(), "L0")
 	if err != nil {
 		t.Errorf("expected no error when automerge enabled, got: %v", err)
 	}
@@ -2903,7 +2913,7 @@ func TestCompleteLayerNoUnmergedSpecs(t *testing.T) {
 		stdout: &output,
 	}
 
-	err := exec.completeLayer("L0")
+	err := exec.completeLayer(context.Background(), "L0")
 	if err != nil {
 		t.Errorf("expected no error when no unmerged specs, got: %v", err)
 	}
@@ -2983,59 +2993,43 @@ func TestHandleStagingConflict(t *testing.T) {
 }
 
 func TestValidateResumeState(t *testing.T) {
+	interruptedMerge := func(t *testing.T) string {
+		repo := setupDivergedRepo(t)
+		mustGit(t, repo, "merge", "--no-ff", "--no-commit", "spec-a")
+		return repo
+	}
+
 	tests := map[string]struct {
 		disableLayerStaging bool
-		setupRepo           func(t *testing.T, repoRoot string)
+		setupRepo           func(t *testing.T) string
 		wantErr             bool
 		checkOutput         string
 	}{
 		"layer staging disabled skips validation": {
 			disableLayerStaging: true,
-			setupRepo: func(t *testing.T, repoRoot string) {
-				// Create merge state that would fail if checked
-				gitDir := filepath.Join(repoRoot, ".git")
-				if err := os.MkdirAll(gitDir, 0755); err != nil {
-					t.Fatal(err)
-				}
-				mergeHead := filepath.Join(gitDir, "MERGE_HEAD")
-				if err := os.WriteFile(mergeHead, []byte("abc"), 0644); err != nil {
-					t.Fatal(err)
-				}
-			},
-			wantErr: false,
+			// Merge state that would fail if checked
+			setupRepo: interruptedMerge,
+			wantErr:   false,
 		},
 		"clean repo continues execution": {
 			disableLayerStaging: false,
-			setupRepo: func(t *testing.T, repoRoot string) {
-				gitDir := filepath.Join(repoRoot, ".git")
-				if err := os.MkdirAll(gitDir, 0755); err != nil {
-					t.Fatal(err)
-				}
+			setupRepo: func(t *testing.T) string {
+				return setupDivergedRepo(t)
 			},
 			wantErr:     false,
 			checkOutput: "No interrupted merge detected",
 		},
 		"interrupted merge returns error": {
 			disableLayerStaging: false,
-			setupRepo: func(t *testing.T, repoRoot string) {
-				gitDir := filepath.Join(repoRoot, ".git")
-				if err := os.MkdirAll(gitDir, 0755); err != nil {
-					t.Fatal(err)
-				}
-				mergeHead := filepath.Join(gitDir, "MERGE_HEAD")
-				if err := os.WriteFile(mergeHead, []byte("abc"), 0644); err != nil {
-					t.Fatal(err)
-				}
-			},
-			wantErr:     true,
-			checkOutput: "INTERRUPTED MERGE DETECTED",
+			setupRepo:           interruptedMerge,
+			wantErr:             true,
+			checkOutput:         "INTERRUPTED MERGE DETECTED",
 		},
 	}
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			repoRoot := t.TempDir()
-			tt.setupRepo(t, repoRoot)
+			repoRoot := tt.setupRepo(t)
 
 			var output bytes.Buffer
 			exec := &Executor{
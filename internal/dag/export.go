@@ -0,0 +1,258 @@
+package dag
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/graphexport"
+)
+
+// ExportFormat is a machine-readable diagram format for DAG visualization.
+type ExportFormat string
+
+const (
+	// FormatASCII is the terminal rendering from RenderASCII.
+	FormatASCII ExportFormat = "ascii"
+	// FormatMermaid is a Mermaid flowchart, renderable in GitHub markdown.
+	FormatMermaid ExportFormat = "mermaid"
+	// FormatDOT is a Graphviz DOT digraph.
+	FormatDOT ExportFormat = "dot"
+	// FormatJSON is a JSON document of layers, features, edges, and status.
+	FormatJSON ExportFormat = "json"
+)
+
+// ValidExportFormats lists all valid visualization formats.
+var ValidExportFormats = []ExportFormat{FormatASCII, FormatMermaid, FormatDOT, FormatJSON}
+
+// IsValidExportFormat returns true if the format is a known visualization format.
+func IsValidExportFormat(format string) bool {
+	for _, f := range ValidExportFormats {
+		if string(f) == format {
+			return true
+		}
+	}
+	return false
+}
+
+// statusStyles colours spec statuses in Mermaid and DOT output.
+// Pending specs use the renderer's default style.
+var statusStyles = map[InlineSpecStatus]graphexport.Style{
+	InlineSpecStatusRunning:   graphexport.StyleRunning,
+	InlineSpecStatusCompleted: graphexport.StyleCompleted,
+	InlineSpecStatusFailed:    graphexport.StyleFailed,
+	InlineSpecStatusBlocked:   graphexport.StyleInactive,
+}
+
+// styledStatuses is the order statuses are emitted in for stable output.
+var styledStatuses = []InlineSpecStatus{
+	InlineSpecStatusRunning,
+	InlineSpecStatusCompleted,
+	InlineSpecStatusFailed,
+	InlineSpecStatusBlocked,
+}
+
+// specStatus returns the runtime status of a spec from inline state,
+// or pending when the spec has not run.
+func specStatus(cfg *DAGConfig, specID string) InlineSpecStatus {
+	if state, ok := cfg.Specs[specID]; ok && state != nil && state.Status != "" {
+		return state.Status
+	}
+	return InlineSpecStatusPending
+}
+
+// mermaidID converts an identifier into a prefixed Mermaid node ID, so
+// layers and specs with the same ID stay distinct.
+func mermaidID(prefix, id string) string {
+	return prefix + "_" + graphexport.MermaidID(id)
+}
+
+// layerLabel returns "ID: Name" or just the ID when the layer has no name.
+func layerLabel(layer Layer) string {
+	if layer.Name == "" {
+		return layer.ID
+	}
+	return fmt.Sprintf("%s: %s", layer.ID, layer.Name)
+}
+
+// RenderMermaid generates a Mermaid flowchart of the DAG. Layers become
+// subgraphs, depends_on entries become edges, and specs are coloured by
+// their runtime status from the inline specs state.
+func RenderMermaid(cfg *DAGConfig) string {
+	var sb strings.Builder
+	sb.WriteString("flowchart TD\n")
+
+	for _, layer := range cfg.Layers {
+		fmt.Fprintf(&sb, "  subgraph %s[\"%s\"]\n", mermaidID("layer", layer.ID), graphexport.MermaidLabel(layerLabel(layer)))
+		for _, f := range layer.Features {
+			fmt.Fprintf(&sb, "    %s[\"%s<br/>%s\"]\n", mermaidID("spec", f.ID), graphexport.MermaidLabel(f.ID), specStatus(cfg, f.ID))
+		}
+		sb.WriteString("  end\n")
+	}
+
+	for _, layer := range cfg.Layers {
+		for _, dep := range layer.DependsOn {
+			fmt.Fprintf(&sb, "  %s --> %s\n", mermaidID("layer", dep), mermaidID("layer", layer.ID))
+		}
+	}
+	for _, edge := range featureEdges(cfg) {
+		fmt.Fprintf(&sb, "  %s --> %s\n", mermaidID("spec", edge.From), mermaidID("spec", edge.To))
+	}
+
+	byStatus := make(map[InlineSpecStatus][]string)
+	for _, layer := range cfg.Layers {
+		for _, f := range layer.Features {
+			status := specStatus(cfg, f.ID)
+			byStatus[status] = append(byStatus[status], mermaidID("spec", f.ID))
+		}
+	}
+	for _, status := range styledStatuses {
+		style := statusStyles[status]
+		fmt.Fprintf(&sb, "  classDef %s fill:%s,stroke:%s\n", status, style.Fill, style.Stroke)
+	}
+	for _, status := range styledStatuses {
+		if ids := byStatus[status]; len(ids) > 0 {
+			fmt.Fprintf(&sb, "  class %s %s\n", strings.Join(ids, ","), status)
+		}
+	}
+
+	return sb.String()
+}
+
+// RenderDOT generates a Graphviz DOT digraph of the DAG. Layers become
+// clusters, depends_on entries become edges, and specs are filled by their
+// runtime status from the inline specs state. Layer edges connect an
+// invisible anchor node in each cluster and are clipped to the cluster
+// borders (compound=true), matching the layer edges of RenderMermaid.
+func RenderDOT(cfg *DAGConfig) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "digraph %s {\n", graphexport.DOTQuote(cfg.DAG.Name))
+	sb.WriteString("  rankdir=TB;\n")
+	sb.WriteString("  compound=true;\n")
+	sb.WriteString("  node [shape=box, style=\"rounded,filled\", fillcolor=\"#ffffff\"];\n")
+
+	for _, layer := range cfg.Layers {
+		fmt.Fprintf(&sb, "  subgraph %s {\n", graphexport.DOTQuote("cluster_"+layer.ID))
+		fmt.Fprintf(&sb, "    label=%s;\n", graphexport.DOTQuote(layerLabel(layer)))
+		fmt.Fprintf(&sb, "    %s [shape=point, style=invis, width=0, label=\"\"];\n", dotLayerAnchor(layer.ID))
+		for _, f := range layer.Features {
+			status := specStatus(cfg, f.ID)
+			attrs := fmt.Sprintf("label=%s", graphexport.DOTQuote(fmt.Sprintf("%s\\n%s", f.ID, status)))
+			if style, ok := statusStyles[status]; ok {
+				attrs += fmt.Sprintf(", fillcolor=%s, color=%s", graphexport.DOTQuote(style.Fill), graphexport.DOTQuote(style.Stroke))
+			}
+			fmt.Fprintf(&sb, "    %s [%s];\n", graphexport.DOTQuote(f.ID), attrs)
+		}
+		sb.WriteString("  }\n")
+	}
+
+	for _, layer := range cfg.Layers {
+		for _, dep := range layer.DependsOn {
+			fmt.Fprintf(&sb, "  %s -> %s [ltail=%s, lhead=%s];\n",
+				dotLayerAnchor(dep), dotLayerAnchor(layer.ID),
+				graphexport.DOTQuote("cluster_"+dep), graphexport.DOTQuote("cluster_"+layer.ID))
+		}
+	}
+	for _, edge := range featureEdges(cfg) {
+		fmt.Fprintf(&sb, "  %s -> %s;\n", graphexport.DOTQuote(edge.From), graphexport.DOTQuote(edge.To))
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// dotLayerAnchor returns the quoted ID of a layer's invisible anchor node.
+// The space keeps it distinct from spec IDs, which name git branches.
+func dotLayerAnchor(layerID string) string {
+	return graphexport.DOTQuote("layer " + layerID)
+}
+
+// GraphEdge is a dependency edge: To depends on From.
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// featureEdges returns feature dependency edges in DAG order.
+func featureEdges(cfg *DAGConfig) []GraphEdge {
+	var edges []GraphEdge
+	for _, layer := range cfg.Layers {
+		for _, f := range layer.Features {
+			deps := make([]string, len(f.DependsOn))
+			copy(deps, f.DependsOn)
+			sort.Strings(deps)
+			for _, dep := range deps {
+				edges = append(edges, GraphEdge{From: dep, To: f.ID})
+			}
+		}
+	}
+	return edges
+}
+
+// graphJSON is the JSON export document.
+type graphJSON struct {
+	Name   string          `json:"name"`
+	Status InlineRunStatus `json:"status,omitempty"`
+	Layers []layerJSON     `json:"layers"`
+	Edges  []GraphEdge     `json:"edges"`
+}
+
+type layerJSON struct {
+	ID        string        `json:"id"`
+	Name      string        `json:"name,omitempty"`
+	DependsOn []string      `json:"depends_on,omitempty"`
+	Features  []featureJSON `json:"features"`
+}
+
+type featureJSON struct {
+	ID           string           `json:"id"`
+	Description  string           `json:"description,omitempty"`
+	DependsOn    []string         `json:"depends_on,omitempty"`
+	Status       InlineSpecStatus `json:"status"`
+	CurrentStage string           `json:"current_stage,omitempty"`
+}
+
+// RenderJSON generates a JSON document with the DAG's layers, features,
+// dependency edges, and runtime status from the inline state.
+func RenderJSON(cfg *DAGConfig) (string, error) {
+	doc := graphJSON{
+		Name:   cfg.DAG.Name,
+		Layers: make([]layerJSON, 0, len(cfg.Layers)),
+		Edges:  featureEdges(cfg),
+	}
+	if cfg.Run != nil {
+		doc.Status = cfg.Run.Status
+	}
+	if doc.Edges == nil {
+		doc.Edges = []GraphEdge{}
+	}
+
+	for _, layer := range cfg.Layers {
+		lj := layerJSON{
+			ID:        layer.ID,
+			Name:      layer.Name,
+			DependsOn: layer.DependsOn,
+			Features:  make([]featureJSON, 0, len(layer.Features)),
+		}
+		for _, f := range layer.Features {
+			fj := featureJSON{
+				ID:          f.ID,
+				Description: f.Description,
+				DependsOn:   f.DependsOn,
+				Status:      specStatus(cfg, f.ID),
+			}
+			if state := cfg.Specs[f.ID]; state != nil {
+				fj.CurrentStage = state.CurrentStage
+			}
+			lj.Features = append(lj.Features, fj)
+		}
+		doc.Layers = append(doc.Layers, lj)
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshaling DAG JSON: %w", err)
+	}
+	return string(data) + "\n", nil
+}
@@ -0,0 +1,176 @@
+package dag
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exportTestConfig returns a two-layer DAG with inline run state.
+func exportTestConfig() *DAGConfig {
+	return &DAGConfig{
+		SchemaVersion: "1.0",
+		DAG:           DAGMetadata{Name: "Export \"Test\""},
+		Layers: []Layer{
+			{
+				ID:   "L0",
+				Name: "Foundation",
+				Features: []Feature{
+					{ID: "auth-api", Description: "Auth API"},
+					{ID: "db.schema", Description: "Schema"},
+				},
+			},
+			{
+				ID:        "L1",
+				DependsOn: []string{"L0"},
+				Features: []Feature{
+					{ID: "login-ui", Description: "Login UI", DependsOn: []string{"db.schema", "auth-api"}},
+				},
+			},
+		},
+		Run: &InlineRunState{Status: InlineRunStatusRunning},
+		Specs: map[string]*InlineSpecState{
+			"auth-api":  {Status: InlineSpecStatusCompleted},
+			"db.schema": {Status: InlineSpecStatusRunning, CurrentStage: "implement"},
+		},
+	}
+}
+
+func TestIsValidExportFormat(t *testing.T) {
+	tests := map[string]bool{
+		"ascii":   true,
+		"mermaid": true,
+		"dot":     true,
+		"json":    true,
+		"svg":     false,
+		"":        false,
+	}
+
+	for format, want := range tests {
+		t.Run(format, func(t *testing.T) {
+			assert.Equal(t, want, IsValidExportFormat(format))
+		})
+	}
+}
+
+func TestRenderMermaid(t *testing.T) {
+	out := RenderMermaid(exportTestConfig())
+
+	assert.True(t, strings.HasPrefix(out, "flowchart TD\n"))
+	for _, want := range []string{
+		`subgraph layer_L0["L0: Foundation"]`,
+		`subgraph layer_L1["L1"]`,
+		`spec_auth_2d_api["auth-api<br/>completed"]`,
+		`spec_db_2e_schema["db.schema<br/>running"]`,
+		`spec_login_2d_ui["login-ui<br/>pending"]`,
+		"layer_L0 --> layer_L1",
+		"spec_auth_2d_api --> spec_login_2d_ui",
+		"spec_db_2e_schema --> spec_login_2d_ui",
+		"classDef completed fill:#d4edda,stroke:#28a745",
+		"class spec_auth_2d_api completed",
+		"class spec_db_2e_schema running",
+	} {
+		assert.Contains(t, out, want)
+	}
+	assert.NotContains(t, out, "class spec_login_2d_ui")
+}
+
+func TestRenderMermaid_DistinctIDs(t *testing.T) {
+	cfg := &DAGConfig{
+		Layers: []Layer{{
+			ID:       "L0",
+			Features: []Feature{{ID: "a-b"}, {ID: "a_b"}},
+		}},
+	}
+
+	out := RenderMermaid(cfg)
+
+	assert.Contains(t, out, `spec_a_2d_b["a-b<br/>pending"]`)
+	assert.Contains(t, out, `spec_a__b["a_b<br/>pending"]`)
+}
+
+func TestRenderDOT(t *testing.T) {
+	out := RenderDOT(exportTestConfig())
+
+	assert.True(t, strings.HasPrefix(out, `digraph "Export \"Test\"" {`))
+	for _, want := range []string{
+		`subgraph "cluster_L0" {`,
+		`label="L0: Foundation";`,
+		`"auth-api" [label="auth-api\ncompleted", fillcolor="#d4edda", color="#28a745"];`,
+		`"login-ui" [label="login-ui\npending"];`,
+		"compound=true;",
+		`"layer L0" [shape=point, style=invis, width=0, label=""];`,
+		`"layer L0" -> "layer L1" [ltail="cluster_L0", lhead="cluster_L1"];`,
+		`"auth-api" -> "login-ui";`,
+		`"db.schema" -> "login-ui";`,
+	} {
+		assert.Contains(t, out, want)
+	}
+	assert.True(t, strings.HasSuffix(out, "}\n"))
+}
+
+// TestRender_LayerOnlyDependency verifies a depends_on between layers with no
+// feature dependencies across them is drawn in both Mermaid and DOT.
+func TestRender_LayerOnlyDependency(t *testing.T) {
+	cfg := &DAGConfig{
+		DAG: DAGMetadata{Name: "layers"},
+		Layers: []Layer{
+			{ID: "L0", Features: []Feature{{ID: "schema"}}},
+			{ID: "L1", DependsOn: []string{"L0"}, Features: []Feature{{ID: "ui"}}},
+		},
+	}
+
+	assert.Contains(t, RenderMermaid(cfg), "layer_L0 --> layer_L1")
+	dot := RenderDOT(cfg)
+	assert.Contains(t, dot, `"layer L0" -> "layer L1" [ltail="cluster_L0", lhead="cluster_L1"];`)
+	assert.NotContains(t, dot, `"schema" -> "ui"`)
+}
+
+func TestRenderJSON(t *testing.T) {
+	out, err := RenderJSON(exportTestConfig())
+	require.NoError(t, err)
+
+	var doc struct {
+		Name   string `json:"name"`
+		Status string `json:"status"`
+		Layers []struct {
+			ID        string   `json:"id"`
+			DependsOn []string `json:"depends_on"`
+			Features  []struct {
+				ID           string `json:"id"`
+				Status       string `json:"status"`
+				CurrentStage string `json:"current_stage"`
+			} `json:"features"`
+		} `json:"layers"`
+		Edges []GraphEdge `json:"edges"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &doc))
+
+	assert.Equal(t, `Export "Test"`, doc.Name)
+	assert.Equal(t, "running", doc.Status)
+	require.Len(t, doc.Layers, 2)
+	assert.Equal(t, []string{"L0"}, doc.Layers[1].DependsOn)
+	assert.Equal(t, "completed", doc.Layers[0].Features[0].Status)
+	assert.Equal(t, "implement", doc.Layers[0].Features[1].CurrentStage)
+	assert.Equal(t, "pending", doc.Layers[1].Features[0].Status)
+	assert.Equal(t, []GraphEdge{
+		{From: "auth-api", To: "login-ui"},
+		{From: "db.schema", To: "login-ui"},
+	}, doc.Edges)
+}
+
+func TestRenderJSON_NoState(t *testing.T) {
+	cfg := &DAGConfig{
+		DAG:    DAGMetadata{Name: "Plain"},
+		Layers: []Layer{{ID: "L0", Features: []Feature{{ID: "a"}}}},
+	}
+
+	out, err := RenderJSON(cfg)
+	require.NoError(t, err)
+	assert.Contains(t, out, `"edges": []`)
+	assert.Contains(t, out, `"status": "pending"`)
+	assert.NotContains(t, out, `"status": "running"`)
+}
@@ -0,0 +1,66 @@
+package dag
+
+import (
+	"os"
+	"reflect"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/ariel-frischer/autospec/internal/yaml"
+)
+
+// jsonSchemaEnums lists the values of the string types used in dag.yaml, so
+// editors can complete and check them.
+var jsonSchemaEnums = map[reflect.Type][]string{
+	reflect.TypeOf(InlineRunStatus("")): {
+		string(InlineRunStatusPending), string(InlineRunStatusRunning), string(InlineRunStatusCompleted),
+		string(InlineRunStatusFailed), string(InlineRunStatusInterrupted),
+	},
+	reflect.TypeOf(InlineSpecStatus("")): {
+		string(InlineSpecStatusPending), string(InlineSpecStatusRunning), string(InlineSpecStatusCompleted),
+		string(InlineSpecStatusFailed), string(InlineSpecStatusBlocked),
+	},
+	reflect.TypeOf(MergeStatus("")): {
+		string(MergeStatusPending), string(MergeStatusMerged), string(MergeStatusMergeFailed),
+		string(MergeStatusSkipped), string(MergeStatusPROpen), string(MergeStatusPRClosed),
+	},
+	reflect.TypeOf(CommitStatus("")): {
+		string(CommitStatusPending), string(CommitStatusCommitted), string(CommitStatusFailed),
+	},
+	reflect.TypeOf(FailureClass("")): {
+		string(FailureAgentCrash), string(FailureTimeout), string(FailureValidationExhausted),
+		string(FailureCommitVerification), string(FailureMergeConflict), string(FailureWorktree),
+		string(FailurePreMergeCheck),
+	},
+	reflect.TypeOf(PRState("")): {
+		string(PRStateOpen), string(PRStateMerged), string(PRStateClosed),
+	},
+}
+
+// JSONSchema returns a JSON Schema for dag.yaml generated from DAGConfig,
+// covering the definition and the inline runtime state sections.
+func JSONSchema() map[string]any {
+	doc := validation.StructJSONSchema(reflect.TypeOf(DAGConfig{}), jsonSchemaEnums)
+	doc["title"] = "autospec DAG file"
+	doc["description"] = "Multi-spec workflow definition (dag.yaml) with inline run state"
+	return doc
+}
+
+// StampSchemaModeline writes the dag.yaml JSON Schema to the project and points
+// the modeline of the DAG file at path to it.
+func StampSchemaModeline(path string) error {
+	return validation.StampJSONSchemaModeline(path, "dag", JSONSchema())
+}
+
+// preserveSchemaModeline carries the modeline of the DAG file at path over to
+// data, which is about to replace it, so saving run state keeps editor support.
+func preserveSchemaModeline(path string, data []byte) []byte {
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		return data
+	}
+	ref := yaml.SchemaModelineRef(string(existing))
+	if ref == "" {
+		return data
+	}
+	return []byte(yaml.WithSchemaModeline(string(data), ref))
+}
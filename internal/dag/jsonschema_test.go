@@ -0,0 +1,100 @@
+package dag
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/ariel-frischer/autospec/internal/yaml"
+)
+
+func TestJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	doc := JSONSchema()
+	if _, err := validation.MarshalJSONSchema(doc); err != nil {
+		t.Fatalf("MarshalJSONSchema() error: %v", err)
+	}
+
+	// Required fields match the ones ValidateDAG reports as missing
+	if got, want := doc["required"], []string{"schema_version", "dag", "layers"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("root required = %v, want %v", got, want)
+	}
+	layer := property(t, doc, "layers")["items"].(map[string]any)
+	if got, want := layer["required"], []string{"id", "features"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("layer required = %v, want %v", got, want)
+	}
+	feature := property(t, layer, "features")["items"].(map[string]any)
+	if got, want := feature["required"], []string{"id", "description"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("feature required = %v, want %v", got, want)
+	}
+
+	spec := property(t, doc, "specs")["additionalProperties"].(map[string]any)
+	if got := property(t, spec, "status")["enum"]; !reflect.DeepEqual(got, jsonSchemaEnums[reflect.TypeOf(InlineSpecStatus(""))]) {
+		t.Errorf("specs.*.status enum = %v", got)
+	}
+	if got := property(t, spec, "started_at")["format"]; got != "date-time" {
+		t.Errorf("specs.*.started_at format = %v, want date-time", got)
+	}
+	if got := property(t, property(t, spec, "merge"), "pr_state")["enum"]; !reflect.DeepEqual(got, []string{"open", "merged", "closed"}) {
+		t.Errorf("specs.*.merge.pr_state enum = %v", got)
+	}
+}
+
+func TestStampSchemaModeline(t *testing.T) {
+	tmpDir := t.TempDir()
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(origDir) }()
+
+	path := filepath.Join(".autospec", "dags", "roadmap.yaml")
+	config := &DAGConfig{
+		SchemaVersion: "1.0",
+		DAG:           DAGMetadata{Name: "Roadmap"},
+		Layers:        []Layer{{ID: "L0", Features: []Feature{{ID: "001-auth", Description: "Auth"}}}},
+	}
+	if err := SaveDAGWithState(path, config); err != nil {
+		t.Fatalf("SaveDAGWithState() error: %v", err)
+	}
+
+	if err := StampSchemaModeline(path); err != nil {
+		t.Fatalf("StampSchemaModeline() error: %v", err)
+	}
+	if _, err := os.Stat(validation.JSONSchemaPath("dag")); err != nil {
+		t.Errorf("JSON Schema not written: %v", err)
+	}
+	const wantRef = "../schemas/json/dag.schema.json"
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), yaml.SchemaModelinePrefix+wantRef+"\n") {
+		t.Errorf("DAG file does not start with the modeline:\n%s", data)
+	}
+
+	// Saving run state keeps the modeline
+	config.Run = &InlineRunState{Status: InlineRunStatusRunning}
+	if err := SaveDAGWithState(path, config); err != nil {
+		t.Fatalf("SaveDAGWithState() error: %v", err)
+	}
+	data, _ = os.ReadFile(path)
+	if got := yaml.SchemaModelineRef(string(data)); got != wantRef {
+		t.Errorf("modeline after saving state = %q, want %q", got, wantRef)
+	}
+}
+
+// property returns the schema of a property of an object schema.
+func property(t *testing.T, doc map[string]any, name string) map[string]any {
+	t.Helper()
+	properties, _ := doc["properties"].(map[string]any)
+	prop, ok := properties[name].(map[string]any)
+	if !ok {
+		t.Fatalf("schema has no property %q", name)
+	}
+	return prop
+}
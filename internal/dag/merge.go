@@ -47,7 +47,12 @@ type MergeExecutor struct {
 	forceVerify     bool
 	cleanup         bool
 	onConflict      OnConflict
+	strategy        MergeStrategy
 	agent           cliagent.Agent
+	verifyCmd       string
+	logBase         string
+	prProvider      PRProvider
+	remote          string
 }
 
 // MergeExecutorOption configures a MergeExecutor.
@@ -109,6 +114,16 @@ func WithMergeForce(force bool) MergeExecutorOption {
 	}
 }
 
+// WithMergeStrategy sets how the final staging branch or individual spec
+// branches are merged into the target branch. Unknown strategies are ignored.
+func WithMergeStrategy(strategy MergeStrategy) MergeExecutorOption {
+	return func(me *MergeExecutor) {
+		if IsValidMergeStrategy(string(strategy)) {
+			me.strategy = strategy
+		}
+	}
+}
+
 // WithMergeAgent sets the agent for conflict resolution.
 func WithMergeAgent(agent cliagent.Agent) MergeExecutorOption {
 	return func(me *MergeExecutor) {
@@ -116,6 +131,22 @@ func WithMergeAgent(agent cliagent.Agent) MergeExecutorOption {
 	}
 }
 
+// WithMergeConflictVerifyCmd sets the command that must pass after the agent
+// resolves conflicts. See DAGExecutionConfig.ConflictVerifyCmd.
+func WithMergeConflictVerifyCmd(cmd string) MergeExecutorOption {
+	return func(me *MergeExecutor) {
+		me.verifyCmd = cmd
+	}
+}
+
+// WithMergeLogBase sets the base log directory (dag.log_dir) used for
+// conflict reports when the run has no log directory of its own.
+func WithMergeLogBase(dir string) MergeExecutorOption {
+	return func(me *MergeExecutor) {
+		me.logBase = dir
+	}
+}
+
 // NewMergeExecutor creates a new MergeExecutor.
 func NewMergeExecutor(
 	stateDir string,
@@ -129,6 +160,7 @@ func NewMergeExecutor(
 		stdout:          os.Stdout,
 		repoRoot:        repoRoot,
 		onConflict:      OnConflictManual, // Default to manual resolution
+		strategy:        MergeStrategyNoFF,
 	}
 
 	for _, opt := range opts {
@@ -176,6 +208,10 @@ func (me *MergeExecutor) mergeFinalStaging(
 
 	fmt.Fprintf(me.stdout, "✓ Final staging branch verified: %s\n", verification.FinalStagingBranch)
 
+	if me.prProvider != nil {
+		return me.openStagingPullRequest(ctx, run, dag, verification.FinalStagingBranch, targetBranch)
+	}
+
 	// Perform the merge
 	return me.executeStagingMerge(ctx, run, dag, verification.FinalStagingBranch, targetBranch)
 }
@@ -202,11 +238,16 @@ func (me *MergeExecutor) executeStagingMerge(
 		return fmt.Errorf("checking out target branch: %w", err)
 	}
 
-	// Merge staging branch with --no-ff for clear merge commit
+	// Staging already holds one commit per spec under squash, so the final
+	// merge keeps those commits by rebasing instead of squashing them together
+	strategy := me.strategy
+	if strategy == MergeStrategySquash {
+		strategy = MergeStrategyRebase
+	}
 	mergeMsg := fmt.Sprintf("Merge DAG %s staging branch %s", run.DAGId, stagingBranch)
-	fmt.Fprintf(me.stdout, "Merging %s into %s...\n", stagingBranch, targetBranch)
+	fmt.Fprintf(me.stdout, "Merging %s into %s (%s)...\n", stagingBranch, targetBranch, strategy)
 
-	conflicts, err := me.performNoFFMerge(ctx, stagingBranch, mergeMsg)
+	conflicts, err := mergeWithStrategy(ctx, me.repoRoot, strategy, targetBranch, stagingBranch, mergeMsg)
 	if err != nil {
 		if len(conflicts) > 0 {
 			return me.handleStagingMergeConflicts(ctx, run, dag, stagingBranch, targetBranch, conflicts)
@@ -218,26 +259,6 @@ func (me *MergeExecutor) executeStagingMerge(
 	return nil
 }
 
-// performNoFFMerge executes git merge with --no-ff flag.
-func (me *MergeExecutor) performNoFFMerge(
-	ctx context.Context,
-	sourceBranch, mergeMsg string,
-) ([]string, error) {
-	cmd := exec.CommandContext(ctx, "git", "merge", "--no-ff", "-m", mergeMsg, sourceBranch)
-	cmd.Dir = me.repoRoot
-	_, err := cmd.CombinedOutput()
-
-	if err != nil {
-		conflicts := DetectConflictedFiles(me.repoRoot)
-		if len(conflicts) > 0 {
-			return conflicts, fmt.Errorf("merge conflict in %d file(s)", len(conflicts))
-		}
-		return nil, err
-	}
-
-	return nil, nil
-}
-
 // handleStagingMergeConflicts handles conflicts when merging staging to target.
 func (me *MergeExecutor) handleStagingMergeConflicts(
 	_ context.Context,
@@ -246,15 +267,23 @@ func (me *MergeExecutor) handleStagingMergeConflicts(
 	stagingBranch, targetBranch string,
 	conflicts []string,
 ) error {
+	publishMergeConflict(stagingBranch, targetBranch, conflicts)
 	fmt.Fprintf(me.stdout, "✗ Merge conflict detected in %d file(s):\n", len(conflicts))
 	for _, file := range conflicts {
 		fmt.Fprintf(me.stdout, "  - %s\n", file)
 	}
 
 	fmt.Fprintln(me.stdout, "\nTo resolve:")
-	fmt.Fprintln(me.stdout, "  1. Resolve conflicts in the listed files")
-	fmt.Fprintln(me.stdout, "  2. Run: git add <resolved-files>")
-	fmt.Fprintln(me.stdout, "  3. Run: git commit")
+	if me.strategy == MergeStrategyNoFF {
+		fmt.Fprintln(me.stdout, "  1. Resolve conflicts in the listed files")
+		fmt.Fprintln(me.stdout, "  2. Run: git add <resolved-files>")
+		fmt.Fprintln(me.stdout, "  3. Run: git commit")
+	} else {
+		// The rebase was aborted, so the staging branch is rebased by hand
+		fmt.Fprintf(me.stdout, "  1. Run: git checkout %s && git rebase %s\n", stagingBranch, targetBranch)
+		fmt.Fprintln(me.stdout, "  2. Resolve conflicts, then: git add <resolved-files> && git rebase --continue")
+		fmt.Fprintf(me.stdout, "  3. Run: git checkout %s\n", targetBranch)
+	}
 	fmt.Fprintln(me.stdout, "  4. Re-run: autospec dag merge <workflow>")
 
 	return &MergeConflictError{
@@ -296,6 +325,9 @@ func (me *MergeExecutor) mergeIndividualBranches(
 
 	fmt.Fprintf(me.stdout, "Merge order: %v\n\n", mergeOrder)
 
+	if me.prProvider != nil {
+		return me.openSpecPullRequests(ctx, run, dag, mergeOrder, targetBranch)
+	}
 	return me.executeMerges(ctx, run, dag, mergeOrder, targetBranch)
 }
 
@@ -398,7 +430,7 @@ func (me *MergeExecutor) processSingleMerge(
 		return true, nil
 	}
 
-	result := me.MergeSpec(ctx, run, specID, targetBranch)
+	result := me.MergeSpec(ctx, run, dag, specID, targetBranch)
 
 	if err := me.resolveConflictsIfPresent(ctx, run, dag, specID, result, targetBranch); err != nil {
 		return false, err
@@ -431,6 +463,7 @@ func (me *MergeExecutor) resolveConflictsIfPresent(
 	if len(result.Conflicts) == 0 {
 		return nil
 	}
+	publishMergeConflict(specID, targetBranch, result.Conflicts)
 
 	resolved, err := me.handleConflicts(ctx, run, dag, specID, result, targetBranch)
 	if err != nil {
@@ -506,7 +539,7 @@ func (me *MergeExecutor) handleConflicts(
 		return false, fmt.Errorf("getting source branch: %w", err)
 	}
 
-	resolver := NewConflictResolver(me.repoRoot, me.agent, me.stdout)
+	resolver := NewConflictResolver(me.repoRoot, me.agent, me.stdout, WithConflictVerifyCmd(me.verifyCmd))
 	contexts, err := resolver.BuildAllConflictContexts(
 		result.Conflicts, specID, dag, sourceBranch, targetBranch,
 	)
@@ -540,6 +573,10 @@ func (me *MergeExecutor) resolveConflictsWithStrategy(
 }
 
 // tryAgentResolution attempts agent resolution with retry logic.
+// Each resolution must pass ConflictResolver.VerifyResolution; a rejected
+// resolution is reverted to the original conflict and its failure is given
+// to the agent on the next attempt. When all attempts fail, a conflict report
+// is saved to the DAG log directory and the merge falls back to manual.
 func (me *MergeExecutor) tryAgentResolution(
 	ctx context.Context,
 	run *DAGRun,
@@ -549,11 +586,22 @@ func (me *MergeExecutor) tryAgentResolution(
 ) (bool, error) {
 	specState := run.Specs[specID]
 
+	snapshot, err := snapshotConflictFiles(me.repoRoot, contexts)
+	if err != nil {
+		return false, fmt.Errorf("snapshotting conflicted files: %w", err)
+	}
+
+	var feedback string
+	var lastErr error
+	var diffs map[string]string
 	for attempt := 1; attempt <= MaxAgentRetries; attempt++ {
 		fmt.Fprintf(me.stdout, "Agent resolution attempt %d/%d for %s...\n",
 			attempt, MaxAgentRetries, specID)
 
-		err := resolver.ResolveWithAgent(ctx, contexts)
+		err := resolver.ResolveWithAgentFeedback(ctx, contexts, feedback)
+		if err == nil {
+			err = resolver.VerifyResolution(ctx, contexts)
+		}
 		if err == nil {
 			specState.Merge = &MergeState{
 				Status:           MergeStatusMerged,
@@ -563,15 +611,40 @@ func (me *MergeExecutor) tryAgentResolution(
 		}
 
 		fmt.Fprintf(me.stdout, "Attempt %d failed: %v\n", attempt, err)
+		lastErr = err
+		feedback = conflictFailureText(err)
+		diffs = resolutionDiffs(me.repoRoot, contexts)
+		if err := restoreConflictFiles(me.repoRoot, snapshot); err != nil {
+			return false, fmt.Errorf("reverting agent resolution: %w", err)
+		}
 	}
 
 	// All attempts failed, fall back to manual
 	fmt.Fprintf(me.stdout, "Agent failed after %d attempts, falling back to manual\n",
 		MaxAgentRetries)
+	reportPath, err := writeConflictReport(me.conflictReportDir(run), specID, contexts, diffs, MaxAgentRetries, lastErr)
+	if err != nil {
+		fmt.Fprintf(me.stdout, "Warning: %v\n", err)
+	} else {
+		fmt.Fprintf(me.stdout, "Conflict report saved to %s\n", reportPath)
+	}
 	me.outputManualContextAndPause(run, specID, resolver, contexts)
 	return false, fmt.Errorf("agent resolution failed after %d attempts", MaxAgentRetries)
 }
 
+// conflictReportDir returns the DAG log directory for conflict reports: the
+// run's log directory, or the one derived from the configured log base.
+func (me *MergeExecutor) conflictReportDir(run *DAGRun) string {
+	if dir := GetLogDirForRun(run); dir != "" {
+		return dir
+	}
+	projectID := run.ProjectID
+	if projectID == "" {
+		projectID = GetProjectID()
+	}
+	return GetCacheLogDirWithConfig(&DAGExecutionConfig{LogDir: me.logBase}, projectID, run.DAGId)
+}
+
 // outputManualContextAndPause outputs manual context and updates state.
 func (me *MergeExecutor) outputManualContextAndPause(
 	run *DAGRun,
@@ -616,10 +689,13 @@ func (me *MergeExecutor) handleMergeFailure(
 	return err
 }
 
-// MergeSpec performs a git merge of a single spec's branch into the target.
+// MergeSpec performs a git merge of a single spec's branch into the target
+// using the configured merge strategy. dag provides the feature description
+// for squash commit messages.
 func (me *MergeExecutor) MergeSpec(
 	ctx context.Context,
 	run *DAGRun,
+	dag *DAGConfig,
 	specID, targetBranch string,
 ) *MergeResult {
 	result := &MergeResult{SpecID: specID, Status: MergeStatusPending}
@@ -640,8 +716,13 @@ func (me *MergeExecutor) MergeSpec(
 		return me.failMergeResult(result, fmt.Errorf("checking out target branch: %w", err))
 	}
 
-	conflicts, err := me.performMerge(ctx, sourceBranch)
+	conflicts, err := me.mergeSpecBranch(ctx, dag, specID, sourceBranch, targetBranch)
 	if err != nil {
+		if me.strategy == MergeStrategyRebase && len(conflicts) > 0 {
+			// The rebase was aborted, so there is nothing left to resolve in place
+			return me.failMergeResult(result, fmt.Errorf("%w: %v; rebase %s onto %s in its worktree and re-run",
+				err, conflicts, sourceBranch, targetBranch))
+		}
 		result.Conflicts = conflicts
 		return me.failMergeResult(result, err)
 	}
@@ -650,6 +731,24 @@ func (me *MergeExecutor) MergeSpec(
 	return result
 }
 
+// mergeSpecBranch merges a spec branch into the checked out target branch.
+// The no-ff strategy keeps the plain git merge used before strategies existed.
+func (me *MergeExecutor) mergeSpecBranch(
+	ctx context.Context,
+	dag *DAGConfig,
+	specID, sourceBranch, targetBranch string,
+) ([]string, error) {
+	switch me.strategy {
+	case MergeStrategySquash:
+		msg := squashCommitMessage(ctx, me.repoRoot, targetBranch, sourceBranch, specID, featureDescription(dag, specID))
+		return mergeWithStrategy(ctx, me.repoRoot, me.strategy, targetBranch, sourceBranch, msg)
+	case MergeStrategyRebase:
+		return mergeWithStrategy(ctx, me.repoRoot, me.strategy, targetBranch, sourceBranch, "")
+	default:
+		return me.performMerge(ctx, sourceBranch)
+	}
+}
+
 // failMergeResult sets the result to failed state with the given error.
 func (me *MergeExecutor) failMergeResult(result *MergeResult, err error) *MergeResult {
 	result.Status = MergeStatusMergeFailed
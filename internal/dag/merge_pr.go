@@ -0,0 +1,230 @@
+package dag
+
+// merge_pr.go implements dag merge --pr for repositories that forbid local
+// merges to the target branch.
+//
+// Instead of merging locally, each spec branch (or the final staging branch
+// when layer staging was used) is pushed to the configured remote and a pull
+// request is opened against the target branch. PR URLs and review state are
+// stored in the spec merge state; running dag merge --pr again pushes new
+// commits and refreshes the state of existing pull requests, or opens a new
+// one in place of a closed pull request.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WithMergePullRequests enables pull request mode: branches are pushed to
+// remote and pull requests opened through provider instead of merged locally.
+func WithMergePullRequests(provider PRProvider, remote string) MergeExecutorOption {
+	return func(me *MergeExecutor) {
+		me.prProvider = provider
+		me.remote = remote
+	}
+}
+
+// openSpecPullRequests opens one pull request per spec branch, in merge order.
+func (me *MergeExecutor) openSpecPullRequests(
+	ctx context.Context,
+	run *DAGRun,
+	dag *DAGConfig,
+	mergeOrder []string,
+	targetBranch string,
+) error {
+	for _, specID := range mergeOrder {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		specState := run.Specs[specID]
+		if specState == nil || me.shouldSkipSpec(specState) {
+			continue
+		}
+
+		branch, err := me.specBranch(specState)
+		if err != nil {
+			return fmt.Errorf("getting branch for %s: %w", specID, err)
+		}
+
+		input := PullRequestInput{
+			Title: squashSubject(specID, featureDescription(dag, specID)),
+			Body:  specPullRequestBody(dag, run, specID),
+			Head:  branch,
+			Base:  targetBranch,
+		}
+		pr, err := me.publishPullRequest(ctx, specState.Merge, input)
+		if err != nil {
+			return me.recordPullRequestFailure(run, []string{specID}, err)
+		}
+
+		specState.Merge = pullRequestMergeState(specState.Merge, pr)
+		if err := SaveState(me.stateDir, run); err != nil {
+			return fmt.Errorf("saving state after pull request: %w", err)
+		}
+	}
+	return nil
+}
+
+// openStagingPullRequest opens a single pull request from the final staging
+// branch and records it on every spec it contains.
+func (me *MergeExecutor) openStagingPullRequest(
+	ctx context.Context,
+	run *DAGRun,
+	dag *DAGConfig,
+	stagingBranch, targetBranch string,
+) error {
+	specIDs, err := ComputeMergeOrder(dag, run)
+	if err != nil {
+		return fmt.Errorf("computing merge order: %w", err)
+	}
+
+	var existing *MergeState
+	for _, specID := range specIDs {
+		if merge := run.Specs[specID].Merge; merge != nil && merge.PRNumber > 0 {
+			existing = merge
+			break
+		}
+	}
+
+	input := PullRequestInput{
+		Title: fmt.Sprintf("DAG %s: merge %s", run.DAGId, stagingBranch),
+		Body:  stagingPullRequestBody(dag, run, specIDs),
+		Head:  stagingBranch,
+		Base:  targetBranch,
+	}
+	pr, err := me.publishPullRequest(ctx, existing, input)
+	if err != nil {
+		return me.recordPullRequestFailure(run, specIDs, err)
+	}
+
+	for _, specID := range specIDs {
+		specState := run.Specs[specID]
+		specState.Merge = pullRequestMergeState(specState.Merge, pr)
+	}
+	if err := SaveState(me.stateDir, run); err != nil {
+		return fmt.Errorf("saving state after pull request: %w", err)
+	}
+	return nil
+}
+
+// recordPullRequestFailure marks each spec merge_failed with err and saves
+// the state. A pull request already recorded on a spec is kept, so the next
+// run refreshes it instead of opening a duplicate.
+func (me *MergeExecutor) recordPullRequestFailure(run *DAGRun, specIDs []string, err error) error {
+	for _, specID := range specIDs {
+		specState := run.Specs[specID]
+		if specState == nil {
+			continue
+		}
+		failed := &MergeState{Status: MergeStatusMergeFailed, Error: err.Error()}
+		if prev := specState.Merge; prev != nil {
+			failed.PRURL = prev.PRURL
+			failed.PRNumber = prev.PRNumber
+			failed.PRState = prev.PRState
+		}
+		specState.Merge = failed
+	}
+	if saveErr := SaveState(me.stateDir, run); saveErr != nil {
+		return fmt.Errorf("saving state after pull request failure: %w", saveErr)
+	}
+	return err
+}
+
+// publishPullRequest pushes the head branch and opens a pull request for it,
+// or refreshes the pull request already recorded in existing. A recorded
+// pull request that was closed is replaced by a new one.
+func (me *MergeExecutor) publishPullRequest(
+	ctx context.Context,
+	existing *MergeState,
+	input PullRequestInput,
+) (*PullRequest, error) {
+	if existing != nil && existing.PRNumber > 0 && existing.PRState == PRStateMerged {
+		return &PullRequest{Number: existing.PRNumber, URL: existing.PRURL, State: PRStateMerged}, nil
+	}
+
+	fmt.Fprintf(me.stdout, "Pushing %s to %s...\n", input.Head, me.remote)
+	if _, err := runGitOutput(ctx, me.repoRoot, "push", "--set-upstream", me.remote, input.Head); err != nil {
+		return nil, fmt.Errorf("pushing %s: %w", input.Head, err)
+	}
+
+	if existing != nil && existing.PRNumber > 0 && existing.PRState == PRStateClosed {
+		fmt.Fprintf(me.stdout, "Pull request #%d was closed, opening a new one\n", existing.PRNumber)
+	} else if existing != nil && existing.PRNumber > 0 {
+		pr, err := me.prProvider.GetPullRequest(ctx, existing.PRNumber)
+		if err != nil {
+			return nil, fmt.Errorf("refreshing pull request #%d: %w", existing.PRNumber, err)
+		}
+		fmt.Fprintf(me.stdout, "✓ Pull request #%d is %s: %s\n", pr.Number, pr.State, pr.URL)
+		return pr, nil
+	}
+
+	pr, err := me.prProvider.CreatePullRequest(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("opening pull request for %s: %w", input.Head, err)
+	}
+	fmt.Fprintf(me.stdout, "✓ Opened pull request #%d: %s\n", pr.Number, pr.URL)
+	return pr, nil
+}
+
+// pullRequestMergeState returns the merge state for a spec whose changes are
+// in pr. A merged pull request marks the spec merged.
+func pullRequestMergeState(prev *MergeState, pr *PullRequest) *MergeState {
+	state := &MergeState{
+		Status:   MergeStatusPROpen,
+		PRURL:    pr.URL,
+		PRNumber: pr.Number,
+		PRState:  pr.State,
+	}
+	switch pr.State {
+	case PRStateMerged:
+		state.Status = MergeStatusMerged
+		if prev != nil && prev.MergedAt != nil {
+			state.MergedAt = prev.MergedAt
+		} else {
+			now := time.Now()
+			state.MergedAt = &now
+		}
+	case PRStateClosed:
+		state.Status = MergeStatusPRClosed
+	}
+	return state
+}
+
+// specBranch returns the branch of a spec, read from its worktree when the
+// state does not record it.
+func (me *MergeExecutor) specBranch(specState *SpecState) (string, error) {
+	if specState.Branch != "" {
+		return specState.Branch, nil
+	}
+	return me.getWorktreeBranch(specState.WorktreePath)
+}
+
+// specPullRequestBody describes a spec for its pull request.
+func specPullRequestBody(dag *DAGConfig, run *DAGRun, specID string) string {
+	var sb strings.Builder
+	if desc := featureDescription(dag, specID); desc != "" {
+		sb.WriteString(strings.TrimSpace(desc))
+		sb.WriteString("\n\n")
+	}
+	fmt.Fprintf(&sb, "Spec `%s` from DAG `%s`, opened by `autospec dag merge --pr`.\n", specID, run.DAGId)
+	return sb.String()
+}
+
+// stagingPullRequestBody lists the specs in the final staging branch.
+func stagingPullRequestBody(dag *DAGConfig, run *DAGRun, specIDs []string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Completed specs from DAG `%s`:\n\n", run.DAGId)
+	for _, specID := range specIDs {
+		summary, _, _ := strings.Cut(strings.TrimSpace(featureDescription(dag, specID)), "\n")
+		if summary == "" {
+			fmt.Fprintf(&sb, "- `%s`\n", specID)
+			continue
+		}
+		fmt.Fprintf(&sb, "- `%s`: %s\n", specID, summary)
+	}
+	sb.WriteString("\nOpened by `autospec dag merge --pr`.\n")
+	return sb.String()
+}
@@ -0,0 +1,171 @@
+package dag
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMerge_PullRequestMode(t *testing.T) {
+	repo := setupDivergedRepo(t)
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	mustGit(t, repo, "init", "--bare", "-q", remote)
+	mustGit(t, repo, "remote", "add", "origin", remote)
+
+	standIn, srv := newPRStandIn(t, map[string]string{
+		"POST /repos/acme/app/pulls":  `{"number":5,"html_url":"https://github.com/acme/app/pull/5","state":"open"}`,
+		"GET /repos/acme/app/pulls/5": `{"number":5,"html_url":"https://github.com/acme/app/pull/5","state":"closed","merged":true}`,
+	})
+	provider, err := NewPRProvider(PRProviderConfig{Provider: PRProviderGitHub, APIURL: srv.URL, Repo: "acme/app", Token: "secret"})
+	if err != nil {
+		t.Fatalf("NewPRProvider() error: %v", err)
+	}
+
+	dagCfg := &DAGConfig{
+		DAG:    DAGMetadata{ID: "pr"},
+		Layers: []Layer{{ID: "L0", Features: []Feature{{ID: "spec-a", Description: "Add feature a"}}}},
+	}
+	run := &DAGRun{
+		DAGId: "pr",
+		Specs: map[string]*SpecState{
+			"spec-a": {SpecID: "spec-a", Status: SpecStatusCompleted, Branch: "spec-a"},
+		},
+	}
+	me := NewMergeExecutor(t.TempDir(), nil, repo,
+		WithMergeStdout(io.Discard),
+		WithMergeTargetBranch("main"),
+		WithMergeForce(true),
+		WithMergePullRequests(provider, "origin"),
+	)
+	mainHead := gitOutput(t, repo, "rev-parse", "main")
+
+	if err := me.Merge(context.Background(), run, dagCfg); err != nil {
+		t.Fatalf("Merge() error: %v", err)
+	}
+
+	merge := run.Specs["spec-a"].Merge
+	if merge == nil || merge.Status != MergeStatusPROpen || merge.PRNumber != 5 || merge.PRURL != "https://github.com/acme/app/pull/5" {
+		t.Fatalf("Merge state = %+v, want open PR #5", merge)
+	}
+	req := standIn.lastRequest()
+	if req.body["head"] != "spec-a" || req.body["base"] != "main" || req.body["title"] != "feat(spec-a): add feature a" {
+		t.Errorf("pull request body = %v", req.body)
+	}
+	if got := gitOutput(t, remote, "rev-parse", "spec-a"); got != gitOutput(t, repo, "rev-parse", "spec-a") {
+		t.Error("spec-a not pushed to remote")
+	}
+	if got := gitOutput(t, repo, "rev-parse", "main"); got != mainHead {
+		t.Error("main changed in pull request mode")
+	}
+
+	// A second run refreshes the existing pull request instead of opening another
+	if err := me.Merge(context.Background(), run, dagCfg); err != nil {
+		t.Fatalf("second Merge() error: %v", err)
+	}
+	merge = run.Specs["spec-a"].Merge
+	if merge.Status != MergeStatusMerged || merge.PRState != PRStateMerged || merge.MergedAt == nil {
+		t.Errorf("Merge state after refresh = %+v, want merged", merge)
+	}
+	if last := standIn.lastRequest(); last.method != "GET" {
+		t.Errorf("refresh sent %s, want GET", last.method)
+	}
+}
+
+func TestPullRequestMergeState(t *testing.T) {
+	tests := map[string]struct {
+		state      PRState
+		wantStatus MergeStatus
+	}{
+		"open":   {state: PRStateOpen, wantStatus: MergeStatusPROpen},
+		"merged": {state: PRStateMerged, wantStatus: MergeStatusMerged},
+		"closed": {state: PRStateClosed, wantStatus: MergeStatusPRClosed},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := pullRequestMergeState(nil, &PullRequest{Number: 3, URL: "u", State: tt.state})
+			if got.Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", got.Status, tt.wantStatus)
+			}
+			if got.PRNumber != 3 || got.PRURL != "u" || got.PRState != tt.state {
+				t.Errorf("pull request fields not recorded: %+v", got)
+			}
+			if (got.MergedAt != nil) != (tt.state == PRStateMerged) {
+				t.Errorf("MergedAt = %v for state %q", got.MergedAt, tt.state)
+			}
+		})
+	}
+}
+
+func TestOpenStagingPullRequest_RecordsFailure(t *testing.T) {
+	repo := setupDivergedRepo(t)
+	mustGit(t, repo, "branch", "dag/pr/stage-L0", "spec-a")
+
+	dagCfg := &DAGConfig{
+		DAG:    DAGMetadata{ID: "pr"},
+		Layers: []Layer{{ID: "L0", Features: []Feature{{ID: "spec-a"}, {ID: "spec-b"}}}},
+	}
+	run := &DAGRun{
+		DAGId: "pr",
+		Specs: map[string]*SpecState{
+			"spec-a": {
+				SpecID: "spec-a", Status: SpecStatusCompleted,
+				Merge: &MergeState{Status: MergeStatusPROpen, PRNumber: 5, PRURL: "u", PRState: PRStateOpen},
+			},
+			"spec-b": {SpecID: "spec-b", Status: SpecStatusCompleted},
+		},
+	}
+	me := NewMergeExecutor(t.TempDir(), nil, repo,
+		WithMergeStdout(io.Discard),
+		WithMergePullRequests(nil, "missing"),
+	)
+
+	err := me.openStagingPullRequest(context.Background(), run, dagCfg, "dag/pr/stage-L0", "main")
+	if err == nil {
+		t.Fatal("openStagingPullRequest() succeeded with an unknown remote")
+	}
+
+	for _, specID := range []string{"spec-a", "spec-b"} {
+		merge := run.Specs[specID].Merge
+		if merge == nil || merge.Status != MergeStatusMergeFailed || !strings.Contains(merge.Error, "pushing dag/pr/stage-L0") {
+			t.Errorf("%s merge state = %+v, want merge_failed with push error", specID, merge)
+		}
+	}
+	if merge := run.Specs["spec-a"].Merge; merge.PRNumber != 5 || merge.PRURL != "u" {
+		t.Errorf("recorded pull request dropped: %+v", merge)
+	}
+}
+
+func TestPublishPullRequest_ReplacesClosed(t *testing.T) {
+	repo := setupDivergedRepo(t)
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	mustGit(t, repo, "init", "--bare", "-q", remote)
+	mustGit(t, repo, "remote", "add", "origin", remote)
+
+	standIn, srv := newPRStandIn(t, map[string]string{
+		"POST /repos/acme/app/pulls": `{"number":6,"html_url":"https://github.com/acme/app/pull/6","state":"open"}`,
+	})
+	provider, err := NewPRProvider(PRProviderConfig{Provider: PRProviderGitHub, APIURL: srv.URL, Repo: "acme/app", Token: "secret"})
+	if err != nil {
+		t.Fatalf("NewPRProvider() error: %v", err)
+	}
+	me := NewMergeExecutor(t.TempDir(), nil, repo,
+		WithMergeStdout(io.Discard),
+		WithMergePullRequests(provider, "origin"),
+	)
+
+	existing := &MergeState{Status: MergeStatusPRClosed, PRNumber: 5, PRState: PRStateClosed}
+	pr, err := me.publishPullRequest(context.Background(), existing, PullRequestInput{Title: "t", Head: "spec-a", Base: "main"})
+	if err != nil {
+		t.Fatalf("publishPullRequest() error: %v", err)
+	}
+
+	if pr.Number != 6 || pr.State != PRStateOpen {
+		t.Errorf("pull request = %+v, want new open #6", pr)
+	}
+	if last := standIn.lastRequest(); last.method != "POST" {
+		t.Errorf("closed pull request handled with %s, want POST", last.method)
+	}
+}
@@ -0,0 +1,238 @@
+package dag
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ariel-frischer/autospec/internal/worktree"
+)
+
+// MergeStrategy controls how spec branches are merged into staging branches
+// and how the result is merged into the target branch.
+type MergeStrategy string
+
+const (
+	// MergeStrategyNoFF creates a merge commit for every merge (default).
+	MergeStrategyNoFF MergeStrategy = "no-ff"
+	// MergeStrategySquash collapses each spec into a single commit with a
+	// generated conventional commit message.
+	MergeStrategySquash MergeStrategy = "squash"
+	// MergeStrategyRebase replays the source commits onto the target branch
+	// and fast-forwards it, keeping history linear.
+	MergeStrategyRebase MergeStrategy = "rebase"
+)
+
+// ValidMergeStrategies lists the accepted merge strategies.
+var ValidMergeStrategies = []MergeStrategy{MergeStrategyNoFF, MergeStrategySquash, MergeStrategyRebase}
+
+// IsValidMergeStrategy returns true if s is a known merge strategy.
+func IsValidMergeStrategy(s string) bool {
+	for _, valid := range ValidMergeStrategies {
+		if MergeStrategy(s) == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// squashSubjectLimit is the maximum length of a generated squash commit subject.
+const squashSubjectLimit = 72
+
+// mergeWithStrategy merges sourceBranch into targetBranch, which must be
+// checked out in repoRoot. message is the merge commit message for no-ff and
+// the commit message for squash; rebase keeps the source commits as they are.
+// Returns the conflicting files when the merge conflicts.
+//
+// A conflicted no-ff or squash merge is left in progress for resolution with
+// git commit. A conflicted rebase is aborted and targetBranch checked out
+// again, since resolving it requires rebasing the source branch itself.
+func mergeWithStrategy(
+	ctx context.Context,
+	repoRoot string,
+	strategy MergeStrategy,
+	targetBranch, sourceBranch, message string,
+) ([]string, error) {
+	switch strategy {
+	case MergeStrategySquash:
+		return squashMerge(ctx, repoRoot, sourceBranch, message)
+	case MergeStrategyRebase:
+		return rebaseFastForward(ctx, repoRoot, targetBranch, sourceBranch)
+	default:
+		return runMergeCommand(ctx, repoRoot, "merge", "--no-ff", "-m", message, sourceBranch)
+	}
+}
+
+// squashMerge stages the changes of sourceBranch and commits them as one
+// commit. Nothing is committed when the changes are already present, and
+// the leftover SQUASH_MSG is removed so the merge does not read as pending.
+func squashMerge(ctx context.Context, repoRoot, sourceBranch, message string) ([]string, error) {
+	if conflicts, err := runMergeCommand(ctx, repoRoot, "merge", "--squash", sourceBranch); err != nil {
+		return conflicts, err
+	}
+
+	diff := exec.CommandContext(ctx, "git", "diff", "--cached", "--quiet")
+	diff.Dir = repoRoot
+	if diff.Run() == nil {
+		if path, err := gitPath(repoRoot, "SQUASH_MSG"); err == nil {
+			_ = os.Remove(path)
+		}
+		return nil, nil
+	}
+
+	if _, err := runGitOutput(ctx, repoRoot, "commit", "-m", message); err != nil {
+		return nil, fmt.Errorf("committing squash merge: %w", err)
+	}
+	return nil, nil
+}
+
+// rebaseFastForward rebases the commits of sourceBranch onto targetBranch
+// on a detached HEAD, fast-forwards targetBranch to the result, and moves
+// sourceBranch to the rebased head so it reads as merged into targetBranch.
+func rebaseFastForward(ctx context.Context, repoRoot, targetBranch, sourceBranch string) ([]string, error) {
+	if _, err := runGitOutput(ctx, repoRoot, "checkout", "--detach", sourceBranch); err != nil {
+		return nil, fmt.Errorf("checking out %s: %w", sourceBranch, err)
+	}
+
+	if _, err := runGitOutput(ctx, repoRoot, "rebase", targetBranch); err != nil {
+		conflicts := DetectConflictedFiles(repoRoot)
+		_, _ = runGitOutput(ctx, repoRoot, "rebase", "--abort")
+		_, _ = runGitOutput(ctx, repoRoot, "checkout", targetBranch)
+		if len(conflicts) > 0 {
+			return conflicts, fmt.Errorf("rebase conflict in %d file(s)", len(conflicts))
+		}
+		return nil, fmt.Errorf("rebasing %s onto %s: %w", sourceBranch, targetBranch, err)
+	}
+
+	head, err := runGitOutput(ctx, repoRoot, "rev-parse", "HEAD")
+	if err != nil {
+		_, _ = runGitOutput(ctx, repoRoot, "checkout", targetBranch)
+		return nil, fmt.Errorf("resolving rebased head: %w", err)
+	}
+	if _, err := runGitOutput(ctx, repoRoot, "checkout", targetBranch); err != nil {
+		return nil, fmt.Errorf("checking out %s: %w", targetBranch, err)
+	}
+	if _, err := runGitOutput(ctx, repoRoot, "merge", "--ff-only", head); err != nil {
+		return nil, fmt.Errorf("fast-forwarding %s: %w", targetBranch, err)
+	}
+	if err := moveBranch(ctx, repoRoot, sourceBranch, head); err != nil {
+		return nil, fmt.Errorf("%s fast-forwarded but %w", targetBranch, err)
+	}
+	return nil, nil
+}
+
+// moveBranch points branch at commit. A branch checked out in a worktree is
+// moved with reset --keep there, which keeps uncommitted changes that do not
+// conflict; otherwise the ref is updated with git branch -f.
+func moveBranch(ctx context.Context, repoRoot, branch, commit string) error {
+	entries, err := worktree.GitWorktreeList(repoRoot)
+	if err != nil {
+		return fmt.Errorf("moving %s: %w", branch, err)
+	}
+	for _, entry := range entries {
+		if entry.Branch == branch {
+			if _, err := runGitOutput(ctx, entry.Path, "reset", "--keep", commit); err != nil {
+				return fmt.Errorf("moving %s in worktree %s: %w", branch, entry.Path, err)
+			}
+			return nil
+		}
+	}
+	if _, err := runGitOutput(ctx, repoRoot, "branch", "-f", branch, commit); err != nil {
+		return fmt.Errorf("moving %s: %w", branch, err)
+	}
+	return nil
+}
+
+// runMergeCommand runs a git merge command and reports conflicting files.
+func runMergeCommand(ctx context.Context, repoRoot string, args ...string) ([]string, error) {
+	if _, err := runGitOutput(ctx, repoRoot, args...); err != nil {
+		conflicts := DetectConflictedFiles(repoRoot)
+		if len(conflicts) > 0 {
+			return conflicts, fmt.Errorf("merge conflict in %d file(s)", len(conflicts))
+		}
+		return nil, err
+	}
+	return nil, nil
+}
+
+// runGitOutput runs a git command in repoRoot and returns its trimmed output.
+func runGitOutput(ctx context.Context, repoRoot string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = repoRoot
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %s: %w", args[0], strings.TrimSpace(string(output)), err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// squashCommitMessage builds the conventional commit message for a squashed
+// spec: a "feat(<spec-id>): <summary>" subject from the feature description,
+// followed by the subjects of the squashed commits on sourceBranch.
+func squashCommitMessage(ctx context.Context, repoRoot, targetBranch, sourceBranch, specID, description string) string {
+	var sb strings.Builder
+	sb.WriteString(squashSubject(specID, description))
+
+	log, err := runGitOutput(ctx, repoRoot, "log", "--reverse", "--format=%s", targetBranch+".."+sourceBranch)
+	if err == nil && log != "" {
+		fmt.Fprintf(&sb, "\n\nSquashed commits from %s:\n", sourceBranch)
+		for _, subject := range strings.Split(log, "\n") {
+			fmt.Fprintf(&sb, "- %s\n", subject)
+		}
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// squashSubject returns the conventional commit subject for a spec. The
+// summary is the first line of the description, lowercased at the start and
+// truncated to keep the subject within squashSubjectLimit.
+func squashSubject(specID, description string) string {
+	prefix := fmt.Sprintf("feat(%s): ", specID)
+
+	summary, _, _ := strings.Cut(strings.TrimSpace(description), "\n")
+	summary = strings.TrimRight(strings.TrimSpace(summary), ".")
+	if summary == "" {
+		summary = "implement " + specID
+	}
+	summary = lowerFirst(summary)
+
+	if limit := squashSubjectLimit - len(prefix); len(summary) > limit && limit > 3 {
+		cut := strings.LastIndex(summary[:limit-3], " ")
+		if cut <= 0 {
+			cut = limit - 3
+		}
+		summary = strings.TrimRight(strings.ToValidUTF8(summary[:cut], ""), " ,;:") + "..."
+	}
+	return prefix + summary
+}
+
+// lowerFirst lowercases the first letter of s unless the first word is an
+// acronym such as "JWT".
+func lowerFirst(s string) string {
+	first, size := utf8.DecodeRuneInString(s)
+	next, _ := utf8.DecodeRuneInString(s[size:])
+	if unicode.IsUpper(next) {
+		return s
+	}
+	return string(unicode.ToLower(first)) + s[size:]
+}
+
+// featureDescription returns the description of a feature in the DAG, or an
+// empty string if the feature is not found.
+func featureDescription(cfg *DAGConfig, specID string) string {
+	if cfg == nil {
+		return ""
+	}
+	for _, layer := range cfg.Layers {
+		for _, feature := range layer.Features {
+			if feature.ID == specID {
+				return feature.Description
+			}
+		}
+	}
+	return ""
+}
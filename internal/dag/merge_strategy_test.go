@@ -0,0 +1,310 @@
+package dag
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsValidMergeStrategy(t *testing.T) {
+	tests := map[string]struct {
+		strategy string
+		want     bool
+	}{
+		"no-ff":   {strategy: "no-ff", want: true},
+		"squash":  {strategy: "squash", want: true},
+		"rebase":  {strategy: "rebase", want: true},
+		"empty":   {strategy: "", want: false},
+		"unknown": {strategy: "octopus", want: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := IsValidMergeStrategy(tt.strategy); got != tt.want {
+				t.Errorf("IsValidMergeStrategy(%q) = %v, want %v", tt.strategy, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSquashSubject(t *testing.T) {
+	tests := map[string]struct {
+		specID      string
+		description string
+		want        string
+	}{
+		"description first line": {
+			specID:      "050-auth",
+			description: "Implement login endpoints.\nWith refresh tokens.",
+			want:        "feat(050-auth): implement login endpoints",
+		},
+		"acronym kept": {
+			specID:      "050-auth",
+			description: "JWT middleware",
+			want:        "feat(050-auth): JWT middleware",
+		},
+		"empty description": {
+			specID: "051-users",
+			want:   "feat(051-users): implement 051-users",
+		},
+		"long description truncated at word": {
+			specID:      "052-profile",
+			description: "Add user profile endpoints for viewing, updating, avatar upload, and account deletion",
+			want:        "feat(052-profile): add user profile endpoints for viewing, updating...",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := squashSubject(tt.specID, tt.description)
+			if got != tt.want {
+				t.Errorf("squashSubject() = %q, want %q", got, tt.want)
+			}
+			if len(got) > squashSubjectLimit {
+				t.Errorf("subject length %d exceeds %d", len(got), squashSubjectLimit)
+			}
+		})
+	}
+}
+
+func TestMergeWithStrategy(t *testing.T) {
+	tests := map[string]struct {
+		strategy    MergeStrategy
+		wantParents int
+		wantCommits string // commits on main after the initial commit
+		wantSubject string
+	}{
+		"no-ff creates merge commit": {
+			strategy:    MergeStrategyNoFF,
+			wantParents: 2,
+			wantCommits: "4",
+			wantSubject: "Merge spec-a",
+		},
+		"squash creates one commit": {
+			strategy:    MergeStrategySquash,
+			wantParents: 1,
+			wantCommits: "2",
+			wantSubject: "feat(spec-a): add feature a",
+		},
+		"rebase keeps linear history": {
+			strategy:    MergeStrategyRebase,
+			wantParents: 1,
+			wantCommits: "3",
+			wantSubject: "a: second",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			repo := setupDivergedRepo(t)
+			ctx := context.Background()
+
+			msg := "Merge spec-a"
+			if tt.strategy == MergeStrategySquash {
+				msg = squashCommitMessage(ctx, repo, "main", "spec-a", "spec-a", "Add feature a")
+			}
+			conflicts, err := mergeWithStrategy(ctx, repo, tt.strategy, "main", "spec-a", msg)
+			if err != nil {
+				t.Fatalf("mergeWithStrategy() error: %v (conflicts %v)", err, conflicts)
+			}
+
+			if branch := gitOutput(t, repo, "rev-parse", "--abbrev-ref", "HEAD"); branch != "main" {
+				t.Errorf("HEAD = %q, want main", branch)
+			}
+			parents := strings.Fields(gitOutput(t, repo, "log", "-1", "--format=%P"))
+			if len(parents) != tt.wantParents {
+				t.Errorf("HEAD has %d parent(s), want %d", len(parents), tt.wantParents)
+			}
+			count := gitOutput(t, repo, "rev-list", "--count", "main", "^main-base")
+			if count != tt.wantCommits {
+				t.Errorf("main has %s new commit(s), want %s", count, tt.wantCommits)
+			}
+			if subject := gitOutput(t, repo, "log", "-1", "--format=%s"); subject != tt.wantSubject {
+				t.Errorf("HEAD subject = %q, want %q", subject, tt.wantSubject)
+			}
+			for _, file := range []string{"a.txt", "main.txt"} {
+				if out := gitOutput(t, repo, "ls-files", file); out != file {
+					t.Errorf("%s missing after merge", file)
+				}
+			}
+		})
+	}
+}
+
+func TestMergeWithStrategy_SquashBody(t *testing.T) {
+	repo := setupDivergedRepo(t)
+	ctx := context.Background()
+
+	msg := squashCommitMessage(ctx, repo, "main", "spec-a", "spec-a", "Add feature a")
+	want := "feat(spec-a): add feature a\n\nSquashed commits from spec-a:\n- a: first\n- a: second"
+	if msg != want {
+		t.Errorf("squashCommitMessage() =\n%s\nwant\n%s", msg, want)
+	}
+}
+
+func TestMergeWithStrategy_RebaseMovesSourceBranch(t *testing.T) {
+	tests := map[string]struct {
+		inWorktree bool
+	}{
+		"branch not checked out":         {},
+		"branch checked out in worktree": {inWorktree: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			repo := setupDivergedRepo(t)
+			wt := filepath.Join(t.TempDir(), "wt")
+			if tt.inWorktree {
+				mustGit(t, repo, "worktree", "add", wt, "spec-a")
+			}
+
+			if _, err := mergeWithStrategy(context.Background(), repo, MergeStrategyRebase, "main", "spec-a", ""); err != nil {
+				t.Fatalf("mergeWithStrategy() error: %v", err)
+			}
+
+			main := gitOutput(t, repo, "rev-parse", "main")
+			if after := gitOutput(t, repo, "rev-parse", "spec-a"); after != main {
+				t.Errorf("spec-a = %s, want rebased head %s", after, main)
+			}
+			if !isAncestor(repo, "spec-a", "main") {
+				t.Error("spec-a does not read as merged into main")
+			}
+			if tt.inWorktree {
+				if status := gitOutput(t, wt, "status", "--porcelain"); status != "" {
+					t.Errorf("worktree not clean after move:\n%s", status)
+				}
+			}
+		})
+	}
+}
+
+func TestMergeWithStrategy_Conflicts(t *testing.T) {
+	tests := map[string]struct {
+		strategy       MergeStrategy
+		wantInProgress bool
+	}{
+		"no-ff leaves merge for resolution":  {strategy: MergeStrategyNoFF, wantInProgress: true},
+		"squash leaves merge for resolution": {strategy: MergeStrategySquash, wantInProgress: true},
+		"rebase is aborted":                  {strategy: MergeStrategyRebase},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			repo := setupDivergedRepo(t)
+			mustGit(t, repo, "checkout", "spec-a")
+			createFile(t, repo, "shared.txt", "spec-a")
+			mustGit(t, repo, "add", ".")
+			mustGit(t, repo, "commit", "-m", "a: shared")
+			mustGit(t, repo, "checkout", "main")
+			createFile(t, repo, "shared.txt", "main")
+			mustGit(t, repo, "add", ".")
+			mustGit(t, repo, "commit", "-m", "main: shared")
+			head := gitOutput(t, repo, "rev-parse", "main")
+
+			conflicts, err := mergeWithStrategy(context.Background(), repo, tt.strategy, "main", "spec-a", "msg")
+			if err == nil {
+				t.Fatal("expected conflict error")
+			}
+			if len(conflicts) != 1 || conflicts[0] != "shared.txt" {
+				t.Errorf("conflicts = %v, want [shared.txt]", conflicts)
+			}
+
+			inProgress := len(DetectConflictedFiles(repo)) > 0
+			if inProgress != tt.wantInProgress {
+				t.Errorf("conflicts in progress = %v, want %v", inProgress, tt.wantInProgress)
+			}
+			if !tt.wantInProgress {
+				if branch := gitOutput(t, repo, "rev-parse", "--abbrev-ref", "HEAD"); branch != "main" {
+					t.Errorf("HEAD = %q, want main", branch)
+				}
+				if got := gitOutput(t, repo, "rev-parse", "main"); got != head {
+					t.Error("main moved after aborted rebase")
+				}
+			}
+		})
+	}
+}
+
+func TestMergeIntoStaging_Squash(t *testing.T) {
+	repo := setupDivergedRepo(t)
+	mustGit(t, repo, "branch", "dag/d/stage-L0", "main")
+
+	if err := mergeIntoStaging(repo, "dag/d/stage-L0", "spec-a", "spec-a", MergeStrategySquash, "Add feature a"); err != nil {
+		t.Fatalf("mergeIntoStaging() error: %v", err)
+	}
+
+	subject := gitOutput(t, repo, "log", "-1", "--format=%s", "dag/d/stage-L0")
+	if subject != "feat(spec-a): add feature a" {
+		t.Errorf("staging HEAD subject = %q", subject)
+	}
+
+	// Merging again is a no-op rather than an empty commit
+	if err := mergeIntoStaging(repo, "dag/d/stage-L0", "spec-a", "spec-a", MergeStrategySquash, "Add feature a"); err != nil {
+		t.Fatalf("second mergeIntoStaging() error: %v", err)
+	}
+	if count := gitOutput(t, repo, "rev-list", "--count", "dag/d/stage-L0", "^main"); count != "1" {
+		t.Errorf("staging has %s commit(s) ahead of main, want 1", count)
+	}
+}
+
+// TestExecuteStagingMerge_SquashRebases checks that the final staging merge
+// under squash rebases, and reports the strategy it actually uses.
+func TestExecuteStagingMerge_SquashRebases(t *testing.T) {
+	repo := setupDivergedRepo(t)
+	var stdout strings.Builder
+	me := NewMergeExecutor("", nil, repo, WithMergeStdout(&stdout), WithMergeStrategy(MergeStrategySquash))
+	run := &DAGRun{DAGId: "final"}
+
+	if err := me.executeStagingMerge(context.Background(), run, &DAGConfig{}, "spec-a", "main"); err != nil {
+		t.Fatalf("executeStagingMerge() error: %v", err)
+	}
+
+	if !strings.Contains(stdout.String(), "Merging spec-a into main (rebase)...") {
+		t.Errorf("output = %q, want the rebase strategy reported", stdout.String())
+	}
+	if count := gitOutput(t, repo, "rev-list", "--count", "main", "^main-base"); count != "3" {
+		t.Errorf("main has %s new commit(s), want 3", count)
+	}
+}
+
+// setupDivergedRepo creates a repository where main and spec-a diverged from
+// main-base: spec-a has two commits and main has one.
+func setupDivergedRepo(t *testing.T) string {
+	t.Helper()
+	repo, cleanup := setupTestRepo(t)
+	t.Cleanup(cleanup)
+
+	createFile(t, repo, "README.md", "base")
+	mustGit(t, repo, "add", ".")
+	mustGit(t, repo, "commit", "-m", "initial")
+	mustGit(t, repo, "branch", "-M", "main")
+	mustGit(t, repo, "branch", "main-base")
+
+	mustGit(t, repo, "checkout", "-b", "spec-a")
+	createFile(t, repo, "a.txt", "one")
+	mustGit(t, repo, "add", ".")
+	mustGit(t, repo, "commit", "-m", "a: first")
+	createFile(t, repo, "a.txt", "two")
+	mustGit(t, repo, "add", ".")
+	mustGit(t, repo, "commit", "-m", "a: second")
+
+	mustGit(t, repo, "checkout", "main")
+	createFile(t, repo, "main.txt", "main")
+	mustGit(t, repo, "add", ".")
+	mustGit(t, repo, "commit", "-m", "main: change")
+	return repo
+}
+
+// gitOutput runs a git command in dir and returns its trimmed output.
+func gitOutput(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git %v: %v", args, err)
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,237 @@
+package dag
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"gopkg.in/yaml.v3"
+)
+
+// FileOverlap is a predicted merge conflict: two specs that can run
+// concurrently are expected to touch the same files.
+type FileOverlap struct {
+	// SpecA is the spec declared first in the DAG.
+	SpecA string
+	// SpecB is the spec declared later in the DAG.
+	SpecB string
+	// Files are the shared file paths, sorted.
+	Files []string
+}
+
+// Suggestion returns the depends_on edge that serialises the two specs.
+// The edge points from the later spec to the earlier one, so it never
+// introduces a cycle with the DAG's declaration order.
+func (o FileOverlap) Suggestion() string {
+	return fmt.Sprintf("add %q to depends_on of %q, or move %q to a later layer", o.SpecA, o.SpecB, o.SpecB)
+}
+
+// OverlapReport contains the predicted file overlaps for a DAG.
+type OverlapReport struct {
+	// Overlaps are the concurrently schedulable spec pairs sharing files.
+	Overlaps []FileOverlap
+	// Unanalyzed are specs with neither tasks.yaml nor plan.yaml, whose
+	// files cannot be predicted until they are planned.
+	Unanalyzed []string
+}
+
+// HasOverlaps returns true if any overlap was predicted.
+func (r *OverlapReport) HasOverlaps() bool {
+	return len(r.Overlaps) > 0
+}
+
+// PredictFileOverlaps predicts which specs that can run at the same time
+// will touch the same files. Two specs can run concurrently unless one is a
+// direct or transitive depends_on of the other, matching the parallel ready
+// queue. Each spec's files come from task file_path entries in tasks.yaml and
+// from project_structure paths and path-like deliverables in plan.yaml.
+//
+// Artifacts are read from specsDir, falling back to the spec's worktree from
+// the inline state. Completed specs are skipped since they no longer run.
+func PredictFileOverlaps(cfg *DAGConfig, specsDir string) *OverlapReport {
+	report := &OverlapReport{}
+	ancestors := featureAncestors(cfg)
+
+	var order []string
+	files := make(map[string]map[string]bool)
+	for _, layer := range cfg.Layers {
+		for _, feature := range layer.Features {
+			if specStatus(cfg, feature.ID) == InlineSpecStatusCompleted {
+				continue
+			}
+			specFiles, found := collectSpecFiles(specArtifactDir(cfg, specsDir, feature.ID))
+			if !found {
+				report.Unanalyzed = append(report.Unanalyzed, feature.ID)
+				continue
+			}
+			order = append(order, feature.ID)
+			files[feature.ID] = specFiles
+		}
+	}
+
+	for i, a := range order {
+		for _, b := range order[i+1:] {
+			if ancestors[a][b] || ancestors[b][a] {
+				continue
+			}
+			if shared := sharedFiles(files[a], files[b]); len(shared) > 0 {
+				report.Overlaps = append(report.Overlaps, FileOverlap{SpecA: a, SpecB: b, Files: shared})
+			}
+		}
+	}
+	return report
+}
+
+// featureAncestors returns, for each feature, the set of features it depends
+// on directly or transitively.
+func featureAncestors(cfg *DAGConfig) map[string]map[string]bool {
+	deps := buildFeatureDependencyMap(cfg)
+	ancestors := make(map[string]map[string]bool, len(deps))
+
+	var visit func(id string, seen map[string]bool) map[string]bool
+	visit = func(id string, seen map[string]bool) map[string]bool {
+		if set, ok := ancestors[id]; ok {
+			return set
+		}
+		set := make(map[string]bool)
+		seen[id] = true
+		for _, dep := range deps[id] {
+			set[dep] = true
+			if seen[dep] {
+				continue // cycle; reported by ValidateDAG
+			}
+			for anc := range visit(dep, seen) {
+				set[anc] = true
+			}
+		}
+		ancestors[id] = set
+		return set
+	}
+	for id := range deps {
+		visit(id, make(map[string]bool))
+	}
+	return ancestors
+}
+
+// specArtifactDir returns the directory holding a spec's artifacts: the
+// spec folder in specsDir, or the same folder in the spec's worktree when
+// the spec has only been planned there.
+func specArtifactDir(cfg *DAGConfig, specsDir, specID string) string {
+	dir := filepath.Join(specsDir, specID)
+	if dirExists(dir) || filepath.IsAbs(specsDir) {
+		return dir
+	}
+	if state := cfg.Specs[specID]; state != nil && state.Worktree != "" {
+		return filepath.Join(state.Worktree, specsDir, specID)
+	}
+	return dir
+}
+
+// collectSpecFiles returns the normalized file paths a spec is expected to
+// touch. found is false when the spec has neither tasks.yaml nor plan.yaml.
+func collectSpecFiles(specDir string) (files map[string]bool, found bool) {
+	files = make(map[string]bool)
+
+	tasksPath := filepath.Join(specDir, "tasks.yaml")
+	if tasks, err := validation.GetAllTasks(tasksPath); err == nil {
+		found = true
+		for _, task := range tasks {
+			for _, p := range strings.Split(task.FilePath, ",") {
+				addFilePath(files, p)
+			}
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(specDir, "plan.yaml")); err == nil {
+		found = true
+		var plan struct {
+			ProjectStructure     interface{} `yaml:"project_structure"`
+			ImplementationPhases []struct {
+				Deliverables []string `yaml:"deliverables"`
+			} `yaml:"implementation_phases"`
+		}
+		if yaml.Unmarshal(data, &plan) == nil {
+			collectStructurePaths(plan.ProjectStructure, files)
+			for _, phase := range plan.ImplementationPhases {
+				for _, deliverable := range phase.Deliverables {
+					for _, word := range strings.Fields(deliverable) {
+						if isPathLike(word) {
+							addFilePath(files, word)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return files, found
+}
+
+// collectStructurePaths adds every "path" value found in plan.yaml's
+// project_structure, whatever its grouping.
+func collectStructurePaths(node interface{}, files map[string]bool) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if p, ok := v["path"].(string); ok {
+			addFilePath(files, p)
+		}
+		for _, child := range v {
+			collectStructurePaths(child, files)
+		}
+	case []interface{}:
+		for _, child := range v {
+			collectStructurePaths(child, files)
+		}
+	}
+}
+
+// pathTrimChars are stripped from deliverable words before path detection.
+const pathTrimChars = "`'\"()[]{},;:"
+
+// isPathLike reports whether a deliverable word names a file: a relative
+// path with a directory and an extension, such as internal/auth/handler.go.
+// Absolute paths and URLs are usually API routes, not repository files.
+func isPathLike(word string) bool {
+	word = trimPathWord(word)
+	if !strings.Contains(word, "/") || strings.HasPrefix(word, "/") || strings.Contains(word, "://") {
+		return false
+	}
+	return path.Ext(word) != ""
+}
+
+// trimPathWord strips quoting and punctuation around a path, keeping a
+// leading "./" but not a sentence-ending period.
+func trimPathWord(word string) string {
+	return strings.TrimRight(strings.TrimLeft(word, pathTrimChars), pathTrimChars+".")
+}
+
+// addFilePath normalizes p and adds it to files. Directories (trailing
+// slash) and empty paths are ignored, since they are too coarse to predict
+// conflicts.
+func addFilePath(files map[string]bool, p string) {
+	p = trimPathWord(strings.TrimSpace(p))
+	if p == "" || strings.HasSuffix(p, "/") {
+		return
+	}
+	p = path.Clean(filepath.ToSlash(p))
+	if p == "." || strings.HasPrefix(p, "../") {
+		return
+	}
+	files[p] = true
+}
+
+// sharedFiles returns the sorted paths present in both sets.
+func sharedFiles(a, b map[string]bool) []string {
+	var shared []string
+	for p := range a {
+		if b[p] {
+			shared = append(shared, p)
+		}
+	}
+	sort.Strings(shared)
+	return shared
+}
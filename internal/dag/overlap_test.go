@@ -0,0 +1,138 @@
+package dag
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSpecArtifact writes an artifact file into specsDir/specID.
+func writeSpecArtifact(t *testing.T, specsDir, specID, name, content string) {
+	t.Helper()
+	dir := filepath.Join(specsDir, specID)
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+}
+
+// tasksWithFiles returns a tasks.yaml whose tasks have the given file paths.
+func tasksWithFiles(paths ...string) string {
+	content := "phases:\n  - number: 1\n    title: \"Core\"\n    tasks:\n"
+	for i, p := range paths {
+		content += "      - id: \"T00" + string(rune('1'+i)) + "\"\n        title: \"Task\"\n        status: \"Pending\"\n        file_path: \"" + p + "\"\n"
+	}
+	return content
+}
+
+const overlapPlanYAML = `project_structure:
+  source_code:
+    - path: "./internal/auth/handler.go"
+      description: "Handlers"
+  tests:
+    - path: "internal/auth/handler_test.go"
+implementation_phases:
+  - phase: 1
+    name: "Core"
+    deliverables:
+      - "Router changes in ` + "`cmd/server/routes.go`" + `."
+      - "Login endpoint at /api/v1/login and/or refresh"
+`
+
+func TestCollectSpecFiles(t *testing.T) {
+	specsDir := t.TempDir()
+	writeSpecArtifact(t, specsDir, "001-auth", "tasks.yaml", tasksWithFiles("internal/auth/service.go", "docs/, internal/auth/store.go"))
+	writeSpecArtifact(t, specsDir, "001-auth", "plan.yaml", overlapPlanYAML)
+
+	files, found := collectSpecFiles(filepath.Join(specsDir, "001-auth"))
+	require.True(t, found)
+	assert.Equal(t, map[string]bool{
+		"internal/auth/service.go":      true,
+		"internal/auth/store.go":        true,
+		"internal/auth/handler.go":      true,
+		"internal/auth/handler_test.go": true,
+		"cmd/server/routes.go":          true,
+	}, files)
+
+	_, found = collectSpecFiles(filepath.Join(specsDir, "002-missing"))
+	assert.False(t, found)
+}
+
+func TestIsPathLike(t *testing.T) {
+	tests := map[string]bool{
+		"internal/auth/handler.go":   true,
+		"`cmd/server/routes.go`.":    true,
+		"(web/src/App.tsx),":         true,
+		"/api/v1/login":              false,
+		"https://example.com/x.html": false,
+		"and/or":                     false,
+		"handler.go":                 false,
+		"internal/auth/":             false,
+	}
+
+	for word, want := range tests {
+		t.Run(word, func(t *testing.T) {
+			assert.Equal(t, want, isPathLike(word))
+		})
+	}
+}
+
+func TestPredictFileOverlaps(t *testing.T) {
+	specsDir := t.TempDir()
+	writeSpecArtifact(t, specsDir, "001-auth", "tasks.yaml", tasksWithFiles("internal/auth/service.go", "internal/router.go"))
+	writeSpecArtifact(t, specsDir, "002-billing", "tasks.yaml", tasksWithFiles("internal/billing/service.go", "internal/router.go"))
+	writeSpecArtifact(t, specsDir, "003-profile", "tasks.yaml", tasksWithFiles("internal/auth/service.go"))
+	writeSpecArtifact(t, specsDir, "004-admin", "plan.yaml", "project_structure:\n  source_code:\n    - path: internal/router.go\n")
+
+	cfg := &DAGConfig{
+		Layers: []Layer{
+			{ID: "L0", Features: []Feature{{ID: "001-auth"}, {ID: "002-billing"}}},
+			{ID: "L1", Features: []Feature{
+				{ID: "003-profile", DependsOn: []string{"001-auth"}},
+				{ID: "004-admin", DependsOn: []string{"003-profile"}},
+				{ID: "005-reports"},
+			}},
+		},
+	}
+
+	t.Run("concurrent specs with shared files", func(t *testing.T) {
+		report := PredictFileOverlaps(cfg, specsDir)
+
+		// 001-auth and 004-admin share internal/router.go but 004 depends on
+		// 001 transitively, and 003-profile depends on 001-auth directly.
+		assert.Equal(t, []FileOverlap{
+			{SpecA: "001-auth", SpecB: "002-billing", Files: []string{"internal/router.go"}},
+			{SpecA: "002-billing", SpecB: "004-admin", Files: []string{"internal/router.go"}},
+		}, report.Overlaps)
+		assert.Equal(t, []string{"005-reports"}, report.Unanalyzed)
+		assert.True(t, report.HasOverlaps())
+		assert.Equal(t, `add "001-auth" to depends_on of "002-billing", or move "002-billing" to a later layer`,
+			report.Overlaps[0].Suggestion())
+	})
+
+	t.Run("completed specs are skipped", func(t *testing.T) {
+		withState := *cfg
+		withState.Specs = map[string]*InlineSpecState{
+			"002-billing": {Status: InlineSpecStatusCompleted},
+		}
+		report := PredictFileOverlaps(&withState, specsDir)
+		assert.False(t, report.HasOverlaps())
+	})
+
+	t.Run("artifacts read from worktree", func(t *testing.T) {
+		// A relative specs dir that does not exist in the working directory,
+		// as for a spec that so far has only been planned in its worktree.
+		const relSpecsDir = "testdata-missing-specs"
+		worktree := t.TempDir()
+		writeSpecArtifact(t, filepath.Join(worktree, relSpecsDir), "005-reports", "tasks.yaml", tasksWithFiles("internal/billing/service.go"))
+
+		withState := *cfg
+		withState.Specs = map[string]*InlineSpecState{
+			"005-reports": {Status: InlineSpecStatusFailed, Worktree: worktree},
+		}
+
+		report := PredictFileOverlaps(&withState, relSpecsDir)
+		assert.Equal(t, []string{"001-auth", "002-billing", "003-profile", "004-admin"}, report.Unanalyzed)
+	})
+}
@@ -4,14 +4,44 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/ariel-frischer/autospec/internal/worktree"
 	"golang.org/x/sync/errgroup"
 )
 
+// SchedulePriority controls which ready specs start first when more specs are
+// ready than there are free parallel slots.
+type SchedulePriority string
+
+const (
+	// PriorityDAGOrder starts ready specs in DAG declaration order (default).
+	PriorityDAGOrder SchedulePriority = "dag-order"
+	// PriorityCriticalPath starts ready specs with the longest chain of
+	// dependent specs first, so the longest path through the DAG starts early.
+	PriorityCriticalPath SchedulePriority = "critical-path"
+)
+
+// ValidSchedulePriorities lists all valid schedule priority values.
+var ValidSchedulePriorities = []SchedulePriority{PriorityDAGOrder, PriorityCriticalPath}
+
+// IsValidSchedulePriority returns true if p is a known schedule priority.
+func IsValidSchedulePriority(p string) bool {
+	for _, v := range ValidSchedulePriorities {
+		if string(v) == p {
+			return true
+		}
+	}
+	return false
+}
+
 // ParallelExecutor orchestrates concurrent execution of specs in a DAG.
 // It extends the sequential Executor with concurrency control using errgroup.
+// Specs are scheduled from a ready queue driven by feature-level depends_on:
+// a spec starts as soon as its dependencies complete, regardless of layer.
+// Layers remain staging boundaries for worktree start points and merges.
 type ParallelExecutor struct {
 	// executor is the underlying sequential executor providing core functionality.
 	executor *Executor
@@ -25,6 +55,11 @@ type ParallelExecutor struct {
 	progress *ProgressTracker
 	// stdout is the output writer for progress messages.
 	stdout io.Writer
+	// priority orders ready specs when more are ready than can start.
+	priority SchedulePriority
+	// mergedLayers tracks layers whose completion (batch merge) has run.
+	// Only accessed from the scheduling loop goroutine.
+	mergedLayers map[string]bool
 	// mu protects runningSpecs map access.
 	mu sync.Mutex
 }
@@ -55,6 +90,16 @@ func WithParallelStdout(stdout io.Writer) ParallelExecutorOption {
 	}
 }
 
+// WithParallelPriority sets the order in which ready specs start.
+// Unknown values are ignored and the default DAG order is kept.
+func WithParallelPriority(priority SchedulePriority) ParallelExecutorOption {
+	return func(pe *ParallelExecutor) {
+		if IsValidSchedulePriority(string(priority)) {
+			pe.priority = priority
+		}
+	}
+}
+
 // NewParallelExecutor creates a new ParallelExecutor wrapping the given Executor.
 // Default maxParallel is 4 if not specified via options.
 func NewParallelExecutor(executor *Executor, opts ...ParallelExecutorOption) *ParallelExecutor {
@@ -63,6 +108,8 @@ func NewParallelExecutor(executor *Executor, opts ...ParallelExecutorOption) *Pa
 		maxParallel:  4, // Default as per FR-003
 		failFast:     false,
 		runningSpecs: make(map[string]struct{}),
+		priority:     PriorityDAGOrder,
+		mergedLayers: make(map[string]bool),
 	}
 
 	for _, opt := range opts {
@@ -73,10 +120,34 @@ func NewParallelExecutor(executor *Executor, opts ...ParallelExecutorOption) *Pa
 }
 
 // Execute runs the DAG workflow with parallel spec execution.
+// The executor handles state setup and locking; specs are then scheduled
+// from the ready queue (see ExecuteWithDependencies).
 // Returns the run ID and any error encountered.
 func (pe *ParallelExecutor) Execute(ctx context.Context) (string, error) {
-	// Delegate to executor for setup, then override execution
-	return pe.executor.Execute(ctx)
+	return pe.executor.run(ctx, pe.maxParallel, pe.executeReadyQueue)
+}
+
+// executeReadyQueue runs the ready-queue scheduler and persists the final
+// run status to dag.yaml.
+func (pe *ParallelExecutor) executeReadyQueue(ctx context.Context) error {
+	err := pe.ExecuteWithDependencies(ctx)
+
+	state := pe.executor.State()
+	if err != nil && state.Status == RunStatusRunning {
+		state.Status = RunStatusFailed
+	}
+	now := time.Now()
+	state.CompletedAt = &now
+	if saveErr := pe.executor.saveInlineState(); saveErr != nil && err == nil {
+		return fmt.Errorf("saving final state: %w", saveErr)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(pe.executor.stdout, "\n=== DAG Run Complete ===\n")
+	fmt.Fprintf(pe.executor.stdout, "DAG: %s\n", pe.executor.dagFile)
+	return nil
 }
 
 // ExecuteParallel runs specs concurrently with the configured parallelism limit.
@@ -98,6 +169,8 @@ func (pe *ParallelExecutor) ExecuteParallel(ctx context.Context, specIDs []strin
 
 // ExecuteWithDependencies runs specs respecting their dependencies.
 // It continuously finds ready specs and executes them until all complete or fail.
+// Readiness depends only on each feature's depends_on, so a slow spec in one
+// layer does not hold back later-layer specs whose dependencies are done.
 // When failFast is false (default), spec failures don't stop other specs from running.
 // When failFast is true, first failure cancels all running specs.
 func (pe *ParallelExecutor) ExecuteWithDependencies(ctx context.Context) error {
@@ -105,6 +178,13 @@ func (pe *ParallelExecutor) ExecuteWithDependencies(ctx context.Context) error {
 	pe.initProgress(len(allSpecs))
 	pe.printInitialProgress()
 
+	// Layers overlap, so refresh staging branches before cutting worktrees
+	pe.executor.syncStaging = true
+
+	// Cancel running specs once a spec or run budget is reached
+	ctx, stopBudget := pe.executor.startBudgetMonitor(ctx)
+	defer stopBudget()
+
 	// Use errgroup with context for coordinated cancellation
 	g, gCtx := errgroup.WithContext(ctx)
 	g.SetLimit(pe.maxParallel)
@@ -145,24 +225,37 @@ func (pe *ParallelExecutor) runSchedulingLoop(
 	}
 
 	done := make(chan string, len(allSpecs))
+	inFlight := 0
 
 	for len(pendingSpecs) > 0 {
-		if err := pe.processReadySpecs(ctx, g, pendingSpecs, completedSpecs, failedSpecs, done, &completedMu); err != nil {
+		if err := pe.processReadySpecs(ctx, g, pendingSpecs, completedSpecs, failedSpecs, done, &completedMu, &inFlight); err != nil {
 			// Handle interruption gracefully
 			if ctx.Err() != nil {
 				pe.handleInterruption()
+				return pe.executor.interruptionError(ctx)
 			}
+			g.Wait() //nolint:errcheck // already returning the scheduling error
 			return err
 		}
 	}
 
 	// Wait for errgroup to complete
 	err := g.Wait()
+	if ctx.Err() != nil && pe.executor.BudgetExceeded() != nil {
+		pe.handleInterruption()
+		return pe.executor.budgetStopError()
+	}
 	if err != nil && ctx.Err() != nil {
 		pe.handleInterruption()
 		return err
 	}
 
+	// Complete layers whose last spec finished after the final launch
+	if err := pe.completeFinishedLayers(ctx, pendingSpecs, completedSpecs, failedSpecs, &completedMu); err != nil {
+		return err
+	}
+	pe.syncFinalStaging()
+
 	// Update final run status based on failures
 	pe.updateFinalRunStatus(failedSpecs, &completedMu)
 
@@ -174,15 +267,25 @@ func (pe *ParallelExecutor) runSchedulingLoop(
 }
 
 // processReadySpecs finds and launches ready specs, returning when one completes.
+// Pending specs are only marked blocked once nothing is in flight, since a
+// running spec may still unlock them.
 func (pe *ParallelExecutor) processReadySpecs(
 	ctx context.Context,
 	g *errgroup.Group,
 	pendingSpecs, completedSpecs, failedSpecs map[string]bool,
 	done chan string,
 	completedMu *sync.Mutex,
+	inFlight *int,
 ) error {
+	if err := pe.completeFinishedLayers(ctx, pendingSpecs, completedSpecs, failedSpecs, completedMu); err != nil {
+		return err
+	}
+
+	completedMu.Lock()
 	readySpecs := pe.findReadySpecs(pendingSpecs, completedSpecs, failedSpecs)
-	if len(readySpecs) == 0 && len(pendingSpecs) > 0 {
+	completedMu.Unlock()
+
+	if len(readySpecs) == 0 && *inFlight == 0 {
 		pe.markBlockedSpecs(pendingSpecs, failedSpecs)
 		// Clear pending to exit loop
 		for k := range pendingSpecs {
@@ -191,7 +294,15 @@ func (pe *ParallelExecutor) processReadySpecs(
 		return nil
 	}
 
-	pe.launchSpecs(ctx, g, readySpecs, pendingSpecs, completedSpecs, failedSpecs, done, completedMu)
+	if len(readySpecs) > 0 {
+		// Stop before launching more specs once a budget limit is reached
+		if pe.executor.enforceBudget() {
+			return ctx.Err()
+		}
+
+		pe.launchSpecs(ctx, g, readySpecs, pendingSpecs, completedSpecs, failedSpecs, done, completedMu)
+		*inFlight += len(readySpecs)
+	}
 
 	if len(pendingSpecs) > 0 {
 		select {
@@ -199,6 +310,7 @@ func (pe *ParallelExecutor) processReadySpecs(
 			return ctx.Err()
 		case <-done:
 			// A spec completed, loop to find newly ready specs
+			*inFlight--
 		}
 	}
 	return nil
@@ -297,19 +409,171 @@ func (pe *ParallelExecutor) getAllSpecIDs() []string {
 	return ids
 }
 
-// findReadySpecs returns spec IDs that are pending and have all dependencies satisfied.
+// findReadySpecs returns spec IDs that are pending and have all dependencies
+// satisfied, ordered by the configured priority. Ties keep DAG declaration order.
 func (pe *ParallelExecutor) findReadySpecs(
 	pending, completed, failed map[string]bool,
 ) []string {
 	var ready []string
-	for specID := range pending {
-		if pe.areDependenciesSatisfied(specID, completed, failed) {
+	for _, specID := range pe.getAllSpecIDs() {
+		if !pending[specID] {
+			continue
+		}
+		if pe.areDependenciesSatisfied(specID, completed, failed) && pe.isStartPointReady(specID) {
 			ready = append(ready, specID)
 		}
 	}
+
+	if pe.priority == PriorityCriticalPath {
+		lengths := pe.criticalPathLengths()
+		sort.SliceStable(ready, func(i, j int) bool {
+			return lengths[ready[i]] > lengths[ready[j]]
+		})
+	}
 	return ready
 }
 
+// criticalPathLengths returns, for each spec, the number of specs on the
+// longest dependency chain starting at that spec (1 for specs nothing depends on).
+func (pe *ParallelExecutor) criticalPathLengths() map[string]int {
+	dependents := make(map[string][]string)
+	for _, layer := range pe.executor.dag.Layers {
+		for _, feature := range layer.Features {
+			for _, dep := range feature.DependsOn {
+				dependents[dep] = append(dependents[dep], feature.ID)
+			}
+		}
+	}
+
+	lengths := make(map[string]int)
+	var visit func(specID string) int
+	visit = func(specID string) int {
+		if n, ok := lengths[specID]; ok {
+			return n
+		}
+		longest := 0
+		for _, dependent := range dependents[specID] {
+			if n := visit(dependent); n > longest {
+				longest = n
+			}
+		}
+		lengths[specID] = longest + 1
+		return longest + 1
+	}
+	for _, specID := range pe.getAllSpecIDs() {
+		visit(specID)
+	}
+	return lengths
+}
+
+// isStartPointReady reports whether the staging branch a spec would branch
+// from contains its dependencies. With automerge disabled, specs merge into
+// staging only when their layer completes, so a dependency in another layer
+// counts once that layer has been merged.
+func (pe *ParallelExecutor) isStartPointReady(specID string) bool {
+	e := pe.executor
+	if e.disableLayerStaging || e.config == nil || e.config.IsAutomergeEnabled() {
+		return true
+	}
+	feature := pe.findFeature(specID)
+	if feature == nil {
+		return false
+	}
+	layerID := pe.layerOf(specID)
+	for _, dep := range feature.DependsOn {
+		if depLayer := pe.layerOf(dep); depLayer != layerID && !pe.mergedLayers[depLayer] {
+			return false
+		}
+	}
+	return true
+}
+
+// layerOf returns the ID of the layer containing specID.
+func (pe *ParallelExecutor) layerOf(specID string) string {
+	for _, layer := range pe.executor.dag.Layers {
+		for _, feature := range layer.Features {
+			if feature.ID == specID {
+				return layer.ID
+			}
+		}
+	}
+	return ""
+}
+
+// completeFinishedLayers runs layer completion (batch merge when automerge is
+// disabled, plus the completion summary) for each layer whose specs have all
+// completed, failed, or can no longer run.
+func (pe *ParallelExecutor) completeFinishedLayers(
+	ctx context.Context,
+	pending, completed, failed map[string]bool,
+	completedMu *sync.Mutex,
+) error {
+	for _, layer := range pe.executor.getLayersInOrder() {
+		if pe.mergedLayers[layer.ID] {
+			continue
+		}
+		completedMu.Lock()
+		finished := pe.isLayerFinished(layer, pending, completed, failed)
+		completedMu.Unlock()
+		if !finished {
+			continue
+		}
+
+		if err := pe.executor.completeLayer(ctx, layer.ID); err != nil {
+			return fmt.Errorf("completing layer %s: %w", layer.ID, err)
+		}
+		pe.executor.printLayerCompletionSummary(layer.ID)
+		pe.mergedLayers[layer.ID] = true
+	}
+	return nil
+}
+
+// isLayerFinished returns true when no spec in the layer is running or can still run.
+func (pe *ParallelExecutor) isLayerFinished(layer Layer, pending, completed, failed map[string]bool) bool {
+	for _, feature := range layer.Features {
+		if completed[feature.ID] || failed[feature.ID] {
+			continue
+		}
+		if pending[feature.ID] && pe.dependsOnFailed(feature.ID, failed) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// dependsOnFailed returns true if any direct or transitive dependency failed.
+func (pe *ParallelExecutor) dependsOnFailed(specID string, failed map[string]bool) bool {
+	feature := pe.findFeature(specID)
+	if feature == nil {
+		return false
+	}
+	for _, dep := range feature.DependsOn {
+		if failed[dep] || pe.dependsOnFailed(dep, failed) {
+			return true
+		}
+	}
+	return false
+}
+
+// syncFinalStaging merges every layer's staging branch forward into the final
+// layer's staging branch, which dag merge uses as the merge source. Needed
+// because earlier layers may have merged specs after later staging branches
+// were created.
+func (pe *ParallelExecutor) syncFinalStaging() {
+	e := pe.executor
+	if e.disableLayerStaging || e.state == nil || len(e.state.StagingBranches) == 0 {
+		return
+	}
+	finalLayerID := getFinalLayerID(e.dag)
+	if finalLayerID == "" {
+		return
+	}
+	if err := e.syncStagingChain(finalLayerID); err != nil && pe.stdout != nil {
+		fmt.Fprintf(pe.stdout, "Warning: failed to sync staging branches: %v\n", err)
+	}
+}
+
 // areDependenciesSatisfied checks if all dependencies of a spec are completed.
 func (pe *ParallelExecutor) areDependenciesSatisfied(
 	specID string, completed, failed map[string]bool,
@@ -369,7 +633,7 @@ func (pe *ParallelExecutor) markRunning(specID string) {
 	count := len(pe.runningSpecs)
 	// Update state running count while holding lock to prevent races
 	if state := pe.executor.State(); state != nil {
-		state.RunningCount = count
+		pe.executor.updateState(func() { state.RunningCount = count })
 	}
 	pe.mu.Unlock()
 
@@ -386,7 +650,7 @@ func (pe *ParallelExecutor) markDone(specID string) {
 	count := len(pe.runningSpecs)
 	// Update state running count while holding lock to prevent races
 	if state := pe.executor.State(); state != nil {
-		state.RunningCount = count
+		pe.executor.updateState(func() { state.RunningCount = count })
 	}
 	pe.mu.Unlock()
 }
@@ -427,12 +691,16 @@ func (pe *ParallelExecutor) handleInterruption() {
 
 	// Hold lock during entire state modification and save to prevent races
 	pe.mu.Lock()
-
-	// Mark all currently running specs as interrupted
-	for specID := range pe.runningSpecs {
-		if specState := state.Specs[specID]; specState != nil {
-			specState.Status = SpecStatusFailed
-			specState.FailureReason = "interrupted by signal"
+	pe.executor.stateMu.Lock()
+
+	// Mark all currently running specs as interrupted. Specs stopped by a
+	// budget limit stay running so resume re-executes them in place.
+	if pe.executor.BudgetExceeded() == nil {
+		for specID := range pe.runningSpecs {
+			if specState := state.Specs[specID]; specState != nil {
+				specState.Status = SpecStatusFailed
+				specState.FailureReason = "interrupted by signal"
+			}
 		}
 	}
 
@@ -442,6 +710,7 @@ func (pe *ParallelExecutor) handleInterruption() {
 
 	// Save state while holding lock to ensure consistent serialization
 	saveErr := SaveStateByWorkflow(pe.executor.stateDir, state)
+	pe.executor.stateMu.Unlock()
 	pe.mu.Unlock()
 
 	// Report errors after releasing lock
@@ -537,6 +806,9 @@ func CreateParallelExecutorFromConfig(
 		WithParallelFailFast(failFast),
 		WithParallelStdout(stdout),
 	}
+	if config != nil && config.SchedulePriority != "" {
+		parallelOpts = append(parallelOpts, WithParallelPriority(SchedulePriority(config.SchedulePriority)))
+	}
 
 	return NewParallelExecutor(executor, parallelOpts...)
 }
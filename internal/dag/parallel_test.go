@@ -3,11 +3,15 @@ package dag
 import (
 	"context"
 	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/ariel-frischer/autospec/internal/worktree"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -1152,6 +1156,298 @@ func TestParallelExecutor_UpdateFinalRunStatus(t *testing.T) {
 	}
 }
 
+func TestParallelExecutor_FindReadySpecs_Priority(t *testing.T) {
+	// spec-b heads the longest chain (b -> d -> e), spec-a and spec-c are leaves
+	dagCfg := &DAGConfig{
+		Layers: []Layer{
+			{ID: "L0", Features: []Feature{
+				{ID: "spec-a"},
+				{ID: "spec-b"},
+				{ID: "spec-c"},
+			}},
+			{ID: "L1", DependsOn: []string{"L0"}, Features: []Feature{
+				{ID: "spec-d", DependsOn: []string{"spec-b"}},
+				{ID: "spec-f", DependsOn: []string{"spec-c"}},
+			}},
+			{ID: "L2", DependsOn: []string{"L1"}, Features: []Feature{
+				{ID: "spec-e", DependsOn: []string{"spec-d"}},
+			}},
+		},
+	}
+	pending := map[string]bool{"spec-a": true, "spec-b": true, "spec-c": true}
+
+	tests := map[string]struct {
+		opts []ParallelExecutorOption
+		want []string
+	}{
+		"dag order by default": {
+			want: []string{"spec-a", "spec-b", "spec-c"},
+		},
+		"critical path first": {
+			opts: []ParallelExecutorOption{WithParallelPriority(PriorityCriticalPath)},
+			want: []string{"spec-b", "spec-c", "spec-a"},
+		},
+		"unknown priority keeps dag order": {
+			opts: []ParallelExecutorOption{WithParallelPriority("fastest")},
+			want: []string{"spec-a", "spec-b", "spec-c"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			executor := NewExecutor(dagCfg, "test.yaml", nil, "", "", nil, nil)
+			pe := NewParallelExecutor(executor, tt.opts...)
+
+			got := pe.findReadySpecs(pending, map[string]bool{}, map[string]bool{})
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("findReadySpecs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParallelExecutor_CriticalPathLengths(t *testing.T) {
+	dagCfg := &DAGConfig{
+		Layers: []Layer{
+			{ID: "L0", Features: []Feature{
+				{ID: "spec-a"},
+				{ID: "spec-b", DependsOn: []string{"spec-a"}},
+				{ID: "spec-c", DependsOn: []string{"spec-a"}},
+				{ID: "spec-d", DependsOn: []string{"spec-c"}},
+				{ID: "spec-e"},
+			}},
+		},
+	}
+	executor := NewExecutor(dagCfg, "test.yaml", nil, "", "", nil, nil)
+	pe := NewParallelExecutor(executor)
+
+	want := map[string]int{"spec-a": 3, "spec-b": 1, "spec-c": 2, "spec-d": 1, "spec-e": 1}
+	got := pe.criticalPathLengths()
+	for specID, n := range want {
+		if got[specID] != n {
+			t.Errorf("criticalPathLengths()[%s] = %d, want %d", specID, got[specID], n)
+		}
+	}
+}
+
+func TestParallelExecutor_IsStartPointReady(t *testing.T) {
+	dagCfg := &DAGConfig{
+		Layers: []Layer{
+			{ID: "L0", Features: []Feature{{ID: "spec-a"}, {ID: "spec-b", DependsOn: []string{"spec-a"}}}},
+			{ID: "L1", DependsOn: []string{"L0"}, Features: []Feature{{ID: "spec-c", DependsOn: []string{"spec-a"}}}},
+		},
+	}
+	automergeOff := false
+
+	tests := map[string]struct {
+		specID       string
+		automerge    *bool
+		mergedLayers map[string]bool
+		want         bool
+	}{
+		"automerge on: merged on completion": {
+			specID: "spec-c",
+			want:   true,
+		},
+		"automerge off: dependency layer not merged": {
+			specID:    "spec-c",
+			automerge: &automergeOff,
+			want:      false,
+		},
+		"automerge off: dependency layer merged": {
+			specID:       "spec-c",
+			automerge:    &automergeOff,
+			mergedLayers: map[string]bool{"L0": true},
+			want:         true,
+		},
+		"automerge off: same-layer dependency": {
+			specID:    "spec-b",
+			automerge: &automergeOff,
+			want:      true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := DefaultDAGConfig()
+			if tt.automerge != nil {
+				cfg.Automerge = tt.automerge
+			}
+			executor := NewExecutor(dagCfg, "test.yaml", nil, "", "", cfg, nil)
+			pe := NewParallelExecutor(executor)
+			for layerID := range tt.mergedLayers {
+				pe.mergedLayers[layerID] = true
+			}
+
+			if got := pe.isStartPointReady(tt.specID); got != tt.want {
+				t.Errorf("isStartPointReady(%s) = %v, want %v", tt.specID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParallelExecutor_IsLayerFinished(t *testing.T) {
+	dagCfg := &DAGConfig{
+		Layers: []Layer{
+			{ID: "L0", Features: []Feature{
+				{ID: "spec-a"},
+				{ID: "spec-b", DependsOn: []string{"spec-a"}},
+				{ID: "spec-c", DependsOn: []string{"spec-b"}},
+			}},
+		},
+	}
+	executor := NewExecutor(dagCfg, "test.yaml", nil, "", "", nil, nil)
+	pe := NewParallelExecutor(executor)
+
+	tests := map[string]struct {
+		pending   map[string]bool
+		completed map[string]bool
+		failed    map[string]bool
+		want      bool
+	}{
+		"spec still pending": {
+			pending:   map[string]bool{"spec-c": true},
+			completed: map[string]bool{"spec-a": true, "spec-b": true},
+			want:      false,
+		},
+		"spec in flight": {
+			completed: map[string]bool{"spec-a": true, "spec-b": true},
+			want:      false,
+		},
+		"all specs done": {
+			completed: map[string]bool{"spec-a": true, "spec-c": true},
+			failed:    map[string]bool{"spec-b": true},
+			want:      true,
+		},
+		"pending specs transitively blocked": {
+			pending: map[string]bool{"spec-b": true, "spec-c": true},
+			failed:  map[string]bool{"spec-a": true},
+			want:    true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := pe.isLayerFinished(dagCfg.Layers[0], tt.pending, tt.completed, tt.failed)
+			if got != tt.want {
+				t.Errorf("isLayerFinished() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// gatedRunner is a thread-safe CommandRunner that holds the spec whose
+// worktree ends in -<hold> until release is closed.
+type gatedRunner struct {
+	mu      sync.Mutex
+	started []string
+	hold    string
+	release chan struct{}
+}
+
+func (r *gatedRunner) Run(ctx context.Context, dir string, _, _ io.Writer, _ string, _ ...string) (int, error) {
+	r.mu.Lock()
+	r.started = append(r.started, filepath.Base(dir))
+	r.mu.Unlock()
+
+	if strings.HasSuffix(dir, "-"+r.hold) {
+		select {
+		case <-r.release:
+		case <-ctx.Done():
+			return -1, ctx.Err()
+		}
+	}
+	return 0, nil
+}
+
+func (r *gatedRunner) Started() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.started...)
+}
+
+// TestParallelExecutor_Execute_CrossesLayerBoundaries verifies that a spec in a
+// later layer starts once its own dependencies complete, while a slow spec in
+// an earlier layer is still running.
+func TestParallelExecutor_Execute_CrossesLayerBoundaries(t *testing.T) {
+	tmpDir := t.TempDir()
+	dagCfg := &DAGConfig{
+		SchemaVersion: "1.0",
+		DAG:           DAGMetadata{Name: "Ready Queue"},
+		Layers: []Layer{
+			{ID: "L0", Features: []Feature{
+				{ID: "slow", Description: "Slow spec"},
+				{ID: "fast", Description: "Fast spec"},
+			}},
+			{ID: "L1", DependsOn: []string{"L0"}, Features: []Feature{
+				{ID: "next", Description: "Depends on fast", DependsOn: []string{"fast"}},
+				{ID: "last", Description: "Depends on slow", DependsOn: []string{"slow"}},
+			}},
+		},
+	}
+	dagFile := filepath.Join(tmpDir, "dag.yaml")
+	if err := SaveDAGWithState(dagFile, dagCfg); err != nil {
+		t.Fatalf("saving dag: %v", err)
+	}
+
+	runner := &gatedRunner{hold: "slow", release: make(chan struct{})}
+	executor := NewExecutor(
+		dagCfg,
+		dagFile,
+		newMockWorktreeManager(),
+		filepath.Join(tmpDir, "state"),
+		tmpDir,
+		DefaultDAGConfig(),
+		worktree.DefaultConfig(),
+		WithExecutorStdout(io.Discard),
+		WithCommandRunner(runner),
+		WithDisableLayerStaging(true),
+	)
+	pe := NewParallelExecutor(executor, WithParallelMaxParallel(2))
+
+	// Release the slow spec once the L1 spec that only needs "fast" has started
+	var timedOut atomic.Bool
+	go func() {
+		deadline := time.After(5 * time.Second)
+		for {
+			for _, dir := range runner.Started() {
+				if strings.HasSuffix(dir, "-next") {
+					close(runner.release)
+					return
+				}
+			}
+			select {
+			case <-deadline:
+				timedOut.Store(true)
+				close(runner.release)
+				return
+			case <-time.After(5 * time.Millisecond):
+			}
+		}
+	}()
+
+	if _, err := pe.Execute(context.Background()); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+
+	if timedOut.Load() {
+		t.Errorf("next did not start while slow was running; started order: %v", runner.Started())
+	}
+
+	state := pe.State()
+	if state.Status != RunStatusCompleted {
+		t.Errorf("run status = %v, want completed", state.Status)
+	}
+	if state.MaxParallel != 2 {
+		t.Errorf("run max_parallel = %d, want 2", state.MaxParallel)
+	}
+	for specID, specState := range state.Specs {
+		if specState.Status != SpecStatusCompleted {
+			t.Errorf("spec %s status = %v, want completed", specID, specState.Status)
+		}
+	}
+}
+
 // =============================================================================
 // Race Condition Tests - designed to be run with: go test -race
 // These tests exercise concurrent access patterns to verify thread safety.
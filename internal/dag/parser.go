@@ -5,6 +5,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/ariel-frischer/autospec/internal/cliagent"
 	"gopkg.in/yaml.v3"
 )
 
@@ -360,12 +361,26 @@ func parseSpecStateField(state *InlineSpecState, keyNode, valueNode *yaml.Node)
 		state.CommitStatus = CommitStatus(valueNode.Value)
 	case "failure_reason":
 		state.FailureReason = valueNode.Value
+	case "failure_class":
+		state.FailureClass = FailureClass(valueNode.Value)
+	case "attempts":
+		var attempts []SpecAttempt
+		if err := valueNode.Decode(&attempts); err != nil {
+			return &ParseError{Line: valueNode.Line, Column: valueNode.Column, Message: "invalid attempts"}
+		}
+		state.Attempts = attempts
 	case "exit_code":
 		exitCode := 0
 		if err := valueNode.Decode(&exitCode); err != nil {
 			return &ParseError{Line: valueNode.Line, Column: valueNode.Column, Message: "invalid exit_code"}
 		}
 		state.ExitCode = &exitCode
+	case "usage":
+		var usage cliagent.Usage
+		if err := valueNode.Decode(&usage); err != nil {
+			return &ParseError{Line: valueNode.Line, Column: valueNode.Column, Message: "invalid usage"}
+		}
+		state.Usage = &usage
 	}
 	return nil
 }
@@ -0,0 +1,120 @@
+package dag
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// specNumberRegexp matches the number prefix of a spec folder (e.g. "003" in "003-auth").
+var specNumberRegexp = regexp.MustCompile(`^(\d+)-`)
+
+// ExistingSpecIDs returns the sorted names of the spec folders in specsDir.
+// A missing specsDir has no specs.
+func ExistingSpecIDs(specsDir string) ([]string, error) {
+	entries, err := os.ReadDir(specsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading specs directory %s: %w", specsDir, err)
+	}
+
+	var ids []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			ids = append(ids, entry.Name())
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// DetectSpecCollisions returns an error for each feature whose ID matches an
+// existing spec folder or reuses the number prefix of one. A planned DAG must
+// only describe new specs, since dag run reuses existing spec folders as-is.
+func DetectSpecCollisions(cfg *DAGConfig, result *ParseResult, existing []string) []error {
+	byID := make(map[string]bool, len(existing))
+	byNumber := make(map[string]string, len(existing))
+	for _, id := range existing {
+		byID[id] = true
+		if m := specNumberRegexp.FindStringSubmatch(id); m != nil {
+			byNumber[m[1]] = id
+		}
+	}
+
+	var errs []error
+	for i, layer := range cfg.Layers {
+		for j, feature := range layer.Features {
+			info := result.NodeInfos[fmt.Sprintf("layers[%d].features[%d]", i, j)]
+			if byID[feature.ID] {
+				errs = append(errs, &SpecCollisionError{FeatureID: feature.ID, ExistingSpec: feature.ID, Line: info.Line})
+				continue
+			}
+			if m := specNumberRegexp.FindStringSubmatch(feature.ID); m != nil {
+				if spec, ok := byNumber[m[1]]; ok {
+					errs = append(errs, &SpecCollisionError{FeatureID: feature.ID, ExistingSpec: spec, Line: info.Line})
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// ValidatePlannedDAG validates a DAG file written by dag plan. It checks the
+// structure with ValidateDAG, resolved ID uniqueness against the other DAG
+// files in the same directory with ValidateDAGUniqueness, and spec ID
+// collisions with specsDir. The returned error lists each problem as a
+// "- " bullet for retry context.
+func ValidatePlannedDAG(path, specsDir string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("DAG plan validation failed:\n- DAG file was not written to %s", path)
+	}
+
+	result, err := ParseDAGFile(path)
+	if err != nil {
+		return fmt.Errorf("DAG plan validation failed for %s:\n- %s", filepath.Base(path), err)
+	}
+
+	vr := ValidateDAG(result.Config, result, specsDir)
+	errs := vr.Errors
+
+	uniqueness, err := ValidateDAGUniqueness(filepath.Dir(path))
+	if err != nil {
+		return fmt.Errorf("checking DAG ID uniqueness: %w", err)
+	}
+	for _, uerr := range uniqueness.Errors {
+		var dup *DuplicateDAGIDError
+		if errors.As(uerr, &dup) && involvesFile(dup, path) {
+			errs = append(errs, fmt.Errorf("%w; set a different dag.name or dag.id", dup))
+		}
+	}
+
+	existing, err := ExistingSpecIDs(specsDir)
+	if err != nil {
+		return err
+	}
+	errs = append(errs, DetectSpecCollisions(result.Config, result, existing)...)
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "DAG plan validation failed for %s:\n", filepath.Base(path))
+	for _, e := range errs {
+		fmt.Fprintf(&sb, "- %s\n", e)
+	}
+	return errors.New(sb.String())
+}
+
+// involvesFile returns true if either side of a duplicate ID error is path.
+// Both sides come from the same directory, so base names are compared.
+func involvesFile(dup *DuplicateDAGIDError, path string) bool {
+	base := filepath.Base(path)
+	return filepath.Base(dup.FirstFile) == base || filepath.Base(dup.SecondFile) == base
+}
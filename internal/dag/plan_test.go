@@ -0,0 +1,148 @@
+package dag
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const plannedDAGYAML = `schema_version: "1.0"
+dag:
+  name: "Q3 Roadmap"
+layers:
+  - id: "L0"
+    features:
+      - id: "003-auth"
+        description: "Add authentication"
+  - id: "L1"
+    depends_on: ["L0"]
+    features:
+      - id: "004-profile"
+        description: "Add user profiles"
+        depends_on: ["003-auth"]
+`
+
+func TestExistingSpecIDs(t *testing.T) {
+	specsDir := t.TempDir()
+	for _, dir := range []string{"002-b", "001-a"} {
+		require.NoError(t, os.Mkdir(filepath.Join(specsDir, dir), 0o755))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(specsDir, "README.md"), nil, 0o644))
+
+	ids, err := ExistingSpecIDs(specsDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"001-a", "002-b"}, ids)
+
+	ids, err = ExistingSpecIDs(filepath.Join(specsDir, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
+func TestDetectSpecCollisions(t *testing.T) {
+	result, err := ParseDAGBytes([]byte(plannedDAGYAML))
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		existing []string
+		want     []string
+	}{
+		"no existing specs": {},
+		"unrelated specs": {
+			existing: []string{"001-setup", "002-cli"},
+		},
+		"same ID": {
+			existing: []string{"003-auth"},
+			want:     []string{`feature "003-auth" already exists as a spec folder`},
+		},
+		"same number": {
+			existing: []string{"001-setup", "004-billing"},
+			want:     []string{`feature "004-profile" reuses the number of existing spec "004-billing"`},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := DetectSpecCollisions(result.Config, result, tt.existing)
+			require.Len(t, errs, len(tt.want))
+			for i, want := range tt.want {
+				assert.Contains(t, errs[i].Error(), want)
+			}
+		})
+	}
+}
+
+func TestValidatePlannedDAG(t *testing.T) {
+	tests := map[string]struct {
+		content  string
+		sibling  string
+		specs    []string
+		wantErrs []string
+	}{
+		"valid plan": {
+			content: plannedDAGYAML,
+			specs:   []string{"001-setup"},
+		},
+		"file not written": {
+			wantErrs: []string{"- DAG file was not written to"},
+		},
+		"unparseable file": {
+			content:  "layers: [\n",
+			wantErrs: []string{"DAG plan validation failed for q3.yaml:\n- "},
+		},
+		"structural errors": {
+			content: `schema_version: "1.0"
+dag:
+  name: "Broken"
+layers:
+  - id: "L0"
+    features:
+      - id: "001-a"
+        description: "A"
+        depends_on: ["009-missing"]
+`,
+			wantErrs: []string{`- line 7: feature "001-a" depends on non-existent feature "009-missing"`},
+		},
+		"duplicate DAG ID": {
+			content:  plannedDAGYAML,
+			sibling:  "schema_version: \"1.0\"\ndag:\n  name: \"Q3 Roadmap\"\nlayers:\n  - id: L0\n    features:\n      - id: x\n        description: x\n",
+			wantErrs: []string{`- duplicate resolved DAG ID "q3-roadmap"`, "set a different dag.name or dag.id"},
+		},
+		"spec collision": {
+			content:  plannedDAGYAML,
+			specs:    []string{"003-auth"},
+			wantErrs: []string{`- line 7: feature "003-auth" already exists as a spec folder`},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			dagsDir := filepath.Join(tmpDir, "dags")
+			specsDir := filepath.Join(tmpDir, "specs")
+			require.NoError(t, os.MkdirAll(dagsDir, 0o755))
+			for _, spec := range tt.specs {
+				require.NoError(t, os.MkdirAll(filepath.Join(specsDir, spec), 0o755))
+			}
+			if tt.sibling != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(dagsDir, "existing.yaml"), []byte(tt.sibling), 0o644))
+			}
+			path := filepath.Join(dagsDir, "q3.yaml")
+			if tt.content != "" {
+				require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o644))
+			}
+
+			err := ValidatePlannedDAG(path, specsDir)
+			if len(tt.wantErrs) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, want := range tt.wantErrs {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
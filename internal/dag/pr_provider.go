@@ -0,0 +1,415 @@
+package dag
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// PRState is the review state of a pull request.
+type PRState string
+
+const (
+	// PRStateOpen indicates the pull request awaits review.
+	PRStateOpen PRState = "open"
+	// PRStateMerged indicates the pull request was merged.
+	PRStateMerged PRState = "merged"
+	// PRStateClosed indicates the pull request was closed without merging.
+	PRStateClosed PRState = "closed"
+)
+
+// PullRequestInput describes a pull request to open.
+type PullRequestInput struct {
+	// Title is the pull request title.
+	Title string
+	// Body is the pull request description.
+	Body string
+	// Head is the branch with the changes.
+	Head string
+	// Base is the branch the changes are merged into.
+	Base string
+}
+
+// PullRequest is a pull request on the hosting service.
+type PullRequest struct {
+	// Number identifies the pull request within the repository
+	// (GitLab: merge request IID).
+	Number int
+	// URL is the web URL of the pull request.
+	URL string
+	// State is the current review state.
+	State PRState
+}
+
+// PRProvider opens and inspects pull requests on a hosting service.
+type PRProvider interface {
+	// Name returns the provider identifier (github, gitlab, gitea).
+	Name() string
+	// CreatePullRequest opens a pull request and returns it.
+	CreatePullRequest(ctx context.Context, in PullRequestInput) (*PullRequest, error)
+	// GetPullRequest returns the current state of a pull request.
+	GetPullRequest(ctx context.Context, number int) (*PullRequest, error)
+}
+
+// Supported pull request providers.
+const (
+	PRProviderGitHub = "github"
+	PRProviderGitLab = "gitlab"
+	PRProviderGitea  = "gitea"
+)
+
+// ValidPRProviders lists the accepted dag.pr_provider values.
+var ValidPRProviders = []string{PRProviderGitHub, PRProviderGitLab, PRProviderGitea}
+
+// IsValidPRProvider returns true if s is a supported pull request provider.
+func IsValidPRProvider(s string) bool {
+	for _, valid := range ValidPRProviders {
+		if s == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// PRProviderConfig configures a PRProvider.
+type PRProviderConfig struct {
+	// Provider is github, gitlab, or gitea.
+	Provider string
+	// APIURL is the REST API base URL. Defaults to the public GitHub and
+	// GitLab APIs; required for Gitea.
+	APIURL string
+	// Repo is the repository path on the host, e.g. "owner/name".
+	Repo string
+	// Token authenticates API requests.
+	Token string
+}
+
+// prTimeout bounds each provider API request.
+const prTimeout = 30 * time.Second
+
+// NewPRProvider creates a provider for the given configuration.
+func NewPRProvider(cfg PRProviderConfig) (PRProvider, error) {
+	if cfg.Repo == "" {
+		return nil, fmt.Errorf("%s provider: repository is required", cfg.Provider)
+	}
+	if cfg.Token == "" {
+		return nil, fmt.Errorf("%s provider: token is required", cfg.Provider)
+	}
+
+	client := prClient{
+		apiURL: strings.TrimRight(cfg.APIURL, "/"),
+		http:   &http.Client{Timeout: prTimeout},
+	}
+	switch cfg.Provider {
+	case PRProviderGitHub:
+		if client.apiURL == "" {
+			client.apiURL = "https://api.github.com"
+		}
+		client.headers = map[string]string{
+			"Authorization": "Bearer " + cfg.Token,
+			"Accept":        "application/vnd.github+json",
+		}
+		return &githubProvider{client: client, repo: cfg.Repo}, nil
+	case PRProviderGitLab:
+		if client.apiURL == "" {
+			client.apiURL = "https://gitlab.com/api/v4"
+		}
+		client.headers = map[string]string{"PRIVATE-TOKEN": cfg.Token}
+		return &gitlabProvider{client: client, project: url.PathEscape(cfg.Repo)}, nil
+	case PRProviderGitea:
+		if client.apiURL == "" {
+			return nil, fmt.Errorf("gitea provider: api url is required")
+		}
+		client.headers = map[string]string{"Authorization": "token " + cfg.Token}
+		return &giteaProvider{client: client, repo: cfg.Repo}, nil
+	default:
+		return nil, fmt.Errorf("unknown pull request provider %q (must be one of: %s)",
+			cfg.Provider, strings.Join(ValidPRProviders, ", "))
+	}
+}
+
+// prClient sends JSON requests to a provider REST API.
+type prClient struct {
+	apiURL  string
+	headers map[string]string
+	http    *http.Client
+}
+
+// do sends a request with an optional JSON body and decodes the JSON
+// response into out. Non-2xx responses are returned as errors.
+func (c prClient) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encoding request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s %s: status %d: %s", method, path, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
+
+// githubPull is the subset of a GitHub or Gitea pull request response used here.
+type githubPull struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+	State   string `json:"state"`
+	Merged  bool   `json:"merged"`
+}
+
+// pullRequest converts the response, treating a merged closed pull request
+// as merged.
+func (p githubPull) pullRequest() *PullRequest {
+	state := PRStateOpen
+	switch {
+	case p.Merged:
+		state = PRStateMerged
+	case p.State == "closed":
+		state = PRStateClosed
+	}
+	return &PullRequest{Number: p.Number, URL: p.HTMLURL, State: state}
+}
+
+// githubProvider opens pull requests through the GitHub REST API.
+type githubProvider struct {
+	client prClient
+	repo   string
+}
+
+// Name implements PRProvider.
+func (p *githubProvider) Name() string { return PRProviderGitHub }
+
+// CreatePullRequest implements PRProvider.
+func (p *githubProvider) CreatePullRequest(ctx context.Context, in PullRequestInput) (*PullRequest, error) {
+	var pull githubPull
+	body := map[string]string{"title": in.Title, "body": in.Body, "head": in.Head, "base": in.Base}
+	if err := p.client.do(ctx, http.MethodPost, "/repos/"+p.repo+"/pulls", body, &pull); err != nil {
+		return nil, fmt.Errorf("creating github pull request: %w", err)
+	}
+	return pull.pullRequest(), nil
+}
+
+// GetPullRequest implements PRProvider.
+func (p *githubProvider) GetPullRequest(ctx context.Context, number int) (*PullRequest, error) {
+	var pull githubPull
+	if err := p.client.do(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/pulls/%d", p.repo, number), nil, &pull); err != nil {
+		return nil, fmt.Errorf("getting github pull request: %w", err)
+	}
+	return pull.pullRequest(), nil
+}
+
+// giteaProvider opens pull requests through the Gitea REST API, which
+// mirrors GitHub's pull request endpoints.
+type giteaProvider struct {
+	client prClient
+	repo   string
+}
+
+// Name implements PRProvider.
+func (p *giteaProvider) Name() string { return PRProviderGitea }
+
+// CreatePullRequest implements PRProvider.
+func (p *giteaProvider) CreatePullRequest(ctx context.Context, in PullRequestInput) (*PullRequest, error) {
+	var pull githubPull
+	body := map[string]string{"title": in.Title, "body": in.Body, "head": in.Head, "base": in.Base}
+	if err := p.client.do(ctx, http.MethodPost, "/repos/"+p.repo+"/pulls", body, &pull); err != nil {
+		return nil, fmt.Errorf("creating gitea pull request: %w", err)
+	}
+	return pull.pullRequest(), nil
+}
+
+// GetPullRequest implements PRProvider.
+func (p *giteaProvider) GetPullRequest(ctx context.Context, number int) (*PullRequest, error) {
+	var pull githubPull
+	if err := p.client.do(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/pulls/%d", p.repo, number), nil, &pull); err != nil {
+		return nil, fmt.Errorf("getting gitea pull request: %w", err)
+	}
+	return pull.pullRequest(), nil
+}
+
+// gitlabMergeRequest is the subset of a GitLab merge request response used here.
+type gitlabMergeRequest struct {
+	IID    int    `json:"iid"`
+	WebURL string `json:"web_url"`
+	State  string `json:"state"`
+}
+
+// pullRequest converts the response. GitLab states are opened, closed,
+// locked, and merged.
+func (mr gitlabMergeRequest) pullRequest() *PullRequest {
+	state := PRStateOpen
+	switch mr.State {
+	case "merged":
+		state = PRStateMerged
+	case "closed":
+		state = PRStateClosed
+	}
+	return &PullRequest{Number: mr.IID, URL: mr.WebURL, State: state}
+}
+
+// gitlabProvider opens merge requests through the GitLab REST API.
+type gitlabProvider struct {
+	client  prClient
+	project string // URL-encoded project path
+}
+
+// Name implements PRProvider.
+func (p *gitlabProvider) Name() string { return PRProviderGitLab }
+
+// CreatePullRequest implements PRProvider.
+func (p *gitlabProvider) CreatePullRequest(ctx context.Context, in PullRequestInput) (*PullRequest, error) {
+	var mr gitlabMergeRequest
+	body := map[string]string{
+		"title":         in.Title,
+		"description":   in.Body,
+		"source_branch": in.Head,
+		"target_branch": in.Base,
+	}
+	if err := p.client.do(ctx, http.MethodPost, "/projects/"+p.project+"/merge_requests", body, &mr); err != nil {
+		return nil, fmt.Errorf("creating gitlab merge request: %w", err)
+	}
+	return mr.pullRequest(), nil
+}
+
+// GetPullRequest implements PRProvider.
+func (p *gitlabProvider) GetPullRequest(ctx context.Context, number int) (*PullRequest, error) {
+	var mr gitlabMergeRequest
+	if err := p.client.do(ctx, http.MethodGet, fmt.Sprintf("/projects/%s/merge_requests/%d", p.project, number), nil, &mr); err != nil {
+		return nil, fmt.Errorf("getting gitlab merge request: %w", err)
+	}
+	return mr.pullRequest(), nil
+}
+
+// prTokenEnv maps each provider to the environment variable used when
+// dag.pr_token is not set.
+var prTokenEnv = map[string]string{
+	PRProviderGitHub: "GITHUB_TOKEN",
+	PRProviderGitLab: "GITLAB_TOKEN",
+	PRProviderGitea:  "GITEA_TOKEN",
+}
+
+// ResolvePRProviderConfig builds the provider configuration for dag merge
+// --pr. The provider, repository, and API URL default to those of the
+// remote's URL, and the token to the provider's environment variable (GITHUB_TOKEN,
+// GITLAB_TOKEN, GITEA_TOKEN). dag.pr_token may reference environment
+// variables, e.g. "${MY_TOKEN}".
+func ResolvePRProviderConfig(ctx context.Context, cfg *DAGExecutionConfig, repoRoot string) (PRProviderConfig, error) {
+	remote := cfg.Remote
+	if remote == "" {
+		remote = "origin"
+	}
+	remoteURL, err := runGitOutput(ctx, repoRoot, "remote", "get-url", remote)
+	if err != nil {
+		return PRProviderConfig{}, fmt.Errorf("reading url of remote %q: %w", remote, err)
+	}
+	host, repo := parseRemoteURL(remoteURL)
+
+	pc := PRProviderConfig{
+		Provider: cfg.PRProvider,
+		APIURL:   cfg.PRAPIURL,
+		Repo:     cfg.PRRepo,
+		Token:    os.ExpandEnv(cfg.PRToken),
+	}
+	if pc.Provider == "" {
+		pc.Provider = detectPRProvider(host)
+		if pc.Provider == "" {
+			return PRProviderConfig{}, fmt.Errorf("cannot detect pull request provider for host %q: set dag.pr_provider", host)
+		}
+	}
+	if pc.Repo == "" {
+		pc.Repo = repo
+	}
+	if pc.Token == "" {
+		pc.Token = os.Getenv(prTokenEnv[pc.Provider])
+	}
+	if pc.APIURL == "" {
+		pc.APIURL = defaultPRAPIURL(pc.Provider, host)
+	}
+	return pc, nil
+}
+
+// defaultPRAPIURL returns the API base URL of a self-hosted instance at
+// host, or "" for the public GitHub and GitLab hosts, which NewPRProvider
+// handles itself.
+func defaultPRAPIURL(provider, host string) string {
+	if host == "" {
+		return ""
+	}
+	switch provider {
+	case PRProviderGitHub:
+		if host != "github.com" {
+			return "https://" + host + "/api/v3"
+		}
+	case PRProviderGitLab:
+		if host != "gitlab.com" {
+			return "https://" + host + "/api/v4"
+		}
+	case PRProviderGitea:
+		return "https://" + host + "/api/v1"
+	}
+	return ""
+}
+
+// detectPRProvider returns the provider for well-known hosts, or "" if unknown.
+func detectPRProvider(host string) string {
+	switch {
+	case host == "github.com":
+		return PRProviderGitHub
+	case host == "gitlab.com" || strings.HasPrefix(host, "gitlab."):
+		return PRProviderGitLab
+	case strings.HasPrefix(host, "gitea.") || host == "codeberg.org":
+		return PRProviderGitea
+	default:
+		return ""
+	}
+}
+
+// parseRemoteURL extracts the host and repository path from a git remote
+// URL in SSH ("git@host:owner/name.git") or URL form
+// ("https://host/owner/name.git").
+func parseRemoteURL(remoteURL string) (host, repo string) {
+	remoteURL = strings.TrimSpace(remoteURL)
+	if u, err := url.Parse(remoteURL); err == nil && u.Host != "" {
+		host, repo = u.Hostname(), u.Path
+	} else if at, rest, ok := strings.Cut(remoteURL, ":"); ok {
+		host, repo = at, rest
+		if i := strings.LastIndex(host, "@"); i >= 0 {
+			host = host[i+1:]
+		}
+	}
+	repo = strings.TrimSuffix(strings.Trim(repo, "/"), ".git")
+	return host, repo
+}
@@ -0,0 +1,254 @@
+package dag
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// prStandIn is a local HTTP stand-in for a provider REST API. It records
+// requests and answers with the configured JSON responses keyed by
+// "METHOD path".
+type prStandIn struct {
+	mu        sync.Mutex
+	responses map[string]string
+	requests  []prRequest
+}
+
+type prRequest struct {
+	method string
+	path   string
+	header http.Header
+	body   map[string]string
+}
+
+func newPRStandIn(t *testing.T, responses map[string]string) (*prStandIn, *httptest.Server) {
+	t.Helper()
+	s := &prStandIn{responses: responses}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		s.mu.Lock()
+		s.requests = append(s.requests, prRequest{method: r.Method, path: r.URL.EscapedPath(), header: r.Header, body: body})
+		resp, ok := s.responses[r.Method+" "+r.URL.EscapedPath()]
+		s.mu.Unlock()
+
+		if !ok {
+			http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(resp))
+	}))
+	t.Cleanup(srv.Close)
+	return s, srv
+}
+
+func (s *prStandIn) lastRequest() prRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[len(s.requests)-1]
+}
+
+func TestPRProviders(t *testing.T) {
+	tests := map[string]struct {
+		provider   string
+		repo       string
+		createPath string
+		getPath    string
+		createResp string
+		getResp    string
+		authHeader string
+		authValue  string
+		headField  string
+		wantURL    string
+		wantState  PRState
+	}{
+		"github": {
+			provider:   PRProviderGitHub,
+			repo:       "acme/app",
+			createPath: "/repos/acme/app/pulls",
+			getPath:    "/repos/acme/app/pulls/12",
+			createResp: `{"number":12,"html_url":"https://github.com/acme/app/pull/12","state":"open"}`,
+			getResp:    `{"number":12,"html_url":"https://github.com/acme/app/pull/12","state":"closed","merged":true}`,
+			authHeader: "Authorization",
+			authValue:  "Bearer secret",
+			headField:  "head",
+			wantURL:    "https://github.com/acme/app/pull/12",
+			wantState:  PRStateMerged,
+		},
+		"gitlab": {
+			provider:   PRProviderGitLab,
+			repo:       "acme/platform/app",
+			createPath: "/projects/acme%2Fplatform%2Fapp/merge_requests",
+			getPath:    "/projects/acme%2Fplatform%2Fapp/merge_requests/12",
+			createResp: `{"iid":12,"web_url":"https://gitlab.com/acme/platform/app/-/merge_requests/12","state":"opened"}`,
+			getResp:    `{"iid":12,"web_url":"https://gitlab.com/acme/platform/app/-/merge_requests/12","state":"closed"}`,
+			authHeader: "PRIVATE-TOKEN",
+			authValue:  "secret",
+			headField:  "source_branch",
+			wantURL:    "https://gitlab.com/acme/platform/app/-/merge_requests/12",
+			wantState:  PRStateClosed,
+		},
+		"gitea": {
+			provider:   PRProviderGitea,
+			repo:       "acme/app",
+			createPath: "/repos/acme/app/pulls",
+			getPath:    "/repos/acme/app/pulls/12",
+			createResp: `{"number":12,"html_url":"https://gitea.example.com/acme/app/pulls/12","state":"open"}`,
+			getResp:    `{"number":12,"html_url":"https://gitea.example.com/acme/app/pulls/12","state":"open","merged":false}`,
+			authHeader: "Authorization",
+			authValue:  "token secret",
+			headField:  "head",
+			wantURL:    "https://gitea.example.com/acme/app/pulls/12",
+			wantState:  PRStateOpen,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			standIn, srv := newPRStandIn(t, map[string]string{
+				"POST " + tt.createPath: tt.createResp,
+				"GET " + tt.getPath:     tt.getResp,
+			})
+			provider, err := NewPRProvider(PRProviderConfig{Provider: tt.provider, APIURL: srv.URL, Repo: tt.repo, Token: "secret"})
+			if err != nil {
+				t.Fatalf("NewPRProvider() error: %v", err)
+			}
+			ctx := context.Background()
+
+			pr, err := provider.CreatePullRequest(ctx, PullRequestInput{Title: "t", Body: "b", Head: "spec-a", Base: "main"})
+			if err != nil {
+				t.Fatalf("CreatePullRequest() error: %v", err)
+			}
+			if pr.Number != 12 || pr.URL != tt.wantURL || pr.State != PRStateOpen {
+				t.Errorf("CreatePullRequest() = %+v", pr)
+			}
+			req := standIn.lastRequest()
+			if got := req.header.Get(tt.authHeader); got != tt.authValue {
+				t.Errorf("%s header = %q, want %q", tt.authHeader, got, tt.authValue)
+			}
+			if req.body[tt.headField] != "spec-a" {
+				t.Errorf("request body %v lacks %s=spec-a", req.body, tt.headField)
+			}
+
+			pr, err = provider.GetPullRequest(ctx, 12)
+			if err != nil {
+				t.Fatalf("GetPullRequest() error: %v", err)
+			}
+			if pr.State != tt.wantState {
+				t.Errorf("GetPullRequest() state = %q, want %q", pr.State, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestPRProviderErrorStatus(t *testing.T) {
+	_, srv := newPRStandIn(t, nil)
+	provider, err := NewPRProvider(PRProviderConfig{Provider: PRProviderGitHub, APIURL: srv.URL, Repo: "acme/app", Token: "secret"})
+	if err != nil {
+		t.Fatalf("NewPRProvider() error: %v", err)
+	}
+
+	if _, err := provider.GetPullRequest(context.Background(), 1); err == nil {
+		t.Error("expected error for 404 response")
+	}
+}
+
+func TestNewPRProviderValidation(t *testing.T) {
+	tests := map[string]struct {
+		cfg PRProviderConfig
+	}{
+		"missing repo":      {cfg: PRProviderConfig{Provider: PRProviderGitHub, Token: "t"}},
+		"missing token":     {cfg: PRProviderConfig{Provider: PRProviderGitHub, Repo: "o/r"}},
+		"gitea without api": {cfg: PRProviderConfig{Provider: PRProviderGitea, Repo: "o/r", Token: "t"}},
+		"unknown provider":  {cfg: PRProviderConfig{Provider: "bitbucket", Repo: "o/r", Token: "t"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewPRProvider(tt.cfg); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := map[string]struct {
+		url      string
+		wantHost string
+		wantRepo string
+	}{
+		"https":           {url: "https://github.com/acme/app.git", wantHost: "github.com", wantRepo: "acme/app"},
+		"https no suffix": {url: "https://gitlab.com/acme/platform/app", wantHost: "gitlab.com", wantRepo: "acme/platform/app"},
+		"scp-like ssh":    {url: "git@github.com:acme/app.git", wantHost: "github.com", wantRepo: "acme/app"},
+		"ssh url":         {url: "ssh://git@gitea.example.com:2222/acme/app.git", wantHost: "gitea.example.com", wantRepo: "acme/app"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			host, repo := parseRemoteURL(tt.url)
+			if host != tt.wantHost || repo != tt.wantRepo {
+				t.Errorf("parseRemoteURL(%q) = (%q, %q), want (%q, %q)", tt.url, host, repo, tt.wantHost, tt.wantRepo)
+			}
+		})
+	}
+}
+
+func TestResolvePRProviderConfig(t *testing.T) {
+	tests := map[string]struct {
+		remoteURL string
+		cfg       DAGExecutionConfig
+		env       map[string]string
+		want      PRProviderConfig
+		wantErr   bool
+	}{
+		"github from remote": {
+			remoteURL: "git@github.com:acme/app.git",
+			env:       map[string]string{"GITHUB_TOKEN": "gh"},
+			want:      PRProviderConfig{Provider: PRProviderGitHub, Repo: "acme/app", Token: "gh"},
+		},
+		"self-hosted gitlab api": {
+			remoteURL: "https://gitlab.example.com/acme/app.git",
+			env:       map[string]string{"GITLAB_TOKEN": "gl"},
+			want:      PRProviderConfig{Provider: PRProviderGitLab, APIURL: "https://gitlab.example.com/api/v4", Repo: "acme/app", Token: "gl"},
+		},
+		"explicit settings": {
+			remoteURL: "https://git.internal/acme/app.git",
+			cfg:       DAGExecutionConfig{PRProvider: PRProviderGitea, PRRepo: "team/app", PRToken: "${MY_TOKEN}"},
+			env:       map[string]string{"MY_TOKEN": "mine"},
+			want:      PRProviderConfig{Provider: PRProviderGitea, APIURL: "https://git.internal/api/v1", Repo: "team/app", Token: "mine"},
+		},
+		"unknown host": {
+			remoteURL: "https://git.internal/acme/app.git",
+			wantErr:   true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			repo, cleanup := setupTestRepo(t)
+			t.Cleanup(cleanup)
+			mustGit(t, repo, "remote", "add", "origin", tt.remoteURL)
+			for _, key := range []string{"GITHUB_TOKEN", "GITLAB_TOKEN", "GITEA_TOKEN"} {
+				t.Setenv(key, "")
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			got, err := ResolvePRProviderConfig(context.Background(), &tt.cfg, repo)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolvePRProviderConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ResolvePRProviderConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
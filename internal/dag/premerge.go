@@ -0,0 +1,95 @@
+package dag
+
+// premerge.go gates staging merges on dag.pre_merge_cmd.
+//
+// The command (typically the project test suite) runs in the spec worktree
+// before the merge and again on the staging branch after it, so a spec that
+// breaks the build never reaches the next layer. A failure after the merge
+// resets the staging branch to its pre-merge head.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// PreMergeCheckError reports a failed dag.pre_merge_cmd run.
+type PreMergeCheckError struct {
+	// SpecID is the spec being merged.
+	SpecID string
+	// Location is where the command ran: "worktree" or "staging".
+	Location string
+	// ExitCode is the exit code of the command.
+	ExitCode int
+	// Output is the tail of the command's combined output.
+	Output string
+}
+
+// Error implements the error interface.
+func (e *PreMergeCheckError) Error() string {
+	return fmt.Sprintf("pre-merge command failed on %s for spec %q with exit code %d", e.Location, e.SpecID, e.ExitCode)
+}
+
+// runPreMergeCmd runs dag.pre_merge_cmd in dir, where branch is checked out.
+// Template variables: {{.Worktree}} is dir, {{.Branch}} is branch, and
+// {{.BaseBranch}} is the staging branch. Does nothing when no command is set.
+// The command is stopped when ctx is cancelled.
+func (e *Executor) runPreMergeCmd(ctx context.Context, specID, location, dir, branch, stagingBranch string) error {
+	if e.config == nil || e.config.PreMergeCmd == "" {
+		return nil
+	}
+
+	cmd, err := ExpandTemplateVars(e.config.PreMergeCmd, TemplateVars{
+		SpecID:     specID,
+		Worktree:   dir,
+		Branch:     branch,
+		BaseBranch: stagingBranch,
+		DagID:      e.state.DAGId,
+	})
+	if err != nil {
+		return fmt.Errorf("expanding pre-merge command: %w", err)
+	}
+
+	fmt.Fprintf(e.stdout, "[%s] Running pre-merge command on %s: %s\n", specID, location, cmd)
+	tail := newTailBuffer(failureTailSize)
+	w := io.MultiWriter(e.stdout, tail)
+	exitCode, err := e.cmdRunner.Run(ctx, dir, w, w, "sh", "-c", cmd)
+	if err != nil {
+		return fmt.Errorf("running pre-merge command: %w", err)
+	}
+	if exitCode != 0 {
+		return &PreMergeCheckError{
+			SpecID:   specID,
+			Location: location,
+			ExitCode: exitCode,
+			Output:   strings.TrimSpace(tail.String()),
+		}
+	}
+	return nil
+}
+
+// failPreMergeCheck marks the spec merge_failed with the command output and
+// returns the error.
+func (e *Executor) failPreMergeCheck(specID string, specState *SpecState, checkErr error) error {
+	reason := checkErr.Error()
+	var pmErr *PreMergeCheckError
+	if errors.As(checkErr, &pmErr) && pmErr.Output != "" {
+		reason += "\n" + pmErr.Output
+	}
+
+	e.updateState(func() {
+		specState.FailureClass = FailurePreMergeCheck
+		specState.Merge = &MergeState{
+			Status: MergeStatusMergeFailed,
+			Error:  reason,
+		}
+	})
+	if err := e.saveInlineState(); err != nil {
+		return fmt.Errorf("saving state after pre-merge failure: %w", err)
+	}
+
+	fmt.Fprintf(e.stdout, "[%s] Pre-merge check failed; spec not merged to staging\n", specID)
+	return fmt.Errorf("pre-merge check for spec %s: %w", specID, checkErr)
+}
@@ -0,0 +1,165 @@
+package dag
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMergeSpecToStaging_PreMergeCmd(t *testing.T) {
+	// Passes in the spec worktree, where main.txt does not exist yet, but fails
+	// on staging once main's change and the spec are combined
+	const failsOnStaging = `if [ -f a.txt ] && [ -f main.txt ]; then echo "combined build broken"; exit 1; fi`
+
+	tests := map[string]struct {
+		cmd          string
+		wantErr      bool
+		wantLocation string
+		wantOutput   string
+		wantMerged   bool
+	}{
+		"no command merges": {
+			wantMerged: true,
+		},
+		"passing command merges": {
+			cmd:        "test -f a.txt",
+			wantMerged: true,
+		},
+		"worktree failure blocks merge": {
+			cmd:          `echo "unit tests failed for {{.SpecID}}"; exit 3`,
+			wantErr:      true,
+			wantLocation: "worktree",
+			wantOutput:   "unit tests failed for spec-a",
+		},
+		"staging failure reverts merge": {
+			cmd:          failsOnStaging,
+			wantErr:      true,
+			wantLocation: "staging",
+			wantOutput:   "combined build broken",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			executor, repo, worktree := setupPreMergeExecutor(t, tt.cmd)
+			specState := executor.state.Specs["spec-a"]
+			specState.WorktreePath = worktree
+			specState.Branch = "spec-a"
+			stagingHead := gitOutput(t, repo, "rev-parse", "main")
+
+			err := executor.mergeSpecToStaging(context.Background(), "spec-a", specState)
+
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("mergeSpecToStaging() error: %v", err)
+				}
+				if !specState.MergedToStaging {
+					t.Error("spec not marked merged to staging")
+				}
+				if !isAncestor(repo, "spec-a", "dag/gate/stage-L0") {
+					t.Error("staging does not contain spec-a")
+				}
+				return
+			}
+
+			var pmErr *PreMergeCheckError
+			if !errors.As(err, &pmErr) {
+				t.Fatalf("error = %v, want *PreMergeCheckError", err)
+			}
+			if pmErr.Location != tt.wantLocation {
+				t.Errorf("Location = %q, want %q", pmErr.Location, tt.wantLocation)
+			}
+			if specState.MergedToStaging {
+				t.Error("spec marked merged to staging after failed check")
+			}
+			if specState.FailureClass != FailurePreMergeCheck {
+				t.Errorf("FailureClass = %q, want %q", specState.FailureClass, FailurePreMergeCheck)
+			}
+			if specState.Merge == nil || specState.Merge.Status != MergeStatusMergeFailed {
+				t.Fatalf("Merge = %+v, want status merge_failed", specState.Merge)
+			}
+			if !strings.Contains(specState.Merge.Error, tt.wantOutput) {
+				t.Errorf("Merge.Error = %q, want output %q", specState.Merge.Error, tt.wantOutput)
+			}
+			if branchExists(repo, "dag/gate/stage-L0") {
+				if got := gitOutput(t, repo, "rev-parse", "dag/gate/stage-L0"); got != stagingHead {
+					t.Errorf("staging head = %s, want pre-merge head %s", got, stagingHead)
+				}
+			}
+		})
+	}
+}
+
+func TestMergeSpecToStaging_PreMergeCmdCancelled(t *testing.T) {
+	executor, repo, worktree := setupPreMergeExecutor(t, "sleep 30")
+	specState := executor.state.Specs["spec-a"]
+	specState.WorktreePath = worktree
+	specState.Branch = "spec-a"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := executor.mergeSpecToStaging(ctx, "spec-a", specState)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error = %v, want context.Canceled", err)
+	}
+	if specState.MergedToStaging {
+		t.Error("spec marked merged to staging after cancelled check")
+	}
+	if branchExists(repo, "dag/gate/stage-L0") && isAncestor(repo, "spec-a", "dag/gate/stage-L0") {
+		t.Error("staging contains spec-a after cancelled check")
+	}
+}
+
+func TestResetStagingBranch_KeepsLocalChanges(t *testing.T) {
+	repo := setupDivergedRepo(t)
+	before := gitOutput(t, repo, "rev-parse", "HEAD")
+	mustGit(t, repo, "merge", "--no-ff", "-m", "merge spec-a", "spec-a")
+
+	// a.txt came in with the merge, so resetting would discard the edit
+	createFile(t, repo, "a.txt", "edited")
+	if err := resetStagingBranch(repo, before); err == nil {
+		t.Fatal("resetStagingBranch() succeeded over uncommitted changes to merged files")
+	}
+	if got := gitOutput(t, repo, "show", ":a.txt"); got != "two" {
+		t.Errorf("index a.txt = %q, want merged content", got)
+	}
+
+	mustGit(t, repo, "checkout", "--", "a.txt")
+	createFile(t, repo, "notes.txt", "untracked")
+	if err := resetStagingBranch(repo, before); err != nil {
+		t.Fatalf("resetStagingBranch() error: %v", err)
+	}
+	if got := gitOutput(t, repo, "rev-parse", "HEAD"); got != before {
+		t.Errorf("HEAD = %s, want %s", got, before)
+	}
+	if _, err := os.Stat(filepath.Join(repo, "notes.txt")); err != nil {
+		t.Errorf("untracked file removed by reset: %v", err)
+	}
+}
+
+// setupPreMergeExecutor creates a repository with a spec-a worktree that
+// diverged from main, and an executor configured with preMergeCmd.
+func setupPreMergeExecutor(t *testing.T, preMergeCmd string) (*Executor, string, string) {
+	t.Helper()
+	repo := setupDivergedRepo(t)
+	worktree := filepath.Join(t.TempDir(), "spec-a")
+	mustGit(t, repo, "worktree", "add", worktree, "spec-a")
+
+	dagCfg := &DAGConfig{
+		DAG:    DAGMetadata{ID: "gate"},
+		Layers: []Layer{{ID: "L0", Features: []Feature{{ID: "spec-a", Description: "Add feature a"}}}},
+	}
+	cfg := DefaultDAGConfig()
+	cfg.BaseBranch = "main"
+	cfg.PreMergeCmd = preMergeCmd
+
+	dagFile := filepath.Join(t.TempDir(), "dag.yaml")
+	executor := NewExecutor(dagCfg, dagFile, nil, "", repo, cfg, nil, WithExecutorStdout(io.Discard))
+	executor.state = NewDAGRun(dagFile, dagCfg, 1)
+	return executor, repo, worktree
+}
@@ -0,0 +1,105 @@
+package dag
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+)
+
+// ArtifactProgress records which workflow artifacts of a spec already
+// validate in its worktree, so a resumed spec can skip finished stages.
+type ArtifactProgress struct {
+	// SpecValid indicates spec.yaml exists and passes schema validation.
+	SpecValid bool
+	// PlanValid indicates plan.yaml exists and passes schema validation.
+	PlanValid bool
+	// TasksValid indicates tasks.yaml exists and passes schema validation.
+	TasksValid bool
+	// TasksTotal is the number of tasks in tasks.yaml.
+	TasksTotal int
+	// TasksCompleted is the number of tasks with status Completed.
+	TasksCompleted int
+}
+
+// InspectArtifacts validates spec.yaml, plan.yaml, and tasks.yaml in specDir.
+// Stages run in order, so a later artifact only counts when every earlier
+// artifact also validates.
+func InspectArtifacts(specDir string) ArtifactProgress {
+	var progress ArtifactProgress
+
+	progress.SpecValid = artifactValid(validation.ArtifactTypeSpec, filepath.Join(specDir, "spec.yaml"))
+	if !progress.SpecValid {
+		return progress
+	}
+	progress.PlanValid = artifactValid(validation.ArtifactTypePlan, filepath.Join(specDir, "plan.yaml"))
+	if !progress.PlanValid {
+		return progress
+	}
+
+	tasksPath := filepath.Join(specDir, "tasks.yaml")
+	progress.TasksValid = artifactValid(validation.ArtifactTypeTasks, tasksPath)
+	if !progress.TasksValid {
+		return progress
+	}
+	if stats, err := validation.GetTaskStats(tasksPath); err == nil {
+		progress.TasksTotal = stats.TotalTasks
+		progress.TasksCompleted = stats.CompletedTasks
+	}
+	return progress
+}
+
+// artifactValid reports whether the artifact at path passes schema validation.
+func artifactValid(artifactType validation.ArtifactType, path string) bool {
+	validator, err := validation.NewArtifactValidator(artifactType)
+	if err != nil {
+		return false
+	}
+	return validator.Validate(path).Valid
+}
+
+// NextStage returns the first stage whose artifact does not validate,
+// or "implement" when spec, plan, and tasks are all valid.
+func (p ArtifactProgress) NextStage() string {
+	switch {
+	case !p.SpecValid:
+		return "specify"
+	case !p.PlanValid:
+		return "plan"
+	case !p.TasksValid:
+		return "tasks"
+	default:
+		return "implement"
+	}
+}
+
+// ResumeArgs returns the autospec arguments that continue specID from its
+// next stage. Returns nil when spec.yaml does not validate, since the full
+// pipeline must run from specify.
+func (p ArtifactProgress) ResumeArgs(specID string) []string {
+	switch p.NextStage() {
+	case "plan":
+		return []string{"run", "-pti", "--spec", specID}
+	case "tasks":
+		return []string{"run", "-ti", "--spec", specID}
+	case "implement":
+		return []string{"run", "-i", "--resume", "--spec", specID}
+	default:
+		return nil
+	}
+}
+
+// Summary describes the artifact progress for terminal output.
+func (p ArtifactProgress) Summary() string {
+	switch p.NextStage() {
+	case "specify":
+		return "no valid spec.yaml, running full pipeline"
+	case "plan":
+		return "spec.yaml valid, continuing from plan"
+	case "tasks":
+		return "spec.yaml and plan.yaml valid, continuing from tasks"
+	default:
+		return fmt.Sprintf("artifacts valid, resuming implement (%d/%d tasks completed)",
+			p.TasksCompleted, p.TasksTotal)
+	}
+}
@@ -0,0 +1,179 @@
+package dag
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/worktree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// copyArtifactFixture copies a valid artifact from the validation testdata
+// into specDir, applying an optional string replacement.
+func copyArtifactFixture(t *testing.T, specDir, artifact, old, new string) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "validation", "testdata", artifact, "valid.yaml"))
+	require.NoError(t, err)
+	content := string(data)
+	if old != "" {
+		content = strings.Replace(content, old, new, 1)
+	}
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, artifact+".yaml"), []byte(content), 0o644))
+}
+
+func TestInspectArtifacts(t *testing.T) {
+	tests := map[string]struct {
+		setup         func(t *testing.T, specDir string)
+		wantStage     string
+		wantArgs      []string
+		wantCompleted int
+	}{
+		"no artifacts": {
+			setup:     func(t *testing.T, specDir string) {},
+			wantStage: "specify",
+		},
+		"invalid spec": {
+			setup: func(t *testing.T, specDir string) {
+				require.NoError(t, os.MkdirAll(specDir, 0o755))
+				require.NoError(t, os.WriteFile(filepath.Join(specDir, "spec.yaml"), []byte("feature: {}\n"), 0o644))
+			},
+			wantStage: "specify",
+		},
+		"valid spec only": {
+			setup: func(t *testing.T, specDir string) {
+				copyArtifactFixture(t, specDir, "spec", "", "")
+			},
+			wantStage: "plan",
+			wantArgs:  []string{"run", "-pti", "--spec", "001-feature"},
+		},
+		"tasks ignored without valid plan": {
+			setup: func(t *testing.T, specDir string) {
+				copyArtifactFixture(t, specDir, "spec", "", "")
+				copyArtifactFixture(t, specDir, "tasks", "", "")
+			},
+			wantStage: "plan",
+			wantArgs:  []string{"run", "-pti", "--spec", "001-feature"},
+		},
+		"valid spec and plan": {
+			setup: func(t *testing.T, specDir string) {
+				copyArtifactFixture(t, specDir, "spec", "", "")
+				copyArtifactFixture(t, specDir, "plan", "", "")
+			},
+			wantStage: "tasks",
+			wantArgs:  []string{"run", "-ti", "--spec", "001-feature"},
+		},
+		"all artifacts valid with task progress": {
+			setup: func(t *testing.T, specDir string) {
+				copyArtifactFixture(t, specDir, "spec", "", "")
+				copyArtifactFixture(t, specDir, "plan", "", "")
+				copyArtifactFixture(t, specDir, "tasks", `status: "Pending"`, `status: "Completed"`)
+			},
+			wantStage:     "implement",
+			wantArgs:      []string{"run", "-i", "--resume", "--spec", "001-feature"},
+			wantCompleted: 1,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			specDir := filepath.Join(t.TempDir(), "specs", "001-feature")
+			tt.setup(t, specDir)
+
+			progress := InspectArtifacts(specDir)
+			assert.Equal(t, tt.wantStage, progress.NextStage())
+			assert.Equal(t, tt.wantArgs, progress.ResumeArgs("001-feature"))
+			assert.Equal(t, tt.wantCompleted, progress.TasksCompleted)
+			if tt.wantStage == "implement" {
+				assert.Positive(t, progress.TasksTotal)
+				assert.Contains(t, progress.Summary(), "tasks completed")
+			}
+		})
+	}
+}
+
+// argsRecordingRunner records the autospec arguments of each run.
+type argsRecordingRunner struct {
+	args [][]string
+}
+
+func (r *argsRecordingRunner) Run(
+	_ context.Context,
+	_ string,
+	_, _ io.Writer,
+	_ string,
+	args ...string,
+) (int, error) {
+	r.args = append(r.args, args)
+	return 0, nil
+}
+
+func TestRunAutospecInWorktreeResume(t *testing.T) {
+	tests := map[string]struct {
+		currentStage string
+		artifacts    []string
+		wantArgs     []string
+		wantStage    string
+	}{
+		"fresh spec runs full pipeline": {
+			artifacts: []string{"spec", "plan", "tasks"},
+			wantArgs:  []string{"run", "-spti", "-a", "Build it"},
+			wantStage: "implement",
+		},
+		"resumed spec without artifacts runs full pipeline": {
+			currentStage: "implement",
+			wantArgs:     []string{"run", "-spti", "-a", "Build it"},
+			wantStage:    "implement",
+		},
+		"resumed spec skips to tasks": {
+			currentStage: "implement",
+			artifacts:    []string{"spec", "plan"},
+			wantArgs:     []string{"run", "-ti", "--spec", "001-feature"},
+			wantStage:    "tasks",
+		},
+		"resumed spec continues implement": {
+			currentStage: "plan",
+			artifacts:    []string{"spec", "plan", "tasks"},
+			wantArgs:     []string{"run", "-i", "--resume", "--spec", "001-feature"},
+			wantStage:    "implement",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			worktreePath := filepath.Join(tmpDir, "wt")
+			for _, artifact := range tt.artifacts {
+				copyArtifactFixture(t, filepath.Join(worktreePath, "specs", "001-feature"), artifact, "", "")
+			}
+
+			dagConfig := &DAGConfig{
+				SchemaVersion: "1.0",
+				DAG:           DAGMetadata{Name: "Resume DAG"},
+				Layers:        []Layer{{ID: "L0", Features: []Feature{{ID: "001-feature"}}}},
+			}
+			dagFile := filepath.Join(tmpDir, "dag.yaml")
+			require.NoError(t, SaveDAGWithState(dagFile, dagConfig))
+
+			runner := &argsRecordingRunner{}
+			exec := NewExecutor(dagConfig, dagFile, newMockWorktreeManager(), filepath.Join(tmpDir, "state"),
+				tmpDir, DefaultDAGConfig(), worktree.DefaultConfig(),
+				WithExecutorStdout(io.Discard), WithCommandRunner(runner))
+			exec.state = NewDAGRun(dagFile, dagConfig, 0)
+			exec.state.Specs["001-feature"].CurrentStage = tt.currentStage
+
+			exitCode, err := exec.runAutospecInWorktree(context.Background(), "001-feature", worktreePath, "Build it", io.Discard)
+			require.NoError(t, err)
+			assert.Equal(t, 0, exitCode)
+
+			require.Len(t, runner.args, 1)
+			assert.Equal(t, tt.wantArgs, runner.args[0])
+			assert.Equal(t, tt.wantStage, exec.state.Specs["001-feature"].CurrentStage)
+		})
+	}
+}
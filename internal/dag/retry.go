@@ -0,0 +1,183 @@
+package dag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FailureClass categorizes why a spec attempt failed.
+// Transient classes are retried automatically up to dag.max_spec_retries.
+type FailureClass string
+
+const (
+	// FailureAgentCrash indicates autospec exited abnormally or could not be started.
+	FailureAgentCrash FailureClass = "agent_crash"
+	// FailureTimeout indicates the agent command exceeded its timeout.
+	FailureTimeout FailureClass = "timeout"
+	// FailureValidationExhausted indicates a stage used up its validation retries.
+	FailureValidationExhausted FailureClass = "validation_exhausted"
+	// FailureCommitVerification indicates the post-execution commit flow failed.
+	FailureCommitVerification FailureClass = "commit_verification"
+	// FailureMergeConflict indicates the spec branch conflicts with its staging branch.
+	FailureMergeConflict FailureClass = "merge_conflict"
+	// FailureWorktree indicates the spec's worktree could not be prepared.
+	FailureWorktree FailureClass = "worktree"
+	// FailurePreMergeCheck indicates dag.pre_merge_cmd failed before or after
+	// the staging merge.
+	FailurePreMergeCheck FailureClass = "pre_merge_check"
+)
+
+// IsTransient reports whether a failure of this class may succeed on retry
+// without human intervention.
+func (c FailureClass) IsTransient() bool {
+	switch c {
+	case FailureAgentCrash, FailureTimeout, FailureCommitVerification:
+		return true
+	default:
+		return false
+	}
+}
+
+// Exit codes reported by autospec (see internal/cli/shared/constants.go).
+const (
+	exitCodeRetryExhausted = 2
+	exitCodeTimeout        = 5
+)
+
+// Markers autospec prints when a stage fails. The CLI exits 1 for most
+// errors, so the output tail is checked when the exit code is ambiguous.
+var (
+	timeoutMarkers            = []string{"command timed out after"}
+	validationExhaustedMarker = []string{"retry limit exhausted", "retry exhausted", "exhausted retries"}
+)
+
+// defaultRetryBackoff is the delay before the first automatic retry.
+// Each further retry doubles the delay, up to maxRetryBackoff.
+const (
+	defaultRetryBackoff = 30 * time.Second
+	maxRetryBackoff     = 10 * time.Minute
+)
+
+// failureTailSize is the amount of autospec output kept for classification.
+const failureTailSize = 4096
+
+// ClassifyFailure determines the failure class of a spec attempt from the
+// stage that failed, the autospec exit code, the tail of its output, and
+// the error returned for the stage.
+func ClassifyFailure(stage string, exitCode int, output string, err error) FailureClass {
+	var conflictErr *MergeConflictError
+	switch {
+	case errors.As(err, &conflictErr):
+		return FailureMergeConflict
+	case stage == "worktree":
+		return FailureWorktree
+	case stage == "commit":
+		return FailureCommitVerification
+	case errors.Is(err, context.DeadlineExceeded):
+		return FailureTimeout
+	}
+
+	if stage == "execution" {
+		return FailureAgentCrash
+	}
+
+	lower := strings.ToLower(output)
+	switch {
+	case exitCode == exitCodeTimeout || containsAny(lower, timeoutMarkers):
+		return FailureTimeout
+	case exitCode == exitCodeRetryExhausted || containsAny(lower, validationExhaustedMarker):
+		return FailureValidationExhausted
+	default:
+		return FailureAgentCrash
+	}
+}
+
+// containsAny reports whether s contains any of the substrings.
+func containsAny(s string, substrings []string) bool {
+	for _, sub := range substrings {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// SpecAttempt records a single execution attempt of a spec.
+type SpecAttempt struct {
+	// Number is the 1-based attempt number across runs.
+	Number int `yaml:"number"`
+	// Stage is the stage the attempt started from (implement or commit).
+	Stage string `yaml:"stage,omitempty"`
+	// StartedAt is when the attempt began.
+	StartedAt *time.Time `yaml:"started_at,omitempty"`
+	// CompletedAt is when the attempt finished.
+	CompletedAt *time.Time `yaml:"completed_at,omitempty"`
+	// ExitCode is the autospec exit code (nil if autospec did not run).
+	ExitCode *int `yaml:"exit_code,omitempty"`
+	// FailureClass is the classified failure (empty if the attempt succeeded).
+	FailureClass FailureClass `yaml:"failure_class,omitempty"`
+	// FailureReason contains the error details if the attempt failed.
+	FailureReason string `yaml:"failure_reason,omitempty"`
+}
+
+// attemptError is a classified failure of a single spec attempt.
+type attemptError struct {
+	stage string
+	class FailureClass
+	err   error
+}
+
+func (a *attemptError) Error() string {
+	return fmt.Sprintf("%s: %v", a.stage, a.err)
+}
+
+func (a *attemptError) Unwrap() error {
+	return a.err
+}
+
+// newAttemptError classifies a stage failure.
+func newAttemptError(stage string, exitCode int, output string, err error) *attemptError {
+	return &attemptError{
+		stage: stage,
+		class: ClassifyFailure(stage, exitCode, output, err),
+		err:   err,
+	}
+}
+
+// retryBackoffDelay returns the delay before retry number n (1-based),
+// doubling from base and capped at maxRetryBackoff.
+func retryBackoffDelay(base time.Duration, n int) time.Duration {
+	delay := base
+	for i := 1; i < n; i++ {
+		delay *= 2
+		if delay >= maxRetryBackoff {
+			return maxRetryBackoff
+		}
+	}
+	return delay
+}
+
+// tailBuffer is an io.Writer that keeps the last size bytes written.
+type tailBuffer struct {
+	size int
+	buf  []byte
+}
+
+func newTailBuffer(size int) *tailBuffer {
+	return &tailBuffer{size: size}
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.buf = append(t.buf, p...)
+	if len(t.buf) > t.size {
+		t.buf = t.buf[len(t.buf)-t.size:]
+	}
+	return len(p), nil
+}
+
+func (t *tailBuffer) String() string {
+	return string(t.buf)
+}
@@ -0,0 +1,344 @@
+package dag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/events"
+	"github.com/ariel-frischer/autospec/internal/worktree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyFailure(t *testing.T) {
+	tests := map[string]struct {
+		stage    string
+		exitCode int
+		output   string
+		err      error
+		want     FailureClass
+	}{
+		"worktree error": {
+			stage: "worktree",
+			err:   errors.New("creating worktree: exists"),
+			want:  FailureWorktree,
+		},
+		"command could not start": {
+			stage:    "execution",
+			exitCode: -1,
+			err:      errors.New("exec: \"autospec\": executable file not found"),
+			want:     FailureAgentCrash,
+		},
+		"context deadline": {
+			stage:    "execution",
+			exitCode: -1,
+			err:      fmt.Errorf("running: %w", context.DeadlineExceeded),
+			want:     FailureTimeout,
+		},
+		"timeout exit code": {
+			stage:    "implement",
+			exitCode: 5,
+			err:      errors.New("exit code 5"),
+			want:     FailureTimeout,
+		},
+		"timeout in output": {
+			stage:    "implement",
+			exitCode: 1,
+			output:   "Error: command timed out after 10m0s: claude -p (hint: increase timeout in config)",
+			err:      errors.New("exit code 1"),
+			want:     FailureTimeout,
+		},
+		"retry exhausted exit code": {
+			stage:    "implement",
+			exitCode: 2,
+			err:      errors.New("exit code 2"),
+			want:     FailureValidationExhausted,
+		},
+		"validation retry exhausted in output": {
+			stage:    "implement",
+			exitCode: 1,
+			output:   "Error: plan stage failed: validation failed and retry exhausted: missing field",
+			err:      errors.New("exit code 1"),
+			want:     FailureValidationExhausted,
+		},
+		"phase exhausted retries in output": {
+			stage:    "implement",
+			exitCode: 1,
+			output:   "Error: phase 3 exhausted retries: tasks incomplete",
+			err:      errors.New("exit code 1"),
+			want:     FailureValidationExhausted,
+		},
+		"unexplained non-zero exit": {
+			stage:    "implement",
+			exitCode: 1,
+			output:   "panic: runtime error",
+			err:      errors.New("exit code 1"),
+			want:     FailureAgentCrash,
+		},
+		"commit verification": {
+			stage: "commit",
+			err:   errors.New("no commits ahead of main"),
+			want:  FailureCommitVerification,
+		},
+		"merge conflict": {
+			stage: "merge",
+			err:   fmt.Errorf("staging merge conflict: %w", &MergeConflictError{SpecID: "a"}),
+			want:  FailureMergeConflict,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := ClassifyFailure(tt.stage, tt.exitCode, tt.output, tt.err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFailureClassIsTransient(t *testing.T) {
+	tests := map[FailureClass]bool{
+		FailureAgentCrash:          true,
+		FailureTimeout:             true,
+		FailureCommitVerification:  true,
+		FailureValidationExhausted: false,
+		FailureMergeConflict:       false,
+		FailureWorktree:            false,
+	}
+
+	for class, want := range tests {
+		t.Run(string(class), func(t *testing.T) {
+			assert.Equal(t, want, class.IsTransient())
+		})
+	}
+}
+
+func TestRetryBackoffDelay(t *testing.T) {
+	tests := map[string]struct {
+		base time.Duration
+		n    int
+		want time.Duration
+	}{
+		"first retry uses base":     {base: 30 * time.Second, n: 1, want: 30 * time.Second},
+		"second retry doubles":      {base: 30 * time.Second, n: 2, want: time.Minute},
+		"third retry doubles":       {base: 30 * time.Second, n: 3, want: 2 * time.Minute},
+		"capped at max backoff":     {base: 30 * time.Second, n: 10, want: maxRetryBackoff},
+		"large base is capped":      {base: time.Hour, n: 2, want: maxRetryBackoff},
+		"zero base retries at once": {base: 0, n: 3, want: 0},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, retryBackoffDelay(tt.base, tt.n))
+		})
+	}
+}
+
+func TestTailBuffer(t *testing.T) {
+	tail := newTailBuffer(8)
+
+	n, err := io.WriteString(tail, "hello ")
+	require.NoError(t, err)
+	assert.Equal(t, 6, n)
+	_, err = io.WriteString(tail, "world")
+	require.NoError(t, err)
+
+	assert.Equal(t, "lo world", tail.String())
+}
+
+// sequenceCommandRunner returns exit codes in order, then 0.
+type sequenceCommandRunner struct {
+	exitCodes []int
+	calls     int
+}
+
+func (r *sequenceCommandRunner) Run(
+	_ context.Context,
+	_ string,
+	_, _ io.Writer,
+	_ string,
+	_ ...string,
+) (int, error) {
+	r.calls++
+	if r.calls <= len(r.exitCodes) {
+		return r.exitCodes[r.calls-1], nil
+	}
+	return 0, nil
+}
+
+// newRetryTestExecutor creates an executor for a single-spec DAG with
+// max_spec_retries set and no backoff delay.
+func newRetryTestExecutor(t *testing.T, maxRetries int, runner CommandRunner, opts ...ExecutorOption) (*Executor, string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+
+	dagConfig := &DAGConfig{
+		SchemaVersion: "1.0",
+		DAG:           DAGMetadata{Name: "Retry DAG"},
+		Layers: []Layer{
+			{ID: "L0", Features: []Feature{{ID: "retry-spec", Description: "Retried spec"}}},
+		},
+	}
+	dagFile := filepath.Join(tmpDir, "retry.yaml")
+	require.NoError(t, SaveDAGWithState(dagFile, dagConfig))
+
+	cfg := DefaultDAGConfig()
+	cfg.MaxSpecRetries = maxRetries
+
+	opts = append([]ExecutorOption{
+		WithExecutorStdout(io.Discard),
+		WithCommandRunner(runner),
+		WithRetryBackoff(0),
+	}, opts...)
+	exec := NewExecutor(dagConfig, dagFile, newMockWorktreeManager(), filepath.Join(tmpDir, "state"),
+		tmpDir, cfg, worktree.DefaultConfig(), opts...)
+	return exec, dagFile
+}
+
+func TestExecuteSpecRetries(t *testing.T) {
+	tests := map[string]struct {
+		maxRetries  int
+		exitCodes   []int
+		wantErr     bool
+		wantCalls   int
+		wantClasses []FailureClass
+		wantClass   FailureClass
+	}{
+		"no retries by default": {
+			maxRetries:  0,
+			exitCodes:   []int{1},
+			wantErr:     true,
+			wantCalls:   1,
+			wantClasses: []FailureClass{FailureAgentCrash},
+			wantClass:   FailureAgentCrash,
+		},
+		"transient failure retried until success": {
+			maxRetries:  3,
+			exitCodes:   []int{1, 5},
+			wantCalls:   3,
+			wantClasses: []FailureClass{FailureAgentCrash, FailureTimeout, ""},
+		},
+		"retries exhausted": {
+			maxRetries:  2,
+			exitCodes:   []int{1, 1, 1, 1},
+			wantErr:     true,
+			wantCalls:   3,
+			wantClasses: []FailureClass{FailureAgentCrash, FailureAgentCrash, FailureAgentCrash},
+			wantClass:   FailureAgentCrash,
+		},
+		"validation exhaustion not retried": {
+			maxRetries:  3,
+			exitCodes:   []int{2},
+			wantErr:     true,
+			wantCalls:   1,
+			wantClasses: []FailureClass{FailureValidationExhausted},
+			wantClass:   FailureValidationExhausted,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			runner := &sequenceCommandRunner{exitCodes: tt.exitCodes}
+			exec, dagFile := newRetryTestExecutor(t, tt.maxRetries, runner)
+
+			_, err := exec.Execute(context.Background())
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCalls, runner.calls)
+
+			spec := exec.State().Specs["retry-spec"]
+			assert.Equal(t, tt.wantClass, spec.FailureClass)
+			require.Len(t, spec.Attempts, len(tt.wantClasses))
+			for i, attempt := range spec.Attempts {
+				assert.Equal(t, i+1, attempt.Number)
+				assert.Equal(t, "implement", attempt.Stage)
+				assert.Equal(t, tt.wantClasses[i], attempt.FailureClass)
+				assert.NotNil(t, attempt.CompletedAt)
+				require.NotNil(t, attempt.ExitCode)
+			}
+
+			// Attempts are persisted in the inline specs state
+			config, err := LoadDAGConfigFull(dagFile)
+			require.NoError(t, err)
+			require.Contains(t, config.Specs, "retry-spec")
+			assert.Len(t, config.Specs["retry-spec"].Attempts, len(tt.wantClasses))
+			assert.Equal(t, tt.wantClass, config.Specs["retry-spec"].FailureClass)
+		})
+	}
+}
+
+func TestExecuteSpecPublishesStartedWithWorktree(t *testing.T) {
+	var mu sync.Mutex
+	var started []events.Event
+	unsubscribe := events.Subscribe(func(e events.Event) {
+		if e.Type == events.SpecStarted && e.Spec == "retry-spec" {
+			mu.Lock()
+			started = append(started, e)
+			mu.Unlock()
+		}
+	})
+	defer unsubscribe()
+
+	runner := &sequenceCommandRunner{exitCodes: []int{1}}
+	exec, _ := newRetryTestExecutor(t, 1, runner)
+
+	_, err := exec.Execute(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, runner.calls)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, started, 1, "spec.started is published once across retries")
+	assert.Equal(t, exec.State().Specs["retry-spec"].WorktreePath, started[0].Path)
+	assert.NotEmpty(t, started[0].Path)
+}
+
+func TestExecuteSpecRetryResumesAtCommitStage(t *testing.T) {
+	runner := &sequenceCommandRunner{}
+	exec, dagFile := newRetryTestExecutor(t, 1, runner)
+
+	// A running spec whose worktree exists but is not a git repository, so
+	// commit verification fails after autospec succeeds.
+	worktreePath := filepath.Join(t.TempDir(), "wt")
+	require.NoError(t, os.MkdirAll(worktreePath, 0o755))
+	state := NewDAGRun(dagFile, exec.dag, 0)
+	state.Specs["retry-spec"].Status = SpecStatusRunning
+	state.Specs["retry-spec"].WorktreePath = worktreePath
+	exec.existingState = state
+
+	_, err := exec.Execute(context.Background())
+	require.Error(t, err)
+
+	assert.Equal(t, 1, runner.calls, "retry should not re-run autospec")
+	spec := exec.State().Specs["retry-spec"]
+	assert.Equal(t, FailureCommitVerification, spec.FailureClass)
+	assert.Equal(t, "commit", spec.CurrentStage)
+	require.Len(t, spec.Attempts, 2)
+	assert.Equal(t, "implement", spec.Attempts[0].Stage)
+	assert.Equal(t, "commit", spec.Attempts[1].Stage)
+	assert.Nil(t, spec.Attempts[1].ExitCode)
+	assert.True(t, strings.HasPrefix(spec.Attempts[1].FailureReason, "commit:"))
+}
+
+func TestExecuteSpecRetryStopsOnCancel(t *testing.T) {
+	runner := &sequenceCommandRunner{exitCodes: []int{1, 1}}
+	exec, _ := newRetryTestExecutor(t, 3, runner, WithRetryBackoff(time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := exec.Execute(ctx)
+	require.Error(t, err)
+	assert.Equal(t, 1, runner.calls)
+	assert.Equal(t, SpecStatusFailed, exec.State().Specs["retry-spec"].Status)
+}
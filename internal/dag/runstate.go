@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/ariel-frischer/autospec/internal/cliagent"
 	"gopkg.in/yaml.v3"
 )
 
@@ -111,6 +112,10 @@ type SpecState struct {
 	BlockedBy []string `yaml:"blocked_by,omitempty"`
 	// FailureReason contains detailed error info if failed.
 	FailureReason string `yaml:"failure_reason,omitempty"`
+	// FailureClass categorizes the most recent failure (empty if none).
+	FailureClass FailureClass `yaml:"failure_class,omitempty"`
+	// Attempts records each execution attempt, including automatic retries.
+	Attempts []SpecAttempt `yaml:"attempts,omitempty"`
 	// ExitCode is the exit code of autospec run command (nil if not completed).
 	ExitCode *int `yaml:"exit_code,omitempty"`
 	// Merge tracks the merge status for this spec (nil if not yet merged).
@@ -128,6 +133,8 @@ type SpecState struct {
 	// MergedToStaging indicates whether this spec has been merged into its layer's staging branch.
 	// Set to true after successful staging merge. Used to prevent double-merging on resume.
 	MergedToStaging bool `yaml:"merged_to_staging,omitempty"`
+	// Usage is the agent token and cost usage accumulated for this spec (nil if none reported).
+	Usage *cliagent.Usage `yaml:"usage,omitempty"`
 }
 
 // NewDAGRun creates a new DAGRun with workflow path as primary identifier.
@@ -14,12 +14,16 @@ package dag
 //   - Merge completed spec branches into layer staging branch
 //   - Track merge status per spec (merged_to_staging flag)
 //   - Handle merge conflicts with clear error messages
+//   - Sync the staging chain when the ready-queue scheduler overlaps layers
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -99,20 +103,26 @@ func createBranchFrom(repoRoot, branchName, sourceBranch string) error {
 	return nil
 }
 
-// mergeIntoStaging merges a spec branch into the layer's staging branch.
-// Uses --no-ff to preserve merge history for traceability.
+// mergeIntoStaging merges a spec branch into the layer's staging branch using
+// the given strategy. The default no-ff strategy preserves merge history for
+// traceability; squash commits the spec as one commit whose message is built
+// from description.
 // Returns MergeConflictError if conflicts are detected.
-func mergeIntoStaging(repoRoot, stagingBranch, specBranch, specID string) error {
+func mergeIntoStaging(repoRoot, stagingBranch, specBranch, specID string, strategy MergeStrategy, description string) error {
 	// First checkout the staging branch
 	if err := checkoutBranch(repoRoot, stagingBranch); err != nil {
 		return fmt.Errorf("checking out staging branch: %w", err)
 	}
 
-	// Perform the merge with --no-ff
+	ctx := context.Background()
 	mergeMsg := fmt.Sprintf("Merge spec %s into %s", specID, stagingBranch)
-	conflicts, err := performNoFFMerge(repoRoot, specBranch, mergeMsg)
+	if strategy == MergeStrategySquash {
+		mergeMsg = squashCommitMessage(ctx, repoRoot, stagingBranch, specBranch, specID, description)
+	}
+	conflicts, err := mergeWithStrategy(ctx, repoRoot, strategy, stagingBranch, specBranch, mergeMsg)
 	if err != nil {
 		if len(conflicts) > 0 {
+			publishMergeConflict(specID, stagingBranch, conflicts)
 			return &MergeConflictError{
 				StageBranch: stagingBranch,
 				SpecBranch:  specBranch,
@@ -126,6 +136,136 @@ func mergeIntoStaging(repoRoot, stagingBranch, specBranch, specID string) error
 	return nil
 }
 
+// isAncestor reports whether ancestor is reachable from branch.
+func isAncestor(repoRoot, ancestor, branch string) bool {
+	cmd := exec.Command("git", "merge-base", "--is-ancestor", ancestor, branch)
+	cmd.Dir = repoRoot
+	return cmd.Run() == nil
+}
+
+// syncStartPoint brings the staging chain up to the spec's start point when
+// syncStaging is set. A layer N worktree branches from the layer N-1 staging
+// branch, which may not exist yet or may miss specs merged into earlier
+// layers after it was created.
+func (e *Executor) syncStartPoint(specID string) error {
+	if !e.syncStaging || e.disableLayerStaging || e.dag == nil {
+		return nil
+	}
+	specState := e.state.Specs[specID]
+	if specState == nil {
+		return nil
+	}
+
+	layers := e.getLayersInOrder()
+	for i, layer := range layers {
+		if layer.ID == specState.LayerID {
+			if i == 0 {
+				return nil
+			}
+			return e.syncStagingChain(layers[i-1].ID)
+		}
+	}
+	return nil
+}
+
+// syncStagingChain ensures the staging branches of every layer up to and
+// including layerID exist, and merges each staging branch into the next one
+// that does not already contain it.
+func (e *Executor) syncStagingChain(layerID string) error {
+	e.stagingMu.Lock()
+	defer e.stagingMu.Unlock()
+
+	prevBranch := ""
+	for _, layer := range e.getLayersInOrder() {
+		branch := stageBranchName(e.state.DAGId, layer.ID)
+		if !branchExists(e.repoRoot, branch) {
+			sourceBranch := e.getBaseBranchForLayer(layer.ID)
+			if _, err := createStagingBranch(e.repoRoot, e.state.DAGId, layer.ID, sourceBranch); err != nil {
+				return err
+			}
+			e.updateState(func() { e.recordStagingBranch(layer.ID, branch) })
+			fmt.Fprintf(e.stdout, "[Layer %s] Created staging branch: %s (from %s)\n", layer.ID, branch, sourceBranch)
+		} else if prevBranch != "" && !isAncestor(e.repoRoot, prevBranch, branch) {
+			if err := mergeStagingBranches(e.repoRoot, prevBranch, branch); err != nil {
+				return err
+			}
+			fmt.Fprintf(e.stdout, "[Layer %s] Merged %s into %s\n", layer.ID, prevBranch, branch)
+		}
+
+		if layer.ID == layerID {
+			return nil
+		}
+		prevBranch = branch
+	}
+	return fmt.Errorf("layer %s not found", layerID)
+}
+
+// recordStagingBranch adds a staging branch to the run state without marking
+// any spec as merged.
+func (e *Executor) recordStagingBranch(layerID, branchName string) {
+	if e.state.StagingBranches == nil {
+		e.state.StagingBranches = make(map[string]*StagingBranchInfo)
+	}
+	if e.state.StagingBranches[layerID] == nil {
+		e.state.StagingBranches[layerID] = &StagingBranchInfo{
+			Branch:    branchName,
+			CreatedAt: time.Now(),
+		}
+	}
+}
+
+// mergeStagingBranches merges the previous layer's staging branch into the
+// next layer's staging branch. It always uses a no-ff merge regardless of the
+// configured merge strategy: specs are already squashed or rebased when they
+// enter staging, a squash here would drop the ancestry syncStagingChain checks
+// with isAncestor, and a rebase would rewrite the previous layer's staging
+// branch that its specs were verified against.
+func mergeStagingBranches(repoRoot, fromBranch, intoBranch string) error {
+	if err := checkoutBranch(repoRoot, intoBranch); err != nil {
+		return fmt.Errorf("checking out staging branch: %w", err)
+	}
+
+	mergeMsg := fmt.Sprintf("Merge %s into %s", fromBranch, intoBranch)
+	conflicts, err := performNoFFMerge(repoRoot, fromBranch, mergeMsg)
+	if err != nil {
+		if len(conflicts) > 0 {
+			publishMergeConflict(fromBranch, intoBranch, conflicts)
+			return &MergeConflictError{
+				StageBranch: intoBranch,
+				SpecBranch:  fromBranch,
+				SpecID:      fromBranch,
+				Conflicts:   conflicts,
+			}
+		}
+		return fmt.Errorf("merging %s into %s: %w", fromBranch, intoBranch, err)
+	}
+	return nil
+}
+
+// branchHead returns the commit SHA a branch points to.
+func branchHead(repoRoot, branchName string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--verify", branchName)
+	cmd.Dir = repoRoot
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("resolving %s: %w", branchName, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// resetStagingBranch moves the checked out staging branch back to head,
+// discarding a merge that failed its post-merge check. It uses --keep, so
+// the reset fails instead of discarding uncommitted changes in repoRoot.
+func resetStagingBranch(repoRoot, head string) error {
+	cmd := exec.Command("git", "reset", "--keep", head)
+	cmd.Dir = repoRoot
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git reset failed: %s: %w", string(output), err)
+	}
+	return nil
+}
+
 // checkoutBranch switches to the specified branch.
 func checkoutBranch(repoRoot, branchName string) error {
 	cmd := exec.Command("git", "checkout", branchName)
@@ -157,11 +297,44 @@ func performNoFFMerge(repoRoot, sourceBranch, mergeMsg string) ([]string, error)
 }
 
 // isMergeInProgress returns true if the repository is in the middle of a merge.
-// This is detected by checking for the presence of .git/MERGE_HEAD file.
+// A no-ff merge leaves MERGE_HEAD and a squash merge leaves SQUASH_MSG; both
+// are resolved with git rev-parse --git-path so linked worktrees work too.
+// Staged changes that were not committed also count as an unfinished merge.
 func isMergeInProgress(repoRoot string) bool {
-	gitDir := filepath.Join(repoRoot, ".git")
-	mergeHead := filepath.Join(gitDir, "MERGE_HEAD")
-	_, err := os.Stat(mergeHead)
+	for _, name := range []string{"MERGE_HEAD", "SQUASH_MSG"} {
+		if gitPathExists(repoRoot, name) {
+			return true
+		}
+	}
+	cmd := exec.Command("git", "diff", "--cached", "--quiet", "HEAD")
+	cmd.Dir = repoRoot
+	var exitErr *exec.ExitError
+	return errors.As(cmd.Run(), &exitErr) && exitErr.ExitCode() == 1
+}
+
+// gitPath resolves name inside the git directory of repoRoot, which is
+// not repoRoot/.git in a linked worktree.
+func gitPath(repoRoot, name string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--git-path", name)
+	cmd.Dir = repoRoot
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("resolving git path %s: %w", name, err)
+	}
+	path := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(repoRoot, path)
+	}
+	return path, nil
+}
+
+// gitPathExists reports whether the file git resolves for name exists.
+func gitPathExists(repoRoot, name string) bool {
+	path, err := gitPath(repoRoot, name)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
 	return err == nil
 }
 
@@ -174,7 +347,7 @@ func validateMergeResolution(repoRoot string) error {
 		return fmt.Errorf("unresolved conflicts in %d file(s): %v", len(conflicts), conflicts)
 	}
 
-	// If MERGE_HEAD exists but no conflicts, user resolved but didn't commit
+	// If the merge state remains but no conflicts, user resolved but didn't commit
 	if isMergeInProgress(repoRoot) {
 		return fmt.Errorf("merge in progress but not committed; run 'git commit' to complete")
 	}
@@ -1,7 +1,8 @@
 package dag
 
 import (
-	"os"
+	"io"
+	"os/exec"
 	"path/filepath"
 	"testing"
 )
@@ -106,36 +107,53 @@ func TestMergeConflictError_Error(t *testing.T) {
 
 func TestIsMergeInProgress(t *testing.T) {
 	tests := map[string]struct {
-		setupFunc func(t *testing.T, repoRoot string)
+		setupFunc func(t *testing.T) string
 		expected  bool
 	}{
-		"no merge in progress": {
-			setupFunc: func(t *testing.T, repoRoot string) {
-				// Create .git directory but no MERGE_HEAD
-				gitDir := filepath.Join(repoRoot, ".git")
-				if err := os.MkdirAll(gitDir, 0755); err != nil {
-					t.Fatalf("failed to create .git dir: %v", err)
-				}
+		"clean repository": {
+			setupFunc: func(t *testing.T) string {
+				return setupDivergedRepo(t)
 			},
 			expected: false,
 		},
 		"merge in progress": {
-			setupFunc: func(t *testing.T, repoRoot string) {
-				// Create .git directory with MERGE_HEAD
-				gitDir := filepath.Join(repoRoot, ".git")
-				if err := os.MkdirAll(gitDir, 0755); err != nil {
-					t.Fatalf("failed to create .git dir: %v", err)
-				}
-				mergeHead := filepath.Join(gitDir, "MERGE_HEAD")
-				if err := os.WriteFile(mergeHead, []byte("abc123"), 0644); err != nil {
-					t.Fatalf("failed to create MERGE_HEAD: %v", err)
-				}
+			setupFunc: func(t *testing.T) string {
+				repo := setupDivergedRepo(t)
+				mustGit(t, repo, "merge", "--no-ff", "--no-commit", "spec-a")
+				return repo
+			},
+			expected: true,
+		},
+		"squash merge not committed": {
+			setupFunc: func(t *testing.T) string {
+				repo := setupDivergedRepo(t)
+				mustGit(t, repo, "merge", "--squash", "spec-a")
+				return repo
+			},
+			expected: true,
+		},
+		"staged changes without merge state": {
+			setupFunc: func(t *testing.T) string {
+				repo := setupDivergedRepo(t)
+				createFile(t, repo, "README.md", "resolved")
+				mustGit(t, repo, "add", "README.md")
+				return repo
+			},
+			expected: true,
+		},
+		"merge in progress in linked worktree": {
+			setupFunc: func(t *testing.T) string {
+				repo := setupDivergedRepo(t)
+				wt := filepath.Join(t.TempDir(), "wt")
+				mustGit(t, repo, "worktree", "add", "-b", "wt-branch", wt, "main")
+				mustGit(t, wt, "merge", "--no-ff", "--no-commit", "spec-a")
+				return wt
 			},
 			expected: true,
 		},
 		"no git directory": {
-			setupFunc: func(_ *testing.T, _ string) {
-				// Don't create .git directory
+			setupFunc: func(t *testing.T) string {
+				return t.TempDir()
 			},
 			expected: false,
 		},
@@ -143,8 +161,7 @@ func TestIsMergeInProgress(t *testing.T) {
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			repoRoot := t.TempDir()
-			tt.setupFunc(t, repoRoot)
+			repoRoot := tt.setupFunc(t)
 
 			result := isMergeInProgress(repoRoot)
 			if result != tt.expected {
@@ -156,31 +173,30 @@ func TestIsMergeInProgress(t *testing.T) {
 
 func TestValidateMergeResolution(t *testing.T) {
 	tests := map[string]struct {
-		setupFunc func(t *testing.T, repoRoot string)
+		setupFunc func(t *testing.T) string
 		wantErr   bool
 		errMsg    string
 	}{
 		"no merge state - clean repo": {
-			setupFunc: func(t *testing.T, repoRoot string) {
-				// Create minimal git structure
-				gitDir := filepath.Join(repoRoot, ".git")
-				if err := os.MkdirAll(gitDir, 0755); err != nil {
-					t.Fatalf("failed to create .git dir: %v", err)
-				}
+			setupFunc: func(t *testing.T) string {
+				return setupDivergedRepo(t)
 			},
 			wantErr: false,
 		},
 		"merge in progress not committed": {
-			setupFunc: func(t *testing.T, repoRoot string) {
-				// Create .git with MERGE_HEAD (merge started but not committed)
-				gitDir := filepath.Join(repoRoot, ".git")
-				if err := os.MkdirAll(gitDir, 0755); err != nil {
-					t.Fatalf("failed to create .git dir: %v", err)
-				}
-				mergeHead := filepath.Join(gitDir, "MERGE_HEAD")
-				if err := os.WriteFile(mergeHead, []byte("abc123"), 0644); err != nil {
-					t.Fatalf("failed to create MERGE_HEAD: %v", err)
-				}
+			setupFunc: func(t *testing.T) string {
+				repo := setupDivergedRepo(t)
+				mustGit(t, repo, "merge", "--no-ff", "--no-commit", "spec-a")
+				return repo
+			},
+			wantErr: true,
+			errMsg:  "merge in progress but not committed",
+		},
+		"squash merge not committed": {
+			setupFunc: func(t *testing.T) string {
+				repo := setupDivergedRepo(t)
+				mustGit(t, repo, "merge", "--squash", "spec-a")
+				return repo
 			},
 			wantErr: true,
 			errMsg:  "merge in progress but not committed",
@@ -189,8 +205,7 @@ func TestValidateMergeResolution(t *testing.T) {
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			repoRoot := t.TempDir()
-			tt.setupFunc(t, repoRoot)
+			repoRoot := tt.setupFunc(t)
 
 			err := validateMergeResolution(repoRoot)
 			if tt.wantErr {
@@ -205,3 +220,119 @@ func TestValidateMergeResolution(t *testing.T) {
 		})
 	}
 }
+
+// TestSyncStagingChain checks that staging branches are synced with no-ff
+// merges under every merge strategy, without rewriting earlier layers.
+func TestSyncStagingChain(t *testing.T) {
+	for _, strategy := range ValidMergeStrategies {
+		t.Run(string(strategy), func(t *testing.T) {
+			repo, cleanup := setupTestRepo(t)
+			defer cleanup()
+
+			createFile(t, repo, "README.md", "base")
+			mustGit(t, repo, "add", ".")
+			mustGit(t, repo, "commit", "-m", "initial")
+			mustGit(t, repo, "branch", "-M", "main")
+
+			dagCfg := &DAGConfig{
+				DAG: DAGMetadata{ID: "sync"},
+				Layers: []Layer{
+					{ID: "L0", Features: []Feature{{ID: "spec-a"}}},
+					{ID: "L1", DependsOn: []string{"L0"}, Features: []Feature{{ID: "spec-b"}}},
+					{ID: "L2", DependsOn: []string{"L1"}, Features: []Feature{{ID: "spec-c"}}},
+				},
+			}
+			cfg := DefaultDAGConfig()
+			cfg.BaseBranch = "main"
+			cfg.MergeStrategy = string(strategy)
+			executor := NewExecutor(dagCfg, "dag.yaml", nil, "", repo, cfg, nil, WithExecutorStdout(io.Discard))
+			executor.state = NewDAGRun("dag.yaml", dagCfg, 2)
+
+			// L1 staging exists but was cut before a later L0 merge
+			mustGit(t, repo, "branch", "dag/sync/stage-L0", "main")
+			mustGit(t, repo, "branch", "dag/sync/stage-L1", "dag/sync/stage-L0")
+			mustGit(t, repo, "checkout", "dag/sync/stage-L1")
+			createFile(t, repo, "b.txt", "spec-b")
+			mustGit(t, repo, "add", ".")
+			mustGit(t, repo, "commit", "-m", "spec-b")
+			mustGit(t, repo, "checkout", "dag/sync/stage-L0")
+			createFile(t, repo, "a.txt", "spec-a")
+			mustGit(t, repo, "add", ".")
+			mustGit(t, repo, "commit", "-m", "spec-a")
+			l0Head, err := branchHead(repo, "dag/sync/stage-L0")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := executor.syncStagingChain("L2"); err != nil {
+				t.Fatalf("syncStagingChain() error: %v", err)
+			}
+
+			if got, _ := branchHead(repo, "dag/sync/stage-L0"); got != l0Head {
+				t.Errorf("stage-L0 moved from %s to %s", l0Head, got)
+			}
+			if !isAncestor(repo, "dag/sync/stage-L0", "dag/sync/stage-L1") {
+				t.Error("stage-L1 does not contain stage-L0 after sync")
+			}
+			if !branchExists(repo, "dag/sync/stage-L2") {
+				t.Fatal("stage-L2 was not created")
+			}
+			if !isAncestor(repo, "dag/sync/stage-L1", "dag/sync/stage-L2") {
+				t.Error("stage-L2 does not contain stage-L1")
+			}
+			if executor.state.StagingBranches["L2"] == nil {
+				t.Error("stage-L2 not recorded in run state")
+			}
+		})
+	}
+}
+
+func TestSyncStartPoint_Disabled(t *testing.T) {
+	dagCfg := &DAGConfig{
+		Layers: []Layer{
+			{ID: "L0", Features: []Feature{{ID: "spec-a"}}},
+			{ID: "L1", Features: []Feature{{ID: "spec-b"}}},
+		},
+	}
+
+	tests := map[string]struct {
+		syncStaging    bool
+		disableStaging bool
+		specID         string
+	}{
+		"sequential execution": {specID: "spec-b"},
+		"layer staging disabled": {
+			syncStaging:    true,
+			disableStaging: true,
+			specID:         "spec-b",
+		},
+		"first layer": {
+			syncStaging: true,
+			specID:      "spec-a",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// repoRoot is not a git repository, so any git call would fail
+			executor := NewExecutor(dagCfg, "dag.yaml", nil, "", t.TempDir(), DefaultDAGConfig(), nil,
+				WithDisableLayerStaging(tt.disableStaging))
+			executor.state = NewDAGRun("dag.yaml", dagCfg, 0)
+			executor.syncStaging = tt.syncStaging
+
+			if err := executor.syncStartPoint(tt.specID); err != nil {
+				t.Errorf("syncStartPoint(%s) error: %v", tt.specID, err)
+			}
+		})
+	}
+}
+
+// mustGit runs a git command in dir and fails the test on error.
+func mustGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %s: %v", args, output, err)
+	}
+}
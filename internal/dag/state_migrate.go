@@ -193,8 +193,11 @@ func convertSpecState(spec *SpecState) *InlineSpecState {
 		CommitSHA:     spec.CommitSHA,
 		CommitStatus:  spec.CommitStatus,
 		FailureReason: spec.FailureReason,
+		FailureClass:  spec.FailureClass,
+		Attempts:      spec.Attempts,
 		ExitCode:      spec.ExitCode,
 		Merge:         spec.Merge,
+		Usage:         spec.Usage,
 	}
 }
 
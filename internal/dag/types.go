@@ -1,6 +1,10 @@
 package dag
 
-import "time"
+import (
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+)
 
 // DAGConfig represents the root configuration structure for a DAG file.
 // It contains schema version information, DAG metadata, the ordered list of layers,
@@ -76,6 +80,10 @@ const (
 	MergeStatusMergeFailed MergeStatus = "merge_failed"
 	// MergeStatusSkipped indicates the spec was skipped during merge.
 	MergeStatusSkipped MergeStatus = "skipped"
+	// MergeStatusPROpen indicates a pull request for the spec awaits review.
+	MergeStatusPROpen MergeStatus = "pr_open"
+	// MergeStatusPRClosed indicates the spec's pull request was closed unmerged.
+	MergeStatusPRClosed MergeStatus = "pr_closed"
 )
 
 // MergeState tracks the merge status for a single spec within a DAG run.
@@ -90,6 +98,12 @@ type MergeState struct {
 	ResolutionMethod string `yaml:"resolution_method,omitempty"`
 	// Error contains the error message if merge failed.
 	Error string `yaml:"error,omitempty"`
+	// PRURL is the web URL of the pull request opened by dag merge --pr.
+	PRURL string `yaml:"pr_url,omitempty"`
+	// PRNumber is the provider's pull request number (GitLab: merge request IID).
+	PRNumber int `yaml:"pr_number,omitempty"`
+	// PRState is the last known review state of the pull request.
+	PRState PRState `yaml:"pr_state,omitempty"`
 }
 
 // CommitStatus represents the commit status of a spec after execution.
@@ -192,10 +206,16 @@ type InlineSpecState struct {
 	CommitStatus CommitStatus `yaml:"commit_status,omitempty"`
 	// FailureReason contains detailed error info if failed.
 	FailureReason string `yaml:"failure_reason,omitempty"`
+	// FailureClass categorizes the most recent failure (empty if none).
+	FailureClass FailureClass `yaml:"failure_class,omitempty"`
+	// Attempts records each execution attempt, including automatic retries.
+	Attempts []SpecAttempt `yaml:"attempts,omitempty"`
 	// ExitCode is the exit code of autospec run command.
 	ExitCode *int `yaml:"exit_code,omitempty"`
 	// Merge tracks the merge status for this spec (nil if not yet merged).
 	Merge *MergeState `yaml:"merge,omitempty"`
+	// Usage is the agent token and cost usage accumulated for this spec.
+	Usage *cliagent.Usage `yaml:"usage,omitempty"`
 }
 
 // InlineLayerStaging represents state tracking for layer staging branches.
@@ -0,0 +1,72 @@
+package dag
+
+import (
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/ariel-frischer/autospec/internal/retry"
+)
+
+// specUsageSnapshot returns the usage totals persisted by the autospec
+// subprocess for a spec. Returns nil when usage tracking is not configured.
+func (e *Executor) specUsageSnapshot(specID string) *retry.UsageState {
+	if e.usageStateDir == "" {
+		return nil
+	}
+	total, err := retry.SpecUsageTotal(e.usageStateDir, specID)
+	if err != nil {
+		return nil
+	}
+	return total
+}
+
+// recordSpecUsage adds the usage consumed since baseline to the spec state.
+// Usage accumulates across retries and resumed runs of the same spec.
+func (e *Executor) recordSpecUsage(specID string, baseline *retry.UsageState) {
+	if baseline == nil {
+		return
+	}
+	current := e.specUsageSnapshot(specID)
+	if current == nil {
+		return
+	}
+
+	delta := usageDelta(baseline, current)
+	if delta.IsZero() {
+		return
+	}
+
+	specState := e.state.Specs[specID]
+	e.updateState(func() {
+		if specState.Usage == nil {
+			specState.Usage = &cliagent.Usage{}
+		}
+		specState.Usage.Add(delta)
+	})
+}
+
+// usageDelta returns the usage recorded between two snapshots.
+// Returns zero usage if the counters went backwards (usage was reset).
+func usageDelta(before, after *retry.UsageState) cliagent.Usage {
+	delta := cliagent.Usage{
+		InputTokens:              after.InputTokens - before.InputTokens,
+		OutputTokens:             after.OutputTokens - before.OutputTokens,
+		CacheCreationInputTokens: after.CacheCreationInputTokens - before.CacheCreationInputTokens,
+		CacheReadInputTokens:     after.CacheReadInputTokens - before.CacheReadInputTokens,
+		CostUSD:                  after.CostUSD - before.CostUSD,
+	}
+	if delta.InputTokens < 0 || delta.OutputTokens < 0 || delta.CacheCreationInputTokens < 0 ||
+		delta.CacheReadInputTokens < 0 || delta.CostUSD < 0 {
+		return cliagent.Usage{}
+	}
+	return delta
+}
+
+// InlineUsageTotal sums usage across all specs in inline DAG state.
+func InlineUsageTotal(specs map[string]*InlineSpecState) cliagent.Usage {
+	var total cliagent.Usage
+	for _, spec := range specs {
+		if spec != nil && spec.Usage != nil {
+			total.Add(*spec.Usage)
+		}
+	}
+	return total
+}
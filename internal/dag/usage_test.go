@@ -0,0 +1,125 @@
+package dag
+
+import (
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordSpecUsage(t *testing.T) {
+	tests := map[string]struct {
+		usageStateDir bool
+		existing      *cliagent.Usage
+		recorded      []*retry.UsageState
+		want          *cliagent.Usage
+	}{
+		"no usage state dir": {
+			recorded: []*retry.UsageState{
+				{SpecName: "001-feature", Scope: retry.StageUsageScope("plan"), InputTokens: 10},
+			},
+		},
+		"records delta since baseline": {
+			usageStateDir: true,
+			recorded: []*retry.UsageState{
+				{SpecName: "001-feature", Scope: retry.StageUsageScope("plan"), InputTokens: 10, CostUSD: 0.1},
+				{SpecName: "001-feature", Scope: retry.StageUsageScope("implement"), OutputTokens: 20, CostUSD: 0.2},
+				{SpecName: "002-other", Scope: retry.StageUsageScope("plan"), InputTokens: 99},
+			},
+			want: &cliagent.Usage{InputTokens: 10, OutputTokens: 20, CostUSD: 0.3},
+		},
+		"accumulates onto existing usage": {
+			usageStateDir: true,
+			existing:      &cliagent.Usage{InputTokens: 5, CostUSD: 1},
+			recorded: []*retry.UsageState{
+				{SpecName: "001-feature", Scope: retry.StageUsageScope("plan"), InputTokens: 10, CostUSD: 0.5},
+			},
+			want: &cliagent.Usage{InputTokens: 15, CostUSD: 1.5},
+		},
+		"no new usage": {
+			usageStateDir: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			stateDir := t.TempDir()
+			// Pre-existing usage is part of the baseline and must not be counted
+			require.NoError(t, retry.RecordUsage(stateDir, &retry.UsageState{
+				SpecName: "001-feature", Scope: retry.StageUsageScope("specify"), InputTokens: 1000,
+			}))
+
+			e := &Executor{
+				state: &DAGRun{Specs: map[string]*SpecState{
+					"001-feature": {SpecID: "001-feature", Usage: tt.existing},
+				}},
+			}
+			if tt.usageStateDir {
+				WithUsageStateDir(stateDir)(e)
+			}
+
+			baseline := e.specUsageSnapshot("001-feature")
+			for _, u := range tt.recorded {
+				require.NoError(t, retry.RecordUsage(stateDir, u))
+			}
+			e.recordSpecUsage("001-feature", baseline)
+
+			got := e.state.Specs["001-feature"].Usage
+			if tt.want == nil {
+				assert.Equal(t, tt.existing, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, tt.want.TotalTokens(), got.TotalTokens())
+			assert.InDelta(t, tt.want.CostUSD, got.CostUSD, 1e-9)
+		})
+	}
+}
+
+func TestUsageDelta_Reset(t *testing.T) {
+	before := &retry.UsageState{InputTokens: 100, CostUSD: 1}
+	after := &retry.UsageState{InputTokens: 10, CostUSD: 0.1}
+
+	assert.True(t, usageDelta(before, after).IsZero())
+}
+
+func TestInlineUsageTotal(t *testing.T) {
+	specs := map[string]*InlineSpecState{
+		"a": {Usage: &cliagent.Usage{InputTokens: 10, CostUSD: 0.1}},
+		"b": {Usage: &cliagent.Usage{OutputTokens: 5, CostUSD: 0.2}},
+		"c": {},
+		"d": nil,
+	}
+
+	total := InlineUsageTotal(specs)
+	assert.Equal(t, 15, total.TotalTokens())
+	assert.InDelta(t, 0.3, total.CostUSD, 1e-9)
+}
+
+func TestParseSpecState_Usage(t *testing.T) {
+	content := `schema_version: "1.0"
+dag:
+  name: Test
+layers:
+  - id: L0
+    features:
+      - id: 001-feature
+        description: Test feature
+specs:
+  001-feature:
+    status: completed
+    usage:
+      input_tokens: 120
+      output_tokens: 30
+      cost_usd: 0.42
+`
+	result, err := ParseDAGBytes([]byte(content))
+	require.NoError(t, err)
+	require.NotNil(t, result.Config.Specs["001-feature"])
+	usage := result.Config.Specs["001-feature"].Usage
+	require.NotNil(t, usage)
+	assert.Equal(t, 150, usage.TotalTokens())
+	assert.InDelta(t, 0.42, usage.CostUSD, 1e-9)
+}
@@ -24,6 +24,7 @@ func SaveDAGWithState(path string, config *DAGConfig) error {
 	if err != nil {
 		return fmt.Errorf("marshaling DAG config: %w", err)
 	}
+	data = preserveSchemaModeline(path, data)
 
 	if err := atomicWriteToFile(path, data); err != nil {
 		return fmt.Errorf("writing DAG file: %w", err)
@@ -174,3 +174,15 @@ func GitNotRepository() *CLIError {
 		"Or navigate to an existing repository",
 	)
 }
+
+// BudgetExceeded creates an error when a spending limit from the budget config is reached.
+// configKey names the limit (e.g., "budget.max_run_cost_usd") and resumeCommand
+// is the command that continues the interrupted work.
+func BudgetExceeded(message, configKey, resumeCommand string) *CLIError {
+	return NewRuntimeError(
+		message,
+		fmt.Sprintf("Raise or remove %s in .autospec/config.yml (0 disables the limit)", configKey),
+		"Review recorded spending with: autospec status -v",
+		"Resume once adjusted: "+resumeCommand,
+	)
+}
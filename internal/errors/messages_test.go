@@ -189,3 +189,18 @@ func TestGitNotRepository(t *testing.T) {
 		t.Errorf("Expected Prerequisite category, got %v", err.Category)
 	}
 }
+
+func TestBudgetExceeded(t *testing.T) {
+	err := BudgetExceeded("run budget exceeded", "budget.max_run_cost_usd", "autospec dag run dag.yaml")
+
+	if err.Category != Runtime {
+		t.Errorf("Expected Runtime category, got %v", err.Category)
+	}
+	remediation := strings.Join(err.Remediation, "\n")
+	if !strings.Contains(remediation, "budget.max_run_cost_usd") {
+		t.Error("Expected remediation to name the config key")
+	}
+	if !strings.Contains(remediation, "autospec dag run dag.yaml") {
+		t.Error("Expected remediation to include the resume command")
+	}
+}
@@ -0,0 +1,173 @@
+// Package events provides an in-process event bus for workflow lifecycle events
+// (commands, stages, retries, validation, tasks, worktrees, and merges).
+// Publishers call Publish; subscribers such as the JSONL sink register with
+// Subscribe. Dispatch is synchronous and in publish order, so every subscriber
+// sees the same deterministic sequence of events.
+package events
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// Type identifies the kind of lifecycle event.
+type Type string
+
+// Event type constants.
+const (
+	// CommandStarted is published when a CLI command begins executing.
+	CommandStarted Type = "command.started"
+	// CommandCompleted is published when a CLI command finishes (Status: completed, failed, cancelled).
+	CommandCompleted Type = "command.completed"
+	// StageStarted is published when a workflow stage begins.
+	StageStarted Type = "stage.started"
+	// StageCompleted is published when a workflow stage finishes (Status: completed or failed).
+	StageCompleted Type = "stage.completed"
+	// StageRetried is published when a stage is retried after a failed attempt.
+	StageRetried Type = "stage.retried"
+	// ValidationFailed is published when a stage's artifact fails validation.
+	ValidationFailed Type = "validation.failed"
+	// TaskStatusChanged is published when a task's status in tasks.yaml changes.
+	TaskStatusChanged Type = "task.status_changed"
+	// WorktreeCreated is published when a git worktree is created.
+	WorktreeCreated Type = "worktree.created"
+	// MergeConflict is published when a merge stops on conflicting files.
+	MergeConflict Type = "merge.conflict"
+	// SpecStarted is published when a DAG run starts executing a spec.
+	SpecStarted Type = "spec.started"
+	// SpecCompleted is published when a DAG run finishes a spec (Status: completed or failed).
+	SpecCompleted Type = "spec.completed"
+)
+
+// Status values used by completion events.
+const (
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+	StatusCancelled = "cancelled"
+)
+
+// Event is a single lifecycle event. Only the fields relevant to Type are set.
+type Event struct {
+	// Type is the event kind.
+	Type Type `json:"type"`
+	// Time is when the event was published.
+	Time time.Time `json:"time"`
+	// PID is the publishing process. DAG runs spawn one process per spec,
+	// all appending to the same sink.
+	PID int `json:"pid"`
+	// Command is the CLI command name (e.g., "run", "dag-run").
+	Command string `json:"command,omitempty"`
+	// Spec is the spec name or ID.
+	Spec string `json:"spec,omitempty"`
+	// Stage is the workflow stage (e.g., "plan").
+	Stage string `json:"stage,omitempty"`
+	// TaskID is the task identifier (e.g., "T003").
+	TaskID string `json:"task_id,omitempty"`
+	// Status is the outcome or new status.
+	Status string `json:"status,omitempty"`
+	// PreviousStatus is the status before a change.
+	PreviousStatus string `json:"previous_status,omitempty"`
+	// Attempt is the 1-based attempt number for stage events.
+	Attempt int `json:"attempt,omitempty"`
+	// MaxAttempts is the total attempts allowed (1 + max_retries).
+	MaxAttempts int `json:"max_attempts,omitempty"`
+	// Agent is the agent that ran the stage.
+	Agent string `json:"agent,omitempty"`
+	// Path is a file or worktree path.
+	Path string `json:"path,omitempty"`
+	// Branch is a git branch name.
+	Branch string `json:"branch,omitempty"`
+	// Files lists affected files (e.g., conflicted files).
+	Files []string `json:"files,omitempty"`
+	// Errors lists validation errors.
+	Errors []string `json:"errors,omitempty"`
+	// DurationMS is the elapsed time in milliseconds for completion events.
+	DurationMS int64 `json:"duration_ms,omitempty"`
+	// Error is the failure message.
+	Error string `json:"error,omitempty"`
+}
+
+// Handler receives published events.
+type Handler func(Event)
+
+// subscription is a registered handler.
+type subscription struct {
+	id      int
+	handler Handler
+}
+
+// Bus dispatches events to subscribers in subscription order.
+type Bus struct {
+	mu     sync.RWMutex
+	subs   []subscription
+	nextID int
+}
+
+// NewBus creates an empty event bus.
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe registers handler for all events.
+// Returns an unsubscribe function that should be deferred.
+func (b *Bus) Subscribe(handler Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.subs = append(b.subs, subscription{id: id, handler: handler})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		for i, sub := range b.subs {
+			if sub.id == id {
+				b.subs = append(b.subs[:i:i], b.subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish stamps e with the current time and process ID and delivers it to
+// every subscriber. Handler panics are recovered so a failing subscriber
+// never affects the workflow or other subscribers.
+func (b *Bus) Publish(e Event) {
+	b.mu.RLock()
+	subs := b.subs
+	b.mu.RUnlock()
+	if len(subs) == 0 {
+		return
+	}
+
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	if e.PID == 0 {
+		e.PID = os.Getpid()
+	}
+	for _, sub := range subs {
+		deliver(sub.handler, e)
+	}
+}
+
+// deliver calls handler with panic recovery.
+func deliver(handler Handler, e Event) {
+	defer func() { _ = recover() }()
+	handler(e)
+}
+
+// defaultBus is the process-wide bus used by the package-level functions.
+var defaultBus = NewBus()
+
+// Subscribe registers handler on the process-wide bus.
+func Subscribe(handler Handler) func() {
+	return defaultBus.Subscribe(handler)
+}
+
+// Publish publishes e on the process-wide bus.
+func Publish(e Event) {
+	defaultBus.Publish(e)
+}
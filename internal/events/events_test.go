@@ -0,0 +1,92 @@
+package events
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBus_PublishOrder(t *testing.T) {
+	t.Parallel()
+
+	bus := NewBus()
+	var got []string
+	bus.Subscribe(func(e Event) { got = append(got, "a:"+string(e.Type)) })
+	bus.Subscribe(func(e Event) { got = append(got, "b:"+string(e.Type)) })
+
+	bus.Publish(Event{Type: StageStarted})
+	bus.Publish(Event{Type: StageCompleted})
+
+	assert.Equal(t, []string{
+		"a:stage.started", "b:stage.started",
+		"a:stage.completed", "b:stage.completed",
+	}, got)
+}
+
+func TestBus_Unsubscribe(t *testing.T) {
+	t.Parallel()
+
+	bus := NewBus()
+	var first, second int
+	unsubscribe := bus.Subscribe(func(Event) { first++ })
+	bus.Subscribe(func(Event) { second++ })
+
+	bus.Publish(Event{Type: CommandStarted})
+	unsubscribe()
+	unsubscribe() // Calling twice is a no-op
+	bus.Publish(Event{Type: CommandStarted})
+
+	assert.Equal(t, 1, first)
+	assert.Equal(t, 2, second)
+}
+
+func TestBus_RecoversHandlerPanic(t *testing.T) {
+	t.Parallel()
+
+	bus := NewBus()
+	var delivered bool
+	bus.Subscribe(func(Event) { panic("boom") })
+	bus.Subscribe(func(Event) { delivered = true })
+
+	assert.NotPanics(t, func() { bus.Publish(Event{Type: MergeConflict}) })
+	assert.True(t, delivered)
+}
+
+func TestBus_StampsEvents(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		event Event
+		check func(t *testing.T, e Event)
+	}{
+		"zero time and pid are stamped": {
+			event: Event{Type: TaskStatusChanged},
+			check: func(t *testing.T, e Event) {
+				assert.False(t, e.Time.IsZero())
+				assert.Equal(t, os.Getpid(), e.PID)
+			},
+		},
+		"explicit time and pid are kept": {
+			event: Event{Type: TaskStatusChanged, Time: time.Unix(100, 0), PID: 42},
+			check: func(t *testing.T, e Event) {
+				assert.True(t, e.Time.Equal(time.Unix(100, 0)))
+				assert.Equal(t, 42, e.PID)
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			bus := NewBus()
+			var got []Event
+			bus.Subscribe(func(e Event) { got = append(got, e) })
+			bus.Publish(tt.event)
+			require.Len(t, got, 1)
+			tt.check(t, got[0])
+		})
+	}
+}
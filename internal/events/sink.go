@@ -0,0 +1,78 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// SinkEnvVar is the environment variable holding the JSONL sink path.
+// It maps to the events.sink config key and is exported to child processes
+// (DAG spec runs, agent-invoked update-task) so they append to the same file.
+const SinkEnvVar = "AUTOSPEC_EVENTS_SINK"
+
+// Config configures event output.
+//
+// Example YAML configuration:
+//
+//	events:
+//	  sink: .autospec/events.jsonl
+type Config struct {
+	// Sink is a file path that receives every event as one JSON object per line.
+	// Empty disables the sink. Overridden by the --events-file flag.
+	// Environment variable: AUTOSPEC_EVENTS_SINK
+	Sink string `koanf:"sink" yaml:"sink,omitempty"`
+}
+
+// JSONLSink writes events as JSON Lines.
+type JSONLSink struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewJSONLSink creates a sink writing to w.
+func NewJSONLSink(w io.Writer) *JSONLSink {
+	return &JSONLSink{w: w}
+}
+
+// OpenJSONLFile opens path for appending, creating it and its parent
+// directory if needed, and returns a sink writing to it.
+func OpenJSONLFile(path string) (*JSONLSink, error) {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("creating events directory: %w", err)
+		}
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("opening events file: %w", err)
+	}
+	return &JSONLSink{w: f, closer: f}, nil
+}
+
+// Handle writes e as a single line. Each event is written with one Write call
+// so concurrent processes appending to the same file do not interleave lines.
+// Write errors are ignored; events are best-effort and never fail a workflow.
+func (s *JSONLSink) Handle(e Event) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, _ = s.w.Write(data)
+}
+
+// Close closes the underlying file, if the sink owns one.
+func (s *JSONLSink) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
@@ -0,0 +1,59 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONLSink_Handle(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	sink := NewJSONLSink(&buf)
+	sink.Handle(Event{
+		Type:  ValidationFailed,
+		Time:  time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		PID:   7,
+		Spec:  "003-auth",
+		Stage: "plan",
+		Errors: []string{
+			"missing field: summary",
+		},
+	})
+	sink.Handle(Event{Type: CommandCompleted, Time: time.Date(2025, 1, 2, 3, 4, 6, 0, time.UTC), PID: 7, Command: "plan", Status: StatusCompleted})
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	assert.JSONEq(t, `{"type":"validation.failed","time":"2025-01-02T03:04:05Z","pid":7,"spec":"003-auth","stage":"plan","errors":["missing field: summary"]}`, lines[0])
+	assert.JSONEq(t, `{"type":"command.completed","time":"2025-01-02T03:04:06Z","pid":7,"command":"plan","status":"completed"}`, lines[1])
+}
+
+func TestOpenJSONLFile_Appends(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "nested", "events.jsonl")
+	for _, stage := range []string{"plan", "tasks"} {
+		sink, err := OpenJSONLFile(path)
+		require.NoError(t, err)
+		sink.Handle(Event{Type: StageStarted, Stage: stage})
+		require.NoError(t, sink.Close())
+	}
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	require.Len(t, lines, 2)
+
+	var e Event
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &e))
+	assert.Equal(t, StageStarted, e.Type)
+	assert.Equal(t, "tasks", e.Stage)
+}
@@ -0,0 +1,51 @@
+// Package graphexport provides the identifier quoting, escaping, and status
+// colours shared by the Mermaid and DOT renderers of DAG and task graphs.
+package graphexport
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Style is the fill and stroke colour used for a node status.
+type Style struct {
+	Fill   string
+	Stroke string
+}
+
+// Status colours shared by DAG specs and tasks. Pending nodes use the
+// renderer's default style.
+var (
+	StyleRunning   = Style{Fill: "#fff3cd", Stroke: "#ffc107"}
+	StyleCompleted = Style{Fill: "#d4edda", Stroke: "#28a745"}
+	StyleFailed    = Style{Fill: "#f8d7da", Stroke: "#dc3545"}
+	StyleInactive  = Style{Fill: "#e2e3e5", Stroke: "#6c757d"}
+)
+
+// MermaidID converts an identifier into a valid Mermaid node ID. Letters and
+// digits are kept, "_" becomes "__", and any other rune becomes "_<hex>_",
+// so distinct identifiers such as "a-b" and "a_b" never share a node ID.
+func MermaidID(id string) string {
+	var sb strings.Builder
+	for _, r := range id {
+		switch {
+		case r == '_':
+			sb.WriteString("__")
+		case r < 0x80 && (r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'):
+			sb.WriteRune(r)
+		default:
+			fmt.Fprintf(&sb, "_%x_", r)
+		}
+	}
+	return sb.String()
+}
+
+// MermaidLabel escapes double quotes in a Mermaid label.
+func MermaidLabel(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
+
+// DOTQuote quotes a string for use as a DOT identifier or attribute value.
+func DOTQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -0,0 +1,56 @@
+package graphexport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMermaidID(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		id   string
+		want string
+	}{
+		"alphanumeric": {id: "T001", want: "T001"},
+		"hyphen":       {id: "a-b", want: "a_2d_b"},
+		"underscore":   {id: "a_b", want: "a__b"},
+		"dot":          {id: "db.schema", want: "db_2e_schema"},
+		"unicode":      {id: "é", want: "_e9_"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, MermaidID(tt.id))
+		})
+	}
+}
+
+func TestMermaidID_CollisionFree(t *testing.T) {
+	t.Parallel()
+
+	ids := []string{"a-b", "a_b", "a.b", "a b", "a__b", "a_2d_b", "a_-b", "a-_b", "ab"}
+	seen := make(map[string]string)
+	for _, id := range ids {
+		got := MermaidID(id)
+		if prev, ok := seen[got]; ok {
+			t.Fatalf("MermaidID(%q) and MermaidID(%q) both produce %q", prev, id, got)
+		}
+		seen[got] = id
+	}
+}
+
+func TestDOTQuote(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `"plain"`, DOTQuote("plain"))
+	assert.Equal(t, `"say \"hi\""`, DOTQuote(`say "hi"`))
+}
+
+func TestMermaidLabel(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "say #quot;hi#quot;", MermaidLabel(`say "hi"`))
+}
@@ -33,27 +33,34 @@ const (
 type HistoryEntry struct {
 	// ID is a unique identifier in adjective_noun_YYYYMMDD_HHMMSS format.
 	// Optional for backward compatibility with old entries.
-	ID string `yaml:"id,omitempty"`
+	ID string `json:"id,omitempty" yaml:"id,omitempty"`
 	// Timestamp is when the command started executing (RFC3339 format in YAML).
 	// Kept for backward compatibility with existing entries.
-	Timestamp time.Time `yaml:"timestamp"`
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
 	// Command is the name of the autospec command (e.g., "specify", "run").
-	Command string `yaml:"command"`
+	Command string `json:"command" yaml:"command"`
 	// Spec is the name or path of the spec being worked on (may be empty).
-	Spec string `yaml:"spec,omitempty"`
+	Spec string `json:"spec,omitempty" yaml:"spec,omitempty"`
 	// Status is the current state: running, completed, failed, cancelled.
 	// Optional for backward compatibility with old entries.
-	Status string `yaml:"status,omitempty"`
+	Status string `json:"status,omitempty" yaml:"status,omitempty"`
 	// CreatedAt is when the command started (explicit field, same as Timestamp).
 	// Optional for backward compatibility with old entries.
-	CreatedAt time.Time `yaml:"created_at,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty" yaml:"created_at,omitempty"`
 	// CompletedAt is when the command finished (nil if still running).
 	// Pointer allows distinguishing between "not set" and "zero time".
-	CompletedAt *time.Time `yaml:"completed_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" yaml:"completed_at,omitempty"`
 	// ExitCode is the exit code of the command (0=success).
-	ExitCode int `yaml:"exit_code"`
+	ExitCode int `json:"exit_code" yaml:"exit_code"`
 	// Duration is the execution duration in Go duration format (e.g., "2m15.123s").
-	Duration string `yaml:"duration"`
+	Duration string `json:"duration" yaml:"duration"`
+	// Tokens is the total agent tokens consumed by the command (0 if not reported).
+	Tokens int `json:"tokens,omitempty" yaml:"tokens,omitempty"`
+	// CostUSD is the agent cost reported for the command in US dollars.
+	CostUSD float64 `json:"cost_usd,omitempty" yaml:"cost_usd,omitempty"`
+	// Agents maps each stage run by the command to the agent that produced its
+	// artifacts, e.g. {"plan": "codex"} when a fallback agent took over.
+	Agents map[string]string `json:"agents,omitempty" yaml:"agents,omitempty"`
 }
 
 // HistoryFile represents the YAML file containing all history entries.
@@ -3,7 +3,10 @@ package history
 import (
 	"fmt"
 	"os"
+	"sync"
 	"time"
+
+	"github.com/ariel-frischer/autospec/internal/retry"
 )
 
 // Writer provides thread-safe history logging with automatic pruning.
@@ -12,6 +15,11 @@ type Writer struct {
 	StateDir string
 	// MaxEntries is the maximum number of entries to retain.
 	MaxEntries int
+
+	// usageBaselines holds usage totals captured at WriteStart, keyed by entry ID.
+	// UpdateComplete records the difference as the command's token and cost usage.
+	mu             sync.Mutex
+	usageBaselines map[string]*retry.UsageState
 }
 
 // NewWriter creates a new history writer.
@@ -91,9 +99,56 @@ func (w *Writer) WriteStart(command, spec string) (string, error) {
 		return "", fmt.Errorf("writing start entry: %w", err)
 	}
 
+	w.captureUsageBaseline(id, spec)
 	return id, nil
 }
 
+// captureUsageBaseline snapshots persisted usage so UpdateComplete can
+// attribute the delta to this command. Commands without a spec (e.g., specify)
+// snapshot usage across all specs.
+func (w *Writer) captureUsageBaseline(id, spec string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.usageBaselines == nil {
+		w.usageBaselines = make(map[string]*retry.UsageState)
+	}
+	w.usageBaselines[id] = w.currentUsage(spec)
+}
+
+// currentUsage returns persisted usage for spec, or for all specs if spec is empty.
+func (w *Writer) currentUsage(spec string) *retry.UsageState {
+	var total *retry.UsageState
+	if spec == "" {
+		total, _ = retry.TotalUsage(w.StateDir)
+	} else {
+		total, _ = retry.SpecUsageTotal(w.StateDir, spec)
+	}
+	if total == nil {
+		return &retry.UsageState{}
+	}
+	return total
+}
+
+// usageSince returns the tokens and cost consumed since WriteStart for id.
+func (w *Writer) usageSince(id, spec string) (int, float64) {
+	w.mu.Lock()
+	baseline, ok := w.usageBaselines[id]
+	delete(w.usageBaselines, id)
+	w.mu.Unlock()
+	if !ok {
+		return 0, 0
+	}
+
+	current := w.currentUsage(spec)
+	tokens := current.TotalTokens() - baseline.TotalTokens()
+	cost := current.CostUSD - baseline.CostUSD
+	if tokens < 0 || cost < 0 {
+		// Usage was reset while the command ran; nothing reliable to report
+		return 0, 0
+	}
+	return tokens, cost
+}
+
 // UpdateComplete updates a running history entry with final status when a command completes.
 // Parameters:
 //   - id: the unique entry ID returned by WriteStart
@@ -128,6 +183,8 @@ func (w *Writer) updateEntry(history *HistoryFile, id string, exitCode int, stat
 			history.Entries[i].ExitCode = exitCode
 			history.Entries[i].Duration = duration.String()
 			history.Entries[i].CompletedAt = &now
+			history.Entries[i].Tokens, history.Entries[i].CostUSD = w.usageSince(id, history.Entries[i].Spec)
+			history.Entries[i].Agents = retry.StageAgentsSince(w.StateDir, history.Entries[i].Spec, history.Entries[i].CreatedAt)
 			return nil
 		}
 	}
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ariel-frischer/autospec/internal/retry"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -549,3 +550,82 @@ func TestHistoryWriter_TwoPhaseWorkflow(t *testing.T) {
 	assert.False(t, entry.CreatedAt.IsZero())
 	assert.True(t, entry.CompletedAt.After(entry.CreatedAt) || entry.CompletedAt.Equal(entry.CreatedAt))
 }
+
+func TestHistoryWriter_UpdateComplete_RecordsUsage(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		spec       string
+		usage      []*retry.UsageState
+		wantTokens int
+		wantCost   float64
+		wantAgents map[string]string
+	}{
+		"spec usage delta": {
+			spec: "001-feature",
+			usage: []*retry.UsageState{
+				{SpecName: "001-feature", Scope: retry.StageUsageScope("plan"), InputTokens: 100, OutputTokens: 20, CostUSD: 0.5},
+			},
+			wantTokens: 120,
+			wantCost:   0.5,
+		},
+		"producing agents recorded per stage": {
+			spec: "001-feature",
+			usage: []*retry.UsageState{
+				{SpecName: "001-feature", Scope: retry.StageUsageScope("plan"), CostUSD: 0.5, Agent: "codex"},
+				{SpecName: "001-feature", Scope: retry.TaskUsageScope("T001"), CostUSD: 0.1, Agent: "gemini"},
+			},
+			wantCost:   0.5,
+			wantAgents: map[string]string{"plan": "codex"},
+		},
+		"usage for other specs ignored": {
+			spec: "001-feature",
+			usage: []*retry.UsageState{
+				{SpecName: "002-other", Scope: retry.StageUsageScope("plan"), InputTokens: 100, CostUSD: 0.5},
+			},
+		},
+		"no spec uses total across specs": {
+			spec: "",
+			usage: []*retry.UsageState{
+				{SpecName: "001-feature", Scope: retry.StageUsageScope("specify"), InputTokens: 10, CostUSD: 0.1},
+				{SpecName: "002-other", Scope: retry.StageUsageScope("plan"), InputTokens: 5, CostUSD: 0.2},
+			},
+			wantTokens: 15,
+			wantCost:   0.3,
+		},
+		"no usage recorded": {
+			spec: "001-feature",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			stateDir := t.TempDir()
+
+			// Usage recorded before the command starts is part of the baseline
+			require.NoError(t, retry.RecordUsage(stateDir, &retry.UsageState{
+				SpecName: "001-feature", Scope: retry.StageUsageScope("specify"), InputTokens: 1000, CostUSD: 9, Agent: "claude",
+			}))
+			// Ensure the baseline record predates the command start
+			time.Sleep(time.Millisecond)
+
+			writer := NewWriter(stateDir, 100)
+			id, err := writer.WriteStart("plan", tt.spec)
+			require.NoError(t, err)
+
+			for _, u := range tt.usage {
+				require.NoError(t, retry.RecordUsage(stateDir, u))
+			}
+
+			require.NoError(t, writer.UpdateComplete(id, 0, StatusCompleted, time.Second))
+
+			history, err := LoadHistory(stateDir)
+			require.NoError(t, err)
+			require.Len(t, history.Entries, 1)
+			assert.Equal(t, tt.wantTokens, history.Entries[0].Tokens)
+			assert.InDelta(t, tt.wantCost, history.Entries[0].CostUSD, 1e-9)
+			assert.Equal(t, tt.wantAgents, history.Entries[0].Agents)
+		})
+	}
+}
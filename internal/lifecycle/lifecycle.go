@@ -1,11 +1,11 @@
 // Package lifecycle provides wrapper functions for CLI command and workflow
-// stage execution. It handles timing and notification dispatch, eliminating
-// boilerplate code across CLI commands.
+// stage execution. It handles timing, notification dispatch, and lifecycle
+// events, eliminating boilerplate code across CLI commands.
 //
-// The lifecycle package is intentionally minimal: no event bus, no goroutines,
-// no external dependencies. Each wrapper function captures start time, executes
-// the provided function, calculates duration, and calls the appropriate
-// notification method.
+// The lifecycle package is intentionally minimal: no goroutines, no external
+// dependencies. Each wrapper function captures start time, executes the
+// provided function, calculates duration, calls the appropriate notification
+// method, and publishes command events on the events bus.
 package lifecycle
 
 import (
@@ -14,6 +14,8 @@ import (
 	"fmt"
 	"os"
 	"time"
+
+	"github.com/ariel-frischer/autospec/internal/events"
 )
 
 // Status constants for history entries.
@@ -32,10 +34,12 @@ const (
 // The original error from fn is always returned unchanged.
 func Run(handler NotificationHandler, name string, fn func() error) error {
 	start := time.Now()
+	publishCommandStarted(name, "")
 	fnErr := fn()
 	duration := time.Since(start)
 
 	notifyCommandComplete(handler, name, fnErr == nil, duration)
+	publishCommandCompleted(name, "", fnErr, duration)
 
 	return fnErr
 }
@@ -57,12 +61,14 @@ func Run(handler NotificationHandler, name string, fn func() error) error {
 func RunWithHistory(handler NotificationHandler, logger HistoryLogger, name, spec string, fn func() error) error {
 	start := time.Now()
 	entryID := writeHistoryStart(logger, name, spec)
+	publishCommandStarted(name, spec)
 
 	fnErr := fn()
 	duration := time.Since(start)
 
 	notifyCommandComplete(handler, name, fnErr == nil, duration)
 	updateHistoryComplete(logger, entryID, fnErr, duration)
+	publishCommandCompleted(name, spec, fnErr, duration)
 
 	return fnErr
 }
@@ -74,11 +80,13 @@ func RunWithHistory(handler NotificationHandler, logger HistoryLogger, name, spe
 // The notification is sent regardless of whether fn was executed or cancelled.
 func RunWithContext(ctx context.Context, handler NotificationHandler, name string, fn func(context.Context) error) error {
 	start := time.Now()
+	publishCommandStarted(name, "")
 
 	// Check if context is already cancelled
 	if err := ctx.Err(); err != nil {
 		duration := time.Since(start)
 		notifyCommandComplete(handler, name, false, duration)
+		publishCommandCompleted(name, "", err, duration)
 		return err
 	}
 
@@ -86,6 +94,7 @@ func RunWithContext(ctx context.Context, handler NotificationHandler, name strin
 	duration := time.Since(start)
 
 	notifyCommandComplete(handler, name, fnErr == nil, duration)
+	publishCommandCompleted(name, "", fnErr, duration)
 
 	return fnErr
 }
@@ -107,12 +116,14 @@ func RunWithContext(ctx context.Context, handler NotificationHandler, name strin
 func RunWithHistoryContext(ctx context.Context, handler NotificationHandler, logger HistoryLogger, name, spec string, fn func(context.Context) error) error {
 	start := time.Now()
 	entryID := writeHistoryStart(logger, name, spec)
+	publishCommandStarted(name, spec)
 
 	// Check if context is already cancelled
 	if err := ctx.Err(); err != nil {
 		duration := time.Since(start)
 		notifyCommandComplete(handler, name, false, duration)
 		updateHistoryComplete(logger, entryID, err, duration)
+		publishCommandCompleted(name, spec, err, duration)
 		return err
 	}
 
@@ -121,6 +132,7 @@ func RunWithHistoryContext(ctx context.Context, handler NotificationHandler, log
 
 	notifyCommandComplete(handler, name, fnErr == nil, duration)
 	updateHistoryComplete(logger, entryID, fnErr, duration)
+	publishCommandCompleted(name, spec, fnErr, duration)
 
 	return fnErr
 }
@@ -184,6 +196,27 @@ func updateHistoryComplete(logger HistoryLogger, entryID string, fnErr error, du
 	}
 }
 
+// publishCommandStarted publishes a command.started event.
+func publishCommandStarted(name, spec string) {
+	events.Publish(events.Event{Type: events.CommandStarted, Command: name, Spec: spec})
+}
+
+// publishCommandCompleted publishes a command.completed event with the outcome of fnErr.
+func publishCommandCompleted(name, spec string, fnErr error, duration time.Duration) {
+	status, _ := determineStatusAndCode(fnErr)
+	e := events.Event{
+		Type:       events.CommandCompleted,
+		Command:    name,
+		Spec:       spec,
+		Status:     status,
+		DurationMS: duration.Milliseconds(),
+	}
+	if fnErr != nil {
+		e.Error = fnErr.Error()
+	}
+	events.Publish(e)
+}
+
 // determineStatusAndCode determines the status and exit code from an error.
 func determineStatusAndCode(fnErr error) (status string, exitCode int) {
 	if fnErr == nil {
@@ -10,6 +10,8 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/ariel-frischer/autospec/internal/events"
 )
 
 // mockHandler records notification calls for testing.
@@ -716,3 +718,75 @@ func TestDetermineStatusAndCode(t *testing.T) {
 		})
 	}
 }
+
+func TestRunWithHistoryContext_PublishesEvents(t *testing.T) {
+	t.Parallel()
+
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := map[string]struct {
+		ctx        context.Context
+		fn         func(context.Context) error
+		wantStatus string
+		wantError  string
+	}{
+		"success": {
+			ctx:        context.Background(),
+			fn:         func(context.Context) error { return nil },
+			wantStatus: events.StatusCompleted,
+		},
+		"failure": {
+			ctx:        context.Background(),
+			fn:         func(context.Context) error { return errors.New("boom") },
+			wantStatus: events.StatusFailed,
+			wantError:  "boom",
+		},
+		"cancelled before start": {
+			ctx:        cancelledCtx,
+			fn:         func(context.Context) error { return nil },
+			wantStatus: events.StatusCancelled,
+			wantError:  context.Canceled.Error(),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// The bus is process-wide, so filter on a command name unique to this case
+			command := "events-test-" + name
+			var mu sync.Mutex
+			var got []events.Event
+			unsubscribe := events.Subscribe(func(e events.Event) {
+				if e.Command != command {
+					return
+				}
+				mu.Lock()
+				got = append(got, e)
+				mu.Unlock()
+			})
+			defer unsubscribe()
+
+			_ = RunWithHistoryContext(tt.ctx, nil, nil, command, "003-auth", tt.fn)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if len(got) != 2 {
+				t.Fatalf("got %d events, want 2: %+v", len(got), got)
+			}
+			if got[0].Type != events.CommandStarted || got[0].Spec != "003-auth" {
+				t.Errorf("first event = %+v, want command.started for 003-auth", got[0])
+			}
+			if got[1].Type != events.CommandCompleted {
+				t.Errorf("second event type = %q, want %q", got[1].Type, events.CommandCompleted)
+			}
+			if got[1].Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", got[1].Status, tt.wantStatus)
+			}
+			if got[1].Error != tt.wantError {
+				t.Errorf("error = %q, want %q", got[1].Error, tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,323 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// ChannelType identifies a remote notification channel
+type ChannelType string
+
+const (
+	// ChannelWebhook POSTs a JSON body (optionally templated) to a URL
+	ChannelWebhook ChannelType = "webhook"
+	// ChannelSlack POSTs to a Slack-compatible incoming webhook
+	ChannelSlack ChannelType = "slack"
+	// ChannelNtfy publishes to an ntfy topic URL
+	ChannelNtfy ChannelType = "ntfy"
+	// ChannelGotify publishes to a Gotify server
+	ChannelGotify ChannelType = "gotify"
+)
+
+// ValidChannelType checks if the given string is a valid channel type
+func ValidChannelType(s string) bool {
+	switch ChannelType(s) {
+	case ChannelWebhook, ChannelSlack, ChannelNtfy, ChannelGotify:
+		return true
+	default:
+		return false
+	}
+}
+
+// ChannelConfig configures a remote notification channel.
+// URL, Token, and header values may reference environment variables
+// (e.g., "${SLACK_WEBHOOK_URL}") so secrets stay out of config files.
+type ChannelConfig struct {
+	// Type is the channel kind: webhook, slack, ntfy, or gotify
+	Type ChannelType `koanf:"type" yaml:"type" json:"type"`
+
+	// URL is the endpoint: webhook URL, Slack incoming webhook, ntfy topic URL
+	// (e.g., https://ntfy.sh/my-topic), or Gotify server URL
+	URL string `koanf:"url" yaml:"url" json:"url"`
+
+	// Token authenticates ntfy (sent as a Bearer token) and Gotify (application token)
+	Token string `koanf:"token" yaml:"token,omitempty" json:"token,omitempty"`
+
+	// Template is a Go text/template for the webhook JSON body (webhook only).
+	// Fields: .Title .Message .Type .Event .Subject .Time; the json function
+	// quotes a value (e.g., {"text": {{json .Message}}}).
+	// Empty sends the default JSON payload.
+	Template string `koanf:"template" yaml:"template,omitempty" json:"template,omitempty"`
+
+	// Headers are extra HTTP headers sent with every request
+	Headers map[string]string `koanf:"headers" yaml:"headers,omitempty" json:"headers,omitempty"`
+}
+
+// ChannelSender delivers notifications to a remote endpoint
+type ChannelSender interface {
+	// Send delivers n, returning an error for transport failures or non-2xx responses
+	Send(ctx context.Context, n Notification) error
+}
+
+// channelTimeout bounds each channel request; dispatch stops waiting after 5s
+const channelTimeout = 5 * time.Second
+
+// NewChannelSender creates a sender for the given channel configuration
+func NewChannelSender(cfg ChannelConfig) (ChannelSender, error) {
+	base := httpChannel{
+		url:     os.ExpandEnv(cfg.URL),
+		token:   os.ExpandEnv(cfg.Token),
+		headers: expandHeaders(cfg.Headers),
+		client:  &http.Client{Timeout: channelTimeout},
+	}
+	if base.url == "" {
+		return nil, fmt.Errorf("%s channel: url is required", cfg.Type)
+	}
+
+	switch cfg.Type {
+	case ChannelWebhook:
+		tmpl, err := parseWebhookTemplate(cfg.Template)
+		if err != nil {
+			return nil, err
+		}
+		return &webhookSender{httpChannel: base, tmpl: tmpl}, nil
+	case ChannelSlack:
+		return &slackSender{httpChannel: base}, nil
+	case ChannelNtfy:
+		return &ntfySender{httpChannel: base}, nil
+	case ChannelGotify:
+		if base.token == "" {
+			return nil, fmt.Errorf("gotify channel: token is required")
+		}
+		return &gotifySender{httpChannel: base}, nil
+	default:
+		return nil, fmt.Errorf("unknown channel type %q (must be one of: webhook, slack, ntfy, gotify)", cfg.Type)
+	}
+}
+
+// newChannelSenders creates senders for all configured channels.
+// Invalid channels are logged and skipped so one bad entry doesn't silence the rest.
+func newChannelSenders(configs []ChannelConfig) []ChannelSender {
+	var senders []ChannelSender
+	for i, cfg := range configs {
+		sender, err := NewChannelSender(cfg)
+		if err != nil {
+			log.Printf("[notify] warning: skipping notifications.channels[%d]: %v", i, err)
+			continue
+		}
+		senders = append(senders, sender)
+	}
+	return senders
+}
+
+// ParseWebhookTemplate checks that a webhook template is valid
+func ParseWebhookTemplate(text string) error {
+	_, err := parseWebhookTemplate(text)
+	return err
+}
+
+// parseWebhookTemplate parses text as a webhook body template (nil when empty)
+func parseWebhookTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("webhook").Funcs(template.FuncMap{"json": jsonValue}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parsing webhook template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// jsonValue returns v encoded as JSON for use inside templates
+func jsonValue(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// expandHeaders returns headers with environment variables expanded in values
+func expandHeaders(headers map[string]string) map[string]string {
+	expanded := make(map[string]string, len(headers))
+	for k, v := range headers {
+		expanded[k] = os.ExpandEnv(v)
+	}
+	return expanded
+}
+
+// httpChannel holds the request settings shared by all channel senders
+type httpChannel struct {
+	url     string
+	token   string
+	headers map[string]string
+	client  *http.Client
+}
+
+// post sends body to url with the channel's headers plus extra
+func (c *httpChannel) post(ctx context.Context, url, contentType string, body []byte, extra map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range extra {
+		req.Header.Set(k, v)
+	}
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending notification: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("notification endpoint returned %s", resp.Status)
+	}
+	return nil
+}
+
+// webhookPayload is the default webhook body and the template data
+type webhookPayload struct {
+	Title   string    `json:"title"`
+	Message string    `json:"message"`
+	Type    string    `json:"type"`
+	Event   string    `json:"event,omitempty"`
+	Subject string    `json:"subject,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// newWebhookPayload builds the payload for n
+func newWebhookPayload(n Notification) webhookPayload {
+	return webhookPayload{
+		Title:   n.Title,
+		Message: n.Message,
+		Type:    string(n.NotificationType),
+		Event:   string(n.Hook),
+		Subject: n.Subject,
+		Time:    time.Now(),
+	}
+}
+
+// webhookSender POSTs a JSON body to a generic webhook
+type webhookSender struct {
+	httpChannel
+	tmpl *template.Template
+}
+
+// Send posts the default payload, or the rendered template if configured
+func (s *webhookSender) Send(ctx context.Context, n Notification) error {
+	payload := newWebhookPayload(n)
+	var body []byte
+	if s.tmpl == nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("encoding webhook payload: %w", err)
+		}
+		body = data
+	} else {
+		var buf bytes.Buffer
+		if err := s.tmpl.Execute(&buf, payload); err != nil {
+			return fmt.Errorf("rendering webhook template: %w", err)
+		}
+		body = buf.Bytes()
+	}
+	return s.post(ctx, s.url, "application/json", body, nil)
+}
+
+// slackSender POSTs to a Slack-compatible incoming webhook
+type slackSender struct {
+	httpChannel
+}
+
+// slackEmoji maps notification types to Slack emoji shortcodes
+var slackEmoji = map[NotificationType]string{
+	TypeSuccess: ":white_check_mark:",
+	TypeFailure: ":x:",
+	TypeInfo:    ":information_source:",
+}
+
+// Send posts {"text": ...} with the title in bold
+func (s *slackSender) Send(ctx context.Context, n Notification) error {
+	text := fmt.Sprintf("*%s*\n%s", n.Title, n.Message)
+	if emoji := slackEmoji[n.NotificationType]; emoji != "" {
+		text = emoji + " " + text
+	}
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return fmt.Errorf("encoding slack payload: %w", err)
+	}
+	return s.post(ctx, s.url, "application/json", body, nil)
+}
+
+// ntfySender publishes to an ntfy topic URL
+type ntfySender struct {
+	httpChannel
+}
+
+// ntfyTags maps notification types to ntfy emoji tags
+var ntfyTags = map[NotificationType]string{
+	TypeSuccess: "white_check_mark",
+	TypeFailure: "x",
+	TypeInfo:    "information_source",
+}
+
+// Send posts the message as the body with title, priority, and tags headers.
+// Failures are sent with high priority so they break through on phones.
+func (s *ntfySender) Send(ctx context.Context, n Notification) error {
+	headers := map[string]string{
+		"Title":    n.Title,
+		"Priority": "default",
+	}
+	if n.NotificationType == TypeFailure {
+		headers["Priority"] = "high"
+	}
+	if tag := ntfyTags[n.NotificationType]; tag != "" {
+		headers["Tags"] = tag
+	}
+	if s.token != "" {
+		headers["Authorization"] = "Bearer " + s.token
+	}
+	return s.post(ctx, s.url, "text/plain; charset=utf-8", []byte(n.Message), headers)
+}
+
+// gotifySender publishes to a Gotify server
+type gotifySender struct {
+	httpChannel
+}
+
+// Send posts to <url>/message with the application token.
+// Failures use priority 8 (Gotify's high priority); others use 5.
+func (s *gotifySender) Send(ctx context.Context, n Notification) error {
+	priority := 5
+	if n.NotificationType == TypeFailure {
+		priority = 8
+	}
+	body, err := json.Marshal(map[string]any{
+		"title":    n.Title,
+		"message":  n.Message,
+		"priority": priority,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding gotify payload: %w", err)
+	}
+
+	url := strings.TrimSuffix(s.url, "/")
+	if !strings.HasSuffix(url, "/message") {
+		url += "/message"
+	}
+	return s.post(ctx, url, "application/json", body, map[string]string{"X-Gotify-Key": s.token})
+}
@@ -0,0 +1,312 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// capturedRequest records a request received by the test server.
+type capturedRequest struct {
+	path    string
+	headers http.Header
+	body    string
+}
+
+// newChannelServer starts a server that records requests and replies with status.
+func newChannelServer(t *testing.T, status int) (*httptest.Server, func() []capturedRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	var requests []capturedRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, capturedRequest{path: r.URL.Path, headers: r.Header.Clone(), body: string(body)})
+		mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []capturedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]capturedRequest(nil), requests...)
+	}
+}
+
+func testChannelNotification() Notification {
+	n := NewNotification("autospec", "Command 'run' failed (2.0s)", TypeFailure)
+	n.Hook, n.Subject = HookCommandComplete, "run"
+	return n
+}
+
+func TestChannelSenders_Send(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		config func(url string) ChannelConfig
+		check  func(t *testing.T, req capturedRequest)
+	}{
+		"webhook default payload": {
+			config: func(url string) ChannelConfig {
+				return ChannelConfig{Type: ChannelWebhook, URL: url, Headers: map[string]string{"X-Team": "platform"}}
+			},
+			check: func(t *testing.T, req capturedRequest) {
+				var payload map[string]any
+				if err := json.Unmarshal([]byte(req.body), &payload); err != nil {
+					t.Fatalf("body is not JSON: %v", err)
+				}
+				if payload["message"] != "Command 'run' failed (2.0s)" || payload["type"] != "failure" ||
+					payload["event"] != "command_complete" || payload["subject"] != "run" {
+					t.Errorf("unexpected payload: %v", payload)
+				}
+				if got := req.headers.Get("X-Team"); got != "platform" {
+					t.Errorf("X-Team header = %q, want %q", got, "platform")
+				}
+			},
+		},
+		"webhook template": {
+			config: func(url string) ChannelConfig {
+				return ChannelConfig{Type: ChannelWebhook, URL: url, Template: `{"content": {{json .Message}}, "kind": "{{.Event}}"}`}
+			},
+			check: func(t *testing.T, req capturedRequest) {
+				want := `{"content": "Command 'run' failed (2.0s)", "kind": "command_complete"}`
+				if req.body != want {
+					t.Errorf("body = %s, want %s", req.body, want)
+				}
+			},
+		},
+		"slack": {
+			config: func(url string) ChannelConfig {
+				return ChannelConfig{Type: ChannelSlack, URL: url}
+			},
+			check: func(t *testing.T, req capturedRequest) {
+				var payload map[string]string
+				if err := json.Unmarshal([]byte(req.body), &payload); err != nil {
+					t.Fatalf("body is not JSON: %v", err)
+				}
+				want := ":x: *autospec*\nCommand 'run' failed (2.0s)"
+				if payload["text"] != want {
+					t.Errorf("text = %q, want %q", payload["text"], want)
+				}
+			},
+		},
+		"ntfy": {
+			config: func(url string) ChannelConfig {
+				return ChannelConfig{Type: ChannelNtfy, URL: url + "/autospec", Token: "tk_secret"}
+			},
+			check: func(t *testing.T, req capturedRequest) {
+				if req.path != "/autospec" {
+					t.Errorf("path = %q, want /autospec", req.path)
+				}
+				if req.body != "Command 'run' failed (2.0s)" {
+					t.Errorf("body = %q", req.body)
+				}
+				for header, want := range map[string]string{
+					"Title":         "autospec",
+					"Priority":      "high",
+					"Tags":          "x",
+					"Authorization": "Bearer tk_secret",
+				} {
+					if got := req.headers.Get(header); got != want {
+						t.Errorf("%s header = %q, want %q", header, got, want)
+					}
+				}
+			},
+		},
+		"gotify": {
+			config: func(url string) ChannelConfig {
+				return ChannelConfig{Type: ChannelGotify, URL: url + "/", Token: "app-token"}
+			},
+			check: func(t *testing.T, req capturedRequest) {
+				if req.path != "/message" {
+					t.Errorf("path = %q, want /message", req.path)
+				}
+				if got := req.headers.Get("X-Gotify-Key"); got != "app-token" {
+					t.Errorf("X-Gotify-Key = %q, want app-token", got)
+				}
+				var payload map[string]any
+				if err := json.Unmarshal([]byte(req.body), &payload); err != nil {
+					t.Fatalf("body is not JSON: %v", err)
+				}
+				if payload["title"] != "autospec" || payload["priority"] != float64(8) {
+					t.Errorf("unexpected payload: %v", payload)
+				}
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			srv, requests := newChannelServer(t, http.StatusOK)
+			sender, err := NewChannelSender(tt.config(srv.URL))
+			if err != nil {
+				t.Fatalf("NewChannelSender() error = %v", err)
+			}
+			if err := sender.Send(context.Background(), testChannelNotification()); err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+
+			got := requests()
+			if len(got) != 1 {
+				t.Fatalf("got %d requests, want 1", len(got))
+			}
+			tt.check(t, got[0])
+		})
+	}
+}
+
+func TestChannelSender_ErrorStatus(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := newChannelServer(t, http.StatusForbidden)
+	sender, err := NewChannelSender(ChannelConfig{Type: ChannelSlack, URL: srv.URL})
+	if err != nil {
+		t.Fatalf("NewChannelSender() error = %v", err)
+	}
+
+	err = sender.Send(context.Background(), testChannelNotification())
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("Send() error = %v, want 403 status error", err)
+	}
+}
+
+func TestNewChannelSender_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		config  ChannelConfig
+		wantErr string
+	}{
+		"missing url": {
+			config:  ChannelConfig{Type: ChannelSlack},
+			wantErr: "url is required",
+		},
+		"unknown type": {
+			config:  ChannelConfig{Type: "pager", URL: "https://example.com"},
+			wantErr: "unknown channel type",
+		},
+		"gotify without token": {
+			config:  ChannelConfig{Type: ChannelGotify, URL: "https://gotify.example.com"},
+			wantErr: "token is required",
+		},
+		"invalid template": {
+			config:  ChannelConfig{Type: ChannelWebhook, URL: "https://example.com", Template: "{{.Message"},
+			wantErr: "parsing webhook template",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := NewChannelSender(tt.config)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("NewChannelSender() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewChannelSender_ExpandsEnv(t *testing.T) {
+	srv, requests := newChannelServer(t, http.StatusOK)
+	t.Setenv("AUTOSPEC_TEST_NTFY_URL", srv.URL+"/topic")
+	t.Setenv("AUTOSPEC_TEST_NTFY_TOKEN", "tk_env")
+
+	sender, err := NewChannelSender(ChannelConfig{
+		Type:  ChannelNtfy,
+		URL:   "${AUTOSPEC_TEST_NTFY_URL}",
+		Token: "$AUTOSPEC_TEST_NTFY_TOKEN",
+	})
+	if err != nil {
+		t.Fatalf("NewChannelSender() error = %v", err)
+	}
+	if err := sender.Send(context.Background(), testChannelNotification()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	got := requests()
+	if len(got) != 1 || got[0].path != "/topic" || got[0].headers.Get("Authorization") != "Bearer tk_env" {
+		t.Errorf("unexpected requests: %+v", got)
+	}
+}
+
+// TestHandler_ChannelsBypassDesktopChecks verifies channel notifications are
+// sent from non-interactive sessions (like this test), where desktop
+// notifications are suppressed.
+func TestHandler_ChannelsBypassDesktopChecks(t *testing.T) {
+	t.Parallel()
+
+	srv, requests := newChannelServer(t, http.StatusOK)
+	config := DefaultConfig()
+	config.Enabled = true
+	config.OnStageComplete = true
+	config.Channels = []ChannelConfig{{Type: ChannelWebhook, URL: srv.URL}}
+
+	mock := &testMockSender{}
+	handler := NewHandlerWithSender(config, mock)
+	handler.OnStageComplete("plan", true)
+	handler.OnError("implement", nil)
+	handler.OnCommandComplete("run", true, 3*time.Second)
+
+	got := requests()
+	if len(got) != 3 {
+		t.Fatalf("got %d channel requests, want 3", len(got))
+	}
+	var events []string
+	for _, req := range got {
+		var payload map[string]any
+		if err := json.Unmarshal([]byte(req.body), &payload); err != nil {
+			t.Fatalf("body is not JSON: %v", err)
+		}
+		events = append(events, payload["event"].(string)+":"+payload["subject"].(string))
+	}
+	want := []string{"stage_complete:plan", "error:implement", "command_complete:run"}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Errorf("events = %v, want %v", events, want)
+			break
+		}
+	}
+
+	if !desktopAvailable() && (mock.visualCalled != 0 || mock.soundCalled != 0) {
+		t.Errorf("desktop sender called %d/%d times in non-interactive session", mock.visualCalled, mock.soundCalled)
+	}
+}
+
+func TestHandler_ChannelsDisabled(t *testing.T) {
+	t.Parallel()
+
+	srv, requests := newChannelServer(t, http.StatusOK)
+	config := DefaultConfig()
+	config.Enabled = false
+	config.Channels = []ChannelConfig{{Type: ChannelWebhook, URL: srv.URL}}
+
+	handler := NewHandlerWithSender(config, &testMockSender{})
+	handler.OnCommandComplete("run", false, time.Second)
+
+	if got := requests(); len(got) != 0 {
+		t.Errorf("got %d requests with notifications disabled, want 0", len(got))
+	}
+}
+
+func TestValidChannelType(t *testing.T) {
+	t.Parallel()
+
+	for _, valid := range []string{"webhook", "slack", "ntfy", "gotify"} {
+		if !ValidChannelType(valid) {
+			t.Errorf("ValidChannelType(%q) = false, want true", valid)
+		}
+	}
+	for _, invalid := range []string{"", "email", "Slack"} {
+		if ValidChannelType(invalid) {
+			t.Errorf("ValidChannelType(%q) = true, want false", invalid)
+		}
+	}
+}
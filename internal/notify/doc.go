@@ -9,6 +9,7 @@
 //
 //   - Visual notifications via native OS notification systems
 //   - Audio alerts via system sound tools
+//   - Remote channels: generic webhooks, Slack, ntfy, and Gotify (see ChannelConfig)
 //   - Configurable notification hooks (on_command_complete, on_stage_complete, on_error, on_long_running)
 //   - Graceful degradation when notification tools are unavailable
 //   - Non-blocking async dispatch with configurable timeout
@@ -19,6 +20,9 @@
 //   - Linux: notify-send for visual notifications, paplay for sound
 //   - Windows: PowerShell for toast notifications and sound
 //
+// Remote channels use net/http and work on every platform, including CI and
+// non-interactive sessions where desktop notifications are suppressed.
+//
 // # Usage
 //
 //	config := notify.NotificationConfig{
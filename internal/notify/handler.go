@@ -3,18 +3,22 @@ package notify
 import (
 	"context"
 	"fmt"
+	"log"
 	"os"
+	"sync"
 	"time"
 
 	"golang.org/x/term"
 )
 
 // Handler manages notification dispatch based on configuration and hooks.
-// It wraps a Sender with configuration and provides hook methods for
-// command completion, stage completion, and error notifications.
+// It wraps a Sender and any configured remote channels with configuration
+// and provides hook methods for command completion, stage completion, and
+// error notifications.
 type Handler struct {
 	config    NotificationConfig
 	sender    Sender
+	channels  []ChannelSender
 	startTime time.Time
 }
 
@@ -25,6 +29,7 @@ func NewHandler(config NotificationConfig) *Handler {
 	return &Handler{
 		config:    config,
 		sender:    NewSender(),
+		channels:  newChannelSenders(config.Channels),
 		startTime: time.Now(),
 	}
 }
@@ -34,6 +39,7 @@ func NewHandlerWithSender(config NotificationConfig, sender Sender) *Handler {
 	return &Handler{
 		config:    config,
 		sender:    sender,
+		channels:  newChannelSenders(config.Channels),
 		startTime: time.Now(),
 	}
 }
@@ -49,23 +55,25 @@ func (h *Handler) Config() NotificationConfig {
 }
 
 // isEnabled checks if notifications should be sent.
-// Returns false if notifications are disabled, running in CI, or non-interactive.
+// Returns false if notifications are disabled, or if no channels are
+// configured and desktop notifications are unavailable (CI or non-interactive).
 func (h *Handler) isEnabled() bool {
 	if !h.config.Enabled {
 		return false
 	}
+	return len(h.channels) > 0 || desktopAvailable()
+}
 
+// desktopAvailable reports whether desktop notifications should be sent.
+// Returns false when running in CI or non-interactive sessions.
+func desktopAvailable() bool {
 	// Check CI environment - auto-disable unless running interactively
 	if isCI() {
 		return false
 	}
 
 	// Check TTY availability for interactive mode
-	if !isInteractive() {
-		return false
-	}
-
-	return true
+	return isInteractive()
 }
 
 // isCI checks for common CI environment variables.
@@ -117,26 +125,56 @@ func isInteractive() bool {
 	return term.IsTerminal(int(os.Stdin.Fd()))
 }
 
+// dispatchTimeout bounds how long dispatch waits for notifications to be sent.
+const dispatchTimeout = 5 * time.Second
+
 // dispatch sends a notification asynchronously with a timeout.
 //
-// Concurrency pattern: goroutine + done channel + select with timeout.
-// The 5s timeout allows audio files to play but prevents indefinite blocking.
-// Notification failures are silent (logged internally, don't propagate).
-// This ensures notifications never block or crash the main workflow.
+// Concurrency pattern: the desktop notification and the remote channels are
+// sent from separate goroutines, and dispatch waits for both or the timeout.
+// The 5s timeout allows audio files to play and remote channels to respond
+// but prevents indefinite blocking. Notification failures are silent
+// (logged internally, don't propagate). This ensures notifications never
+// block or crash the main workflow.
 func (h *Handler) dispatch(n Notification) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	h.dispatchWithTimeout(n, len(h.channels) == 0 || desktopAvailable(), dispatchTimeout)
+}
+
+// dispatchWithTimeout sends n to the desktop (if desktop is set) and to all
+// remote channels concurrently, returning when both finish or timeout elapses.
+// Remote channels get their own channelTimeout deadline, so a slow sound or
+// notification daemon cannot use up their time.
+func (h *Handler) dispatchWithTimeout(n Notification, desktop bool, timeout time.Duration) {
+	var wg sync.WaitGroup
+	if desktop {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h.sendNotification(n)
+		}()
+	}
+	if len(h.channels) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), channelTimeout)
+			defer cancel()
+			h.sendToChannels(ctx, n)
+		}()
+	}
 
 	done := make(chan struct{})
 	go func() {
-		defer close(done)
-		h.sendNotification(n)
+		wg.Wait()
+		close(done)
 	}()
 
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
 	select {
 	case <-done:
-		// Notification sent successfully
-	case <-ctx.Done():
+		// Notifications sent
+	case <-timer.C:
 		// Timeout - notification took too long, but we don't block
 	}
 }
@@ -154,6 +192,22 @@ func (h *Handler) sendNotification(n Notification) {
 	}
 }
 
+// sendToChannels sends the notification to all remote channels concurrently.
+// Failures are logged and never propagate.
+func (h *Handler) sendToChannels(ctx context.Context, n Notification) {
+	var wg sync.WaitGroup
+	for _, ch := range h.channels {
+		wg.Add(1)
+		go func(ch ChannelSender) {
+			defer wg.Done()
+			if err := ch.Send(ctx, n); err != nil {
+				log.Printf("[notify] warning: channel notification failed: %v", err)
+			}
+		}(ch)
+	}
+	wg.Wait()
+}
+
 // OnCommandComplete is called when an autospec command finishes.
 //
 // Two-level filtering:
@@ -195,6 +249,7 @@ func (h *Handler) OnCommandComplete(commandName string, success bool, duration t
 		fmt.Sprintf("Command '%s' %s (%s)", commandName, status, formatDuration(duration)),
 		notifType,
 	)
+	n.Hook, n.Subject = HookCommandComplete, commandName
 	h.dispatch(n)
 }
 
@@ -223,6 +278,7 @@ func (h *Handler) OnStageComplete(stageName string, success bool) {
 		fmt.Sprintf("Stage '%s' %s", stageName, status),
 		notifType,
 	)
+	n.Hook, n.Subject = HookStageComplete, stageName
 	h.dispatch(n)
 }
 
@@ -251,6 +307,7 @@ func (h *Handler) OnError(commandName string, err error) {
 		fmt.Sprintf("Error in '%s': %s", commandName, errMsg),
 		TypeFailure,
 	)
+	n.Hook, n.Subject = HookError, commandName
 	h.dispatch(n)
 }
 
@@ -275,6 +332,7 @@ func (h *Handler) OnInteractiveSessionStart(stageName string) {
 		fmt.Sprintf("Interactive session starting: %s (your input required)", stageName),
 		TypeInfo,
 	)
+	n.Hook, n.Subject = HookInteractiveSession, stageName
 	h.dispatch(n)
 }
 
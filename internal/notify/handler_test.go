@@ -5,9 +5,11 @@
 package notify
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -50,7 +52,7 @@ func TestNewHandler(t *testing.T) {
 		t.Fatal("NewHandler returned nil")
 	}
 
-	if handler.Config() != config {
+	if !reflect.DeepEqual(handler.Config(), config) {
 		t.Error("handler config doesn't match input")
 	}
 }
@@ -93,7 +95,7 @@ func TestHandler_Config(t *testing.T) {
 	handler := NewHandler(config)
 
 	gotConfig := handler.Config()
-	if gotConfig != config {
+	if !reflect.DeepEqual(gotConfig, config) {
 		t.Error("Config() returned different config")
 	}
 }
@@ -969,6 +971,45 @@ func TestHandler_DispatchTimeout(t *testing.T) {
 	}
 }
 
+// TestHandler_DispatchChannelsNotBlockedByDesktop verifies a blocked desktop
+// notification neither delays remote channels nor shortens their deadline.
+func TestHandler_DispatchChannelsNotBlockedByDesktop(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	sender := NewMockSender().WithVisualFunc(func(Notification) error {
+		<-release
+		return nil
+	})
+	channel := &recordingChannel{sent: make(chan time.Time, 1)}
+	handler := NewHandlerWithSender(NotificationConfig{Enabled: true, Type: OutputVisual}, sender)
+	handler.channels = []ChannelSender{channel}
+
+	start := time.Now()
+	handler.dispatchWithTimeout(NewNotification("test", "message", TypeSuccess), true, 200*time.Millisecond)
+
+	select {
+	case deadline := <-channel.sent:
+		if remaining := deadline.Sub(start); remaining < channelTimeout-time.Second {
+			t.Errorf("channel deadline %v after dispatch, want about %v", remaining, channelTimeout)
+		}
+	default:
+		t.Fatal("channel was not sent while the desktop notification was blocked")
+	}
+}
+
+// recordingChannel reports the context deadline of each Send.
+type recordingChannel struct {
+	sent chan time.Time
+}
+
+func (c *recordingChannel) Send(ctx context.Context, _ Notification) error {
+	deadline, _ := ctx.Deadline()
+	c.sent <- deadline
+	return nil
+}
+
 type slowMockSender struct {
 	delay time.Duration
 }
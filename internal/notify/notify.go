@@ -14,6 +14,20 @@ const (
 	TypeInfo NotificationType = "info"
 )
 
+// Hook identifies the handler hook that produced a notification
+type Hook string
+
+const (
+	// HookCommandComplete is sent by OnCommandComplete (including on_long_running)
+	HookCommandComplete Hook = "command_complete"
+	// HookStageComplete is sent by OnStageComplete
+	HookStageComplete Hook = "stage_complete"
+	// HookError is sent by OnError
+	HookError Hook = "error"
+	// HookInteractiveSession is sent by OnInteractiveSessionStart
+	HookInteractiveSession Hook = "interactive_session"
+)
+
 // OutputType represents the notification output type
 type OutputType string
 
@@ -67,6 +81,11 @@ type NotificationConfig struct {
 	// OnInteractiveSession notifies when an interactive stage is about to begin (default: true when enabled)
 	// This alerts users to return to the terminal after automated stages complete.
 	OnInteractiveSession bool `koanf:"on_interactive_session" yaml:"on_interactive_session" json:"on_interactive_session"`
+
+	// Channels are remote endpoints (webhook, slack, ntfy, gotify) that receive
+	// the same notifications as the desktop. Unlike desktop notifications,
+	// channels also fire in CI and non-interactive sessions.
+	Channels []ChannelConfig `koanf:"channels" yaml:"channels,omitempty" json:"channels,omitempty"`
 }
 
 // DefaultConfig returns a NotificationConfig with default values
@@ -94,6 +113,12 @@ type Notification struct {
 
 	// NotificationType indicates the event type: success, failure, or info
 	NotificationType NotificationType
+
+	// Hook is the handler hook that produced the notification
+	Hook Hook
+
+	// Subject is the command or stage the notification is about
+	Subject string
 }
 
 // NewNotification creates a new Notification with the given parameters
@@ -1,6 +1,6 @@
 // Package retry provides persistent retry state management for autospec workflows.
 // It tracks retry attempts per spec:stage combination, stage execution progress for
-// phased implementation, and task-level execution state. State is persisted to
+// phased implementation, task-level execution state, and agent token/cost usage. State is persisted to
 // ~/.autospec/state/retry.json with atomic writes for concurrency safety.
 package retry
 
@@ -26,6 +26,7 @@ type RetryStore struct {
 	Retries     map[string]*RetryState          `json:"retries"`
 	StageStates map[string]*StageExecutionState `json:"stage_states,omitempty"`
 	TaskStates  map[string]*TaskExecutionState  `json:"task_states,omitempty"`
+	Usage       map[string]*UsageState          `json:"usage,omitempty"`
 }
 
 // retryStoreLegacy is used for backward-compatible loading of old retry state files
@@ -35,6 +36,7 @@ type retryStoreLegacy struct {
 	PhaseStates map[string]*StageExecutionState `json:"phase_states,omitempty"`
 	StageStates map[string]*StageExecutionState `json:"stage_states,omitempty"`
 	TaskStates  map[string]*TaskExecutionState  `json:"task_states,omitempty"`
+	Usage       map[string]*UsageState          `json:"usage,omitempty"`
 }
 
 // StageExecutionState tracks progress through phased implementation
@@ -213,6 +215,7 @@ func loadStore(stateDir string) (*RetryStore, error) {
 		Retries:     legacy.Retries,
 		StageStates: legacy.StageStates,
 		TaskStates:  legacy.TaskStates,
+		Usage:       legacy.Usage,
 	}
 
 	if store.Retries == nil {
@@ -0,0 +1,214 @@
+package retry
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Usage scope prefixes. Stage scopes roll up to the spec total; phase and
+// task scopes are finer-grained views of the implement stage.
+const (
+	UsageScopeStage = "stage:"
+	UsageScopePhase = "phase:"
+	UsageScopeTask  = "task:"
+)
+
+// UsageState tracks accumulated agent token usage and cost for a spec scope.
+// Scope is one of "stage:<name>", "phase:<number>", or "task:<id>".
+// Agent names the agent of the most recent session, which differs from the
+// configured agent when a fallback took over.
+type UsageState struct {
+	SpecName                 string    `json:"spec_name"`
+	Scope                    string    `json:"scope"`
+	InputTokens              int       `json:"input_tokens"`
+	OutputTokens             int       `json:"output_tokens"`
+	CacheCreationInputTokens int       `json:"cache_creation_input_tokens,omitempty"`
+	CacheReadInputTokens     int       `json:"cache_read_input_tokens,omitempty"`
+	CostUSD                  float64   `json:"cost_usd"`
+	Sessions                 int       `json:"sessions"`
+	Agent                    string    `json:"agent,omitempty"`
+	LastUpdated              time.Time `json:"last_updated"`
+}
+
+// TotalTokens returns the sum of input, output, and cache tokens.
+func (u *UsageState) TotalTokens() int {
+	return u.InputTokens + u.OutputTokens + u.CacheCreationInputTokens + u.CacheReadInputTokens
+}
+
+// add accumulates the counters of other into u.
+func (u *UsageState) add(other *UsageState) {
+	u.InputTokens += other.InputTokens
+	u.OutputTokens += other.OutputTokens
+	u.CacheCreationInputTokens += other.CacheCreationInputTokens
+	u.CacheReadInputTokens += other.CacheReadInputTokens
+	u.CostUSD += other.CostUSD
+	u.Sessions += other.Sessions
+}
+
+// StageUsageScope returns the usage scope for a workflow stage.
+func StageUsageScope(stage string) string {
+	return UsageScopeStage + stage
+}
+
+// PhaseUsageScope returns the usage scope for an implementation phase.
+func PhaseUsageScope(phaseNumber int) string {
+	return fmt.Sprintf("%s%d", UsageScopePhase, phaseNumber)
+}
+
+// TaskUsageScope returns the usage scope for an implementation task.
+func TaskUsageScope(taskID string) string {
+	return UsageScopeTask + taskID
+}
+
+// RecordUsage adds delta to the persisted usage for delta.SpecName and delta.Scope.
+// Counters accumulate across runs until ResetUsage is called for the spec.
+func RecordUsage(stateDir string, delta *UsageState) error {
+	if err := os.MkdirAll(stateDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	store, err := loadStore(stateDir)
+	if err != nil {
+		store = &RetryStore{
+			Retries: make(map[string]*RetryState),
+		}
+	}
+	if store.Usage == nil {
+		store.Usage = make(map[string]*UsageState)
+	}
+
+	key := fmt.Sprintf("%s:%s", delta.SpecName, delta.Scope)
+	existing, ok := store.Usage[key]
+	if !ok {
+		existing = &UsageState{SpecName: delta.SpecName, Scope: delta.Scope}
+		store.Usage[key] = existing
+	}
+	existing.add(delta)
+	existing.Agent = delta.Agent // Empty when the latest session's agent is unknown
+	existing.LastUpdated = time.Now()
+
+	return saveStore(stateDir, store)
+}
+
+// LoadUsage returns all usage entries for a spec, sorted by scope.
+// Returns an empty slice if no usage has been recorded.
+func LoadUsage(stateDir, specName string) ([]*UsageState, error) {
+	store, err := loadStore(stateDir)
+	if err != nil {
+		return nil, nil
+	}
+
+	var entries []*UsageState
+	for _, u := range store.Usage {
+		if u.SpecName == specName {
+			entries = append(entries, u)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Scope < entries[j].Scope
+	})
+	return entries, nil
+}
+
+// SpecUsageTotal sums stage-scoped usage for a spec.
+// Phase and task scopes are excluded since they are already counted in the
+// implement stage total.
+func SpecUsageTotal(stateDir, specName string) (*UsageState, error) {
+	entries, err := LoadUsage(stateDir, specName)
+	if err != nil {
+		return nil, err
+	}
+
+	total := &UsageState{SpecName: specName}
+	for _, u := range entries {
+		if !strings.HasPrefix(u.Scope, UsageScopeStage) {
+			continue
+		}
+		total.add(u)
+		if u.LastUpdated.After(total.LastUpdated) {
+			total.LastUpdated = u.LastUpdated
+		}
+	}
+	return total, nil
+}
+
+// TotalUsage sums stage-scoped usage across all specs.
+// Used to attribute usage to commands that run before a spec name is known.
+func TotalUsage(stateDir string) (*UsageState, error) {
+	store, err := loadStore(stateDir)
+	if err != nil {
+		return &UsageState{}, nil
+	}
+
+	total := &UsageState{}
+	for _, u := range store.Usage {
+		if strings.HasPrefix(u.Scope, UsageScopeStage) {
+			total.add(u)
+		}
+	}
+	return total, nil
+}
+
+// StageAgentsSince returns the agent recorded for each stage scope updated at or
+// after since, keyed by stage name. An empty specName includes all specs.
+// Used to attribute artifacts to the agent that produced them.
+func StageAgentsSince(stateDir, specName string, since time.Time) map[string]string {
+	store, err := loadStore(stateDir)
+	if err != nil {
+		return nil
+	}
+
+	var agents map[string]string
+	for _, u := range store.Usage {
+		if specName != "" && u.SpecName != specName {
+			continue
+		}
+		if u.Agent == "" || !strings.HasPrefix(u.Scope, UsageScopeStage) || u.LastUpdated.Before(since) {
+			continue
+		}
+		if agents == nil {
+			agents = make(map[string]string)
+		}
+		agents[strings.TrimPrefix(u.Scope, UsageScopeStage)] = u.Agent
+	}
+	return agents
+}
+
+// ResetUsage clears all recorded usage for a spec.
+func ResetUsage(stateDir, specName string) error {
+	store, err := loadStore(stateDir)
+	if err != nil {
+		return nil
+	}
+
+	for key, u := range store.Usage {
+		if u.SpecName == specName {
+			delete(store.Usage, key)
+		}
+	}
+	return saveStore(stateDir, store)
+}
+
+// saveStore persists the store atomically via temp file + rename.
+func saveStore(stateDir string, store *RetryStore) error {
+	data, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal retry store: %w", err)
+	}
+
+	retryPath := filepath.Join(stateDir, "retry.json")
+	tmpPath := retryPath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, retryPath); err != nil {
+		return fmt.Errorf("failed to rename temp file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package retry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordUsage_Accumulates(t *testing.T) {
+	t.Parallel()
+	stateDir := t.TempDir()
+
+	require.NoError(t, RecordUsage(stateDir, &UsageState{
+		SpecName: "001-feature", Scope: StageUsageScope("plan"),
+		InputTokens: 100, OutputTokens: 20, CostUSD: 0.5, Sessions: 1,
+	}))
+	require.NoError(t, RecordUsage(stateDir, &UsageState{
+		SpecName: "001-feature", Scope: StageUsageScope("plan"),
+		InputTokens: 50, OutputTokens: 10, CostUSD: 0.25, Sessions: 1,
+	}))
+
+	entries, err := LoadUsage(stateDir, "001-feature")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, 150, entries[0].InputTokens)
+	assert.Equal(t, 30, entries[0].OutputTokens)
+	assert.InDelta(t, 0.75, entries[0].CostUSD, 1e-9)
+	assert.Equal(t, 2, entries[0].Sessions)
+	assert.False(t, entries[0].LastUpdated.IsZero())
+}
+
+func TestSpecUsageTotal(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		records    []*UsageState
+		specName   string
+		wantTokens int
+		wantCost   float64
+	}{
+		"no usage recorded": {
+			specName: "001-feature",
+		},
+		"sums stage scopes only": {
+			records: []*UsageState{
+				{SpecName: "001-feature", Scope: StageUsageScope("plan"), InputTokens: 10, CostUSD: 0.1},
+				{SpecName: "001-feature", Scope: StageUsageScope("implement"), OutputTokens: 40, CostUSD: 0.4},
+				{SpecName: "001-feature", Scope: PhaseUsageScope(1), OutputTokens: 40, CostUSD: 0.4},
+				{SpecName: "001-feature", Scope: TaskUsageScope("T001"), OutputTokens: 40, CostUSD: 0.4},
+			},
+			specName:   "001-feature",
+			wantTokens: 50,
+			wantCost:   0.5,
+		},
+		"ignores other specs": {
+			records: []*UsageState{
+				{SpecName: "001-feature", Scope: StageUsageScope("plan"), InputTokens: 10},
+				{SpecName: "002-other", Scope: StageUsageScope("plan"), InputTokens: 99},
+			},
+			specName:   "001-feature",
+			wantTokens: 10,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			stateDir := t.TempDir()
+			for _, r := range tt.records {
+				require.NoError(t, RecordUsage(stateDir, r))
+			}
+
+			total, err := SpecUsageTotal(stateDir, tt.specName)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTokens, total.TotalTokens())
+			assert.InDelta(t, tt.wantCost, total.CostUSD, 1e-9)
+		})
+	}
+}
+
+func TestResetUsage(t *testing.T) {
+	t.Parallel()
+	stateDir := t.TempDir()
+
+	require.NoError(t, RecordUsage(stateDir, &UsageState{SpecName: "001", Scope: StageUsageScope("plan"), InputTokens: 1}))
+	require.NoError(t, RecordUsage(stateDir, &UsageState{SpecName: "002", Scope: StageUsageScope("plan"), InputTokens: 2}))
+	require.NoError(t, ResetUsage(stateDir, "001"))
+
+	entries, err := LoadUsage(stateDir, "001")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	entries, err = LoadUsage(stateDir, "002")
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestUsageCoexistsWithOtherStates(t *testing.T) {
+	t.Parallel()
+	stateDir := t.TempDir()
+
+	require.NoError(t, SaveRetryState(stateDir, &RetryState{SpecName: "001", Phase: "plan", Count: 2, MaxRetries: 3}))
+	require.NoError(t, RecordUsage(stateDir, &UsageState{SpecName: "001", Scope: StageUsageScope("plan"), InputTokens: 5}))
+	require.NoError(t, SaveTaskState(stateDir, &TaskExecutionState{SpecName: "001", CurrentTaskID: "T001"}))
+
+	state, err := LoadRetryState(stateDir, "001", "plan", 3)
+	require.NoError(t, err)
+	assert.Equal(t, 2, state.Count)
+
+	entries, err := LoadUsage(stateDir, "001")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, 5, entries[0].InputTokens)
+}
+
+func TestStageAgentsSince(t *testing.T) {
+	t.Parallel()
+	stateDir := t.TempDir()
+
+	require.NoError(t, RecordUsage(stateDir, &UsageState{
+		SpecName: "001-feature", Scope: StageUsageScope("specify"), Agent: "claude",
+	}))
+	since := time.Now()
+	time.Sleep(time.Millisecond)
+	for _, u := range []*UsageState{
+		{SpecName: "001-feature", Scope: StageUsageScope("plan"), Agent: "claude"},
+		{SpecName: "001-feature", Scope: StageUsageScope("plan"), Agent: "codex"},
+		{SpecName: "001-feature", Scope: StageUsageScope("tasks"), InputTokens: 10},
+		{SpecName: "001-feature", Scope: PhaseUsageScope(1), Agent: "gemini"},
+		{SpecName: "002-other", Scope: StageUsageScope("plan"), Agent: "opencode"},
+	} {
+		require.NoError(t, RecordUsage(stateDir, u))
+	}
+
+	// Latest agent wins; stages before since, without an agent, or non-stage scopes are excluded
+	assert.Equal(t, map[string]string{"plan": "codex"}, StageAgentsSince(stateDir, "001-feature", since))
+	assert.Len(t, StageAgentsSince(stateDir, "", since), 1, "stage names collide across specs")
+	assert.Nil(t, StageAgentsSince(t.TempDir(), "001-feature", since))
+}
@@ -0,0 +1,168 @@
+package serve
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// EventType identifies the kind of event streamed to clients.
+type EventType string
+
+const (
+	// EventJob reports a job lifecycle change (running, succeeded, failed, cancelled).
+	EventJob EventType = "job"
+	// EventStage reports a workflow stage starting, completing, or failing.
+	EventStage EventType = "stage"
+	// EventOutput carries a single line of job output.
+	EventOutput EventType = "output"
+)
+
+// maxEventsPerJob caps the replay history kept for each job.
+// Output events are dropped oldest-first once the cap is reached.
+const maxEventsPerJob = 2000
+
+// subscriberBuffer is the channel size for each subscriber.
+// Slow subscribers miss events rather than blocking jobs.
+const subscriberBuffer = 256
+
+// Event is a single progress notification for a job.
+type Event struct {
+	// ID is a server-wide monotonically increasing event ID.
+	ID int64 `json:"id"`
+	// Type is the event kind.
+	Type EventType `json:"type"`
+	// JobID is the job that produced the event.
+	JobID string `json:"job_id"`
+	// Time is when the event was published.
+	Time time.Time `json:"time"`
+	// Status is the job status (job events) or stage status (stage events).
+	Status string `json:"status,omitempty"`
+	// Stage is the workflow stage name (stage events).
+	Stage string `json:"stage,omitempty"`
+	// Line is the output line (output events).
+	Line string `json:"line,omitempty"`
+}
+
+// isTerminal returns true if the event marks the end of its job.
+func (e Event) isTerminal() bool {
+	return e.Type == EventJob && JobStatus(e.Status).IsTerminal()
+}
+
+// subscriber receives events for one job, or all jobs when jobID is empty.
+type subscriber struct {
+	jobID string
+	ch    chan Event
+}
+
+// Broker fans out job events to subscribers and keeps a bounded
+// per-job history so late subscribers can replay what they missed.
+type Broker struct {
+	mu      sync.Mutex
+	nextID  int64
+	history map[string][]Event
+	subs    map[*subscriber]struct{}
+}
+
+// NewBroker creates an empty event broker.
+func NewBroker() *Broker {
+	return &Broker{
+		history: make(map[string][]Event),
+		subs:    make(map[*subscriber]struct{}),
+	}
+}
+
+// Publish assigns an ID and timestamp to e, records it, and delivers it to subscribers.
+func (b *Broker) Publish(e Event) Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	e.ID = b.nextID
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	b.history[e.JobID] = appendBounded(b.history[e.JobID], e)
+
+	for sub := range b.subs {
+		if sub.jobID != "" && sub.jobID != e.JobID {
+			continue
+		}
+		select {
+		case sub.ch <- e:
+		default:
+		}
+	}
+	return e
+}
+
+// appendBounded appends e, dropping the oldest output event when over maxEventsPerJob.
+// Job and stage events are always kept.
+func appendBounded(events []Event, e Event) []Event {
+	events = append(events, e)
+	if len(events) <= maxEventsPerJob {
+		return events
+	}
+	for i, old := range events {
+		if old.Type == EventOutput {
+			return append(events[:i], events[i+1:]...)
+		}
+	}
+	return events
+}
+
+// Subscribe registers for events of jobID (all jobs when empty).
+// Returns the recorded history for jobID, the live event channel, and a
+// function that must be called to unsubscribe.
+func (b *Broker) Subscribe(jobID string) ([]Event, <-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub := &subscriber{jobID: jobID, ch: make(chan Event, subscriberBuffer)}
+	b.subs[sub] = struct{}{}
+
+	var replay []Event
+	if jobID != "" {
+		replay = append(replay, b.history[jobID]...)
+	}
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, sub)
+			b.mu.Unlock()
+		})
+	}
+	return replay, sub.ch, unsubscribe
+}
+
+// Forget drops the recorded history of jobIDs, for jobs the server no longer tracks.
+func (b *Broker) Forget(jobIDs ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, id := range jobIDs {
+		delete(b.history, id)
+	}
+}
+
+// History returns the recorded events for jobID.
+func (b *Broker) History(jobID string) []Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]Event(nil), b.history[jobID]...)
+}
+
+// writeSSE writes e in server-sent events format.
+func writeSSE(w io.Writer, e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("encoding event: %w", err)
+	}
+	if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data); err != nil {
+		return fmt.Errorf("writing event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package serve
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// guard rejects requests a browser page or a DNS-rebound host could send
+// on the user's behalf, before they reach an endpoint:
+//   - the Host header must name the listen address or a loopback host
+//   - an Origin header must be on the AllowedOrigins list
+//   - with a Token set, the request must carry it as a bearer token
+//   - POST bodies must be application/json, which browsers cannot send
+//     cross-origin without a CORS preflight
+func (s *Server) guard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.allowedHost(r.Host) {
+			writeError(w, http.StatusForbidden, fmt.Errorf("host %q is not allowed", r.Host))
+			return
+		}
+		if origin := r.Header.Get("Origin"); origin != "" && !s.allowedOrigin(origin) {
+			writeError(w, http.StatusForbidden, fmt.Errorf("origin %q is not allowed", origin))
+			return
+		}
+		if s.opts.Token != "" && !validBearer(r.Header.Get("Authorization"), s.opts.Token) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, errors.New("missing or invalid bearer token"))
+			return
+		}
+		if r.Method == http.MethodPost && !isJSONContentType(r.Header.Get("Content-Type")) {
+			writeError(w, http.StatusUnsupportedMediaType, errors.New("request Content-Type must be application/json"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allowedHost reports whether the Host header names a loopback host or the
+// server's listen host. When listening on all interfaces, IP literals are
+// accepted too; a DNS-rebound request always carries a domain name.
+func (s *Server) allowedHost(hostport string) bool {
+	host := hostOnly(hostport)
+	if host == "" {
+		return false
+	}
+	if isLoopbackHost(host) {
+		return true
+	}
+	listenHost := hostOnly(s.opts.Addr)
+	if strings.EqualFold(host, listenHost) {
+		return true
+	}
+	if ip := net.ParseIP(listenHost); listenHost == "" || (ip != nil && ip.IsUnspecified()) {
+		return net.ParseIP(host) != nil
+	}
+	return false
+}
+
+// allowedOrigin reports whether origin is on the AllowedOrigins list.
+func (s *Server) allowedOrigin(origin string) bool {
+	for _, allowed := range s.opts.AllowedOrigins {
+		if strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// validBearer reports whether header is "Bearer <token>".
+func validBearer(header, token string) bool {
+	scheme, value, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimSpace(value)), []byte(token)) == 1
+}
+
+// isJSONContentType reports whether contentType is application/json,
+// ignoring parameters such as charset.
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "application/json"
+}
+
+// hostOnly strips the port and IPv6 brackets from a host:port string.
+func hostOnly(hostport string) string {
+	if host, _, err := net.SplitHostPort(hostport); err == nil {
+		return host
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(hostport, "["), "]")
+}
+
+// isLoopbackHost reports whether host is localhost or a loopback IP.
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// IsLoopbackAddr reports whether the listen address addr (host:port) only
+// accepts connections from this machine.
+func IsLoopbackAddr(addr string) bool {
+	return isLoopbackHost(hostOnly(addr))
+}
+
+// GenerateToken returns a random bearer token for a server instance.
+func GenerateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating API token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
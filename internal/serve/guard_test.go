@@ -0,0 +1,156 @@
+package serve
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_Guard(t *testing.T) {
+	t.Parallel()
+
+	const token = "s3cret"
+	tests := map[string]struct {
+		opts       Options
+		method     string
+		host       string
+		header     map[string]string
+		wantStatus int
+		wantError  string
+	}{
+		"json post on loopback accepted": {
+			method:     http.MethodPost,
+			host:       "127.0.0.1:8765",
+			header:     map[string]string{"Content-Type": "application/json; charset=utf-8"},
+			wantStatus: http.StatusAccepted,
+		},
+		"text/plain post rejected": {
+			method:     http.MethodPost,
+			host:       "127.0.0.1:8765",
+			header:     map[string]string{"Content-Type": "text/plain"},
+			wantStatus: http.StatusUnsupportedMediaType,
+			wantError:  "application/json",
+		},
+		"post without content type rejected": {
+			method:     http.MethodPost,
+			host:       "localhost:8765",
+			wantStatus: http.StatusUnsupportedMediaType,
+		},
+		"rebound host rejected": {
+			method:     http.MethodGet,
+			host:       "attacker.example:8765",
+			wantStatus: http.StatusForbidden,
+			wantError:  "host",
+		},
+		"listen host accepted": {
+			opts:       Options{Addr: "devbox.lan:8765"},
+			method:     http.MethodGet,
+			host:       "devbox.lan:8765",
+			wantStatus: http.StatusOK,
+		},
+		"ip host accepted on all interfaces": {
+			opts:       Options{Addr: "0.0.0.0:8765", Token: token},
+			method:     http.MethodGet,
+			host:       "192.168.1.20:8765",
+			header:     map[string]string{"Authorization": "Bearer " + token},
+			wantStatus: http.StatusOK,
+		},
+		"name host rejected on all interfaces": {
+			opts:       Options{Addr: "0.0.0.0:8765", Token: token},
+			method:     http.MethodGet,
+			host:       "attacker.example:8765",
+			header:     map[string]string{"Authorization": "Bearer " + token},
+			wantStatus: http.StatusForbidden,
+		},
+		"unknown origin rejected": {
+			method:     http.MethodPost,
+			host:       "127.0.0.1:8765",
+			header:     map[string]string{"Content-Type": "application/json", "Origin": "https://evil.example"},
+			wantStatus: http.StatusForbidden,
+			wantError:  "origin",
+		},
+		"allowed origin accepted": {
+			opts:       Options{AllowedOrigins: []string{"http://localhost:3000/"}},
+			method:     http.MethodPost,
+			host:       "127.0.0.1:8765",
+			header:     map[string]string{"Content-Type": "application/json", "Origin": "http://localhost:3000"},
+			wantStatus: http.StatusAccepted,
+		},
+		"missing token rejected": {
+			opts:       Options{Addr: "0.0.0.0:8765", Token: token},
+			method:     http.MethodGet,
+			host:       "127.0.0.1:8765",
+			wantStatus: http.StatusUnauthorized,
+			wantError:  "bearer token",
+		},
+		"wrong token rejected": {
+			opts:       Options{Addr: "0.0.0.0:8765", Token: token},
+			method:     http.MethodGet,
+			host:       "127.0.0.1:8765",
+			header:     map[string]string{"Authorization": "Bearer nope"},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			srv, _ := newTestServer(t, &fakeRunner{}, false)
+			srv.opts.Addr = tt.opts.Addr
+			srv.opts.Token = tt.opts.Token
+			srv.opts.AllowedOrigins = tt.opts.AllowedOrigins
+
+			path, body := "/api/v1/health", ""
+			if tt.method == http.MethodPost {
+				path, body = "/api/v1/workflows/plan", `{"spec": "003-auth"}`
+			}
+			req := httptest.NewRequest(tt.method, path, strings.NewReader(body))
+			req.Host = tt.host
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			srv.Handler().ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+			if tt.wantError != "" {
+				assert.Contains(t, rec.Body.String(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestIsLoopbackAddr(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]bool{
+		"127.0.0.1:8765": true,
+		"localhost:8765": true,
+		"[::1]:8765":     true,
+		"0.0.0.0:8765":   false,
+		":8765":          false,
+		"10.0.0.5:8765":  false,
+	}
+
+	for addr, want := range tests {
+		t.Run(addr, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, want, IsLoopbackAddr(addr))
+		})
+	}
+}
+
+func TestGenerateToken(t *testing.T) {
+	t.Parallel()
+
+	a, err := GenerateToken()
+	require.NoError(t, err)
+	b, err := GenerateToken()
+	require.NoError(t, err)
+	assert.Len(t, a, 64)
+	assert.NotEqual(t, a, b)
+}
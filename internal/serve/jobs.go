@@ -0,0 +1,401 @@
+package serve
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/dag"
+)
+
+// JobStatus is the lifecycle state of a job.
+type JobStatus string
+
+const (
+	// JobStatusRunning indicates the job's command is executing.
+	JobStatusRunning JobStatus = "running"
+	// JobStatusSucceeded indicates the command exited with code 0.
+	JobStatusSucceeded JobStatus = "succeeded"
+	// JobStatusFailed indicates the command exited non-zero or could not start.
+	JobStatusFailed JobStatus = "failed"
+	// JobStatusCancelled indicates the job was cancelled before finishing.
+	JobStatusCancelled JobStatus = "cancelled"
+)
+
+// IsTerminal returns true if the status is final.
+func (s JobStatus) IsTerminal() bool {
+	return s == JobStatusSucceeded || s == JobStatusFailed || s == JobStatusCancelled
+}
+
+// ErrSpecLocked is returned when a job targets a spec that another
+// workflow (serve job or DAG run) is already working on.
+var ErrSpecLocked = errors.New("spec is locked by another workflow")
+
+// ErrJobNotFound is returned for unknown job IDs.
+var ErrJobNotFound = errors.New("job not found")
+
+// Job is a single autospec command started through the API.
+type Job struct {
+	// ID uniquely identifies the job within the server.
+	ID string `json:"id"`
+	// Operation is the API operation (e.g., "plan", "run", "dag-run").
+	Operation string `json:"operation"`
+	// Spec is the spec the job works on (empty for specify and DAG jobs).
+	Spec string `json:"spec,omitempty"`
+	// Args are the autospec arguments the job runs with.
+	Args []string `json:"args"`
+	// Status is the job lifecycle state.
+	Status JobStatus `json:"status"`
+	// Stage is the most recent workflow stage seen in the job output.
+	Stage string `json:"stage,omitempty"`
+	// ExitCode is the command exit code (nil while running).
+	ExitCode *int `json:"exit_code,omitempty"`
+	// Error describes why the command could not run.
+	Error string `json:"error,omitempty"`
+	// StartedAt is when the job was started.
+	StartedAt time.Time `json:"started_at"`
+	// FinishedAt is when the job reached a terminal status.
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+
+	cancel    context.CancelFunc
+	cancelled bool
+	done      chan struct{}
+}
+
+// maxFinishedJobs caps how many finished jobs (and their event history) are
+// kept. The oldest finished jobs are dropped first; running jobs are always kept.
+const maxFinishedJobs = 100
+
+// managerSeq numbers the job managers of this process, so lock run IDs stay
+// unique when several servers run in one process.
+var managerSeq atomic.Int64
+
+// JobManager starts autospec commands as subprocesses and tracks their state.
+// Spec jobs hold a dag run lock for their spec until they finish, so serve
+// jobs and `autospec dag run` never work on the same spec concurrently.
+type JobManager struct {
+	mu         sync.Mutex
+	jobs       map[string]*Job
+	seq        int
+	lockPrefix string // Makes lock run IDs unique across servers sharing a lock directory
+	wg         sync.WaitGroup
+	runner     dag.CommandRunner
+	broker     *Broker
+	executable string
+	workDir    string
+	lockDir    string
+	globalArgs []string
+}
+
+// NewJobManager creates a job manager that runs executable with runner in workDir.
+// globalArgs are prepended to every job's arguments (e.g., --config <path>).
+func NewJobManager(runner dag.CommandRunner, broker *Broker, executable, workDir, lockDir string, globalArgs []string) *JobManager {
+	return &JobManager{
+		jobs:       make(map[string]*Job),
+		lockPrefix: fmt.Sprintf("serve-%d-%d-", os.Getpid(), managerSeq.Add(1)),
+		runner:     runner,
+		broker:     broker,
+		executable: executable,
+		workDir:    workDir,
+		lockDir:    lockDir,
+		globalArgs: globalArgs,
+	}
+}
+
+// Start launches a job for operation with args. When spec is non-empty the
+// spec lock is acquired first; ErrSpecLocked is returned if it is held.
+func (m *JobManager) Start(operation, spec string, args []string) (Job, error) {
+	m.mu.Lock()
+	m.seq++
+	id := fmt.Sprintf("job-%d", m.seq)
+	if spec != "" {
+		// Held under m.mu so two requests for the same spec cannot both pass the check
+		if err := dag.AcquireLock(m.lockDir, m.lockRunID(id), []string{spec}); err != nil {
+			m.mu.Unlock()
+			return Job{}, fmt.Errorf("%w: %v", ErrSpecLocked, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &Job{
+		ID:        id,
+		Operation: operation,
+		Spec:      spec,
+		Args:      args,
+		Status:    JobStatusRunning,
+		StartedAt: time.Now(),
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+	m.jobs[id] = job
+	snapshot := job.snapshot()
+	m.wg.Add(1)
+	m.mu.Unlock()
+
+	m.broker.Publish(Event{Type: EventJob, JobID: id, Status: string(JobStatusRunning)})
+	go m.run(ctx, job)
+	return snapshot, nil
+}
+
+// lockRunID returns the dag lock run ID for a job. Job IDs restart at job-1 in
+// every server, so the ID includes the process and manager; otherwise a second
+// server would treat the first one's lock as its own.
+func (m *JobManager) lockRunID(jobID string) string {
+	return m.lockPrefix + jobID
+}
+
+// run executes the job command and records its outcome.
+func (m *JobManager) run(ctx context.Context, job *Job) {
+	defer m.wg.Done()
+	defer close(job.done)
+	defer job.cancel()
+
+	out := &lineWriter{onLine: func(line string) { m.handleLine(job, line) }}
+	args := append(append([]string{}, m.globalArgs...), job.Args...)
+	exitCode, err := m.runner.Run(ctx, m.workDir, out, out, m.executable, args...)
+	out.Flush()
+
+	if job.Spec != "" {
+		if relErr := dag.ReleaseLock(m.lockDir, m.lockRunID(job.ID)); relErr != nil {
+			m.handleLine(job, fmt.Sprintf("warning: %v", relErr))
+		}
+	}
+	m.finish(job, exitCode, err)
+}
+
+// finish sets the job's terminal status and publishes the closing events.
+func (m *JobManager) finish(job *Job, exitCode int, runErr error) {
+	m.mu.Lock()
+	now := time.Now()
+	job.FinishedAt = &now
+	switch {
+	case job.cancelled:
+		job.Status = JobStatusCancelled
+	case runErr != nil:
+		job.Status = JobStatusFailed
+		job.Error = runErr.Error()
+	case exitCode != 0:
+		job.Status = JobStatusFailed
+	default:
+		job.Status = JobStatusSucceeded
+	}
+	if runErr == nil {
+		job.ExitCode = &exitCode
+	}
+	status, stage := job.Status, job.Stage
+	pruned := m.pruneFinished()
+	m.mu.Unlock()
+
+	m.broker.Forget(pruned...)
+
+	if stage != "" {
+		stageStatus := stageCompleted
+		if status != JobStatusSucceeded {
+			stageStatus = stageFailed
+		}
+		m.broker.Publish(Event{Type: EventStage, JobID: job.ID, Stage: stage, Status: stageStatus})
+	}
+	m.broker.Publish(Event{Type: EventJob, JobID: job.ID, Status: string(status)})
+}
+
+const (
+	stageStarted   = "started"
+	stageCompleted = "completed"
+	stageFailed    = "failed"
+)
+
+// executingStagePattern matches the "→ Executing: <agent command>" line printed
+// before each agent session; the command names the stage as /autospec.<stage>
+// (or autospec.<stage> for agents taking a command flag).
+var executingStagePattern = regexp.MustCompile(`Executing:.*[/\s]autospec\.([a-z][a-z-]*)`)
+
+// detectStage returns the stage named in an executing line, or "".
+func detectStage(line string) string {
+	if m := executingStagePattern.FindStringSubmatch(line); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// handleLine publishes an output line and any stage transition it signals.
+// Stages run sequentially, so a new stage implies the previous one completed.
+func (m *JobManager) handleLine(job *Job, line string) {
+	m.broker.Publish(Event{Type: EventOutput, JobID: job.ID, Line: line})
+
+	stage := detectStage(line)
+	if stage == "" {
+		return
+	}
+	m.mu.Lock()
+	previous := job.Stage
+	if previous == stage {
+		m.mu.Unlock()
+		return // Retries and implement phases re-run the same stage
+	}
+	job.Stage = stage
+	m.mu.Unlock()
+
+	if previous != "" {
+		m.broker.Publish(Event{Type: EventStage, JobID: job.ID, Stage: previous, Status: stageCompleted})
+	}
+	m.broker.Publish(Event{Type: EventStage, JobID: job.ID, Stage: stage, Status: stageStarted})
+}
+
+// pruneFinished drops the oldest finished jobs beyond maxFinishedJobs and
+// returns their IDs. Callers must hold the manager lock.
+func (m *JobManager) pruneFinished() []string {
+	var finished []*Job
+	for _, job := range m.jobs {
+		if job.Status.IsTerminal() {
+			finished = append(finished, job)
+		}
+	}
+	if len(finished) <= maxFinishedJobs {
+		return nil
+	}
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].FinishedAt.Before(*finished[j].FinishedAt)
+	})
+
+	var pruned []string
+	for _, job := range finished[:len(finished)-maxFinishedJobs] {
+		delete(m.jobs, job.ID)
+		pruned = append(pruned, job.ID)
+	}
+	return pruned
+}
+
+// Get returns a snapshot of the job with the given ID.
+func (m *JobManager) Get(id string) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[id]
+	if !ok {
+		return Job{}, fmt.Errorf("%w: %s", ErrJobNotFound, id)
+	}
+	return job.snapshot(), nil
+}
+
+// List returns snapshots of all jobs, oldest first.
+func (m *JobManager) List() []Job {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	jobs := make([]Job, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		jobs = append(jobs, job.snapshot())
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].StartedAt.Before(jobs[j].StartedAt)
+	})
+	return jobs
+}
+
+// Cancel stops a running job. Cancelling a finished job is a no-op.
+func (m *JobManager) Cancel(id string) (Job, error) {
+	m.mu.Lock()
+	job, ok := m.jobs[id]
+	if !ok {
+		m.mu.Unlock()
+		return Job{}, fmt.Errorf("%w: %s", ErrJobNotFound, id)
+	}
+	if !job.Status.IsTerminal() {
+		job.cancelled = true
+		job.cancel()
+	}
+	m.mu.Unlock()
+
+	<-job.done
+	return m.Get(id)
+}
+
+// Wait blocks until the job with the given ID finishes or ctx is done.
+func (m *JobManager) Wait(ctx context.Context, id string) (Job, error) {
+	m.mu.Lock()
+	job, ok := m.jobs[id]
+	m.mu.Unlock()
+	if !ok {
+		return Job{}, fmt.Errorf("%w: %s", ErrJobNotFound, id)
+	}
+	select {
+	case <-job.done:
+		return m.Get(id)
+	case <-ctx.Done():
+		return Job{}, ctx.Err()
+	}
+}
+
+// Shutdown cancels all running jobs and waits for them to release their locks.
+func (m *JobManager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	for _, job := range m.jobs {
+		if !job.Status.IsTerminal() {
+			job.cancelled = true
+			job.cancel()
+		}
+	}
+	m.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for jobs to stop: %w", ctx.Err())
+	}
+}
+
+// snapshot returns a copy of the job safe to use without the manager lock.
+// Callers must hold the manager lock.
+func (j *Job) snapshot() Job {
+	c := *j
+	c.Args = append([]string(nil), j.Args...)
+	if j.ExitCode != nil {
+		code := *j.ExitCode
+		c.ExitCode = &code
+	}
+	c.cancel, c.done = nil, nil
+	return c
+}
+
+// lineWriter splits written bytes into lines and passes each to onLine.
+type lineWriter struct {
+	mu     sync.Mutex
+	buf    bytes.Buffer
+	onLine func(string)
+}
+
+// Write buffers p and emits every complete line.
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf.Write(p)
+	for {
+		idx := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if idx < 0 {
+			return len(p), nil
+		}
+		line := string(bytes.TrimRight(w.buf.Next(idx+1), "\r\n"))
+		w.onLine(line)
+	}
+}
+
+// Flush emits any trailing partial line.
+func (w *lineWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.buf.Len() > 0 {
+		w.onLine(w.buf.String())
+		w.buf.Reset()
+	}
+}
@@ -0,0 +1,237 @@
+package serve
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/dag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRunner writes output and exits with exitCode.
+// When block is set it waits for ctx cancellation before returning.
+type fakeRunner struct {
+	mu       sync.Mutex
+	output   string
+	exitCode int
+	block    bool
+	calls    [][]string
+}
+
+func (r *fakeRunner) Run(ctx context.Context, dir string, stdout, stderr io.Writer, name string, args ...string) (int, error) {
+	r.mu.Lock()
+	r.calls = append(r.calls, append([]string{name}, args...))
+	r.mu.Unlock()
+
+	fmt.Fprint(stdout, r.output)
+	if r.block {
+		<-ctx.Done()
+		return -1, ctx.Err()
+	}
+	return r.exitCode, nil
+}
+
+func (r *fakeRunner) lastCall() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.calls) == 0 {
+		return nil
+	}
+	return r.calls[len(r.calls)-1]
+}
+
+func newTestJobManager(t *testing.T, runner dag.CommandRunner) (*JobManager, string) {
+	t.Helper()
+	lockDir := t.TempDir()
+	m := NewJobManager(runner, NewBroker(), "autospec", t.TempDir(), lockDir, []string{"--config", "cfg.yml"})
+	t.Cleanup(func() { _ = m.Shutdown(context.Background()) })
+	return m, lockDir
+}
+
+func waitJob(t *testing.T, m *JobManager, id string) Job {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job, err := m.Wait(ctx, id)
+	require.NoError(t, err)
+	return job
+}
+
+func TestJobManager_Start(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		exitCode   int
+		wantStatus JobStatus
+	}{
+		"success": {exitCode: 0, wantStatus: JobStatusSucceeded},
+		"failure": {exitCode: 3, wantStatus: JobStatusFailed},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			runner := &fakeRunner{exitCode: tt.exitCode}
+			m, lockDir := newTestJobManager(t, runner)
+
+			started, err := m.Start("plan", "003-auth", []string{"run", "-p"})
+			require.NoError(t, err)
+			assert.Equal(t, JobStatusRunning, started.Status)
+
+			job := waitJob(t, m, started.ID)
+			assert.Equal(t, tt.wantStatus, job.Status)
+			require.NotNil(t, job.ExitCode)
+			assert.Equal(t, tt.exitCode, *job.ExitCode)
+			assert.NotNil(t, job.FinishedAt)
+			assert.Equal(t, []string{"autospec", "--config", "cfg.yml", "run", "-p"}, runner.lastCall())
+
+			// Lock is released once the job finishes
+			assert.NoError(t, dag.AcquireLock(lockDir, "other", []string{"003-auth"}))
+		})
+	}
+}
+
+func TestJobManager_SpecLocked(t *testing.T) {
+	t.Parallel()
+
+	m, lockDir := newTestJobManager(t, &fakeRunner{block: true})
+
+	first, err := m.Start("implement", "003-auth", []string{"run", "-i"})
+	require.NoError(t, err)
+
+	_, err = m.Start("plan", "003-auth", []string{"run", "-p"})
+	assert.ErrorIs(t, err, ErrSpecLocked)
+
+	// A different spec is unaffected
+	_, err = m.Start("plan", "004-billing", []string{"run", "-p"})
+	assert.NoError(t, err)
+
+	// DAG runs see the serve job's lock
+	assert.Error(t, dag.AcquireLock(lockDir, "dag-run", []string{"003-auth"}))
+
+	job, err := m.Cancel(first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusCancelled, job.Status)
+
+	_, err = m.Start("plan", "003-auth", []string{"run", "-p"})
+	assert.NoError(t, err)
+}
+
+func TestJobManager_SpecLockedAcrossServers(t *testing.T) {
+	t.Parallel()
+
+	first, lockDir := newTestJobManager(t, &fakeRunner{block: true})
+	second := NewJobManager(&fakeRunner{block: true}, NewBroker(), "autospec", t.TempDir(), lockDir, nil)
+	t.Cleanup(func() { _ = second.Shutdown(context.Background()) })
+
+	// Both servers number their first job job-1
+	job, err := first.Start("implement", "003-auth", []string{"run", "-i"})
+	require.NoError(t, err)
+
+	_, err = second.Start("plan", "003-auth", []string{"run", "-p"})
+	assert.ErrorIs(t, err, ErrSpecLocked)
+
+	_, err = first.Cancel(job.ID)
+	require.NoError(t, err)
+	_, err = second.Start("plan", "003-auth", []string{"run", "-p"})
+	assert.NoError(t, err)
+}
+
+func TestJobManager_PrunesFinishedJobs(t *testing.T) {
+	t.Parallel()
+
+	m, _ := newTestJobManager(t, &fakeRunner{output: "done\n"})
+
+	var ids []string
+	for i := 0; i < maxFinishedJobs+2; i++ {
+		job, err := m.Start("status", "", []string{"status"})
+		require.NoError(t, err)
+		waitJob(t, m, job.ID)
+		ids = append(ids, job.ID)
+	}
+
+	assert.Len(t, m.List(), maxFinishedJobs)
+	for _, id := range ids[:2] {
+		_, err := m.Get(id)
+		assert.ErrorIs(t, err, ErrJobNotFound)
+		assert.Empty(t, m.broker.History(id))
+	}
+	_, err := m.Get(ids[len(ids)-1])
+	assert.NoError(t, err)
+	assert.NotEmpty(t, m.broker.History(ids[len(ids)-1]))
+}
+
+func TestJobManager_StageEvents(t *testing.T) {
+	t.Parallel()
+
+	runner := &fakeRunner{output: "→ Executing: claude -p \"/autospec.plan\"\nplanning\n" +
+		"→ Executing: claude -p \"/autospec.tasks\"\n→ Executing: claude -p \"/autospec.tasks\"\ndone"}
+	m, _ := newTestJobManager(t, runner)
+
+	started, err := m.Start("run", "003-auth", []string{"run", "-pt"})
+	require.NoError(t, err)
+	job := waitJob(t, m, started.ID)
+	assert.Equal(t, "tasks", job.Stage)
+
+	var stages []string
+	var lines int
+	for _, e := range m.broker.History(job.ID) {
+		switch e.Type {
+		case EventStage:
+			stages = append(stages, e.Stage+":"+e.Status)
+		case EventOutput:
+			lines++
+		}
+	}
+	assert.Equal(t, []string{"plan:started", "plan:completed", "tasks:started", "tasks:completed"}, stages)
+	assert.Equal(t, 5, lines, "trailing partial line should be flushed")
+
+	history := m.broker.History(job.ID)
+	last := history[len(history)-1]
+	assert.True(t, last.isTerminal())
+	assert.Equal(t, string(JobStatusSucceeded), last.Status)
+}
+
+func TestJobManager_GetUnknown(t *testing.T) {
+	t.Parallel()
+
+	m, _ := newTestJobManager(t, &fakeRunner{})
+	_, err := m.Get("job-404")
+	assert.ErrorIs(t, err, ErrJobNotFound)
+	_, err = m.Cancel("job-404")
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestDetectStage(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		line string
+		want string
+	}{
+		"claude command": {
+			line: `→ Executing: claude -p "/autospec.implement --phase 2"`,
+			want: "implement",
+		},
+		"opencode command": {
+			line: `→ Executing: opencode run "" --command autospec.plan`,
+			want: "plan",
+		},
+		"ordinary output": {
+			line: "Wrote /autospec.plan notes",
+			want: "",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, detectStage(tt.line))
+		})
+	}
+}
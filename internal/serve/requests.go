@@ -0,0 +1,216 @@
+package serve
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// WorkflowOperations lists the workflow operations exposed under /api/v1/workflows/{operation}.
+var WorkflowOperations = []string{"specify", "plan", "tasks", "implement", "run"}
+
+// runStageFlags maps the stages accepted by the run operation to `autospec run` flags.
+// Interactive stages (clarify, analyze) cannot run headless and are not accepted.
+var runStageFlags = map[string]string{
+	"constitution": "-n",
+	"specify":      "-s",
+	"plan":         "-p",
+	"tasks":        "-t",
+	"checklist":    "-l",
+	"implement":    "-i",
+}
+
+// runStageOrder is the order run stage flags are emitted in.
+var runStageOrder = []string{"constitution", "specify", "plan", "tasks", "checklist", "implement"}
+
+// WorkflowRequest is the body of POST /api/v1/workflows/{operation}.
+type WorkflowRequest struct {
+	// Spec is the spec directory name (e.g., "003-auth"). Required unless the
+	// workflow starts with specify.
+	Spec string `json:"spec,omitempty"`
+	// Description is the feature description for specify.
+	Description string `json:"description,omitempty"`
+	// Stages lists the stages for the run operation (e.g., ["plan", "tasks"]).
+	Stages []string `json:"stages,omitempty"`
+	// MaxRetries overrides max_retries when greater than zero.
+	MaxRetries int `json:"max_retries,omitempty"`
+	// Resume resumes implementation from where it left off.
+	Resume bool `json:"resume,omitempty"`
+}
+
+// DAGRunRequest is the body of POST /api/v1/dag/run.
+type DAGRunRequest struct {
+	// File is the path to the dag.yaml file.
+	File string `json:"file"`
+	// Parallel executes specs concurrently.
+	Parallel bool `json:"parallel,omitempty"`
+	// MaxParallel limits concurrent specs when Parallel is set.
+	MaxParallel int `json:"max_parallel,omitempty"`
+	// Only restricts the run to these spec IDs.
+	Only []string `json:"only,omitempty"`
+	// Fresh discards existing state.
+	Fresh bool `json:"fresh,omitempty"`
+	// Merge merges completed specs after the run.
+	Merge bool `json:"merge,omitempty"`
+}
+
+// DAGMergeRequest is the body of POST /api/v1/dag/merge.
+type DAGMergeRequest struct {
+	// File is the path to the dag.yaml file.
+	File string `json:"file"`
+	// Branch is the merge target branch (default: main).
+	Branch string `json:"branch,omitempty"`
+	// Cleanup removes worktrees after a successful merge.
+	Cleanup bool `json:"cleanup,omitempty"`
+	// SkipFailed skips specs that fail to merge.
+	SkipFailed bool `json:"skip_failed,omitempty"`
+	// SkipNoCommits skips specs without commits ahead of the target branch.
+	SkipNoCommits bool `json:"skip_no_commits,omitempty"`
+}
+
+// ArtifactValidateRequest is the body of POST /api/v1/artifacts/validate.
+type ArtifactValidateRequest struct {
+	// Path is the artifact file path, relative to the server's working directory.
+	Path string `json:"path"`
+	// Type is the artifact type; inferred from the filename when empty.
+	Type string `json:"type,omitempty"`
+}
+
+// workflowArgs builds the `autospec run` arguments for a workflow operation.
+// Jobs always pass -y since there is no terminal to confirm prompts.
+func workflowArgs(operation string, req WorkflowRequest) ([]string, error) {
+	var stages []string
+	switch operation {
+	case "specify", "plan", "tasks", "implement":
+		stages = []string{operation}
+	case "run":
+		if len(req.Stages) == 0 {
+			return nil, fmt.Errorf("stages is required for the run operation")
+		}
+		stages = req.Stages
+	default:
+		return nil, fmt.Errorf("unknown workflow operation %q; valid: %s", operation, strings.Join(WorkflowOperations, ", "))
+	}
+
+	selected := make(map[string]bool, len(stages))
+	for _, stage := range stages {
+		if _, ok := runStageFlags[stage]; !ok {
+			return nil, fmt.Errorf("unsupported stage %q", stage)
+		}
+		selected[stage] = true
+	}
+
+	if selected["specify"] {
+		if strings.TrimSpace(req.Description) == "" {
+			return nil, fmt.Errorf("description is required when running specify")
+		}
+		if req.Spec != "" {
+			return nil, fmt.Errorf("spec cannot be set when running specify; a new spec is created")
+		}
+	} else if req.Spec == "" && !(len(selected) == 1 && selected["constitution"]) {
+		return nil, fmt.Errorf("spec is required")
+	}
+	if err := validateSpecName(req.Spec); err != nil {
+		return nil, err
+	}
+	if req.MaxRetries < 0 {
+		return nil, fmt.Errorf("max_retries must be non-negative, got %d", req.MaxRetries)
+	}
+
+	args := []string{"run"}
+	for _, stage := range runStageOrder {
+		if selected[stage] {
+			args = append(args, runStageFlags[stage])
+		}
+	}
+	if req.Spec != "" {
+		args = append(args, "--spec", req.Spec)
+	}
+	args = append(args, "-y")
+	if req.MaxRetries > 0 {
+		args = append(args, "--max-retries", strconv.Itoa(req.MaxRetries))
+	}
+	if req.Resume {
+		args = append(args, "--resume")
+	}
+	if selected["specify"] {
+		args = append(args, "--", req.Description)
+	}
+	return args, nil
+}
+
+// validateSpecName rejects spec names that are not a single path element.
+func validateSpecName(spec string) error {
+	if spec == "" {
+		return nil
+	}
+	if spec != filepath.Base(spec) || spec == "." || spec == ".." || strings.HasPrefix(spec, "-") {
+		return fmt.Errorf("invalid spec name %q", spec)
+	}
+	return nil
+}
+
+// dagRunArgs builds the `autospec dag run` arguments for req.
+// The merge prompt is always skipped since jobs have no terminal.
+func dagRunArgs(req DAGRunRequest) ([]string, error) {
+	if err := validateLocalPath(req.File); err != nil {
+		return nil, err
+	}
+	if req.MaxParallel < 0 {
+		return nil, fmt.Errorf("max_parallel must be non-negative, got %d", req.MaxParallel)
+	}
+
+	args := []string{"dag", "run", req.File}
+	if req.Parallel {
+		args = append(args, "--parallel")
+		if req.MaxParallel > 0 {
+			args = append(args, "--max-parallel", strconv.Itoa(req.MaxParallel))
+		}
+	}
+	if len(req.Only) > 0 {
+		args = append(args, "--only", strings.Join(req.Only, ","))
+	}
+	if req.Fresh {
+		args = append(args, "--fresh")
+	}
+	if req.Merge {
+		args = append(args, "--merge")
+	} else {
+		args = append(args, "--no-merge-prompt")
+	}
+	return args, nil
+}
+
+// dagMergeArgs builds the `autospec dag merge` arguments for req.
+func dagMergeArgs(req DAGMergeRequest) ([]string, error) {
+	if err := validateLocalPath(req.File); err != nil {
+		return nil, err
+	}
+
+	args := []string{"dag", "merge", req.File}
+	if req.Branch != "" {
+		args = append(args, "--branch", req.Branch)
+	}
+	if req.Cleanup {
+		args = append(args, "--cleanup")
+	}
+	if req.SkipFailed {
+		args = append(args, "--skip-failed")
+	}
+	if req.SkipNoCommits {
+		args = append(args, "--skip-no-commits")
+	}
+	return args, nil
+}
+
+// validateLocalPath requires path to be a relative path inside the working directory.
+func validateLocalPath(path string) error {
+	if path == "" {
+		return fmt.Errorf("file path is required")
+	}
+	if filepath.IsAbs(path) || !filepath.IsLocal(path) || strings.HasPrefix(path, "-") {
+		return fmt.Errorf("path %q must be relative to the project directory", path)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package serve
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowArgs(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		operation string
+		req       WorkflowRequest
+		want      []string
+		wantErr   string
+	}{
+		"plan": {
+			operation: "plan",
+			req:       WorkflowRequest{Spec: "003-auth"},
+			want:      []string{"run", "-p", "--spec", "003-auth", "-y"},
+		},
+		"implement with resume and retries": {
+			operation: "implement",
+			req:       WorkflowRequest{Spec: "003-auth", Resume: true, MaxRetries: 2},
+			want:      []string{"run", "-i", "--spec", "003-auth", "-y", "--max-retries", "2", "--resume"},
+		},
+		"specify passes description after separator": {
+			operation: "specify",
+			req:       WorkflowRequest{Description: "-add login"},
+			want:      []string{"run", "-s", "-y", "--", "-add login"},
+		},
+		"run orders stages canonically": {
+			operation: "run",
+			req:       WorkflowRequest{Spec: "003-auth", Stages: []string{"implement", "plan", "tasks"}},
+			want:      []string{"run", "-p", "-t", "-i", "--spec", "003-auth", "-y"},
+		},
+		"run constitution without spec": {
+			operation: "run",
+			req:       WorkflowRequest{Stages: []string{"constitution"}},
+			want:      []string{"run", "-n", "-y"},
+		},
+		"run without stages": {
+			operation: "run",
+			req:       WorkflowRequest{Spec: "003-auth"},
+			wantErr:   "stages is required",
+		},
+		"run with interactive stage": {
+			operation: "run",
+			req:       WorkflowRequest{Spec: "003-auth", Stages: []string{"clarify"}},
+			wantErr:   `unsupported stage "clarify"`,
+		},
+		"unknown operation": {
+			operation: "analyze",
+			req:       WorkflowRequest{Spec: "003-auth"},
+			wantErr:   "unknown workflow operation",
+		},
+		"plan without spec": {
+			operation: "plan",
+			wantErr:   "spec is required",
+		},
+		"specify without description": {
+			operation: "specify",
+			wantErr:   "description is required",
+		},
+		"specify with spec": {
+			operation: "specify",
+			req:       WorkflowRequest{Spec: "003-auth", Description: "x"},
+			wantErr:   "spec cannot be set",
+		},
+		"spec with path traversal": {
+			operation: "plan",
+			req:       WorkflowRequest{Spec: "../003-auth"},
+			wantErr:   "invalid spec name",
+		},
+		"negative max retries": {
+			operation: "tasks",
+			req:       WorkflowRequest{Spec: "003-auth", MaxRetries: -1},
+			wantErr:   "max_retries must be non-negative",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := workflowArgs(tt.operation, tt.req)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDAGRunArgs(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		req     DAGRunRequest
+		want    []string
+		wantErr string
+	}{
+		"defaults skip merge prompt": {
+			req:  DAGRunRequest{File: ".autospec/dags/v1.yaml"},
+			want: []string{"dag", "run", ".autospec/dags/v1.yaml", "--no-merge-prompt"},
+		},
+		"parallel with only and merge": {
+			req:  DAGRunRequest{File: "dag.yaml", Parallel: true, MaxParallel: 2, Only: []string{"a", "b"}, Merge: true},
+			want: []string{"dag", "run", "dag.yaml", "--parallel", "--max-parallel", "2", "--only", "a,b", "--merge"},
+		},
+		"missing file": {
+			wantErr: "file path is required",
+		},
+		"absolute file": {
+			req:     DAGRunRequest{File: "/etc/dag.yaml"},
+			wantErr: "must be relative",
+		},
+		"file outside project": {
+			req:     DAGRunRequest{File: "../dag.yaml"},
+			wantErr: "must be relative",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := dagRunArgs(tt.req)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDAGMergeArgs(t *testing.T) {
+	t.Parallel()
+
+	got, err := dagMergeArgs(DAGMergeRequest{File: "dag.yaml", Branch: "develop", Cleanup: true, SkipNoCommits: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dag", "merge", "dag.yaml", "--branch", "develop", "--cleanup", "--skip-no-commits"}, got)
+}
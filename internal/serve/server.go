@@ -0,0 +1,488 @@
+// Package serve implements the headless HTTP/JSON API behind `autospec serve`.
+//
+// Workflow and DAG operations run as autospec subprocesses, the same way
+// `autospec dag run` executes specs, so the API exercises exactly the code
+// paths of the CLI. Each job's output is parsed for stage transitions and
+// streamed to clients as server-sent events.
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/dag"
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/validation"
+)
+
+// sseKeepAlive is how often an idle event stream sends a comment line
+// so proxies do not close the connection.
+const sseKeepAlive = 15 * time.Second
+
+// maxRequestBody limits JSON request bodies.
+const maxRequestBody = 1 << 20
+
+// Options configures a Server.
+type Options struct {
+	// Executable is the autospec binary jobs run (typically os.Executable()).
+	Executable string
+	// WorkDir is the project directory jobs run in and paths are resolved against.
+	WorkDir string
+	// ConfigPath is forwarded to jobs as --config when set.
+	ConfigPath string
+	// SpecsDir is the specs directory (relative to WorkDir unless absolute).
+	SpecsDir string
+	// HistoryDir is the state directory containing history.yaml.
+	HistoryDir string
+	// LockDir is the dag lock directory; defaults to dag.GetStateDir() under WorkDir.
+	LockDir string
+	// EnableDAG exposes the dag endpoints (dag commands are only available in dev builds).
+	EnableDAG bool
+	// Runner executes job commands; defaults to dag.NewDefaultCommandRunner().
+	Runner dag.CommandRunner
+	// Addr is the listen address; requests must name it or a loopback host.
+	Addr string
+	// Token, when set, must be sent as "Authorization: Bearer <token>".
+	Token string
+	// AllowedOrigins lists the browser origins (scheme://host:port) allowed
+	// to call the API. Requests with any other Origin header are rejected.
+	AllowedOrigins []string
+}
+
+// Server serves the autospec HTTP API.
+type Server struct {
+	opts   Options
+	jobs   *JobManager
+	broker *Broker
+	mux    *http.ServeMux
+}
+
+// NewServer creates a Server from opts.
+func NewServer(opts Options) *Server {
+	if opts.Runner == nil {
+		opts.Runner = dag.NewDefaultCommandRunner()
+	}
+	if opts.LockDir == "" {
+		opts.LockDir = filepath.Join(opts.WorkDir, dag.GetStateDir())
+	}
+
+	var globalArgs []string
+	if opts.ConfigPath != "" {
+		globalArgs = []string{"--config", opts.ConfigPath}
+	}
+
+	broker := NewBroker()
+	s := &Server{
+		opts:   opts,
+		broker: broker,
+		jobs:   NewJobManager(opts.Runner, broker, opts.Executable, opts.WorkDir, opts.LockDir, globalArgs),
+		mux:    http.NewServeMux(),
+	}
+	s.routes()
+	return s
+}
+
+// routes registers all API endpoints.
+func (s *Server) routes() {
+	s.mux.HandleFunc("GET /api/v1/health", s.handleHealth)
+	s.mux.HandleFunc("POST /api/v1/workflows/{operation}", s.handleWorkflow)
+	s.mux.HandleFunc("GET /api/v1/jobs", s.handleListJobs)
+	s.mux.HandleFunc("GET /api/v1/jobs/{id}", s.handleGetJob)
+	s.mux.HandleFunc("POST /api/v1/jobs/{id}/cancel", s.handleCancelJob)
+	s.mux.HandleFunc("GET /api/v1/jobs/{id}/events", s.handleJobEvents)
+	s.mux.HandleFunc("GET /api/v1/events", s.handleAllEvents)
+	s.mux.HandleFunc("GET /api/v1/history", s.handleHistory)
+	s.mux.HandleFunc("POST /api/v1/artifacts/validate", s.handleValidateArtifact)
+	s.mux.HandleFunc("POST /api/v1/dag/run", s.requireDAG(s.handleDAGRun))
+	s.mux.HandleFunc("POST /api/v1/dag/merge", s.requireDAG(s.handleDAGMerge))
+	s.mux.HandleFunc("GET /api/v1/dag/status", s.requireDAG(s.handleDAGStatus))
+}
+
+// Handler returns the HTTP handler for the API.
+func (s *Server) Handler() http.Handler {
+	return s.guard(s.mux)
+}
+
+// Jobs returns the server's job manager.
+func (s *Server) Jobs() *JobManager {
+	return s.jobs
+}
+
+// Shutdown cancels running jobs and waits for them to release their spec locks.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.jobs.Shutdown(ctx)
+}
+
+// errorResponse is the body of every non-2xx response.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// writeJSON writes v as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a JSON error response.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+// decodeBody decodes the JSON request body into v, rejecting unknown fields.
+func decodeBody(r *http.Request, v interface{}) error {
+	dec := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxRequestBody))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
+
+// requireDAG rejects requests when the dag endpoints are disabled.
+func (s *Server) requireDAG(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.opts.EnableDAG {
+			writeError(w, http.StatusNotFound, errors.New("dag endpoints are only available in dev builds"))
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *Server) handleWorkflow(w http.ResponseWriter, r *http.Request) {
+	operation := r.PathValue("operation")
+	var req WorkflowRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	args, err := workflowArgs(operation, req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if req.Spec != "" {
+		if _, err := os.Stat(s.resolve(filepath.Join(s.opts.SpecsDir, req.Spec))); err != nil {
+			writeError(w, http.StatusNotFound, fmt.Errorf("spec %q not found in %s", req.Spec, s.opts.SpecsDir))
+			return
+		}
+	}
+	s.startJob(w, operation, req.Spec, args)
+}
+
+func (s *Server) handleDAGRun(w http.ResponseWriter, r *http.Request) {
+	var req DAGRunRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	args, err := dagRunArgs(req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	// The dag run subprocess acquires its own spec locks
+	s.startJob(w, "dag-run", "", args)
+}
+
+func (s *Server) handleDAGMerge(w http.ResponseWriter, r *http.Request) {
+	var req DAGMergeRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	args, err := dagMergeArgs(req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	s.startJob(w, "dag-merge", "", args)
+}
+
+// startJob starts a job and writes 202 with the job, or 409 if the spec is locked.
+func (s *Server) startJob(w http.ResponseWriter, operation, spec string, args []string) {
+	job, err := s.jobs.Start(operation, spec, args)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrSpecLocked) {
+			status = http.StatusConflict
+		}
+		writeError(w, status, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+func (s *Server) handleListJobs(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"jobs": s.jobs.List()})
+}
+
+func (s *Server) handleGetJob(w http.ResponseWriter, r *http.Request) {
+	job, err := s.jobs.Get(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+func (s *Server) handleCancelJob(w http.ResponseWriter, r *http.Request) {
+	job, err := s.jobs.Cancel(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+func (s *Server) handleJobEvents(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if _, err := s.jobs.Get(id); err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	s.streamEvents(w, r, id)
+}
+
+func (s *Server) handleAllEvents(w http.ResponseWriter, r *http.Request) {
+	s.streamEvents(w, r, "")
+}
+
+// streamEvents writes events for jobID (all jobs when empty) as server-sent events.
+// A single-job stream replays the job's history and ends once the job finishes;
+// the all-jobs stream only carries live events and runs until the client disconnects.
+func (s *Server) streamEvents(w http.ResponseWriter, r *http.Request, jobID string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming not supported"))
+		return
+	}
+
+	replay, events, unsubscribe := s.broker.Subscribe(jobID)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for _, e := range replay {
+		if err := writeSSE(w, e); err != nil {
+			return
+		}
+		if e.isTerminal() {
+			flusher.Flush()
+			return
+		}
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(sseKeepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case e := <-events:
+			if err := writeSSE(w, e); err != nil {
+				return
+			}
+			flusher.Flush()
+			if jobID != "" && e.isTerminal() {
+				return
+			}
+		}
+	}
+}
+
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	spec := r.URL.Query().Get("spec")
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("limit must be a non-negative integer, got %q", v))
+			return
+		}
+		limit = n
+	}
+
+	histFile, err := history.LoadHistory(s.opts.HistoryDir)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("loading history: %w", err))
+		return
+	}
+
+	entries := make([]history.HistoryEntry, 0, len(histFile.Entries))
+	for _, entry := range histFile.Entries {
+		if spec == "" || entry.Spec == spec {
+			entries = append(entries, entry)
+		}
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"entries": entries})
+}
+
+// validationIssue is a validation error or warning in API responses.
+type validationIssue struct {
+	Path     string `json:"path,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Message  string `json:"message"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+	Hint     string `json:"hint,omitempty"`
+}
+
+// validationResponse is the body of POST /api/v1/artifacts/validate.
+type validationResponse struct {
+	Path     string            `json:"path"`
+	Type     string            `json:"type"`
+	Valid    bool              `json:"valid"`
+	Errors   []validationIssue `json:"errors"`
+	Warnings []validationIssue `json:"warnings"`
+	Counts   map[string]int    `json:"counts,omitempty"`
+}
+
+func (s *Server) handleValidateArtifact(w http.ResponseWriter, r *http.Request) {
+	var req ArtifactValidateRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := validateLocalPath(req.Path); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var artType validation.ArtifactType
+	var err error
+	if req.Type != "" {
+		artType, err = validation.ParseArtifactType(req.Type)
+	} else {
+		artType, err = validation.InferArtifactTypeFromFilename(req.Path)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	fullPath := s.resolve(req.Path)
+	if _, err := os.Stat(fullPath); err != nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("artifact %s not found", req.Path))
+		return
+	}
+
+	validator, err := validation.NewArtifactValidator(artType)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newValidationResponse(req.Path, artType, validator.Validate(fullPath)))
+}
+
+// newValidationResponse converts a validation result to its API form.
+func newValidationResponse(path string, artType validation.ArtifactType, result *validation.ValidationResult) validationResponse {
+	resp := validationResponse{
+		Path:     path,
+		Type:     string(artType),
+		Valid:    result.Valid,
+		Errors:   []validationIssue{},
+		Warnings: []validationIssue{},
+	}
+	for _, e := range result.Errors {
+		resp.Errors = append(resp.Errors, validationIssue{
+			Path: e.Path, Line: e.Line, Column: e.Column, Message: e.Message,
+			Expected: e.Expected, Actual: e.Actual, Hint: e.Hint,
+		})
+	}
+	for _, warn := range result.Warnings {
+		resp.Warnings = append(resp.Warnings, validationIssue{
+			Path: warn.Path, Line: warn.Line, Message: warn.Message, Hint: warn.Hint,
+		})
+	}
+	if result.Summary != nil {
+		resp.Counts = result.Summary.Counts
+	}
+	return resp
+}
+
+// dagSpecStatus is the per-spec state in DAG status responses.
+type dagSpecStatus struct {
+	Status        string     `json:"status"`
+	CurrentStage  string     `json:"current_stage,omitempty"`
+	ExitCode      *int       `json:"exit_code,omitempty"`
+	FailureReason string     `json:"failure_reason,omitempty"`
+	StartedAt     *time.Time `json:"started_at,omitempty"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+}
+
+// dagStatusResponse is the body of GET /api/v1/dag/status.
+type dagStatusResponse struct {
+	File        string                   `json:"file"`
+	Name        string                   `json:"name"`
+	Status      string                   `json:"status,omitempty"`
+	StartedAt   *time.Time               `json:"started_at,omitempty"`
+	CompletedAt *time.Time               `json:"completed_at,omitempty"`
+	Specs       map[string]dagSpecStatus `json:"specs"`
+}
+
+func (s *Server) handleDAGStatus(w http.ResponseWriter, r *http.Request) {
+	file := r.URL.Query().Get("file")
+	if err := validateLocalPath(file); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	cfg, err := dag.LoadDAGConfigFull(s.resolve(file))
+	if err != nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("loading DAG config: %w", err))
+		return
+	}
+
+	resp := dagStatusResponse{File: file, Name: cfg.DAG.Name, Specs: make(map[string]dagSpecStatus, len(cfg.Specs))}
+	if cfg.Run != nil {
+		resp.Status = string(cfg.Run.Status)
+		resp.StartedAt = cfg.Run.StartedAt
+		resp.CompletedAt = cfg.Run.CompletedAt
+	}
+	for id, spec := range cfg.Specs {
+		resp.Specs[id] = dagSpecStatus{
+			Status:        string(spec.Status),
+			CurrentStage:  spec.CurrentStage,
+			ExitCode:      spec.ExitCode,
+			FailureReason: spec.FailureReason,
+			StartedAt:     spec.StartedAt,
+			CompletedAt:   spec.CompletedAt,
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// resolve returns path relative to the server's working directory.
+func (s *Server) resolve(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(s.opts.WorkDir, path)
+}
@@ -0,0 +1,369 @@
+package serve
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer creates a server rooted at a temp project with one spec (003-auth).
+func newTestServer(t *testing.T, runner *fakeRunner, enableDAG bool) (*Server, string) {
+	t.Helper()
+	workDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(workDir, "specs", "003-auth"), 0o755))
+
+	srv := NewServer(Options{
+		Executable: "autospec",
+		WorkDir:    workDir,
+		SpecsDir:   "specs",
+		HistoryDir: t.TempDir(),
+		EnableDAG:  enableDAG,
+		Runner:     runner,
+	})
+	t.Cleanup(func() { _ = srv.Shutdown(context.Background()) })
+	return srv, workDir
+}
+
+func doRequest(t *testing.T, srv *Server, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Host = "127.0.0.1:8765"
+	if method == http.MethodPost {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_Workflow(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		path       string
+		body       string
+		wantStatus int
+		wantError  string
+	}{
+		"plan accepted": {
+			path:       "/api/v1/workflows/plan",
+			body:       `{"spec": "003-auth"}`,
+			wantStatus: http.StatusAccepted,
+		},
+		"specify accepted": {
+			path:       "/api/v1/workflows/specify",
+			body:       `{"description": "Add login"}`,
+			wantStatus: http.StatusAccepted,
+		},
+		"unknown spec": {
+			path:       "/api/v1/workflows/tasks",
+			body:       `{"spec": "999-missing"}`,
+			wantStatus: http.StatusNotFound,
+			wantError:  "not found",
+		},
+		"unknown operation": {
+			path:       "/api/v1/workflows/clarify",
+			body:       `{"spec": "003-auth"}`,
+			wantStatus: http.StatusBadRequest,
+			wantError:  "unknown workflow operation",
+		},
+		"unknown field": {
+			path:       "/api/v1/workflows/plan",
+			body:       `{"spec": "003-auth", "agent": "codex"}`,
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid request body",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			srv, _ := newTestServer(t, &fakeRunner{}, false)
+
+			rec := doRequest(t, srv, http.MethodPost, tt.path, tt.body)
+			assert.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+			if tt.wantError != "" {
+				var resp errorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Contains(t, resp.Error, tt.wantError)
+				return
+			}
+
+			var job Job
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+			assert.Equal(t, JobStatusRunning, job.Status)
+			assert.NotEmpty(t, job.ID)
+		})
+	}
+}
+
+func TestServer_WorkflowSpecLocked(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := newTestServer(t, &fakeRunner{block: true}, false)
+
+	rec := doRequest(t, srv, http.MethodPost, "/api/v1/workflows/implement", `{"spec": "003-auth"}`)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	var job Job
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+
+	rec = doRequest(t, srv, http.MethodPost, "/api/v1/workflows/plan", `{"spec": "003-auth"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = doRequest(t, srv, http.MethodPost, "/api/v1/jobs/"+job.ID+"/cancel", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+	assert.Equal(t, JobStatusCancelled, job.Status)
+
+	rec = doRequest(t, srv, http.MethodPost, "/api/v1/workflows/plan", `{"spec": "003-auth"}`)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+}
+
+func TestServer_Jobs(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := newTestServer(t, &fakeRunner{}, false)
+	rec := doRequest(t, srv, http.MethodPost, "/api/v1/workflows/tasks", `{"spec": "003-auth"}`)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	var started Job
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &started))
+	waitJob(t, srv.Jobs(), started.ID)
+
+	rec = doRequest(t, srv, http.MethodGet, "/api/v1/jobs/"+started.ID, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var job Job
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+	assert.Equal(t, JobStatusSucceeded, job.Status)
+	assert.Equal(t, []string{"run", "-t", "--spec", "003-auth", "-y"}, job.Args)
+
+	rec = doRequest(t, srv, http.MethodGet, "/api/v1/jobs", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var list struct {
+		Jobs []Job `json:"jobs"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	assert.Len(t, list.Jobs, 1)
+
+	rec = doRequest(t, srv, http.MethodGet, "/api/v1/jobs/job-404", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestServer_JobEvents(t *testing.T) {
+	t.Parallel()
+
+	runner := &fakeRunner{output: "→ Executing: claude -p \"/autospec.plan\"\nplan written\n"}
+	srv, _ := newTestServer(t, runner, false)
+	ts := httptest.NewServer(srv.Handler())
+	t.Cleanup(ts.Close)
+
+	rec := doRequest(t, srv, http.MethodPost, "/api/v1/workflows/plan", `{"spec": "003-auth"}`)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	var job Job
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/api/v1/jobs/"+job.ID+"/events", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// The stream replays history and closes after the terminal job event
+	var events []Event
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		var e Event
+		require.NoError(t, json.Unmarshal([]byte(data), &e))
+		events = append(events, e)
+	}
+	require.NoError(t, scanner.Err())
+
+	require.NotEmpty(t, events)
+	assert.Equal(t, EventJob, events[0].Type)
+	assert.Equal(t, string(JobStatusRunning), events[0].Status)
+	assert.True(t, events[len(events)-1].isTerminal())
+	var stages []string
+	for _, e := range events {
+		if e.Type == EventStage {
+			stages = append(stages, e.Stage+":"+e.Status)
+		}
+	}
+	assert.Equal(t, []string{"plan:started", "plan:completed"}, stages)
+}
+
+func TestServer_History(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := newTestServer(t, &fakeRunner{}, false)
+	require.NoError(t, history.SaveHistory(srv.opts.HistoryDir, &history.HistoryFile{Entries: []history.HistoryEntry{
+		{Command: "plan", Spec: "003-auth", ExitCode: 0},
+		{Command: "plan", Spec: "004-billing", ExitCode: 1},
+		{Command: "tasks", Spec: "003-auth", ExitCode: 0},
+	}}))
+
+	tests := map[string]struct {
+		query        string
+		wantStatus   int
+		wantCommands []string
+	}{
+		"all entries": {
+			wantStatus:   http.StatusOK,
+			wantCommands: []string{"plan", "plan", "tasks"},
+		},
+		"filter by spec": {
+			query:        "?spec=003-auth",
+			wantStatus:   http.StatusOK,
+			wantCommands: []string{"plan", "tasks"},
+		},
+		"limit keeps most recent": {
+			query:        "?limit=1",
+			wantStatus:   http.StatusOK,
+			wantCommands: []string{"tasks"},
+		},
+		"invalid limit": {
+			query:      "?limit=-1",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			rec := doRequest(t, srv, http.MethodGet, "/api/v1/history"+tt.query, "")
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantCommands == nil {
+				return
+			}
+			var resp struct {
+				Entries []history.HistoryEntry `json:"entries"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			var commands []string
+			for _, e := range resp.Entries {
+				commands = append(commands, e.Command)
+			}
+			assert.Equal(t, tt.wantCommands, commands)
+		})
+	}
+}
+
+func TestServer_ValidateArtifact(t *testing.T) {
+	t.Parallel()
+
+	srv, workDir := newTestServer(t, &fakeRunner{}, false)
+	specPath := filepath.Join("specs", "003-auth", "spec.yaml")
+	require.NoError(t, os.WriteFile(filepath.Join(workDir, specPath), []byte("feature:\n  branch: x\n"), 0o644))
+
+	tests := map[string]struct {
+		body       string
+		wantStatus int
+		wantValid  bool
+	}{
+		"invalid spec artifact": {
+			body:       `{"path": "` + specPath + `"}`,
+			wantStatus: http.StatusOK,
+			wantValid:  false,
+		},
+		"missing artifact": {
+			body:       `{"path": "specs/003-auth/plan.yaml"}`,
+			wantStatus: http.StatusNotFound,
+		},
+		"unrecognized filename": {
+			body:       `{"path": "specs/003-auth/notes.yaml"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		"path outside project": {
+			body:       `{"path": "../spec.yaml"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			rec := doRequest(t, srv, http.MethodPost, "/api/v1/artifacts/validate", tt.body)
+			require.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var resp validationResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantValid, resp.Valid)
+			assert.Equal(t, "spec", resp.Type)
+			assert.NotEmpty(t, resp.Errors)
+		})
+	}
+}
+
+func TestServer_DAGEndpoints(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled outside dev builds", func(t *testing.T) {
+		t.Parallel()
+		srv, _ := newTestServer(t, &fakeRunner{}, false)
+		rec := doRequest(t, srv, http.MethodPost, "/api/v1/dag/run", `{"file": "dag.yaml"}`)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("run starts dag subprocess", func(t *testing.T) {
+		t.Parallel()
+		runner := &fakeRunner{}
+		srv, _ := newTestServer(t, runner, true)
+		rec := doRequest(t, srv, http.MethodPost, "/api/v1/dag/run", `{"file": "dag.yaml", "parallel": true}`)
+		require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+		var job Job
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+		assert.Equal(t, "dag-run", job.Operation)
+		waitJob(t, srv.Jobs(), job.ID)
+		assert.Equal(t, []string{"autospec", "dag", "run", "dag.yaml", "--parallel", "--no-merge-prompt"}, runner.lastCall())
+	})
+
+	t.Run("status reads inline state", func(t *testing.T) {
+		t.Parallel()
+		srv, workDir := newTestServer(t, &fakeRunner{}, true)
+		dagYAML := `schema_version: "1.0"
+dag:
+  name: Test DAG
+layers:
+  - id: L0
+    features:
+      - id: 003-auth
+        description: Auth
+run:
+  status: running
+specs:
+  003-auth:
+    status: running
+    current_stage: plan
+`
+		require.NoError(t, os.WriteFile(filepath.Join(workDir, "dag.yaml"), []byte(dagYAML), 0o644))
+
+		rec := doRequest(t, srv, http.MethodGet, "/api/v1/dag/status?file=dag.yaml", "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp dagStatusResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "Test DAG", resp.Name)
+		assert.Equal(t, "running", resp.Status)
+		assert.Equal(t, "plan", resp.Specs["003-auth"].CurrentStage)
+
+		rec = doRequest(t, srv, http.MethodGet, "/api/v1/dag/status", "")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
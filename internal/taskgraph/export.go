@@ -0,0 +1,219 @@
+package taskgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/graphexport"
+)
+
+// statusStyles colours task statuses in Mermaid and DOT output.
+// Pending tasks use the renderer's default style.
+var statusStyles = map[TaskStatus]graphexport.Style{
+	StatusRunning:   graphexport.StyleRunning,
+	StatusCompleted: graphexport.StyleCompleted,
+	StatusFailed:    graphexport.StyleFailed,
+	StatusSkipped:   graphexport.StyleInactive,
+}
+
+// styledStatuses is the order statuses are emitted in for stable output.
+var styledStatuses = []TaskStatus{StatusRunning, StatusCompleted, StatusFailed, StatusSkipped}
+
+// StatusFromTaskItem maps a tasks.yaml status to a node status.
+// Blocked and unknown statuses map to StatusPending.
+func StatusFromTaskItem(status string) TaskStatus {
+	switch status {
+	case "InProgress":
+		return StatusRunning
+	case "Completed":
+		return StatusCompleted
+	default:
+		return StatusPending
+	}
+}
+
+// ApplyTaskStatuses sets each node's status from its tasks.yaml status,
+// so exported diagrams reflect implementation progress.
+func (g *DependencyGraph) ApplyTaskStatuses() {
+	for _, node := range g.nodes {
+		if node.Task != nil {
+			node.Status = StatusFromTaskItem(node.Task.Status)
+		}
+	}
+}
+
+// sortedIDs returns a sorted copy of ids.
+func sortedIDs(ids []string) []string {
+	sorted := make([]string, len(ids))
+	copy(sorted, ids)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// label returns "ID: title" or just the ID when the task has no title.
+func (n *TaskNode) label() string {
+	if n.Task == nil || n.Task.Title == "" {
+		return n.ID
+	}
+	return fmt.Sprintf("%s: %s", n.ID, n.Task.Title)
+}
+
+// edges returns dependency edges as [from, to] pairs in wave order.
+func (g *DependencyGraph) edges() [][2]string {
+	var edges [][2]string
+	for _, wave := range g.waves {
+		for _, id := range sortedIDs(wave.TaskIDs) {
+			node := g.GetNode(id)
+			if node == nil {
+				continue
+			}
+			for _, dep := range sortedIDs(node.Dependencies) {
+				edges = append(edges, [2]string{dep, id})
+			}
+		}
+	}
+	return edges
+}
+
+// RenderMermaid generates a Mermaid flowchart of the task graph. Waves
+// become subgraphs, dependencies become edges, and tasks are coloured by
+// their node status.
+func (g *DependencyGraph) RenderMermaid() string {
+	if len(g.waves) == 0 {
+		return "No waves computed. Run ComputeWaves() first."
+	}
+
+	var sb strings.Builder
+	sb.WriteString("flowchart TD\n")
+
+	byStatus := make(map[TaskStatus][]string)
+	for _, wave := range g.waves {
+		fmt.Fprintf(&sb, "  subgraph wave_%d[\"Wave %d\"]\n", wave.Number, wave.Number)
+		for _, id := range sortedIDs(wave.TaskIDs) {
+			node := g.GetNode(id)
+			if node == nil {
+				continue
+			}
+			nodeID := graphexport.MermaidID(id)
+			fmt.Fprintf(&sb, "    %s[\"%s\"]\n", nodeID, graphexport.MermaidLabel(node.label()))
+			byStatus[node.Status] = append(byStatus[node.Status], nodeID)
+		}
+		sb.WriteString("  end\n")
+	}
+
+	for _, edge := range g.edges() {
+		fmt.Fprintf(&sb, "  %s --> %s\n", graphexport.MermaidID(edge[0]), graphexport.MermaidID(edge[1]))
+	}
+
+	for _, status := range styledStatuses {
+		style := statusStyles[status]
+		fmt.Fprintf(&sb, "  classDef %s fill:%s,stroke:%s\n", strings.ToLower(status.String()), style.Fill, style.Stroke)
+	}
+	for _, status := range styledStatuses {
+		if ids := byStatus[status]; len(ids) > 0 {
+			fmt.Fprintf(&sb, "  class %s %s\n", strings.Join(ids, ","), strings.ToLower(status.String()))
+		}
+	}
+
+	return sb.String()
+}
+
+// RenderDOT generates a Graphviz DOT digraph of the task graph. Waves
+// become clusters and tasks are filled by their node status.
+func (g *DependencyGraph) RenderDOT() string {
+	if len(g.waves) == 0 {
+		return "No waves computed. Run ComputeWaves() first."
+	}
+
+	var sb strings.Builder
+	sb.WriteString("digraph tasks {\n")
+	sb.WriteString("  rankdir=TB;\n")
+	sb.WriteString("  node [shape=box, style=\"rounded,filled\", fillcolor=\"#ffffff\"];\n")
+
+	for _, wave := range g.waves {
+		fmt.Fprintf(&sb, "  subgraph \"cluster_wave_%d\" {\n", wave.Number)
+		fmt.Fprintf(&sb, "    label=\"Wave %d\";\n", wave.Number)
+		for _, id := range sortedIDs(wave.TaskIDs) {
+			node := g.GetNode(id)
+			if node == nil {
+				continue
+			}
+			attrs := fmt.Sprintf("label=%s", graphexport.DOTQuote(node.label()))
+			if style, ok := statusStyles[node.Status]; ok {
+				attrs += fmt.Sprintf(", fillcolor=%s, color=%s", graphexport.DOTQuote(style.Fill), graphexport.DOTQuote(style.Stroke))
+			}
+			fmt.Fprintf(&sb, "    %s [%s];\n", graphexport.DOTQuote(id), attrs)
+		}
+		sb.WriteString("  }\n")
+	}
+
+	for _, edge := range g.edges() {
+		fmt.Fprintf(&sb, "  %s -> %s;\n", graphexport.DOTQuote(edge[0]), graphexport.DOTQuote(edge[1]))
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// wavesJSON is the JSON export document.
+type wavesJSON struct {
+	Waves []waveJSON `json:"waves"`
+	Edges []edgeJSON `json:"edges"`
+}
+
+type waveJSON struct {
+	Number int        `json:"number"`
+	Tasks  []taskJSON `json:"tasks"`
+}
+
+type taskJSON struct {
+	ID           string   `json:"id"`
+	Title        string   `json:"title,omitempty"`
+	Status       string   `json:"status"`
+	Dependencies []string `json:"dependencies,omitempty"`
+}
+
+type edgeJSON struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// RenderJSON generates a JSON document with the task graph's waves,
+// dependency edges, and node statuses.
+func (g *DependencyGraph) RenderJSON() (string, error) {
+	doc := wavesJSON{
+		Waves: make([]waveJSON, 0, len(g.waves)),
+		Edges: []edgeJSON{},
+	}
+
+	for _, wave := range g.waves {
+		wj := waveJSON{Number: wave.Number, Tasks: []taskJSON{}}
+		for _, id := range sortedIDs(wave.TaskIDs) {
+			node := g.GetNode(id)
+			if node == nil {
+				continue
+			}
+			tj := taskJSON{
+				ID:           id,
+				Status:       node.Status.String(),
+				Dependencies: sortedIDs(node.Dependencies),
+			}
+			if node.Task != nil {
+				tj.Title = node.Task.Title
+			}
+			wj.Tasks = append(wj.Tasks, tj)
+		}
+		doc.Waves = append(doc.Waves, wj)
+	}
+	for _, edge := range g.edges() {
+		doc.Edges = append(doc.Edges, edgeJSON{From: edge[0], To: edge[1]})
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshaling task graph JSON: %w", err)
+	}
+	return string(data) + "\n", nil
+}
@@ -0,0 +1,124 @@
+package taskgraph
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exportTestGraph builds a two-wave graph with statuses from tasks.yaml applied.
+func exportTestGraph(t *testing.T) *DependencyGraph {
+	t.Helper()
+	graph, err := BuildFromTasks([]validation.TaskItem{
+		{ID: "T001", Title: "Create \"models\"", Status: "Completed"},
+		{ID: "T002", Title: "Add API", Status: "InProgress", Dependencies: []string{"T001"}},
+		{ID: "T003", Title: "Add UI", Status: "Blocked", Dependencies: []string{"T001"}},
+	})
+	require.NoError(t, err)
+	_, err = graph.ComputeWaves()
+	require.NoError(t, err)
+	graph.ApplyTaskStatuses()
+	return graph
+}
+
+func TestStatusFromTaskItem(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]TaskStatus{
+		"Pending":    StatusPending,
+		"InProgress": StatusRunning,
+		"Completed":  StatusCompleted,
+		"Blocked":    StatusPending,
+		"":           StatusPending,
+	}
+
+	for status, want := range tests {
+		t.Run(status, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, want, StatusFromTaskItem(status))
+		})
+	}
+}
+
+func TestDependencyGraph_RenderMermaid(t *testing.T) {
+	t.Parallel()
+
+	out := exportTestGraph(t).RenderMermaid()
+
+	assert.True(t, strings.HasPrefix(out, "flowchart TD\n"))
+	for _, want := range []string{
+		`subgraph wave_1["Wave 1"]`,
+		`subgraph wave_2["Wave 2"]`,
+		`T001["T001: Create #quot;models#quot;"]`,
+		"T001 --> T002",
+		"T001 --> T003",
+		"class T001 completed",
+		"class T002 running",
+	} {
+		assert.Contains(t, out, want)
+	}
+	assert.NotContains(t, out, "class T003")
+}
+
+func TestDependencyGraph_RenderDOT(t *testing.T) {
+	t.Parallel()
+
+	out := exportTestGraph(t).RenderDOT()
+
+	for _, want := range []string{
+		"digraph tasks {",
+		`subgraph "cluster_wave_1" {`,
+		`"T001" [label="T001: Create \"models\"", fillcolor="#d4edda", color="#28a745"];`,
+		`"T003" [label="T003: Add UI"];`,
+		`"T001" -> "T002";`,
+	} {
+		assert.Contains(t, out, want)
+	}
+}
+
+func TestDependencyGraph_RenderJSON(t *testing.T) {
+	t.Parallel()
+
+	out, err := exportTestGraph(t).RenderJSON()
+	require.NoError(t, err)
+
+	var doc struct {
+		Waves []struct {
+			Number int `json:"number"`
+			Tasks  []struct {
+				ID           string   `json:"id"`
+				Title        string   `json:"title"`
+				Status       string   `json:"status"`
+				Dependencies []string `json:"dependencies"`
+			} `json:"tasks"`
+		} `json:"waves"`
+		Edges []struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+		} `json:"edges"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &doc))
+
+	require.Len(t, doc.Waves, 2)
+	assert.Equal(t, "Completed", doc.Waves[0].Tasks[0].Status)
+	assert.Equal(t, "T002", doc.Waves[1].Tasks[0].ID)
+	assert.Equal(t, "Running", doc.Waves[1].Tasks[0].Status)
+	assert.Equal(t, []string{"T001"}, doc.Waves[1].Tasks[0].Dependencies)
+	assert.Len(t, doc.Edges, 2)
+}
+
+func TestDependencyGraph_Export_NoWaves(t *testing.T) {
+	t.Parallel()
+
+	graph := NewDependencyGraph()
+	assert.Contains(t, graph.RenderMermaid(), "No waves computed")
+	assert.Contains(t, graph.RenderDOT(), "No waves computed")
+
+	out, err := graph.RenderJSON()
+	require.NoError(t, err)
+	assert.Contains(t, out, `"waves": []`)
+}
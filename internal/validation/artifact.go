@@ -126,7 +126,30 @@ type ArtifactValidator interface {
 }
 
 // NewArtifactValidator creates a validator for the given artifact type.
+// When the project extends the schema in SchemaExtensionDir, the validator
+// also checks the extension; an invalid extension file is an error.
 func NewArtifactValidator(artifactType ArtifactType) (ArtifactValidator, error) {
+	return newArtifactValidator(artifactType, SchemaExtensionDir)
+}
+
+// newArtifactValidator creates a validator honouring extensions in dir.
+func newArtifactValidator(artifactType ArtifactType, dir string) (ArtifactValidator, error) {
+	validator, err := newBuiltinValidator(artifactType)
+	if err != nil {
+		return nil, err
+	}
+	ext, err := loadSchemaExtension(dir, artifactType)
+	if err != nil {
+		return nil, err
+	}
+	if ext == nil {
+		return validator, nil
+	}
+	return &extendedValidator{ArtifactValidator: validator, extension: ext}, nil
+}
+
+// newBuiltinValidator creates the built-in validator for an artifact type.
+func newBuiltinValidator(artifactType ArtifactType) (ArtifactValidator, error) {
 	switch artifactType {
 	case ArtifactTypeSpec:
 		return &SpecValidator{}, nil
@@ -140,6 +163,8 @@ func NewArtifactValidator(artifactType ArtifactType) (ArtifactValidator, error)
 		return &ChecklistValidator{}, nil
 	case ArtifactTypeConstitution:
 		return &ConstitutionValidator{}, nil
+	case ArtifactTypeReview:
+		return &ReviewValidator{}, nil
 	default:
 		return nil, fmt.Errorf("unknown artifact type: %s", artifactType)
 	}
@@ -0,0 +1,251 @@
+package validation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Review finding enums shared by the schema, validator, and review template.
+var (
+	reviewSeverities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW"}
+	reviewCategories = []string{"correctness", "acceptance_criteria", "security", "error_handling", "testing", "performance", "maintainability"}
+	reviewStatuses   = []string{"open", "resolved", "wont_fix"}
+	reviewVerdicts   = []string{"APPROVE", "REQUEST_CHANGES"}
+)
+
+// ReviewValidator validates review.yaml artifacts.
+type ReviewValidator struct {
+	baseValidator
+}
+
+// Type returns the artifact type.
+func (v *ReviewValidator) Type() ArtifactType {
+	return ArtifactTypeReview
+}
+
+// Validate validates a review.yaml file at the given path.
+func (v *ReviewValidator) Validate(path string) *ValidationResult {
+	result := &ValidationResult{Valid: true}
+
+	root, err := parseYAMLFile(path)
+	if err != nil {
+		result.AddError(&ValidationError{
+			Path:    path,
+			Message: fmt.Sprintf("failed to parse YAML: %v", err),
+			Hint:    "Check the YAML syntax for errors",
+		})
+		return result
+	}
+
+	rootMapping := getRootMapping(root)
+	if rootMapping == nil {
+		result.AddError(&ValidationError{
+			Path:    path,
+			Message: "expected a YAML mapping at document root",
+			Hint:    "The review.yaml file should start with key-value pairs, not a list or scalar",
+		})
+		return result
+	}
+
+	reviewNode := validateRequiredField(rootMapping, "review", result)
+	findingsNode := validateRequiredField(rootMapping, "findings", result)
+	summaryNode := validateRequiredField(rootMapping, "summary", result)
+
+	if reviewNode != nil {
+		v.validateReviewSection(reviewNode, result)
+	}
+	if findingsNode != nil {
+		v.validateFindings(findingsNode, result)
+	}
+	if summaryNode != nil {
+		v.validateSummary(summaryNode, result)
+	}
+
+	if result.Valid {
+		result.Summary = v.buildSummary(rootMapping)
+	}
+
+	return result
+}
+
+// validateReviewSection validates the review section.
+func (v *ReviewValidator) validateReviewSection(node *yaml.Node, result *ValidationResult) {
+	if !validateFieldType(node, "review", yaml.MappingNode, "object", result) {
+		return
+	}
+
+	validateRequiredField(node, "branch", result)
+	validateRequiredField(node, "timestamp", result)
+}
+
+// validateFindings validates the findings section.
+func (v *ReviewValidator) validateFindings(node *yaml.Node, result *ValidationResult) {
+	if !validateFieldType(node, "findings", yaml.SequenceNode, "array", result) {
+		return
+	}
+
+	for i, findingNode := range node.Content {
+		v.validateFinding(findingNode, fmt.Sprintf("findings[%d]", i), result)
+	}
+}
+
+// validateFinding validates a single review finding.
+func (v *ReviewValidator) validateFinding(node *yaml.Node, path string, result *ValidationResult) {
+	if node.Kind != yaml.MappingNode {
+		result.AddError(&ValidationError{
+			Path:     path,
+			Line:     getNodeLine(node),
+			Message:  fmt.Sprintf("wrong type for '%s'", path),
+			Expected: "object",
+			Actual:   nodeKindToString(node.Kind),
+		})
+		return
+	}
+
+	for _, field := range []string{"id", "severity", "category", "file", "summary"} {
+		if findNode(node, field) == nil {
+			result.AddError(&ValidationError{
+				Path:    fmt.Sprintf("%s.%s", path, field),
+				Line:    getNodeLine(node),
+				Message: fmt.Sprintf("missing required field: %s", field),
+				Hint:    fmt.Sprintf("Add the '%s' field to this finding", field),
+			})
+		}
+	}
+
+	if severityNode := findNode(node, "severity"); severityNode != nil {
+		validateEnumValue(severityNode, path+".severity", reviewSeverities, result)
+	}
+	if categoryNode := findNode(node, "category"); categoryNode != nil {
+		validateEnumValue(categoryNode, path+".category", reviewCategories, result)
+	}
+	if statusNode := findNode(node, "status"); statusNode != nil {
+		validateEnumValue(statusNode, path+".status", reviewStatuses, result)
+	}
+	if lineNode := findNode(node, "line"); lineNode != nil && lineNode.Tag != "!!int" {
+		result.AddError(&ValidationError{
+			Path:     path + ".line",
+			Line:     getNodeLine(lineNode),
+			Column:   getNodeColumn(lineNode),
+			Message:  fmt.Sprintf("wrong type for field '%s.line'", path),
+			Expected: "integer",
+			Actual:   fmt.Sprintf("'%s'", lineNode.Value),
+			Hint:     "Use the 1-based line number in the file (e.g., line: 42)",
+		})
+	}
+}
+
+// validateSummary validates the summary section.
+func (v *ReviewValidator) validateSummary(node *yaml.Node, result *ValidationResult) {
+	if !validateFieldType(node, "summary", yaml.MappingNode, "object", result) {
+		return
+	}
+
+	verdictNode := findNode(node, "verdict")
+	if verdictNode == nil {
+		result.AddError(&ValidationError{
+			Path:    "summary.verdict",
+			Line:    getNodeLine(node),
+			Message: "missing required field: verdict",
+			Hint:    "Add the 'verdict' field with value APPROVE or REQUEST_CHANGES",
+		})
+		return
+	}
+	validateEnumValue(verdictNode, "summary.verdict", reviewVerdicts, result)
+}
+
+// buildSummary builds the summary for a valid review artifact.
+func (v *ReviewValidator) buildSummary(root *yaml.Node) *ArtifactSummary {
+	summary := &ArtifactSummary{
+		Type:   ArtifactTypeReview,
+		Counts: make(map[string]int),
+	}
+
+	findingsNode := findNode(root, "findings")
+	if findingsNode == nil || findingsNode.Kind != yaml.SequenceNode {
+		return summary
+	}
+
+	summary.Counts["findings"] = len(findingsNode.Content)
+	for _, finding := range findingsNode.Content {
+		if severityNode := findNode(finding, "severity"); severityNode != nil {
+			summary.Counts[strings.ToLower(severityNode.Value)+"_findings"]++
+		}
+		if statusNode := findNode(finding, "status"); statusNode == nil || statusNode.Value == "open" {
+			summary.Counts["open_findings"]++
+		}
+	}
+
+	return summary
+}
+
+// ReviewFinding is a single finding from review.yaml.
+type ReviewFinding struct {
+	ID       string `yaml:"id"`
+	Severity string `yaml:"severity"`
+	Category string `yaml:"category"`
+	File     string `yaml:"file"`
+	Line     int    `yaml:"line"`
+	Summary  string `yaml:"summary"`
+	Status   string `yaml:"status"`
+}
+
+// IsOpen returns true if the finding has not been resolved or waived.
+// Findings without a status are open.
+func (f ReviewFinding) IsOpen() bool {
+	return f.Status == "" || f.Status == "open"
+}
+
+// Location returns "file:line", or just the file when no line is given.
+func (f ReviewFinding) Location() string {
+	if f.Line > 0 {
+		return fmt.Sprintf("%s:%d", f.File, f.Line)
+	}
+	return f.File
+}
+
+// GetReviewFindings parses the findings from a review.yaml file.
+func GetReviewFindings(reviewPath string) ([]ReviewFinding, error) {
+	data, err := os.ReadFile(reviewPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading review file: %w", err)
+	}
+
+	var review struct {
+		Findings []ReviewFinding `yaml:"findings"`
+	}
+	if err := yaml.Unmarshal(data, &review); err != nil {
+		return nil, fmt.Errorf("parsing review file: %w", err)
+	}
+	return review.Findings, nil
+}
+
+// GetReviewFilePath returns the path to review.yaml in specDir.
+func GetReviewFilePath(specDir string) string {
+	return filepath.Join(specDir, "review.yaml")
+}
+
+// ValidateReviewResolved checks that review.yaml has no open CRITICAL findings.
+// The error lists each blocking finding as a "- " bullet for retry context.
+func ValidateReviewResolved(reviewPath string) error {
+	findings, err := GetReviewFindings(reviewPath)
+	if err != nil {
+		return err
+	}
+
+	var blocking []string
+	for _, f := range findings {
+		if f.Severity == "CRITICAL" && f.IsOpen() {
+			blocking = append(blocking, fmt.Sprintf("%s (%s): %s", f.ID, f.Location(), f.Summary))
+		}
+	}
+	if len(blocking) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("review has %d open critical finding(s):\n- %s", len(blocking), strings.Join(blocking, "\n- "))
+}
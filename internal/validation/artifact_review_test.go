@@ -0,0 +1,300 @@
+// Package validation_test tests review.yaml artifact validation and critical finding gating.
+// Related: internal/validation/artifact_review.go
+// Tags: validation, review, artifact, yaml, findings, severity, adversarial
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const validReviewYAML = `review:
+  branch: "001-test-feature"
+  timestamp: "2025-01-01T00:00:00Z"
+
+findings:
+  - id: "REV-001"
+    severity: "CRITICAL"
+    category: "acceptance_criteria"
+    file: "internal/auth/reset.go"
+    line: 87
+    summary: "Reset tokens never expire"
+    status: "open"
+  - id: "REV-002"
+    severity: "LOW"
+    category: "maintainability"
+    file: "internal/auth/store.go"
+    summary: "Duplicated query builder"
+    status: "resolved"
+
+summary:
+  verdict: "REQUEST_CHANGES"
+
+_meta:
+  version: "1.0.0"
+  artifact_type: "review"
+`
+
+func TestReviewValidator_Type(t *testing.T) {
+	t.Parallel()
+
+	v := &ReviewValidator{}
+	if got := v.Type(); got != ArtifactTypeReview {
+		t.Errorf("Type() = %v, want %v", got, ArtifactTypeReview)
+	}
+}
+
+func TestReviewValidator_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		yaml      string
+		wantValid bool
+		wantErrs  int
+	}{
+		"valid review": {
+			yaml:      validReviewYAML,
+			wantValid: true,
+		},
+		"empty findings": {
+			yaml: `review:
+  branch: "001-test"
+  timestamp: "2025-01-01"
+findings: []
+summary:
+  verdict: "APPROVE"
+`,
+			wantValid: true,
+		},
+		"missing review section": {
+			yaml: `findings: []
+summary:
+  verdict: "APPROVE"
+`,
+			wantValid: false,
+			wantErrs:  1,
+		},
+		"missing branch and timestamp": {
+			yaml: `review: {}
+findings: []
+summary:
+  verdict: "APPROVE"
+`,
+			wantValid: false,
+			wantErrs:  2,
+		},
+		"finding missing file and summary": {
+			yaml: `review:
+  branch: "001-test"
+  timestamp: "2025-01-01"
+findings:
+  - id: "REV-001"
+    severity: "HIGH"
+    category: "correctness"
+summary:
+  verdict: "REQUEST_CHANGES"
+`,
+			wantValid: false,
+			wantErrs:  2,
+		},
+		"invalid severity": {
+			yaml: `review:
+  branch: "001-test"
+  timestamp: "2025-01-01"
+findings:
+  - id: "REV-001"
+    severity: "BLOCKER"
+    category: "correctness"
+    file: "main.go"
+    summary: "Bug"
+summary:
+  verdict: "REQUEST_CHANGES"
+`,
+			wantValid: false,
+			wantErrs:  1,
+		},
+		"invalid category and status": {
+			yaml: `review:
+  branch: "001-test"
+  timestamp: "2025-01-01"
+findings:
+  - id: "REV-001"
+    severity: "HIGH"
+    category: "style"
+    file: "main.go"
+    summary: "Bug"
+    status: "ignored"
+summary:
+  verdict: "REQUEST_CHANGES"
+`,
+			wantValid: false,
+			wantErrs:  2,
+		},
+		"line is not an integer": {
+			yaml: `review:
+  branch: "001-test"
+  timestamp: "2025-01-01"
+findings:
+  - id: "REV-001"
+    severity: "HIGH"
+    category: "correctness"
+    file: "main.go"
+    line: "42-50"
+    summary: "Bug"
+summary:
+  verdict: "REQUEST_CHANGES"
+`,
+			wantValid: false,
+			wantErrs:  1,
+		},
+		"missing verdict": {
+			yaml: `review:
+  branch: "001-test"
+  timestamp: "2025-01-01"
+findings: []
+summary:
+  critical_findings: 0
+`,
+			wantValid: false,
+			wantErrs:  1,
+		},
+		"invalid verdict": {
+			yaml: `review:
+  branch: "001-test"
+  timestamp: "2025-01-01"
+findings: []
+summary:
+  verdict: "PASS"
+`,
+			wantValid: false,
+			wantErrs:  1,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			path := filepath.Join(dir, "review.yaml")
+			if err := os.WriteFile(path, []byte(tc.yaml), 0o644); err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+
+			v := &ReviewValidator{}
+			result := v.Validate(path)
+
+			if result.Valid != tc.wantValid {
+				t.Errorf("Valid = %v, want %v", result.Valid, tc.wantValid)
+				for _, err := range result.Errors {
+					t.Logf("  Error: %s", err.Error())
+				}
+			}
+			if len(result.Errors) != tc.wantErrs {
+				t.Errorf("len(Errors) = %d, want %d", len(result.Errors), tc.wantErrs)
+			}
+			if tc.wantValid && result.Summary == nil {
+				t.Error("Summary is nil for valid result")
+			}
+		})
+	}
+}
+
+func TestReviewValidator_Summary(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "review.yaml")
+	if err := os.WriteFile(path, []byte(validReviewYAML), 0o644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	result := (&ReviewValidator{}).Validate(path)
+	if !result.Valid {
+		t.Fatalf("expected valid review, got errors: %v", result.Errors)
+	}
+
+	want := map[string]int{"findings": 2, "critical_findings": 1, "low_findings": 1, "open_findings": 1}
+	for key, count := range want {
+		if got := result.Summary.Counts[key]; got != count {
+			t.Errorf("Counts[%q] = %d, want %d", key, got, count)
+		}
+	}
+}
+
+func TestValidateReviewResolved(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		findings string
+		wantErr  string
+	}{
+		"no findings": {
+			findings: "findings: []\n",
+		},
+		"open critical finding blocks": {
+			findings: `findings:
+  - id: "REV-001"
+    severity: "CRITICAL"
+    file: "internal/auth/reset.go"
+    line: 87
+    summary: "Reset tokens never expire"
+`,
+			wantErr: "- REV-001 (internal/auth/reset.go:87): Reset tokens never expire",
+		},
+		"resolved and waived critical findings pass": {
+			findings: `findings:
+  - id: "REV-001"
+    severity: "CRITICAL"
+    file: "a.go"
+    summary: "Fixed"
+    status: "resolved"
+  - id: "REV-002"
+    severity: "CRITICAL"
+    file: "b.go"
+    summary: "Accepted risk"
+    status: "wont_fix"
+`,
+		},
+		"open high finding does not block": {
+			findings: `findings:
+  - id: "REV-001"
+    severity: "HIGH"
+    file: "a.go"
+    summary: "Missing test"
+    status: "open"
+`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			path := GetReviewFilePath(t.TempDir())
+			if err := os.WriteFile(path, []byte(tc.findings), 0o644); err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+
+			err := ValidateReviewResolved(path)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateReviewResolved() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("ValidateReviewResolved() = %v, want error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateReviewResolved_MissingFile(t *testing.T) {
+	t.Parallel()
+
+	if err := ValidateReviewResolved("/nonexistent/review.yaml"); err == nil {
+		t.Error("expected error for missing review file")
+	}
+}
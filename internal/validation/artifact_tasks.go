@@ -216,6 +216,11 @@ func (v *TasksValidator) validateTask(node *yaml.Node, path string, result *Vali
 		validateEnumValue(typeNode, path+".type", []string{"setup", "implementation", "test", "documentation", "refactor"}, result)
 	}
 
+	// test_kind must be a known EARS test kind if present
+	if testKindNode := findNode(node, "test_kind"); testKindNode != nil {
+		validateEnumValue(testKindNode, path+".test_kind", testKindStrings(), result)
+	}
+
 	// dependencies should be an array if present
 	depsNode := findNode(node, "dependencies")
 	if depsNode != nil {
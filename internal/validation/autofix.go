@@ -95,7 +95,10 @@ func FixArtifact(path string, artifactType ArtifactType) (*AutoFixResult, error)
 	}
 
 	// Run validation again to get remaining errors
-	validator, _ := NewArtifactValidator(artifactType)
+	validator, err := NewArtifactValidator(artifactType)
+	if err != nil {
+		return nil, err
+	}
 	validationResult := validator.Validate(path)
 	if !validationResult.Valid {
 		result.RemainingErrors = validationResult.Errors
@@ -0,0 +1,338 @@
+// Package validation provides EARS requirement to test task traceability.
+// Related: internal/validation/ears_validation.go, internal/validation/artifact_tasks.go
+// Tags: validation, ears, requirements, traceability, test-generation
+package validation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EarsTestKind is the test technique used to verify an EARS requirement.
+type EarsTestKind string
+
+const (
+	// TestKindContract: pre/postcondition and invariant assertions
+	TestKindContract EarsTestKind = "contract"
+	// TestKindProperty: generated inputs checked against a property or state model
+	TestKindProperty EarsTestKind = "property"
+	// TestKindMetamorphic: relations between outputs of related executions
+	TestKindMetamorphic EarsTestKind = "metamorphic"
+)
+
+// ValidTestKinds lists all valid EARS test kind values.
+var ValidTestKinds = []EarsTestKind{TestKindContract, TestKindProperty, TestKindMetamorphic}
+
+// TestKindForTestType maps each EARS test_type to the test kind that verifies it.
+var TestKindForTestType = map[EarsTestType]EarsTestKind{
+	TestTypeInvariant:    TestKindContract,
+	TestTypeException:    TestKindContract,
+	TestTypeProperty:     TestKindProperty,
+	TestTypeStateMachine: TestKindProperty,
+	TestTypeFeatureFlag:  TestKindMetamorphic,
+}
+
+// EarsTestPhaseTitle is the title of the phase that holds generated EARS test tasks.
+const EarsTestPhaseTitle = "EARS Requirement Tests"
+
+// taskIDNumber extracts the numeric part of a TNNN task ID.
+var taskIDNumber = regexp.MustCompile(`^T(\d+)$`)
+
+// GetEarsRequirements parses the ears_requirements section of a spec.yaml file.
+// Returns an empty slice if the section is absent.
+func GetEarsRequirements(specPath string) ([]EarsRequirement, error) {
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading spec file: %w", err)
+	}
+
+	var spec struct {
+		EarsRequirements []EarsRequirement `yaml:"ears_requirements"`
+	}
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parsing spec file: %w", err)
+	}
+	return spec.EarsRequirements, nil
+}
+
+// RequirementsNeedingTests returns the EARS requirements whose test kind is
+// enabled, in spec order.
+func RequirementsNeedingTests(reqs []EarsRequirement, kinds []EarsTestKind) []EarsRequirement {
+	enabled := make(map[EarsTestKind]bool, len(kinds))
+	for _, k := range kinds {
+		enabled[k] = true
+	}
+
+	var needed []EarsRequirement
+	for _, req := range reqs {
+		if kind, ok := TestKindForTestType[req.TestType]; ok && enabled[kind] {
+			needed = append(needed, req)
+		}
+	}
+	return needed
+}
+
+// testedRequirements returns the requirement IDs referenced by test tasks.
+func testedRequirements(tasks []TaskItem) map[string]bool {
+	tested := make(map[string]bool)
+	for _, task := range tasks {
+		if task.Type == "test" && task.RequirementID != "" {
+			tested[task.RequirementID] = true
+		}
+	}
+	return tested
+}
+
+// CheckEarsTraceability adds an error to result for each EARS requirement in
+// specPath whose test kind is enabled but has no test task in tasksPath, and
+// for each task that references an unknown requirement. A missing spec.yaml
+// or one without EARS requirements is not an error.
+func CheckEarsTraceability(tasksPath, specPath string, kinds []EarsTestKind, result *ValidationResult) {
+	if _, err := os.Stat(specPath); err != nil {
+		return
+	}
+	reqs, err := GetEarsRequirements(specPath)
+	if err != nil || len(reqs) == 0 {
+		return
+	}
+	tasks, err := GetAllTasks(tasksPath)
+	if err != nil {
+		return
+	}
+
+	known := make(map[string]bool, len(reqs))
+	for _, req := range reqs {
+		known[req.ID] = true
+	}
+	for _, task := range tasks {
+		if task.RequirementID != "" && !known[task.RequirementID] {
+			result.AddError(&ValidationError{
+				Path:    "phases",
+				Message: fmt.Sprintf("task %s references unknown EARS requirement %s", task.ID, task.RequirementID),
+				Hint:    "requirement_id must match an ears_requirements ID in spec.yaml",
+			})
+		}
+	}
+
+	tested := testedRequirements(tasks)
+	for _, req := range RequirementsNeedingTests(reqs, kinds) {
+		if tested[req.ID] {
+			continue
+		}
+		kind := TestKindForTestType[req.TestType]
+		result.AddError(&ValidationError{
+			Path:    "phases",
+			Message: fmt.Sprintf("EARS requirement %s (%s) has no %s test task", req.ID, req.TestType, kind),
+			Hint:    fmt.Sprintf("Add a task with type: test, requirement_id: %s, test_kind: %s", req.ID, kind),
+		})
+	}
+}
+
+// AddEarsTestTasks appends a test task to tasksPath for each EARS requirement
+// in specPath whose test kind is enabled and that has no test task yet. The
+// tasks are added to a final "EARS Requirement Tests" phase, created if
+// needed. Returns the IDs of the added tasks.
+func AddEarsTestTasks(tasksPath, specPath string, kinds []EarsTestKind) ([]string, error) {
+	if len(kinds) == 0 {
+		return nil, nil
+	}
+	if _, err := os.Stat(specPath); err != nil {
+		return nil, nil
+	}
+	reqs, err := GetEarsRequirements(specPath)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := GetAllTasks(tasksPath)
+	if err != nil {
+		return nil, err
+	}
+
+	tested := testedRequirements(tasks)
+	var missing []EarsRequirement
+	for _, req := range RequirementsNeedingTests(reqs, kinds) {
+		if !tested[req.ID] {
+			missing = append(missing, req)
+		}
+	}
+	if len(missing) == 0 {
+		return nil, nil
+	}
+
+	nextID := nextTaskNumber(tasks)
+	newTasks := make([]TaskItem, len(missing))
+	ids := make([]string, len(missing))
+	for i, req := range missing {
+		ids[i] = fmt.Sprintf("T%03d", nextID+i)
+		newTasks[i] = buildEarsTestTask(ids[i], req)
+	}
+
+	if err := appendEarsTestTasks(tasksPath, newTasks); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// nextTaskNumber returns one more than the highest TNNN task number.
+func nextTaskNumber(tasks []TaskItem) int {
+	highest := 0
+	for _, task := range tasks {
+		if m := taskIDNumber.FindStringSubmatch(task.ID); m != nil {
+			if n, err := strconv.Atoi(m[1]); err == nil && n > highest {
+				highest = n
+			}
+		}
+	}
+	return highest + 1
+}
+
+// buildEarsTestTask builds the test task for a requirement based on its test kind.
+func buildEarsTestTask(id string, req EarsRequirement) TaskItem {
+	kind := TestKindForTestType[req.TestType]
+
+	var criteria []string
+	switch kind {
+	case TestKindContract:
+		criteria = append(criteria, fmt.Sprintf("Contract test asserts %q as a precondition, postcondition, or invariant", req.Text))
+	case TestKindProperty:
+		criteria = append(criteria, fmt.Sprintf("Property-based test checks %q across generated inputs", req.Text))
+	case TestKindMetamorphic:
+		criteria = append(criteria, fmt.Sprintf("Metamorphic test relates outputs of runs with and without the condition in %q", req.Text))
+	}
+	if req.Trigger != "" {
+		criteria = append(criteria, fmt.Sprintf("Exercises trigger: %s; expects: %s", req.Trigger, req.Expected))
+	}
+	if req.State != "" {
+		criteria = append(criteria, fmt.Sprintf("Covers transitions into and out of state: %s", req.State))
+	}
+	if req.Condition != "" {
+		criteria = append(criteria, fmt.Sprintf("Injects failure condition: %s", req.Condition))
+	}
+	if req.Feature != "" {
+		criteria = append(criteria, fmt.Sprintf("Compares behavior with feature on and off: %s", req.Feature))
+	}
+
+	return TaskItem{
+		ID:                 id,
+		Title:              fmt.Sprintf("Write %s test for %s", kind, req.ID),
+		Status:             "Pending",
+		Type:               "test",
+		Parallel:           true,
+		Dependencies:       []string{},
+		AcceptanceCriteria: criteria,
+		RequirementID:      req.ID,
+		TestKind:           string(kind),
+	}
+}
+
+// appendEarsTestTasks adds tasks to the EARS test phase in tasksPath,
+// preserving the rest of the document, and updates summary counts.
+func appendEarsTestTasks(tasksPath string, tasks []TaskItem) error {
+	data, err := os.ReadFile(tasksPath)
+	if err != nil {
+		return fmt.Errorf("reading tasks file: %w", err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("parsing tasks file: %w", err)
+	}
+	rootMapping := getRootMapping(&root)
+	if rootMapping == nil {
+		return fmt.Errorf("tasks file %s is not a YAML mapping", tasksPath)
+	}
+	phasesNode := findNode(rootMapping, "phases")
+	if phasesNode == nil || phasesNode.Kind != yaml.SequenceNode {
+		return fmt.Errorf("tasks file %s has no phases list", tasksPath)
+	}
+
+	newPhase := false
+	phaseTasks := findEarsTestPhase(phasesNode)
+	if phaseTasks == nil {
+		phase := TaskPhase{
+			Number:  len(phasesNode.Content) + 1,
+			Title:   EarsTestPhaseTitle,
+			Purpose: "Tests generated from spec.yaml EARS requirements",
+		}
+		var phaseNode yaml.Node
+		if err := phaseNode.Encode(phase); err != nil {
+			return fmt.Errorf("encoding EARS test phase: %w", err)
+		}
+		phasesNode.Content = append(phasesNode.Content, &phaseNode)
+		phaseTasks = findNode(&phaseNode, "tasks")
+		newPhase = true
+	}
+	// An empty task list encodes as a flow sequence; render added tasks in block style
+	phaseTasks.Style = 0
+
+	for _, task := range tasks {
+		var taskNode yaml.Node
+		if err := taskNode.Encode(task); err != nil {
+			return fmt.Errorf("encoding task %s: %w", task.ID, err)
+		}
+		phaseTasks.Content = append(phaseTasks.Content, &taskNode)
+	}
+
+	if summaryNode := findNode(rootMapping, "summary"); summaryNode != nil && summaryNode.Kind == yaml.MappingNode {
+		incrementIntField(summaryNode, "total_tasks", len(tasks))
+		if newPhase {
+			incrementIntField(summaryNode, "total_phases", 1)
+		}
+	}
+
+	output, err := yaml.Marshal(&root)
+	if err != nil {
+		return fmt.Errorf("serializing tasks file: %w", err)
+	}
+	if err := os.WriteFile(tasksPath, output, 0o644); err != nil {
+		return fmt.Errorf("writing tasks file: %w", err)
+	}
+	return nil
+}
+
+// findEarsTestPhase returns the tasks node of an existing EARS test phase, or nil.
+func findEarsTestPhase(phasesNode *yaml.Node) *yaml.Node {
+	for _, phase := range phasesNode.Content {
+		titleNode := findNode(phase, "title")
+		if titleNode == nil || titleNode.Value != EarsTestPhaseTitle {
+			continue
+		}
+		if tasksNode := findNode(phase, "tasks"); tasksNode != nil && tasksNode.Kind == yaml.SequenceNode {
+			return tasksNode
+		}
+	}
+	return nil
+}
+
+// incrementIntField adds delta to an integer field of a mapping node, if present.
+func incrementIntField(node *yaml.Node, key string, delta int) {
+	valueNode := findNode(node, key)
+	if valueNode == nil || valueNode.Kind != yaml.ScalarNode {
+		return
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(valueNode.Value))
+	if err != nil {
+		return
+	}
+	valueNode.Value = strconv.Itoa(n + delta)
+}
+
+// testKindStrings returns test kind values as strings for error messages.
+func testKindStrings() []string {
+	result := make([]string, len(ValidTestKinds))
+	for i, k := range ValidTestKinds {
+		result[i] = string(k)
+	}
+	return result
+}
+
+// GetSpecFilePath returns the path to spec.yaml in specDir.
+func GetSpecFilePath(specDir string) string {
+	return filepath.Join(specDir, "spec.yaml")
+}
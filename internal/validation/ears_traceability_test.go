@@ -0,0 +1,264 @@
+// Package validation_test tests EARS requirement traceability and test task generation.
+// Related: internal/validation/ears_traceability.go
+// Tags: validation, ears, requirements, traceability, test-generation
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const traceabilitySpecYAML = `feature:
+  branch: "001-test"
+ears_requirements:
+  - id: "EARS-001"
+    pattern: "ubiquitous"
+    text: "The system shall validate all inputs"
+    test_type: "invariant"
+  - id: "EARS-002"
+    pattern: "event-driven"
+    text: "When a user submits a form, the system shall confirm"
+    test_type: "property"
+    trigger: "user submits a form"
+    expected: "confirmation shown"
+  - id: "EARS-003"
+    pattern: "optional"
+    text: "Where dark mode is enabled, the system shall use dark colors"
+    test_type: "feature-flag"
+    feature: "dark mode"
+`
+
+const traceabilityTasksYAML = `tasks:
+  branch: "001-test"
+summary:
+  total_tasks: 2
+  total_phases: 1
+phases:
+  - number: 1
+    title: "Core"
+    tasks:
+      - id: "T001"
+        title: "Implement validation"
+        status: "Pending"
+        type: "implementation"
+        dependencies: []
+      - id: "T009"
+        title: "Contract test for input validation"
+        status: "Pending"
+        type: "test"
+        requirement_id: "EARS-001"
+        test_kind: "contract"
+        dependencies: ["T001"]
+`
+
+// writeTraceabilityFixture writes spec.yaml and tasks.yaml into a temp dir.
+func writeTraceabilityFixture(t *testing.T, specYAML, tasksYAML string) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	specPath := GetSpecFilePath(dir)
+	tasksPath := filepath.Join(dir, "tasks.yaml")
+	if specYAML != "" {
+		if err := os.WriteFile(specPath, []byte(specYAML), 0o644); err != nil {
+			t.Fatalf("failed to write spec: %v", err)
+		}
+	}
+	if err := os.WriteFile(tasksPath, []byte(tasksYAML), 0o644); err != nil {
+		t.Fatalf("failed to write tasks: %v", err)
+	}
+	return tasksPath, specPath
+}
+
+func TestRequirementsNeedingTests(t *testing.T) {
+	t.Parallel()
+
+	reqs := []EarsRequirement{
+		{ID: "EARS-001", TestType: TestTypeInvariant},
+		{ID: "EARS-002", TestType: TestTypeStateMachine},
+		{ID: "EARS-003", TestType: TestTypeException},
+		{ID: "EARS-004", TestType: TestTypeFeatureFlag},
+	}
+
+	tests := map[string]struct {
+		kinds []EarsTestKind
+		want  []string
+	}{
+		"no kinds":         {kinds: nil, want: nil},
+		"contract only":    {kinds: []EarsTestKind{TestKindContract}, want: []string{"EARS-001", "EARS-003"}},
+		"property only":    {kinds: []EarsTestKind{TestKindProperty}, want: []string{"EARS-002"}},
+		"metamorphic only": {kinds: []EarsTestKind{TestKindMetamorphic}, want: []string{"EARS-004"}},
+		"all kinds":        {kinds: ValidTestKinds, want: []string{"EARS-001", "EARS-002", "EARS-003", "EARS-004"}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := RequirementsNeedingTests(reqs, tc.kinds)
+			var ids []string
+			for _, req := range got {
+				ids = append(ids, req.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tc.want, ",") {
+				t.Errorf("RequirementsNeedingTests() = %v, want %v", ids, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckEarsTraceability(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		specYAML  string
+		tasksYAML string
+		kinds     []EarsTestKind
+		wantErrs  []string
+	}{
+		"covered contract requirement": {
+			specYAML:  traceabilitySpecYAML,
+			tasksYAML: traceabilityTasksYAML,
+			kinds:     []EarsTestKind{TestKindContract},
+		},
+		"missing property and metamorphic tests": {
+			specYAML:  traceabilitySpecYAML,
+			tasksYAML: traceabilityTasksYAML,
+			kinds:     ValidTestKinds,
+			wantErrs: []string{
+				"EARS requirement EARS-002 (property) has no property test task",
+				"EARS requirement EARS-003 (feature-flag) has no metamorphic test task",
+			},
+		},
+		"no kinds enabled": {
+			specYAML:  traceabilitySpecYAML,
+			tasksYAML: traceabilityTasksYAML,
+		},
+		"missing spec is not an error": {
+			tasksYAML: traceabilityTasksYAML,
+			kinds:     ValidTestKinds,
+		},
+		"unknown requirement reference": {
+			specYAML:  traceabilitySpecYAML,
+			tasksYAML: strings.Replace(traceabilityTasksYAML, `requirement_id: "EARS-001"`, `requirement_id: "EARS-042"`, 1),
+			wantErrs:  []string{"task T009 references unknown EARS requirement EARS-042"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tasksPath, specPath := writeTraceabilityFixture(t, tc.specYAML, tc.tasksYAML)
+			result := &ValidationResult{Valid: true}
+
+			CheckEarsTraceability(tasksPath, specPath, tc.kinds, result)
+
+			if len(result.Errors) != len(tc.wantErrs) {
+				t.Fatalf("len(Errors) = %d, want %d: %v", len(result.Errors), len(tc.wantErrs), result.Errors)
+			}
+			for i, want := range tc.wantErrs {
+				if result.Errors[i].Message != want {
+					t.Errorf("Errors[%d] = %q, want %q", i, result.Errors[i].Message, want)
+				}
+			}
+			if result.Valid != (len(tc.wantErrs) == 0) {
+				t.Errorf("Valid = %v, want %v", result.Valid, len(tc.wantErrs) == 0)
+			}
+		})
+	}
+}
+
+func TestAddEarsTestTasks(t *testing.T) {
+	t.Parallel()
+
+	tasksPath, specPath := writeTraceabilityFixture(t, traceabilitySpecYAML, traceabilityTasksYAML)
+
+	ids, err := AddEarsTestTasks(tasksPath, specPath, ValidTestKinds)
+	if err != nil {
+		t.Fatalf("AddEarsTestTasks() error = %v", err)
+	}
+	if strings.Join(ids, ",") != "T010,T011" {
+		t.Errorf("ids = %v, want [T010 T011]", ids)
+	}
+
+	tasks, err := ParseTasksYAML(tasksPath)
+	if err != nil {
+		t.Fatalf("ParseTasksYAML() error = %v", err)
+	}
+	if len(tasks.Phases) != 2 {
+		t.Fatalf("len(Phases) = %d, want 2", len(tasks.Phases))
+	}
+	phase := tasks.Phases[1]
+	if phase.Number != 2 || phase.Title != EarsTestPhaseTitle {
+		t.Errorf("phase = %d %q, want 2 %q", phase.Number, phase.Title, EarsTestPhaseTitle)
+	}
+	if len(phase.Tasks) != 2 {
+		t.Fatalf("len(phase.Tasks) = %d, want 2", len(phase.Tasks))
+	}
+	property := phase.Tasks[0]
+	if property.RequirementID != "EARS-002" || property.TestKind != "property" || property.Type != "test" {
+		t.Errorf("first task = %+v, want property test for EARS-002", property)
+	}
+	if !strings.Contains(strings.Join(property.AcceptanceCriteria, "\n"), "user submits a form") {
+		t.Errorf("acceptance criteria should include the trigger, got %v", property.AcceptanceCriteria)
+	}
+	if tasks.Summary.TotalTasks != 4 || tasks.Summary.TotalPhases != 2 {
+		t.Errorf("summary = %+v, want 4 tasks in 2 phases", tasks.Summary)
+	}
+
+	// The updated file passes schema validation and traceability
+	result := (&TasksValidator{}).Validate(tasksPath)
+	CheckEarsTraceability(tasksPath, specPath, ValidTestKinds, result)
+	if !result.Valid {
+		t.Errorf("updated tasks.yaml is invalid: %v", result.Errors)
+	}
+
+	// A second run adds nothing
+	ids, err = AddEarsTestTasks(tasksPath, specPath, ValidTestKinds)
+	if err != nil {
+		t.Fatalf("second AddEarsTestTasks() error = %v", err)
+	}
+	if len(ids) != 0 {
+		t.Errorf("second run added %v, want none", ids)
+	}
+}
+
+func TestAddEarsTestTasks_ReusesPhase(t *testing.T) {
+	t.Parallel()
+
+	tasksPath, specPath := writeTraceabilityFixture(t, traceabilitySpecYAML, traceabilityTasksYAML)
+
+	if _, err := AddEarsTestTasks(tasksPath, specPath, []EarsTestKind{TestKindProperty}); err != nil {
+		t.Fatalf("AddEarsTestTasks() error = %v", err)
+	}
+	ids, err := AddEarsTestTasks(tasksPath, specPath, []EarsTestKind{TestKindMetamorphic})
+	if err != nil {
+		t.Fatalf("AddEarsTestTasks() error = %v", err)
+	}
+	if strings.Join(ids, ",") != "T011" {
+		t.Errorf("ids = %v, want [T011]", ids)
+	}
+
+	tasks, err := ParseTasksYAML(tasksPath)
+	if err != nil {
+		t.Fatalf("ParseTasksYAML() error = %v", err)
+	}
+	if len(tasks.Phases) != 2 || len(tasks.Phases[1].Tasks) != 2 {
+		t.Errorf("expected both generated tasks in one EARS phase, got %+v", tasks.Phases)
+	}
+	if tasks.Summary.TotalPhases != 2 {
+		t.Errorf("TotalPhases = %d, want 2", tasks.Summary.TotalPhases)
+	}
+}
+
+func TestAddEarsTestTasks_NoSpec(t *testing.T) {
+	t.Parallel()
+
+	tasksPath, specPath := writeTraceabilityFixture(t, "", traceabilityTasksYAML)
+
+	ids, err := AddEarsTestTasks(tasksPath, specPath, ValidTestKinds)
+	if err != nil || len(ids) != 0 {
+		t.Errorf("AddEarsTestTasks() = %v, %v; want no tasks and no error", ids, err)
+	}
+}
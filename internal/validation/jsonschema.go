@@ -0,0 +1,229 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/yaml"
+)
+
+// JSONSchemaDraft is the JSON Schema dialect of generated schemas. Draft-07 is
+// the newest draft supported by yaml-language-server.
+const JSONSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// JSONSchemaDir is the project directory generated JSON Schemas are written to
+// for editor integration (see WriteJSONSchemaFile).
+const JSONSchemaDir = ".autospec/schemas/json"
+
+// JSONSchemaPath returns the project file for the JSON Schema called name
+// (an artifact type, "dag", or "config").
+func JSONSchemaPath(name string) string {
+	return filepath.Join(JSONSchemaDir, name+".schema.json")
+}
+
+// ArtifactJSONSchema converts an artifact schema to a JSON Schema document.
+// Undeclared fields are allowed, matching the validators.
+func ArtifactJSONSchema(schema *Schema) map[string]any {
+	doc := objectJSONSchema(schema.Fields)
+	doc["$schema"] = JSONSchemaDraft
+	doc["title"] = fmt.Sprintf("autospec %s artifact", schema.Type)
+	if schema.Description != "" {
+		doc["description"] = schema.Description
+	}
+	return doc
+}
+
+// objectJSONSchema returns the JSON Schema of an object with fields.
+func objectJSONSchema(fields []SchemaField) map[string]any {
+	properties := make(map[string]any, len(fields))
+	var required []string
+	for _, field := range fields {
+		properties[field.Name] = fieldJSONSchema(field)
+		if field.Required {
+			required = append(required, field.Name)
+		}
+	}
+	doc := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		doc["required"] = required
+	}
+	return doc
+}
+
+// fieldJSONSchema returns the JSON Schema of a single field.
+func fieldJSONSchema(field SchemaField) map[string]any {
+	var doc map[string]any
+	switch field.Type {
+	case FieldTypeObject:
+		doc = objectJSONSchema(field.Children)
+	case FieldTypeArray:
+		doc = map[string]any{"type": "array"}
+		if len(field.Children) > 0 {
+			doc["items"] = objectJSONSchema(field.Children)
+		}
+	case FieldTypeInt:
+		doc = map[string]any{"type": "integer"}
+	case FieldTypeBool:
+		doc = map[string]any{"type": "boolean"}
+	default:
+		doc = map[string]any{"type": "string"}
+		if len(field.Enum) > 0 {
+			doc["enum"] = field.Enum
+		}
+		if field.Pattern != "" {
+			doc["pattern"] = field.Pattern
+		}
+	}
+	if field.Description != "" {
+		doc["description"] = field.Description
+	}
+	return doc
+}
+
+// timeType is reported as a date-time string by StructJSONSchema.
+var timeType = reflect.TypeOf(time.Time{})
+
+// StructJSONSchema generates a JSON Schema for the YAML encoding of a Go type
+// from its yaml struct tags. Fields without omitempty are required; named
+// string types listed in enums are restricted to those values.
+func StructJSONSchema(t reflect.Type, enums map[reflect.Type][]string) map[string]any {
+	doc := typeJSONSchema(t, enums)
+	doc["$schema"] = JSONSchemaDraft
+	return doc
+}
+
+// typeJSONSchema returns the JSON Schema of a Go type.
+func typeJSONSchema(t reflect.Type, enums map[reflect.Type][]string) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if values, ok := enums[t]; ok {
+		return map[string]any{"type": "string", "enum": values}
+	}
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return structJSONSchema(t, enums)
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": typeJSONSchema(t.Elem(), enums)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": typeJSONSchema(t.Elem(), enums)}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	default:
+		return map[string]any{}
+	}
+}
+
+// structJSONSchema returns the JSON Schema of a struct's yaml-tagged fields.
+func structJSONSchema(t reflect.Type, enums map[reflect.Type][]string) map[string]any {
+	properties := make(map[string]any)
+	var required []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		properties[name] = typeJSONSchema(field.Type, enums)
+		if !strings.Contains(opts, "omitempty") {
+			required = append(required, name)
+		}
+	}
+	doc := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		doc["required"] = required
+	}
+	return doc
+}
+
+// MarshalJSONSchema encodes a JSON Schema document as indented JSON.
+func MarshalJSONSchema(doc map[string]any) ([]byte, error) {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding JSON Schema: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// WriteJSONSchemaFile writes doc to path, creating parent directories. The
+// file is left untouched when its content is already up to date.
+func WriteJSONSchemaFile(path string, doc map[string]any) error {
+	data, err := MarshalJSONSchema(doc)
+	if err != nil {
+		return err
+	}
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, data) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating schema directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing JSON Schema: %w", err)
+	}
+	return nil
+}
+
+// StampSchemaModeline writes the JSON Schema of an artifact type (with the
+// project extension merged in) and points the artifact's modeline at it.
+func StampSchemaModeline(artifactPath string, artifactType ArtifactType) error {
+	schema, err := GetEffectiveSchema(artifactType)
+	if err != nil {
+		return err
+	}
+	return StampJSONSchemaModeline(artifactPath, string(artifactType), ArtifactJSONSchema(schema))
+}
+
+// StampJSONSchemaModeline writes doc to JSONSchemaPath(name) and sets the
+// yaml-language-server modeline of the YAML document at documentPath to the
+// schema file, relative to the document. Paths are relative to the working
+// directory, which is the project root when autospec runs.
+func StampJSONSchemaModeline(documentPath, name string, doc map[string]any) error {
+	schemaPath := JSONSchemaPath(name)
+	if err := WriteJSONSchemaFile(schemaPath, doc); err != nil {
+		return err
+	}
+	ref, err := relativeSchemaRef(documentPath, schemaPath)
+	if err != nil {
+		return err
+	}
+	return yaml.SetSchemaModeline(documentPath, ref)
+}
+
+// relativeSchemaRef returns schemaPath relative to the directory of
+// documentPath, in the slash-separated form modelines use.
+func relativeSchemaRef(documentPath, schemaPath string) (string, error) {
+	docDir, err := filepath.Abs(filepath.Dir(documentPath))
+	if err != nil {
+		return "", fmt.Errorf("resolving document directory: %w", err)
+	}
+	absSchema, err := filepath.Abs(schemaPath)
+	if err != nil {
+		return "", fmt.Errorf("resolving schema path: %w", err)
+	}
+	rel, err := filepath.Rel(docDir, absSchema)
+	if err != nil {
+		return "", fmt.Errorf("relating schema to document: %w", err)
+	}
+	return filepath.ToSlash(rel), nil
+}
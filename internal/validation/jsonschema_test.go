@@ -0,0 +1,152 @@
+// Package validation_test tests JSON Schema generation for artifacts and Go types.
+// Related: internal/validation/jsonschema.go
+// Tags: validation, json-schema, editor, yaml-language-server
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestArtifactJSONSchema(t *testing.T) {
+	doc := ArtifactJSONSchema(&Schema{
+		Type:        ArtifactTypeSpec,
+		Description: "Feature specification",
+		Fields: []SchemaField{
+			{Name: "feature", Type: FieldTypeObject, Required: true, Children: []SchemaField{
+				{Name: "status", Type: FieldTypeString, Enum: []string{"Draft", "Approved"}, Description: "Feature status"},
+			}},
+			{Name: "user_stories", Type: FieldTypeArray, Required: true, Children: []SchemaField{
+				{Name: "id", Type: FieldTypeString, Required: true, Pattern: `^US-\d+$`},
+				{Name: "points", Type: FieldTypeInt},
+			}},
+			{Name: "draft", Type: FieldTypeBool},
+			{Name: "notes", Type: FieldTypeArray},
+		},
+	})
+
+	want := map[string]any{
+		"$schema":     JSONSchemaDraft,
+		"title":       "autospec spec artifact",
+		"description": "Feature specification",
+		"type":        "object",
+		"properties": map[string]any{
+			"feature": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"status": map[string]any{"type": "string", "enum": []string{"Draft", "Approved"}, "description": "Feature status"},
+				},
+			},
+			"user_stories": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"id":     map[string]any{"type": "string", "pattern": `^US-\d+$`},
+						"points": map[string]any{"type": "integer"},
+					},
+					"required": []string{"id"},
+				},
+			},
+			"draft": map[string]any{"type": "boolean"},
+			"notes": map[string]any{"type": "array"},
+		},
+		"required": []string{"feature", "user_stories"},
+	}
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("ArtifactJSONSchema() =\n%v\nwant:\n%v", doc, want)
+	}
+}
+
+func TestArtifactJSONSchema_BuiltinSchemas(t *testing.T) {
+	for _, name := range ValidArtifactTypes() {
+		schema, err := GetSchema(ArtifactType(name))
+		if err != nil {
+			t.Fatalf("GetSchema(%s) error: %v", name, err)
+		}
+		if _, err := MarshalJSONSchema(ArtifactJSONSchema(schema)); err != nil {
+			t.Errorf("MarshalJSONSchema(%s) error: %v", name, err)
+		}
+	}
+}
+
+type jsonSchemaTestStatus string
+
+type jsonSchemaTestDoc struct {
+	Name     string                          `yaml:"name"`
+	Status   jsonSchemaTestStatus            `yaml:"status,omitempty"`
+	Tags     []string                        `yaml:"tags,omitempty"`
+	Limits   map[string]float64              `yaml:"limits,omitempty"`
+	Started  *time.Time                      `yaml:"started,omitempty"`
+	Children []jsonSchemaTestChild           `yaml:"children"`
+	ByName   map[string]*jsonSchemaTestChild `yaml:"by_name,omitempty"`
+	Ignored  string                          `yaml:"-"`
+	internal string
+}
+
+type jsonSchemaTestChild struct {
+	Count int  `yaml:"count"`
+	Done  bool `yaml:"done,omitempty"`
+}
+
+func TestStructJSONSchema(t *testing.T) {
+	enums := map[reflect.Type][]string{reflect.TypeOf(jsonSchemaTestStatus("")): {"open", "closed"}}
+	doc := StructJSONSchema(reflect.TypeOf(jsonSchemaTestDoc{}), enums)
+
+	child := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"count": map[string]any{"type": "integer"},
+			"done":  map[string]any{"type": "boolean"},
+		},
+		"required": []string{"count"},
+	}
+	want := map[string]any{
+		"$schema": JSONSchemaDraft,
+		"type":    "object",
+		"properties": map[string]any{
+			"name":     map[string]any{"type": "string"},
+			"status":   map[string]any{"type": "string", "enum": []string{"open", "closed"}},
+			"tags":     map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			"limits":   map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "number"}},
+			"started":  map[string]any{"type": "string", "format": "date-time"},
+			"children": map[string]any{"type": "array", "items": child},
+			"by_name":  map[string]any{"type": "object", "additionalProperties": child},
+		},
+		"required": []string{"name", "children"},
+	}
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("StructJSONSchema() =\n%v\nwant:\n%v", doc, want)
+	}
+}
+
+func TestWriteJSONSchemaFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "json", "plan.schema.json")
+	doc := map[string]any{"type": "object"}
+
+	if err := WriteJSONSchemaFile(path, doc); err != nil {
+		t.Fatalf("WriteJSONSchemaFile() error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "{\n  \"type\": \"object\"\n}\n" {
+		t.Errorf("file content = %q", data)
+	}
+
+	// An up-to-date file is not rewritten
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteJSONSchemaFile(path, doc); err != nil {
+		t.Fatalf("WriteJSONSchemaFile() error: %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || !info.ModTime().Equal(old) {
+		t.Errorf("up-to-date file was rewritten (mtime %v, err %v)", info.ModTime(), err)
+	}
+}
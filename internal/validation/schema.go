@@ -21,6 +21,8 @@ const (
 	ArtifactTypeChecklist ArtifactType = "checklist"
 	// ArtifactTypeConstitution represents constitution.yaml artifacts.
 	ArtifactTypeConstitution ArtifactType = "constitution"
+	// ArtifactTypeReview represents review.yaml artifacts.
+	ArtifactTypeReview ArtifactType = "review"
 )
 
 // FieldType represents the expected type of a schema field.
@@ -35,21 +37,22 @@ const (
 )
 
 // SchemaField defines a field in an artifact schema.
+// The yaml tags define the format of user-supplied schema files (see LoadSchemaFile).
 type SchemaField struct {
-	Name        string        // Field name in YAML
-	Type        FieldType     // Expected type
-	Required    bool          // Whether field must be present
-	Pattern     string        // Regex pattern for string validation (optional)
-	Enum        []string      // Valid values for enum fields (optional)
-	Description string        // Human-readable description
-	Children    []SchemaField // Nested fields for object/array types
+	Name        string        `yaml:"name"`        // Field name in YAML
+	Type        FieldType     `yaml:"type"`        // Expected type
+	Required    bool          `yaml:"required"`    // Whether field must be present
+	Pattern     string        `yaml:"pattern"`     // Regex pattern for string validation (optional)
+	Enum        []string      `yaml:"enum"`        // Valid values for enum fields (optional)
+	Description string        `yaml:"description"` // Human-readable description
+	Children    []SchemaField `yaml:"children"`    // Nested fields for object/array types
 }
 
 // Schema represents the complete schema for an artifact type.
 type Schema struct {
-	Type        ArtifactType
-	Description string
-	Fields      []SchemaField
+	Type        ArtifactType  `yaml:"type"`
+	Description string        `yaml:"description"`
+	Fields      []SchemaField `yaml:"fields"`
 }
 
 // SpecSchema defines the schema for spec.yaml artifacts.
@@ -369,6 +372,8 @@ var TaskFieldSchema = []SchemaField{
 	{Name: "file_path", Type: FieldTypeString, Required: false, Description: "Primary file path for this task"},
 	{Name: "dependencies", Type: FieldTypeArray, Required: false, Description: "List of task IDs this task depends on"},
 	{Name: "acceptance_criteria", Type: FieldTypeArray, Required: false, Description: "Acceptance criteria for the task"},
+	{Name: "requirement_id", Type: FieldTypeString, Required: false, Pattern: `^EARS-\d+$`, Description: "EARS requirement this test task verifies"},
+	{Name: "test_kind", Type: FieldTypeString, Required: false, Enum: []string{"contract", "property", "metamorphic"}, Description: "Test technique for an EARS requirement test"},
 }
 
 // AnalysisSchema defines the schema for analysis.yaml artifacts.
@@ -626,6 +631,69 @@ var ConstitutionSchema = Schema{
 	},
 }
 
+// ReviewSchema defines the schema for review.yaml artifacts.
+var ReviewSchema = Schema{
+	Type:        ArtifactTypeReview,
+	Description: "Adversarial review of the implementation diff against spec acceptance criteria",
+	Fields: []SchemaField{
+		{
+			Name:        "review",
+			Type:        FieldTypeObject,
+			Required:    true,
+			Description: "Review metadata including branch and diff base",
+			Children: []SchemaField{
+				{Name: "branch", Type: FieldTypeString, Required: true, Description: "Git branch name"},
+				{Name: "timestamp", Type: FieldTypeString, Required: true, Description: "Review timestamp (ISO 8601)"},
+				{Name: "base_ref", Type: FieldTypeString, Required: false, Description: "Git ref the implementation diff was taken against"},
+				{Name: "spec_path", Type: FieldTypeString, Required: false, Description: "Path to spec file"},
+			},
+		},
+		{
+			Name:        "findings",
+			Type:        FieldTypeArray,
+			Required:    true,
+			Description: "List of review findings",
+			Children: []SchemaField{
+				{Name: "id", Type: FieldTypeString, Required: true, Description: "Finding ID (e.g., REV-001)"},
+				{Name: "severity", Type: FieldTypeString, Required: true, Enum: reviewSeverities, Description: "Finding severity"},
+				{Name: "category", Type: FieldTypeString, Required: true, Enum: reviewCategories, Description: "Finding category"},
+				{Name: "file", Type: FieldTypeString, Required: true, Description: "File the finding refers to (relative to repository root)"},
+				{Name: "line", Type: FieldTypeInt, Required: false, Description: "1-based line number in file"},
+				{Name: "summary", Type: FieldTypeString, Required: true, Description: "Brief summary of the finding"},
+				{Name: "details", Type: FieldTypeString, Required: false, Description: "Detailed explanation"},
+				{Name: "acceptance_criterion", Type: FieldTypeString, Required: false, Description: "Spec acceptance criterion or requirement the finding violates"},
+				{Name: "recommendation", Type: FieldTypeString, Required: false, Description: "Suggested fix"},
+				{Name: "status", Type: FieldTypeString, Required: false, Enum: reviewStatuses, Description: "Finding status (default: open)"},
+			},
+		},
+		{
+			Name:        "summary",
+			Type:        FieldTypeObject,
+			Required:    true,
+			Description: "Review summary",
+			Children: []SchemaField{
+				{Name: "verdict", Type: FieldTypeString, Required: true, Enum: reviewVerdicts, Description: "Overall review verdict"},
+				{Name: "critical_findings", Type: FieldTypeInt, Required: false, Description: "Number of open critical findings"},
+				{Name: "criteria_checked", Type: FieldTypeInt, Required: false, Description: "Number of acceptance criteria checked"},
+				{Name: "criteria_unmet", Type: FieldTypeInt, Required: false, Description: "Number of acceptance criteria not met"},
+			},
+		},
+		{
+			Name:        "_meta",
+			Type:        FieldTypeObject,
+			Required:    false,
+			Description: "Artifact metadata",
+			Children: []SchemaField{
+				{Name: "version", Type: FieldTypeString, Required: false, Description: "Schema version"},
+				{Name: "generator", Type: FieldTypeString, Required: false, Description: "Generator tool name"},
+				{Name: "generator_version", Type: FieldTypeString, Required: false, Description: "Generator version"},
+				{Name: "created", Type: FieldTypeString, Required: false, Description: "Creation timestamp"},
+				{Name: "artifact_type", Type: FieldTypeString, Required: false, Enum: []string{"review"}, Description: "Artifact type"},
+			},
+		},
+	},
+}
+
 // GetSchema returns the schema for the given artifact type.
 func GetSchema(artifactType ArtifactType) (*Schema, error) {
 	switch artifactType {
@@ -641,6 +709,8 @@ func GetSchema(artifactType ArtifactType) (*Schema, error) {
 		return &ChecklistSchema, nil
 	case ArtifactTypeConstitution:
 		return &ConstitutionSchema, nil
+	case ArtifactTypeReview:
+		return &ReviewSchema, nil
 	default:
 		return nil, fmt.Errorf("unknown artifact type: %s", artifactType)
 	}
@@ -661,14 +731,16 @@ func ParseArtifactType(s string) (ArtifactType, error) {
 		return ArtifactTypeChecklist, nil
 	case "constitution":
 		return ArtifactTypeConstitution, nil
+	case "review":
+		return ArtifactTypeReview, nil
 	default:
-		return "", fmt.Errorf("invalid artifact type: %s (valid types: spec, plan, tasks, analysis, checklist, constitution)", s)
+		return "", fmt.Errorf("invalid artifact type: %s (valid types: spec, plan, tasks, analysis, checklist, constitution, review)", s)
 	}
 }
 
 // ValidArtifactTypes returns a list of valid artifact type strings.
 func ValidArtifactTypes() []string {
-	return []string{"spec", "plan", "tasks", "analysis", "checklist", "constitution"}
+	return []string{"spec", "plan", "tasks", "analysis", "checklist", "constitution", "review"}
 }
 
 // artifactFilenames maps canonical filenames to artifact types.
@@ -683,6 +755,8 @@ var artifactFilenames = map[string]ArtifactType{
 	"analysis.yml":      ArtifactTypeAnalysis,
 	"constitution.yaml": ArtifactTypeConstitution,
 	"constitution.yml":  ArtifactTypeConstitution,
+	"review.yaml":       ArtifactTypeReview,
+	"review.yml":        ArtifactTypeReview,
 }
 
 // InferArtifactTypeFromFilename infers the artifact type from a filename.
@@ -700,5 +774,5 @@ func InferArtifactTypeFromFilename(filename string) (ArtifactType, error) {
 
 // ValidArtifactFilenames returns a list of recognized artifact filenames.
 func ValidArtifactFilenames() []string {
-	return []string{"spec.yaml", "plan.yaml", "tasks.yaml", "analysis.yaml", "constitution.yaml"}
+	return []string{"spec.yaml", "plan.yaml", "tasks.yaml", "analysis.yaml", "constitution.yaml", "review.yaml"}
 }
@@ -0,0 +1,204 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SchemaExtensionDir is the project directory holding artifact schema
+// extensions, one <type>.yaml per artifact type (e.g. .autospec/schemas/spec.yaml).
+const SchemaExtensionDir = ".autospec/schemas"
+
+// SchemaExtensionPath returns the project extension file for an artifact type.
+func SchemaExtensionPath(artifactType ArtifactType) string {
+	return filepath.Join(SchemaExtensionDir, string(artifactType)+".yaml")
+}
+
+// LoadSchemaExtension loads the project extension of a built-in artifact
+// schema from SchemaExtensionDir. Returns nil and no error when the project
+// has no extension for artifactType.
+func LoadSchemaExtension(artifactType ArtifactType) (*Schema, error) {
+	return loadSchemaExtension(SchemaExtensionDir, artifactType)
+}
+
+// loadSchemaExtension loads <dir>/<type>.yaml and resolves it against the
+// built-in schema.
+//
+// Extensions use the SchemaField shape. A field with a new name adds a field
+// (type is required). A field named like a built-in one tightens it: it can
+// make it required, restrict its enum to a subset, add a pattern, and extend
+// its children; its type may be omitted and must match when given. Built-in
+// rules always apply as well, so an extension cannot loosen them.
+func loadSchemaExtension(dir string, artifactType ArtifactType) (*Schema, error) {
+	path := filepath.Join(dir, string(artifactType)+".yaml")
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading schema extension: %w", err)
+	}
+
+	base, err := GetSchema(artifactType)
+	if err != nil {
+		return nil, err
+	}
+	ext, err := decodeSchema(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing schema extension %s: %w", path, err)
+	}
+	if ext.Type != "" && ext.Type != artifactType {
+		return nil, fmt.Errorf("schema extension %s: type %q does not match %q", path, ext.Type, artifactType)
+	}
+
+	fields, err := resolveExtensionFields(base.Fields, ext.Fields, "")
+	if err == nil {
+		err = checkSchemaFields(fields, "")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema extension %s: %w", path, err)
+	}
+	return &Schema{Type: artifactType, Description: ext.Description, Fields: fields}, nil
+}
+
+// resolveExtensionFields fills in the types of extension fields that tighten
+// built-in fields and rejects changes the built-in validators would contradict.
+func resolveExtensionFields(base, ext []SchemaField, prefix string) ([]SchemaField, error) {
+	resolved := make([]SchemaField, 0, len(ext))
+	for _, field := range ext {
+		path := joinFieldPath(prefix, field.Name)
+		builtin := findSchemaField(base, field.Name)
+		if builtin == nil {
+			if field.Type == "" {
+				return nil, fmt.Errorf("%s: type is required for new fields", path)
+			}
+			resolved = append(resolved, field)
+			continue
+		}
+
+		if field.Type == "" {
+			field.Type = builtin.Type
+		} else if field.Type != builtin.Type {
+			return nil, fmt.Errorf("%s: type %s conflicts with built-in type %s", path, field.Type, builtin.Type)
+		}
+		if len(field.Enum) > 0 && len(builtin.Enum) > 0 {
+			for _, value := range field.Enum {
+				if findString(builtin.Enum, value) < 0 {
+					return nil, fmt.Errorf("%s: enum value %q is not allowed by the built-in schema (%s)",
+						path, value, strings.Join(builtin.Enum, ", "))
+				}
+			}
+		}
+		children, err := resolveExtensionFields(builtin.Children, field.Children, path)
+		if err != nil {
+			return nil, err
+		}
+		field.Children = children
+		resolved = append(resolved, field)
+	}
+	return resolved, nil
+}
+
+// MergeSchema returns base with the resolved extension applied, for display
+// and prompts. Validation runs the built-in rules and the extension separately.
+func MergeSchema(base, ext *Schema) *Schema {
+	merged := *base
+	merged.Fields = mergeSchemaFields(base.Fields, ext.Fields)
+	return &merged
+}
+
+// mergeSchemaFields merges extension fields into a copy of base fields.
+func mergeSchemaFields(base, ext []SchemaField) []SchemaField {
+	merged := append([]SchemaField{}, base...)
+	for _, field := range ext {
+		i := -1
+		for j := range merged {
+			if merged[j].Name == field.Name {
+				i = j
+				break
+			}
+		}
+		if i < 0 {
+			merged = append(merged, field)
+			continue
+		}
+
+		target := &merged[i]
+		target.Required = target.Required || field.Required
+		if len(field.Enum) > 0 {
+			target.Enum = field.Enum
+		}
+		if field.Pattern != "" {
+			target.Pattern = field.Pattern // Built-in pattern is still enforced by the validator
+		}
+		if field.Description != "" {
+			target.Description = field.Description
+		}
+		target.Children = mergeSchemaFields(target.Children, field.Children)
+	}
+	return merged
+}
+
+// GetEffectiveSchema returns the schema for an artifact type with the project
+// extension (if any) merged in.
+func GetEffectiveSchema(artifactType ArtifactType) (*Schema, error) {
+	base, err := GetSchema(artifactType)
+	if err != nil {
+		return nil, err
+	}
+	ext, err := LoadSchemaExtension(artifactType)
+	if err != nil || ext == nil {
+		return base, err
+	}
+	return MergeSchema(base, ext), nil
+}
+
+// extendedValidator runs a built-in validator and then the project schema
+// extension.
+type extendedValidator struct {
+	ArtifactValidator
+	extension *Schema
+}
+
+// Validate validates the artifact against the built-in rules and the
+// extension. Extension errors at a path the built-in rules already reported
+// are dropped to avoid duplicates.
+func (v *extendedValidator) Validate(path string) *ValidationResult {
+	result := v.ArtifactValidator.Validate(path)
+
+	reported := make(map[string]bool, len(result.Errors))
+	for _, err := range result.Errors {
+		reported[err.Path] = true
+	}
+	for _, err := range ValidateWithSchema(path, v.extension).Errors {
+		if err.Path == "" || reported[err.Path] {
+			continue // Parse and root errors are reported by the built-in validator
+		}
+		result.AddError(err)
+	}
+	return result
+}
+
+// findSchemaField returns the field named name, or nil.
+func findSchemaField(fields []SchemaField, name string) *SchemaField {
+	for i := range fields {
+		if fields[i].Name == name {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+// findString returns the index of s in values, or -1.
+func findString(values []string, s string) int {
+	for i, v := range values {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,195 @@
+// Package validation_test tests project schema extensions for built-in artifacts.
+// Related: internal/validation/schema_extension.go
+// Tags: validation, schema, extension, spec, yaml, pattern, enum
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const specExtensionYAML = `type: spec
+fields:
+  - name: feature
+    children:
+      - name: status
+        enum: [Draft, Approved]
+      - name: ticket
+        type: string
+        required: true
+        pattern: "^PROJ-\\d+$"
+  - name: compliance
+    type: object
+    required: true
+    children:
+      - name: data_classification
+        type: string
+        required: true
+        enum: [public, internal, restricted]
+`
+
+// writeSchemaExtension writes content to <dir>/<type>.yaml.
+func writeSchemaExtension(t *testing.T, dir string, artifactType ArtifactType, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, string(artifactType)+".yaml"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadSchemaExtension(t *testing.T) {
+	tests := map[string]struct {
+		content     string
+		errContains string
+	}{
+		"valid extension": {content: specExtensionYAML},
+		"type mismatch": {
+			content:     "type: plan\nfields: []\n",
+			errContains: `type "plan" does not match "spec"`,
+		},
+		"new field without type": {
+			content:     "fields:\n  - name: ticket\n    required: true\n",
+			errContains: "ticket: type is required for new fields",
+		},
+		"conflicting type": {
+			content:     "fields:\n  - name: feature\n    type: string\n",
+			errContains: "feature: type string conflicts with built-in type object",
+		},
+		"enum outside built-in enum": {
+			content:     "fields:\n  - name: feature\n    children:\n      - name: status\n        enum: [Draft, Shipped]\n",
+			errContains: `feature.status: enum value "Shipped" is not allowed`,
+		},
+		"invalid pattern": {
+			content:     "fields:\n  - name: ticket\n    type: string\n    pattern: \"([\"\n",
+			errContains: "ticket: invalid pattern",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeSchemaExtension(t, dir, ArtifactTypeSpec, tt.content)
+
+			ext, err := loadSchemaExtension(dir, ArtifactTypeSpec)
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Fatalf("loadSchemaExtension() error = %v, want containing %q", err, tt.errContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadSchemaExtension() unexpected error: %v", err)
+			}
+			status := findSchemaField(findSchemaField(ext.Fields, "feature").Children, "status")
+			if status == nil || status.Type != FieldTypeString {
+				t.Errorf("feature.status = %+v, want type inherited from built-in schema", status)
+			}
+		})
+	}
+
+	t.Run("no extension", func(t *testing.T) {
+		ext, err := loadSchemaExtension(t.TempDir(), ArtifactTypeSpec)
+		if err != nil || ext != nil {
+			t.Errorf("loadSchemaExtension() = %v, %v, want nil, nil", ext, err)
+		}
+	})
+}
+
+func TestMergeSchema(t *testing.T) {
+	base := &Schema{Type: ArtifactTypeSpec, Fields: []SchemaField{
+		{Name: "feature", Type: FieldTypeObject, Required: true, Children: []SchemaField{
+			{Name: "status", Type: FieldTypeString, Enum: []string{"Draft", "Review", "Approved"}},
+		}},
+	}}
+	ext := &Schema{Fields: []SchemaField{
+		{Name: "feature", Type: FieldTypeObject, Children: []SchemaField{
+			{Name: "status", Type: FieldTypeString, Required: true, Enum: []string{"Draft", "Approved"}},
+			{Name: "ticket", Type: FieldTypeString, Pattern: `^PROJ-\d+$`},
+		}},
+		{Name: "compliance", Type: FieldTypeObject, Required: true},
+	}}
+
+	got := MergeSchema(base, ext)
+
+	want := "feature: object (required)\n" +
+		"  status: enum[Draft, Approved] (required)\n" +
+		"  ticket: string (pattern ^PROJ-\\d+$)\n" +
+		"compliance: object (required)\n"
+	if outline := FormatSchemaOutline(got); outline != want {
+		t.Errorf("MergeSchema() =\n%s\nwant:\n%s", outline, want)
+	}
+	if len(base.Fields[0].Children) != 1 || base.Fields[0].Children[0].Required {
+		t.Errorf("MergeSchema() modified base schema: %+v", base.Fields[0])
+	}
+}
+
+func TestNewArtifactValidator_SchemaExtension(t *testing.T) {
+	validSpec, err := os.ReadFile(filepath.Join("testdata", "spec", "valid.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	withTicket := strings.Replace(string(validSpec), "  status: \"Draft\"\n", "  status: \"Draft\"\n  ticket: \"PROJ-42\"\n", 1)
+
+	tests := map[string]struct {
+		content    string
+		wantErrors []string
+	}{
+		"extension satisfied": {
+			content: withTicket + "\ncompliance:\n  data_classification: internal\n",
+		},
+		"missing extension fields": {
+			content: string(validSpec),
+			wantErrors: []string{
+				"feature.ticket: missing required field: feature.ticket",
+				"compliance: missing required field: compliance",
+			},
+		},
+		"restricted enum and pattern": {
+			content: strings.Replace(strings.Replace(withTicket, "PROJ-42", "TICKET-42", 1), "\"Draft\"", "\"Review\"", 1) +
+				"\ncompliance:\n  data_classification: secret\n",
+			wantErrors: []string{
+				"feature.status: invalid value",
+				"feature.ticket: value for field 'feature.ticket' does not match the required format",
+				"compliance.data_classification: invalid value",
+			},
+		},
+		"built-in errors are not duplicated": {
+			content:    strings.Replace(withTicket, "feature:", "feature_info:", 1) + "\ncompliance:\n  data_classification: public\n",
+			wantErrors: []string{"feature: missing required field"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			schemaDir := t.TempDir()
+			writeSchemaExtension(t, schemaDir, ArtifactTypeSpec, specExtensionYAML)
+			path := filepath.Join(t.TempDir(), "spec.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			validator, err := newArtifactValidator(ArtifactTypeSpec, schemaDir)
+			if err != nil {
+				t.Fatalf("newArtifactValidator() unexpected error: %v", err)
+			}
+			result := validator.Validate(path)
+			if len(result.Errors) != len(tt.wantErrors) {
+				t.Fatalf("Validate() errors = %v, want %d errors", result.Errors, len(tt.wantErrors))
+			}
+			for i, want := range tt.wantErrors {
+				if !strings.Contains(result.Errors[i].Error(), want) {
+					t.Errorf("error[%d] = %q, want containing %q", i, result.Errors[i].Error(), want)
+				}
+			}
+		})
+	}
+
+	t.Run("invalid extension", func(t *testing.T) {
+		schemaDir := t.TempDir()
+		writeSchemaExtension(t, schemaDir, ArtifactTypeSpec, "fields:\n  - name: ticket\n")
+		if _, err := newArtifactValidator(ArtifactTypeSpec, schemaDir); err == nil {
+			t.Error("newArtifactValidator() expected error for invalid extension")
+		}
+	})
+}
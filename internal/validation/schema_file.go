@@ -0,0 +1,272 @@
+package validation
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadSchemaFile reads a user-supplied artifact schema from a YAML file.
+// The file uses the Schema and SchemaField shape:
+//
+//	type: threat-model
+//	description: Threats identified for the feature
+//	fields:
+//	  - name: threats
+//	    type: array
+//	    required: true
+//	    children:
+//	      - name: id
+//	        type: string
+//	        required: true
+//	        pattern: "^T-\\d+$"
+//	      - name: severity
+//	        type: string
+//	        enum: [low, medium, high]
+//
+// Field types and patterns are checked when loading, so a broken schema is
+// reported before any artifact is validated against it.
+func LoadSchemaFile(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading schema: %w", err)
+	}
+	schema, err := decodeSchema(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing schema %s: %w", path, err)
+	}
+	if err := checkSchemaFields(schema.Fields, ""); err != nil {
+		return nil, fmt.Errorf("invalid schema %s: %w", path, err)
+	}
+	return schema, nil
+}
+
+// decodeSchema decodes a schema file, rejecting unknown keys.
+func decodeSchema(data []byte) (*Schema, error) {
+	var schema Schema
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&schema); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// checkSchemaFields verifies names, types, patterns, and nesting of fields.
+func checkSchemaFields(fields []SchemaField, prefix string) error {
+	for _, field := range fields {
+		path := joinFieldPath(prefix, field.Name)
+		if field.Name == "" {
+			return fmt.Errorf("%sfield without a name", pathPrefix(prefix))
+		}
+		switch field.Type {
+		case FieldTypeString, FieldTypeInt, FieldTypeBool, FieldTypeArray, FieldTypeObject:
+		default:
+			return fmt.Errorf("%s: unknown type %q (valid: string, int, bool, array, object)", path, field.Type)
+		}
+		if field.Pattern != "" {
+			if _, err := regexp.Compile(field.Pattern); err != nil {
+				return fmt.Errorf("%s: invalid pattern: %w", path, err)
+			}
+		}
+		if (field.Pattern != "" || len(field.Enum) > 0) && field.Type != FieldTypeString {
+			return fmt.Errorf("%s: pattern and enum are only valid for string fields", path)
+		}
+		if len(field.Children) > 0 && field.Type != FieldTypeObject && field.Type != FieldTypeArray {
+			return fmt.Errorf("%s: children are only valid for object and array fields", path)
+		}
+		if err := checkSchemaFields(field.Children, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateWithSchema validates the YAML file at path against schema: required
+// fields, types, enums, and patterns, recursing into objects and array items.
+// Fields not declared in the schema are allowed.
+func ValidateWithSchema(path string, schema *Schema) *ValidationResult {
+	result := &ValidationResult{Valid: true}
+
+	root, err := parseYAMLFile(path)
+	if err != nil {
+		result.AddError(&ValidationError{
+			Message: fmt.Sprintf("failed to parse YAML: %v", err),
+			Hint:    "Check YAML syntax (indentation, colons, quotes)",
+		})
+		return result
+	}
+
+	mapping := getRootMapping(root)
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		result.AddError(&ValidationError{
+			Message:  "root must be a mapping",
+			Expected: "object",
+			Hint:     "Start the file with top-level keys such as '" + firstFieldName(schema) + ":'",
+		})
+		return result
+	}
+
+	validateSchemaMapping(mapping, schema.Fields, "", result)
+	return result
+}
+
+// validateSchemaMapping validates the fields of a mapping node.
+func validateSchemaMapping(mapping *yaml.Node, fields []SchemaField, prefix string, result *ValidationResult) {
+	for _, field := range fields {
+		path := joinFieldPath(prefix, field.Name)
+		node := findNode(mapping, field.Name)
+		if node == nil {
+			if field.Required {
+				result.AddError(&ValidationError{
+					Path:    path,
+					Line:    getNodeLine(mapping),
+					Message: fmt.Sprintf("missing required field: %s", path),
+					Hint:    fmt.Sprintf("Add the '%s' field to your YAML file", field.Name),
+				})
+			}
+			continue
+		}
+		validateSchemaNode(node, field, path, result)
+	}
+}
+
+// validateSchemaNode validates a single value against its field definition.
+func validateSchemaNode(node *yaml.Node, field SchemaField, path string, result *ValidationResult) {
+	switch field.Type {
+	case FieldTypeString:
+		if !validateFieldType(node, path, yaml.ScalarNode, "string", result) {
+			return
+		}
+		if len(field.Enum) > 0 && !validateEnumValue(node, path, field.Enum, result) {
+			return
+		}
+		validatePatternValue(node, path, field.Pattern, result)
+	case FieldTypeInt:
+		validateScalarTag(node, path, "!!int", "int", result)
+	case FieldTypeBool:
+		validateScalarTag(node, path, "!!bool", "bool", result)
+	case FieldTypeObject:
+		if validateFieldType(node, path, yaml.MappingNode, "object", result) {
+			validateSchemaMapping(node, field.Children, path, result)
+		}
+	case FieldTypeArray:
+		if !validateFieldType(node, path, yaml.SequenceNode, "array", result) || len(field.Children) == 0 {
+			return
+		}
+		for i, item := range node.Content {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			if validateFieldType(item, itemPath, yaml.MappingNode, "object", result) {
+				validateSchemaMapping(item, field.Children, itemPath, result)
+			}
+		}
+	}
+}
+
+// validateScalarTag checks that node is a scalar with the given resolved tag.
+func validateScalarTag(node *yaml.Node, path, tag, typeName string, result *ValidationResult) {
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == tag {
+		return
+	}
+	actual := nodeKindToString(node.Kind)
+	if node.Kind == yaml.ScalarNode {
+		actual = fmt.Sprintf("'%s'", node.Value)
+	}
+	result.AddError(&ValidationError{
+		Path:     path,
+		Line:     getNodeLine(node),
+		Column:   getNodeColumn(node),
+		Message:  fmt.Sprintf("wrong type for field '%s'", path),
+		Expected: typeName,
+		Actual:   actual,
+		Hint:     fmt.Sprintf("Change '%s' to be a %s", path, typeName),
+	})
+}
+
+// validatePatternValue checks that a string value matches pattern (if set).
+func validatePatternValue(node *yaml.Node, path, pattern string, result *ValidationResult) {
+	if pattern == "" {
+		return
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil || re.MatchString(node.Value) {
+		return // Patterns are checked by LoadSchemaFile
+	}
+	result.AddError(&ValidationError{
+		Path:     path,
+		Line:     getNodeLine(node),
+		Column:   getNodeColumn(node),
+		Message:  fmt.Sprintf("value for field '%s' does not match the required format", path),
+		Expected: fmt.Sprintf("pattern %s", pattern),
+		Actual:   fmt.Sprintf("'%s'", node.Value),
+	})
+}
+
+// FormatSchemaOutline renders schema as an indented outline of its fields,
+// suitable for telling an agent the structure an artifact must have.
+func FormatSchemaOutline(schema *Schema) string {
+	var sb strings.Builder
+	for _, field := range schema.Fields {
+		writeSchemaOutlineField(&sb, field, "")
+	}
+	return sb.String()
+}
+
+// writeSchemaOutlineField writes one field and its children to sb.
+func writeSchemaOutlineField(sb *strings.Builder, field SchemaField, indent string) {
+	typeStr := string(field.Type)
+	if len(field.Enum) > 0 {
+		typeStr = fmt.Sprintf("enum[%s]", strings.Join(field.Enum, ", "))
+	}
+	if field.Type == FieldTypeArray && len(field.Children) > 0 {
+		typeStr = "array of objects"
+	}
+
+	var notes []string
+	if field.Required {
+		notes = append(notes, "required")
+	}
+	if field.Pattern != "" {
+		notes = append(notes, "pattern "+field.Pattern)
+	}
+	line := fmt.Sprintf("%s%s: %s", indent, field.Name, typeStr)
+	if len(notes) > 0 {
+		line += " (" + strings.Join(notes, ", ") + ")"
+	}
+	if field.Description != "" {
+		line += " # " + field.Description
+	}
+	sb.WriteString(line + "\n")
+
+	for _, child := range field.Children {
+		writeSchemaOutlineField(sb, child, indent+"  ")
+	}
+}
+
+// joinFieldPath joins a parent path and a field name with a dot.
+func joinFieldPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// pathPrefix formats prefix for an error message ("" or "prefix: ").
+func pathPrefix(prefix string) string {
+	if prefix == "" {
+		return ""
+	}
+	return prefix + ": "
+}
+
+// firstFieldName returns the name of the first schema field, for hints.
+func firstFieldName(schema *Schema) string {
+	if len(schema.Fields) == 0 {
+		return "key"
+	}
+	return schema.Fields[0].Name
+}
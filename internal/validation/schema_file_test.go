@@ -0,0 +1,174 @@
+// Package validation_test tests user-supplied schema files and schema-driven validation.
+// Related: internal/validation/schema_file.go
+// Tags: validation, schema, custom-stage, yaml, pattern, enum
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const threatSchemaYAML = `type: threat-model
+description: Threats identified for the feature
+fields:
+  - name: threats
+    type: array
+    required: true
+    children:
+      - name: id
+        type: string
+        required: true
+        pattern: "^T-\\d+$"
+      - name: severity
+        type: string
+        enum: [low, medium, high]
+      - name: mitigated
+        type: bool
+  - name: meta
+    type: object
+    children:
+      - name: reviewers
+        type: int
+        required: true
+`
+
+func TestLoadSchemaFile(t *testing.T) {
+	tests := map[string]struct {
+		content     string
+		errContains string
+	}{
+		"valid schema": {content: threatSchemaYAML},
+		"unknown type": {
+			content:     "fields:\n  - name: a\n    type: number\n",
+			errContains: `a: unknown type "number"`,
+		},
+		"invalid pattern": {
+			content:     "fields:\n  - name: a\n    type: string\n    pattern: \"([\"\n",
+			errContains: "a: invalid pattern",
+		},
+		"enum on non-string": {
+			content:     "fields:\n  - name: a\n    type: int\n    enum: [\"1\"]\n",
+			errContains: "only valid for string fields",
+		},
+		"children on scalar": {
+			content:     "fields:\n  - name: a\n    type: string\n    children:\n      - name: b\n        type: string\n",
+			errContains: "children are only valid",
+		},
+		"nested field without name": {
+			content:     "fields:\n  - name: a\n    type: object\n    children:\n      - type: string\n",
+			errContains: "a: field without a name",
+		},
+		"unknown key": {
+			content:     "fields:\n  - name: a\n    type: string\n    optional: true\n",
+			errContains: "optional",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "schema.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			schema, err := LoadSchemaFile(path)
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Fatalf("LoadSchemaFile() error = %v, want containing %q", err, tt.errContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadSchemaFile() unexpected error: %v", err)
+			}
+			if schema.Type != "threat-model" || len(schema.Fields) != 2 {
+				t.Errorf("LoadSchemaFile() = %+v, want threat-model schema with 2 fields", schema)
+			}
+		})
+	}
+}
+
+func TestValidateWithSchema(t *testing.T) {
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "schema.yaml")
+	if err := os.WriteFile(schemaPath, []byte(threatSchemaYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := LoadSchemaFile(schemaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		content    string
+		wantErrors []string
+	}{
+		"valid artifact": {
+			content: "threats:\n  - id: T-1\n    severity: high\n    mitigated: true\n    notes: extra fields are allowed\nmeta:\n  reviewers: 2\n",
+		},
+		"missing required field": {
+			content:    "meta:\n  reviewers: 1\n",
+			wantErrors: []string{"threats: missing required field: threats"},
+		},
+		"pattern, enum and type errors": {
+			content: "threats:\n  - id: THREAT-1\n    severity: critical\n    mitigated: maybe\n",
+			wantErrors: []string{
+				"threats[0].id: value for field 'threats[0].id' does not match the required format",
+				"threats[0].severity: invalid value",
+				"threats[0].mitigated: wrong type",
+			},
+		},
+		"nested required field": {
+			content:    "threats: []\nmeta:\n  owner: sec\n",
+			wantErrors: []string{"meta.reviewers: missing required field: meta.reviewers"},
+		},
+		"array item not an object": {
+			content:    "threats:\n  - T-1\n",
+			wantErrors: []string{"threats[0]: wrong type"},
+		},
+		"root not a mapping": {
+			content:    "- T-1\n",
+			wantErrors: []string{"root must be a mapping"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "threat-model.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			result := ValidateWithSchema(path, schema)
+			if len(result.Errors) != len(tt.wantErrors) {
+				t.Fatalf("ValidateWithSchema() errors = %v, want %d errors", result.Errors, len(tt.wantErrors))
+			}
+			for i, want := range tt.wantErrors {
+				if !strings.Contains(result.Errors[i].Error(), want) {
+					t.Errorf("error[%d] = %q, want containing %q", i, result.Errors[i].Error(), want)
+				}
+			}
+			if result.Valid != (len(tt.wantErrors) == 0) {
+				t.Errorf("Valid = %v, want %v", result.Valid, len(tt.wantErrors) == 0)
+			}
+		})
+	}
+}
+
+func TestFormatSchemaOutline(t *testing.T) {
+	schema := &Schema{Fields: []SchemaField{
+		{Name: "threats", Type: FieldTypeArray, Required: true, Description: "Identified threats", Children: []SchemaField{
+			{Name: "id", Type: FieldTypeString, Required: true, Pattern: `^T-\d+$`},
+			{Name: "severity", Type: FieldTypeString, Enum: []string{"low", "high"}},
+		}},
+	}}
+
+	want := "threats: array of objects (required) # Identified threats\n" +
+		"  id: string (required, pattern ^T-\\d+$)\n" +
+		"  severity: enum[low, high]\n"
+	if got := FormatSchemaOutline(schema); got != want {
+		t.Errorf("FormatSchemaOutline() =\n%s\nwant:\n%s", got, want)
+	}
+}
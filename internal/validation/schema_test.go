@@ -107,6 +107,7 @@ func TestParseArtifactType(t *testing.T) {
 		"spec":                {input: "spec", expected: ArtifactTypeSpec, wantErr: false},
 		"plan":                {input: "plan", expected: ArtifactTypePlan, wantErr: false},
 		"tasks":               {input: "tasks", expected: ArtifactTypeTasks, wantErr: false},
+		"review":              {input: "review", expected: ArtifactTypeReview, wantErr: false},
 		"unknown":             {input: "unknown", expected: "", wantErr: true},
 		"SPEC case-sensitive": {input: "SPEC", expected: "", wantErr: true},
 		"empty string":        {input: "", expected: "", wantErr: true},
@@ -128,8 +129,8 @@ func TestParseArtifactType(t *testing.T) {
 
 func TestValidArtifactTypes(t *testing.T) {
 	types := ValidArtifactTypes()
-	if len(types) != 6 {
-		t.Errorf("ValidArtifactTypes() returned %d types, want 6", len(types))
+	if len(types) != 7 {
+		t.Errorf("ValidArtifactTypes() returned %d types, want 7", len(types))
 	}
 
 	expected := map[string]bool{
@@ -139,6 +140,7 @@ func TestValidArtifactTypes(t *testing.T) {
 		"analysis":     true,
 		"checklist":    true,
 		"constitution": true,
+		"review":       true,
 	}
 	for _, typ := range types {
 		if !expected[typ] {
@@ -302,6 +304,7 @@ func TestInferArtifactTypeFromFilename(t *testing.T) {
 		// Full paths with .yml
 		"path with spec.yml": {filename: "/absolute/path/spec.yml", want: ArtifactTypeSpec, wantErr: false},
 		"path with plan.yml": {filename: "relative/plan.yml", want: ArtifactTypePlan, wantErr: false},
+		"review.yaml":        {filename: "specs/016-feature/review.yaml", want: ArtifactTypeReview, wantErr: false},
 
 		// Unrecognized filenames
 		"config.yaml":              {filename: "config.yaml", want: "", wantErr: true},
@@ -328,8 +331,8 @@ func TestInferArtifactTypeFromFilename(t *testing.T) {
 
 func TestValidArtifactFilenames(t *testing.T) {
 	filenames := ValidArtifactFilenames()
-	if len(filenames) != 5 {
-		t.Errorf("ValidArtifactFilenames() returned %d filenames, want 5", len(filenames))
+	if len(filenames) != 6 {
+		t.Errorf("ValidArtifactFilenames() returned %d filenames, want 6", len(filenames))
 	}
 
 	expected := map[string]bool{
@@ -338,6 +341,7 @@ func TestValidArtifactFilenames(t *testing.T) {
 		"tasks.yaml":        true,
 		"analysis.yaml":     true,
 		"constitution.yaml": true,
+		"review.yaml":       true,
 	}
 	for _, filename := range filenames {
 		if !expected[filename] {
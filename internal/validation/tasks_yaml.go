@@ -63,6 +63,8 @@ type TaskItem struct {
 	AcceptanceCriteria []string `yaml:"acceptance_criteria"`
 	BlockedReason      string   `yaml:"blocked_reason,omitempty"`
 	Notes              string   `yaml:"notes,omitempty"`
+	RequirementID      string   `yaml:"requirement_id,omitempty"`
+	TestKind           string   `yaml:"test_kind,omitempty"`
 }
 
 // TaskStats contains computed statistics about task completion
@@ -366,6 +368,69 @@ func GetTaskByID(tasks []TaskItem, id string) (*TaskItem, error) {
 	return nil, fmt.Errorf("task %s not found", id)
 }
 
+// UpdateTaskStatuses sets the status of each task in statuses (task ID -> status)
+// in a tasks.yaml file, preserving the rest of the document.
+// Returns the previous status of every task whose status changed; the file is
+// only rewritten when at least one status changed. Unknown task IDs are ignored.
+func UpdateTaskStatuses(tasksPath string, statuses map[string]string) (map[string]string, error) {
+	data, err := os.ReadFile(tasksPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tasks file: %w", err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse tasks YAML: %w", err)
+	}
+
+	changed := make(map[string]string)
+	setTaskStatuses(&root, statuses, changed)
+	if len(changed) == 0 {
+		return changed, nil
+	}
+
+	output, err := yaml.Marshal(&root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize tasks YAML: %w", err)
+	}
+	if err := os.WriteFile(tasksPath, output, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write tasks file: %w", err)
+	}
+	return changed, nil
+}
+
+// setTaskStatuses walks the YAML node tree and updates the status of every
+// task mapping whose id is in statuses, recording previous values in changed.
+func setTaskStatuses(node *yaml.Node, statuses, changed map[string]string) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			setTaskStatuses(child, statuses, changed)
+		}
+	case yaml.MappingNode:
+		var id string
+		var statusNode *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			switch node.Content[i].Value {
+			case "id":
+				id = node.Content[i+1].Value
+			case "status":
+				statusNode = node.Content[i+1]
+			}
+		}
+		if status, ok := statuses[id]; ok && statusNode != nil {
+			if statusNode.Value != status {
+				changed[id] = statusNode.Value
+				statusNode.Value = status
+			}
+			return
+		}
+		for i := 1; i < len(node.Content); i += 2 {
+			setTaskStatuses(node.Content[i], statuses, changed)
+		}
+	}
+}
+
 // GetTasksInDependencyOrder returns tasks sorted by dependency order (topological sort)
 // Tasks with no dependencies come first, followed by tasks whose dependencies are satisfied
 // Returns an error if a circular dependency is detected
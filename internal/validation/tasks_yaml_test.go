@@ -1675,3 +1675,57 @@ func TestValidateTaskDependenciesMet(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateTaskStatuses(t *testing.T) {
+	content := `# tasks for feature
+phases:
+  - number: 1
+    title: Setup
+    tasks:
+      - id: T001
+        title: First
+        status: Pending
+        dependencies: []
+      - id: T002
+        title: Second
+        status: Completed
+        dependencies: [T001]
+`
+	tests := map[string]struct {
+		statuses    map[string]string
+		wantChanged map[string]string
+		wantStatus  map[string]string
+	}{
+		"updates changed statuses": {
+			statuses:    map[string]string{"T001": "Completed", "T002": "Completed"},
+			wantChanged: map[string]string{"T001": "Pending"},
+			wantStatus:  map[string]string{"T001": "Completed", "T002": "Completed"},
+		},
+		"ignores unknown tasks": {
+			statuses:    map[string]string{"T999": "Completed"},
+			wantChanged: map[string]string{},
+			wantStatus:  map[string]string{"T001": "Pending", "T002": "Completed"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tasksPath := filepath.Join(t.TempDir(), "tasks.yaml")
+			require.NoError(t, os.WriteFile(tasksPath, []byte(content), 0o644))
+
+			changed, err := UpdateTaskStatuses(tasksPath, tt.statuses)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantChanged, changed)
+
+			tasks, err := GetAllTasks(tasksPath)
+			require.NoError(t, err)
+			for _, task := range tasks {
+				assert.Equal(t, tt.wantStatus[task.ID], task.Status, "status of %s", task.ID)
+			}
+
+			data, err := os.ReadFile(tasksPath)
+			require.NoError(t, err)
+			assert.Contains(t, string(data), "# tasks for feature")
+		})
+	}
+}
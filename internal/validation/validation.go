@@ -77,8 +77,11 @@ func ValidateConstitutionFile(projectDir string) error {
 		return fmt.Errorf("checking constitution file: %w", err)
 	}
 
-	// Validate schema
-	validator := &ConstitutionValidator{}
+	// Validate schema, including the project extension if any
+	validator, err := newArtifactValidator(ArtifactTypeConstitution, filepath.Join(projectDir, SchemaExtensionDir))
+	if err != nil {
+		return err
+	}
 	result := validator.Validate(constitutionPath)
 	if !result.Valid {
 		return fmt.Errorf("constitution validation failed: %s", result.Errors[0].Message)
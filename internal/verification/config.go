@@ -64,6 +64,16 @@ type VerificationConfig struct {
 	MutationThreshold float64 `koanf:"mutation_threshold" yaml:"mutation_threshold"`
 	CoverageThreshold float64 `koanf:"coverage_threshold" yaml:"coverage_threshold"`
 	ComplexityMax     int     `koanf:"complexity_max" yaml:"complexity_max"`
+
+	// Gate commands run after implementation to measure the thresholds above.
+	// Empty commands disable the corresponding gate. See RunGates.
+	CoverageCmd   string `koanf:"coverage_cmd" yaml:"coverage_cmd,omitempty"`
+	ComplexityCmd string `koanf:"complexity_cmd" yaml:"complexity_cmd,omitempty"`
+	MutationCmd   string `koanf:"mutation_cmd" yaml:"mutation_cmd,omitempty"`
+
+	// ReviewBlocking makes open CRITICAL findings in review.yaml fail the
+	// review stage (and implement, when adversarial review runs after it).
+	ReviewBlocking bool `koanf:"review_blocking" yaml:"review_blocking"`
 }
 
 // Feature toggle names used for IsEnabled lookups.
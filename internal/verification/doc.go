@@ -10,6 +10,11 @@
 //   - Three verification levels: basic, enhanced, full
 //   - Individual feature toggles: adversarial_review, contracts, property_tests, metamorphic_tests
 //   - Configurable thresholds: mutation_threshold, coverage_threshold, complexity_max
+//   - Quality gates: coverage_cmd, complexity_cmd, mutation_cmd measure the thresholds (see RunGates)
+//   - Adversarial review: adversarial_review runs the review stage after implement;
+//     review_blocking fails it while critical findings remain open
+//   - EARS test tasks: contracts, property_tests, metamorphic_tests select which
+//     EARS requirements get generated test tasks in tasks.yaml
 //   - Resolution order: explicit toggle > level preset > default
 //
 // # Level Presets
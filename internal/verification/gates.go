@@ -0,0 +1,232 @@
+package verification
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Gate identifies a quality gate enforced after implementation.
+type Gate string
+
+// Quality gate constants map to the threshold fields of VerificationConfig.
+const (
+	// GateCoverage compares test coverage against CoverageThreshold.
+	GateCoverage Gate = "coverage"
+	// GateComplexity compares the highest cyclomatic complexity against ComplexityMax.
+	GateComplexity Gate = "complexity"
+	// GateMutation compares the mutation score against MutationThreshold.
+	GateMutation Gate = "mutation"
+)
+
+// maxOutputLines bounds how much command output is kept in GateResult.Output.
+const maxOutputLines = 20
+
+// failureOutputLines is how many output lines each entry from Failures includes.
+const failureOutputLines = 5
+
+// GateResult holds the outcome of a single quality gate.
+type GateResult struct {
+	// Gate is the gate that was evaluated.
+	Gate Gate
+	// Command is the shell command that produced the measurement.
+	Command string
+	// Value is the measured value (ratio for coverage/mutation, count for complexity).
+	Value float64
+	// Threshold is the configured limit the value was compared against.
+	Threshold float64
+	// Passed is true when the value satisfies the threshold.
+	Passed bool
+	// Output is the tail of the command's combined output.
+	Output string
+	// Err is set when the command failed or its output could not be parsed.
+	Err error
+}
+
+// Failure returns a one-line description of why the gate failed.
+// Returns an empty string for passing gates.
+func (r GateResult) Failure() string {
+	if r.Passed {
+		return ""
+	}
+	if r.Err != nil {
+		return fmt.Sprintf("%s gate: %v (command: %s)", r.Gate, r.Err, r.Command)
+	}
+	if r.Gate == GateComplexity {
+		return fmt.Sprintf("complexity gate: highest cyclomatic complexity %d exceeds maximum %d (command: %s)",
+			int(r.Value), int(r.Threshold), r.Command)
+	}
+	return fmt.Sprintf("%s gate: %.1f%% is below threshold %.1f%% (command: %s)",
+		r.Gate, r.Value*100, r.Threshold*100, r.Command)
+}
+
+// GateReport holds the results of all configured quality gates.
+type GateReport struct {
+	Results []GateResult
+}
+
+// Passed returns true when every gate passed.
+func (r *GateReport) Passed() bool {
+	for _, res := range r.Results {
+		if !res.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// Failures returns a single-line description for each failed gate, ending
+// with the last few lines of command output so the agent can see what to fix.
+func (r *GateReport) Failures() []string {
+	var failures []string
+	for _, res := range r.Results {
+		if res.Passed {
+			continue
+		}
+		msg := res.Failure()
+		if res.Output != "" {
+			tail := strings.Split(tailLines(res.Output, failureOutputLines), "\n")
+			msg += "; output: " + strings.Join(tail, " | ")
+		}
+		failures = append(failures, msg)
+	}
+	return failures
+}
+
+// HasGates returns true if at least one quality gate command is configured.
+func (c *VerificationConfig) HasGates() bool {
+	return c.CoverageCmd != "" || c.ComplexityCmd != "" || c.MutationCmd != ""
+}
+
+// RunGates runs each configured gate command in dir and compares its parsed
+// output against the corresponding threshold. Gates without a command are skipped.
+// A non-zero exit status fails the gate, since tools like `go test` report
+// test failures that way.
+func RunGates(ctx context.Context, dir string, cfg *VerificationConfig) *GateReport {
+	report := &GateReport{}
+	if cfg.CoverageCmd != "" {
+		report.Results = append(report.Results, runGate(ctx, dir, GateCoverage, cfg.CoverageCmd, cfg.CoverageThreshold))
+	}
+	if cfg.ComplexityCmd != "" {
+		report.Results = append(report.Results, runGate(ctx, dir, GateComplexity, cfg.ComplexityCmd, float64(cfg.ComplexityMax)))
+	}
+	if cfg.MutationCmd != "" {
+		report.Results = append(report.Results, runGate(ctx, dir, GateMutation, cfg.MutationCmd, cfg.MutationThreshold))
+	}
+	return report
+}
+
+// runGate executes a single gate command and evaluates its output.
+func runGate(ctx context.Context, dir string, gate Gate, command string, threshold float64) GateResult {
+	result := GateResult{Gate: gate, Command: command, Threshold: threshold}
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	runErr := cmd.Run()
+	result.Output = tailLines(out.String(), maxOutputLines)
+
+	evaluated := evaluateGate(result, out.String())
+	if runErr == nil {
+		return evaluated
+	}
+	// Tools like gocyclo -over exit non-zero when they report offenders;
+	// prefer the measured failure over a bare exit status when available.
+	if evaluated.Err == nil && !evaluated.Passed {
+		return evaluated
+	}
+	result.Err = fmt.Errorf("command failed: %w", runErr)
+	return result
+}
+
+// evaluateGate parses output for the gate's metric and compares it to the threshold.
+func evaluateGate(result GateResult, output string) GateResult {
+	var (
+		value float64
+		err   error
+	)
+	if result.Gate == GateComplexity {
+		var complexity int
+		complexity, err = ParseMaxComplexity(output)
+		value = float64(complexity)
+	} else {
+		value, err = ParseRatio(output)
+	}
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	result.Value = value
+	if result.Gate == GateComplexity {
+		result.Passed = result.Threshold <= 0 || value <= result.Threshold
+	} else {
+		result.Passed = value >= result.Threshold
+	}
+	return result
+}
+
+var (
+	percentPattern = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*%`)
+	decimalPattern = regexp.MustCompile(`\b(0\.\d+|1\.0+)\b`)
+	leadingInt     = regexp.MustCompile(`^\s*(\d+)\s`)
+)
+
+// ParseRatio extracts a 0-1 ratio from tool output. The last percentage
+// (e.g., "total: (statements) 87.5%") wins; output without a percentage
+// falls back to the last decimal between 0 and 1 (e.g., "0.82"); bare
+// integers such as "exit 0" or "1 file" are not read as ratios.
+func ParseRatio(output string) (float64, error) {
+	if matches := percentPattern.FindAllStringSubmatch(output, -1); len(matches) > 0 {
+		pct, err := strconv.ParseFloat(matches[len(matches)-1][1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("parsing percentage: %w", err)
+		}
+		return pct / 100, nil
+	}
+	if matches := decimalPattern.FindAllStringSubmatch(output, -1); len(matches) > 0 {
+		ratio, err := strconv.ParseFloat(matches[len(matches)-1][1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("parsing ratio: %w", err)
+		}
+		return ratio, nil
+	}
+	return 0, fmt.Errorf("no percentage or ratio found in command output")
+}
+
+// ParseMaxComplexity returns the highest complexity from tool output where each
+// line starts with a complexity score, as printed by gocyclo and similar tools
+// (e.g., "12 main handleRequest main.go:42:1"). Output without any scored line
+// means no function was reported, which is a complexity of 0.
+func ParseMaxComplexity(output string) (int, error) {
+	maxComplexity := 0
+	for _, line := range strings.Split(output, "\n") {
+		m := leadingInt.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			return 0, fmt.Errorf("parsing complexity %q: %w", m[1], err)
+		}
+		if n > maxComplexity {
+			maxComplexity = n
+		}
+	}
+	return maxComplexity, nil
+}
+
+// tailLines returns the last n lines of s, ignoring trailing newlines.
+func tailLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,195 @@
+package verification
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParseRatio(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		output  string
+		want    float64
+		wantErr bool
+	}{
+		"go tool cover total": {
+			output: "pkg/a.go:10:\tFoo\t100.0%\npkg/a.go:20:\tBar\t50.0%\ntotal:\t(statements)\t87.5%\n",
+			want:   0.875,
+		},
+		"go test coverage line": {
+			output: "ok  \texample.com/pkg\t0.012s\tcoverage: 72.3% of statements\n",
+			want:   0.723,
+		},
+		"mutation score as ratio": {
+			output: "killed 41 of 50 mutants\nscore 0.82\n",
+			want:   0.82,
+		},
+		"ratio of one": {
+			output: "score 1.00\n",
+			want:   1,
+		},
+		"bare integers are not ratios": {
+			output:  "killed 1 of 1 mutants\n1 file checked, exit 0\n",
+			wantErr: true,
+		},
+		"durations are not ratios": {
+			output:  "ok  \texample.com/pkg\t0.012s\n",
+			wantErr: true,
+		},
+		"integer percentage": {
+			output: "Mutation score: 90%",
+			want:   0.9,
+		},
+		"no measurement": {
+			output:  "no test files",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseRatio(tt.output)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseRatio() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRatio() error = %v", err)
+			}
+			if diff := got - tt.want; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("ParseRatio() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMaxComplexity(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		output string
+		want   int
+	}{
+		"gocyclo output": {
+			output: "14 main handleRequest main.go:42:1\n11 config Load config.go:10:1\n",
+			want:   14,
+		},
+		"no offenders": {
+			output: "",
+			want:   0,
+		},
+		"ignores unscored lines": {
+			output: "Average: 3.2\n7 pkg run run.go:1:1\n",
+			want:   7,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseMaxComplexity(tt.output)
+			if err != nil {
+				t.Fatalf("ParseMaxComplexity() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMaxComplexity() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunGates(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		config       VerificationConfig
+		wantResults  int
+		wantPassed   bool
+		wantFailures []string
+	}{
+		"no commands configured": {
+			config:     VerificationConfig{CoverageThreshold: 0.8},
+			wantPassed: true,
+		},
+		"all gates pass": {
+			config: VerificationConfig{
+				CoverageThreshold: 0.8,
+				ComplexityMax:     10,
+				MutationThreshold: 0.7,
+				CoverageCmd:       "echo 'total: (statements) 85.0%'",
+				ComplexityCmd:     "echo '9 pkg Foo foo.go:1:1'",
+				MutationCmd:       "echo 'score: 0.75'",
+			},
+			wantResults: 3,
+			wantPassed:  true,
+		},
+		"coverage below threshold": {
+			config: VerificationConfig{
+				CoverageThreshold: 0.85,
+				CoverageCmd:       "echo 'coverage: 72.0% of statements'",
+			},
+			wantResults:  1,
+			wantFailures: []string{"coverage gate: 72.0% is below threshold 85.0%"},
+		},
+		"complexity over max with non-zero exit": {
+			config: VerificationConfig{
+				ComplexityMax: 10,
+				ComplexityCmd: "echo '15 pkg Foo foo.go:1:1'; exit 1",
+			},
+			wantResults:  1,
+			wantFailures: []string{"complexity 15 exceeds maximum 10", "output: 15 pkg Foo foo.go:1:1"},
+		},
+		"command fails": {
+			config: VerificationConfig{
+				CoverageThreshold: 0.5,
+				CoverageCmd:       "echo 'FAIL: TestFoo'; exit 1",
+			},
+			wantResults:  1,
+			wantFailures: []string{"coverage gate: command failed", "output: FAIL: TestFoo"},
+		},
+		"unparseable output": {
+			config: VerificationConfig{
+				MutationThreshold: 0.5,
+				MutationCmd:       "echo done",
+			},
+			wantResults:  1,
+			wantFailures: []string{"no percentage or ratio found"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			report := RunGates(context.Background(), t.TempDir(), &tt.config)
+
+			if len(report.Results) != tt.wantResults {
+				t.Fatalf("got %d results, want %d", len(report.Results), tt.wantResults)
+			}
+			if report.Passed() != tt.wantPassed {
+				t.Errorf("Passed() = %v, want %v", report.Passed(), tt.wantPassed)
+			}
+			failures := strings.Join(report.Failures(), "\n")
+			for _, want := range tt.wantFailures {
+				if !strings.Contains(failures, want) {
+					t.Errorf("Failures() = %q, want containing %q", failures, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHasGates(t *testing.T) {
+	t.Parallel()
+
+	if (&VerificationConfig{}).HasGates() {
+		t.Error("HasGates() = true for empty config, want false")
+	}
+	if !(&VerificationConfig{MutationCmd: "make mutate"}).HasGates() {
+		t.Error("HasGates() = false with mutation_cmd set, want true")
+	}
+}
@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -17,8 +18,20 @@ type ClaudeExecutor struct {
 	// Agent is the abstraction for CLI agent execution.
 	Agent cliagent.Agent
 
+	// Fallbacks are agents tried in order when the previous agent exits with a
+	// rate-limit or quota failure. Only headless executions fall back.
+	Fallbacks []cliagent.Agent
+
 	Timeout int // Timeout in seconds (0 = no timeout)
 
+	// ExtraArgs are additional CLI arguments appended to every agent command
+	// (from stages.<name>.extra_args).
+	ExtraArgs []string
+
+	// WorkDir is the working directory for agent commands (empty = current
+	// directory). Parallel task execution points it at each task's worktree.
+	WorkDir string
+
 	// CcleanConfig provides detailed configuration for cclean output formatting.
 	// Controls verbose mode, line numbers, and style for stream-json display.
 	// Style field controls output formatting: default, compact, minimal, plain, raw.
@@ -36,6 +49,33 @@ type ClaudeExecutor struct {
 	// When true (default), uses syscall.Exec for full terminal control in interactive mode.
 	// Set to false for multi-stage runs where we need to continue after interactive stages.
 	ReplaceProcessForInteractive bool
+
+	// lastUsage holds token and cost usage reported by the most recent execution.
+	lastUsage cliagent.Usage
+
+	// lastAgent is the name of the agent that completed the most recent execution.
+	lastAgent string
+}
+
+// LastUsage returns the token and cost usage reported by the most recent
+// Execute call, summed across fallback agents. Zero when no agent reported usage.
+func (c *ClaudeExecutor) LastUsage() cliagent.Usage {
+	return c.lastUsage
+}
+
+// LastAgent returns the name of the agent that completed the most recent
+// Execute call, which differs from Agent when a fallback took over.
+// Empty when the execution failed.
+func (c *ClaudeExecutor) LastAgent() string {
+	return c.lastAgent
+}
+
+// ConfiguredAgent returns the name of the primary agent, or empty if none is set.
+func (c *ClaudeExecutor) ConfiguredAgent() string {
+	if c.Agent == nil {
+		return ""
+	}
+	return c.Agent.Name()
 }
 
 // Execute runs an agent command with the given prompt.
@@ -60,7 +100,50 @@ func (c *ClaudeExecutor) ExecuteInteractive(prompt string) error {
 
 // executeWithAgent uses the new Agent interface for execution.
 // When interactive is true, sets ExecOptions.Interactive to skip headless flags.
+// A headless execution that fails with a rate-limit signature is re-run with the
+// same prompt on each fallback agent in turn.
 func (c *ClaudeExecutor) executeWithAgent(prompt string, interactive bool) error {
+	c.lastUsage = cliagent.Usage{}
+	c.lastAgent = ""
+
+	agents := c.agentChain(interactive)
+	var err error
+	for i, agent := range agents {
+		err = c.runAgent(agent, prompt, interactive)
+		if err == nil {
+			c.lastAgent = agent.Name()
+			return nil
+		}
+		if !errors.Is(err, cliagent.ErrRateLimited) || i == len(agents)-1 {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "\nAgent %s is rate limited; retrying with fallback agent %s\n", agent.Name(), agents[i+1].Name())
+	}
+	return err
+}
+
+// agentChain returns the primary agent followed by fallbacks, skipping
+// duplicates. Interactive executions never fall back.
+func (c *ClaudeExecutor) agentChain(interactive bool) []cliagent.Agent {
+	chain := []cliagent.Agent{c.Agent}
+	if interactive {
+		return chain
+	}
+	seen := map[string]bool{c.Agent.Name(): true}
+	for _, agent := range c.Fallbacks {
+		if agent == nil || seen[agent.Name()] {
+			continue
+		}
+		seen[agent.Name()] = true
+		chain = append(chain, agent)
+	}
+	return chain
+}
+
+// runAgent executes prompt with a single agent. Usage is added to lastUsage
+// even on failure since a rate-limited session may still have been billed.
+// Returns an error wrapping cliagent.ErrRateLimited for rate-limit exits.
+func (c *ClaudeExecutor) runAgent(agent cliagent.Agent, prompt string, interactive bool) error {
 	ctx, cancel := c.createTimeoutContext()
 	if cancel != nil {
 		defer cancel()
@@ -70,20 +153,25 @@ func (c *ClaudeExecutor) executeWithAgent(prompt string, interactive bool) error
 	// Skip formatter for interactive mode (no stream-json output)
 	var stdout io.Writer = os.Stdout
 	if !interactive {
-		stdout = c.getFormattedStdout(os.Stdout)
+		stdout = c.formattedStdoutFor(agent, os.Stdout)
 	}
 
 	opts := cliagent.ExecOptions{
 		Stdout:          stdout,
 		Stderr:          os.Stderr,
 		Timeout:         time.Duration(c.Timeout) * time.Second,
+		ExtraArgs:       c.ExtraArgs,
 		UseSubscription: c.UseSubscription,
 		Autonomous:      c.SkipPermissions,
+		WorkDir:         c.WorkDir,
 		Interactive:     interactive,
 		ReplaceProcess:  interactive && c.ReplaceProcessForInteractive,
 	}
 
-	result, err := c.Agent.Execute(ctx, prompt, opts)
+	result, err := agent.Execute(ctx, prompt, opts)
+	if result != nil {
+		c.lastUsage.Add(result.Usage)
+	}
 
 	// Flush formatter if used (only applies to non-interactive mode)
 	if !interactive {
@@ -95,12 +183,15 @@ func (c *ClaudeExecutor) executeWithAgent(prompt string, interactive bool) error
 		if ctx.Err() == context.DeadlineExceeded {
 			return NewTimeoutError(time.Duration(c.Timeout)*time.Second, c.FormatCommand(prompt))
 		}
-		return fmt.Errorf("agent %s command failed: %w", c.Agent.Name(), err)
+		return fmt.Errorf("agent %s command failed: %w", agent.Name(), err)
 	}
 
 	// Check exit code
 	if result.ExitCode != 0 {
-		return fmt.Errorf("agent %s exited with code %d", c.Agent.Name(), result.ExitCode)
+		if result.RateLimited {
+			return fmt.Errorf("agent %s exited with code %d: %w", agent.Name(), result.ExitCode, cliagent.ErrRateLimited)
+		}
+		return fmt.Errorf("agent %s exited with code %d", agent.Name(), result.ExitCode)
 	}
 	return nil
 }
@@ -118,7 +209,7 @@ func (c *ClaudeExecutor) FormatCommand(prompt string) string {
 	if c.Agent == nil {
 		return "[no agent configured]"
 	}
-	cmd, err := c.Agent.BuildCommand(prompt, cliagent.ExecOptions{})
+	cmd, err := c.Agent.BuildCommand(prompt, cliagent.ExecOptions{ExtraArgs: c.ExtraArgs})
 	if err != nil {
 		return fmt.Sprintf("%s [error: %v]", c.Agent.Name(), err)
 	}
@@ -153,9 +244,14 @@ func (c *ClaudeExecutor) StreamCommand(prompt string, stdout, stderr io.Writer)
 		Timeout:         time.Duration(c.Timeout) * time.Second,
 		UseSubscription: c.UseSubscription,
 		Autonomous:      c.SkipPermissions,
+		WorkDir:         c.WorkDir,
 	}
 
+	c.lastUsage = cliagent.Usage{}
 	result, err := c.Agent.Execute(ctx, prompt, opts)
+	if result != nil {
+		c.lastUsage = result.Usage
+	}
 
 	// Flush formatter if used
 	c.flushFormatter(formattedStdout)
@@ -179,6 +275,12 @@ func (c *ClaudeExecutor) StreamCommand(prompt string, stdout, stderr io.Writer)
 // - Stream-json mode with headless flag is detected
 // Otherwise, returns the original writer unchanged.
 func (c *ClaudeExecutor) getFormattedStdout(w io.Writer) io.Writer {
+	return c.formattedStdoutFor(c.Agent, w)
+}
+
+// formattedStdoutFor is getFormattedStdout for a specific agent, so a fallback
+// agent's output is formatted according to its own command args.
+func (c *ClaudeExecutor) formattedStdoutFor(agent cliagent.Agent, w io.Writer) io.Writer {
 	// Skip formatting if style is raw
 	style, _ := config.NormalizeOutputStyle(c.CcleanConfig.Style)
 	if style.IsRaw() {
@@ -186,7 +288,8 @@ func (c *ClaudeExecutor) getFormattedStdout(w io.Writer) io.Writer {
 	}
 
 	// Only format when stream-json + headless mode detected
-	if !c.detectStreamJsonMode() {
+	args := agentCommandArgs(agent)
+	if !hasStreamJsonFormat(args) || !hasHeadlessFlag(args) {
 		return w
 	}
 
@@ -229,10 +332,15 @@ func (c *ClaudeExecutor) detectStreamJsonMode() bool {
 
 // getCommandArgs returns the args that will be used for command execution.
 func (c *ClaudeExecutor) getCommandArgs() []string {
-	if c.Agent == nil {
+	return agentCommandArgs(c.Agent)
+}
+
+// agentCommandArgs returns the args an agent builds for a headless execution.
+func agentCommandArgs(agent cliagent.Agent) []string {
+	if agent == nil {
 		return nil
 	}
-	cmd, err := c.Agent.BuildCommand("", cliagent.ExecOptions{})
+	cmd, err := agent.BuildCommand("", cliagent.ExecOptions{})
 	if err != nil {
 		return nil
 	}
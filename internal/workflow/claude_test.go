@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"os/exec"
 	"testing"
 	"time"
@@ -619,3 +620,131 @@ func TestClaudeExecutor_StreamCommand_SkipPermissions_SetsAutonomous(t *testing.
 		})
 	}
 }
+
+// mockResultAgent is a test agent that returns a fixed result and counts calls
+type mockResultAgent struct {
+	mockCapturingAgent
+	result *cliagent.Result
+	calls  int
+}
+
+func (m *mockResultAgent) Execute(_ context.Context, prompt string, opts cliagent.ExecOptions) (*cliagent.Result, error) {
+	m.calls++
+	m.capturedOpts = opts
+	return m.result, nil
+}
+
+// TestClaudeExecutor_AgentFallbacks verifies that rate-limited executions are
+// re-run on fallback agents in order and the producing agent is reported
+func TestClaudeExecutor_AgentFallbacks(t *testing.T) {
+	t.Parallel()
+
+	rateLimited := &cliagent.Result{ExitCode: 1, RateLimited: true, Usage: cliagent.Usage{CostUSD: 0.01}}
+	failed := &cliagent.Result{ExitCode: 1}
+	succeeded := &cliagent.Result{ExitCode: 0, Usage: cliagent.Usage{CostUSD: 0.5}}
+
+	tests := map[string]struct {
+		primary       *cliagent.Result
+		fallbacks     []*cliagent.Result
+		interactive   bool
+		wantErr       bool
+		wantRateLimit bool
+		wantAgent     string
+		wantCalls     []int
+		wantCost      float64
+	}{
+		"primary succeeds": {
+			primary:   succeeded,
+			fallbacks: []*cliagent.Result{succeeded},
+			wantAgent: "primary",
+			wantCalls: []int{1, 0},
+			wantCost:  0.5,
+		},
+		"rate limited primary falls back": {
+			primary:   rateLimited,
+			fallbacks: []*cliagent.Result{succeeded},
+			wantAgent: "fallback-0",
+			wantCalls: []int{1, 1},
+			wantCost:  0.51,
+		},
+		"chain continues past rate limited fallback": {
+			primary:   rateLimited,
+			fallbacks: []*cliagent.Result{rateLimited, succeeded},
+			wantAgent: "fallback-1",
+			wantCalls: []int{1, 1, 1},
+			wantCost:  0.52,
+		},
+		"ordinary failure does not fall back": {
+			primary:   failed,
+			fallbacks: []*cliagent.Result{succeeded},
+			wantErr:   true,
+			wantCalls: []int{1, 0},
+		},
+		"all agents rate limited": {
+			primary:       rateLimited,
+			fallbacks:     []*cliagent.Result{rateLimited},
+			wantErr:       true,
+			wantRateLimit: true,
+			wantCalls:     []int{1, 1},
+			wantCost:      0.02,
+		},
+		"interactive never falls back": {
+			primary:       rateLimited,
+			fallbacks:     []*cliagent.Result{succeeded},
+			interactive:   true,
+			wantErr:       true,
+			wantRateLimit: true,
+			wantCalls:     []int{1, 0},
+			wantCost:      0.01,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			agents := []*mockResultAgent{{mockCapturingAgent: mockCapturingAgent{name: "primary"}, result: tt.primary}}
+			executor := &ClaudeExecutor{Agent: agents[0], Timeout: 60}
+			for i, result := range tt.fallbacks {
+				fallback := &mockResultAgent{mockCapturingAgent: mockCapturingAgent{name: fmt.Sprintf("fallback-%d", i)}, result: result}
+				agents = append(agents, fallback)
+				executor.Fallbacks = append(executor.Fallbacks, fallback)
+			}
+
+			var err error
+			if tt.interactive {
+				err = executor.ExecuteInteractive("/autospec.plan")
+			} else {
+				err = executor.Execute("/autospec.plan")
+			}
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantRateLimit, errors.Is(err, cliagent.ErrRateLimited))
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantAgent, executor.LastAgent())
+			assert.InDelta(t, tt.wantCost, executor.LastUsage().CostUSD, 1e-9)
+			for i, agent := range agents {
+				assert.Equal(t, tt.wantCalls[i], agent.calls, "calls to %s", agent.Name())
+			}
+		})
+	}
+}
+
+// TestClaudeExecutor_AgentFallbacks_SkipsPrimaryDuplicate verifies that listing
+// the primary agent as a fallback does not re-run it
+func TestClaudeExecutor_AgentFallbacks_SkipsPrimaryDuplicate(t *testing.T) {
+	t.Parallel()
+
+	primary := &mockResultAgent{
+		mockCapturingAgent: mockCapturingAgent{name: "claude"},
+		result:             &cliagent.Result{ExitCode: 1, RateLimited: true},
+	}
+	executor := &ClaudeExecutor{Agent: primary, Fallbacks: []cliagent.Agent{primary}}
+
+	err := executor.Execute("/autospec.plan")
+	require.ErrorIs(t, err, cliagent.ErrRateLimited)
+	assert.Equal(t, 1, primary.calls)
+}
@@ -0,0 +1,140 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/validation"
+)
+
+// CustomStage is a pipeline stage defined in config under stages.<name> with a
+// command template (see config.StageConfig). Custom stages run through the
+// same executor as built-in stages, so retries, budgets, events, and
+// per-stage agents apply to them too.
+type CustomStage struct {
+	Name        Stage
+	Description string
+	Command     string   // Command template rendered for the stage (e.g., "autospec.threat-model")
+	Requires    []string // Artifacts that must exist in the spec directory before the stage runs
+	Produces    []string // Artifacts the stage must create in the spec directory
+	Schema      string   // Schema file the first produced artifact is validated against (optional)
+
+	// After lists the stages this stage runs after, nearest first: the
+	// configured after stage followed by that stage's own after chain, ending
+	// at a built-in stage. Empty means after implement.
+	After []Stage
+}
+
+// Dependency returns the artifacts the stage requires and produces.
+func (c CustomStage) Dependency() ArtifactDependency {
+	return ArtifactDependency{Stage: c.Name, Requires: c.Requires, Produces: c.Produces}
+}
+
+// CustomStagesFromConfig returns the custom stages defined in cfg, keyed by
+// name. Stage definitions are validated when the config is loaded.
+func CustomStagesFromConfig(cfg *config.Configuration) map[Stage]CustomStage {
+	stages := make(map[Stage]CustomStage)
+	for _, name := range cfg.CustomStageNames() {
+		sc := cfg.StageConfigFor(name)
+		stages[Stage(name)] = CustomStage{
+			Name:        Stage(name),
+			Description: sc.Description,
+			Command:     sc.Command,
+			Requires:    sc.Requires,
+			Produces:    sc.Produces,
+			Schema:      sc.Schema,
+			After:       afterChain(cfg, sc.After),
+		}
+	}
+	return stages
+}
+
+// afterChain follows stages.<name>.after from after until it reaches a
+// built-in stage. Config validation rules out unknown stages and cycles.
+func afterChain(cfg *config.Configuration, after string) []Stage {
+	var chain []Stage
+	for after != "" && len(chain) <= len(cfg.Stages) {
+		chain = append(chain, Stage(after))
+		if IsBuiltinStage(Stage(after)) {
+			break
+		}
+		after = cfg.StageConfigFor(after).After
+	}
+	return chain
+}
+
+// ExecuteCustom runs a custom stage with optional prompt. When the stage has a
+// schema, the expected structure is added to the rendered command and the
+// first produced artifact is validated against it; validation failures are
+// retried like built-in stages.
+func (s *StageExecutor) ExecuteCustom(specName string, stage CustomStage, prompt string) error {
+	s.debugLog("ExecuteCustom called for stage: %s, spec: %s, prompt: %s", stage.Name, specName, prompt)
+
+	var schema *validation.Schema
+	if stage.Schema != "" {
+		loaded, err := validation.LoadSchemaFile(stage.Schema)
+		if err != nil {
+			return fmt.Errorf("loading %s schema: %w", stage.Name, err)
+		}
+		schema = loaded
+	}
+
+	rendered, err := s.computeAndRenderCommand(stage.Command)
+	if err != nil {
+		return fmt.Errorf("building %s command: %w", stage.Name, err)
+	}
+	command := injectExpectedStructure(rendered, stage, schema)
+	if prompt != "" {
+		command = fmt.Sprintf("%s\n\n## User Input\n\n%s", command, prompt)
+	}
+	s.printExecuting("/"+stage.Command, prompt)
+
+	result, err := s.executor.ExecuteStage(specName, stage.Name, command, customStageValidator(stage, schema))
+	if err != nil {
+		return s.formatStageError(string(stage.Name), result, err)
+	}
+
+	fmt.Printf("\n✓ %s stage complete for specs/%s/\n", stage.Name, specName)
+	return nil
+}
+
+// injectExpectedStructure appends the structure the first produced artifact
+// must follow, so the agent does not have to guess the schema.
+func injectExpectedStructure(command string, stage CustomStage, schema *validation.Schema) string {
+	if schema == nil || len(stage.Produces) == 0 {
+		return command
+	}
+	return fmt.Sprintf("%s\n\n## Expected Structure\n\nWrite `%s` in the spec directory as YAML with these fields (extra fields are allowed):\n\n```\n%s```",
+		command, stage.Produces[0], validation.FormatSchemaOutline(schema))
+}
+
+// customStageValidator returns a validation function for ExecuteStage that
+// checks every produced artifact exists and that the first one matches schema.
+func customStageValidator(stage CustomStage, schema *validation.Schema) func(string) error {
+	return func(specDir string) error {
+		for _, artifact := range stage.Produces {
+			if _, err := os.Stat(filepath.Join(specDir, artifact)); err != nil {
+				return fmt.Errorf("%s stage did not create %s: %w", stage.Name, artifact, err)
+			}
+		}
+		if schema == nil {
+			return nil
+		}
+		result := validation.ValidateWithSchema(filepath.Join(specDir, stage.Produces[0]), schema)
+		if result.Valid {
+			return nil
+		}
+		return formatValidationErrors(stage.Produces[0], result.Errors)
+	}
+}
+
+// ExecuteCustomStage runs a config-defined custom stage with optional prompt.
+func (w *WorkflowOrchestrator) ExecuteCustomStage(specNameArg string, stage CustomStage, prompt string) error {
+	specName, err := w.resolveSpecName(specNameArg)
+	if err != nil {
+		return fmt.Errorf("resolving spec name: %w", err)
+	}
+	return w.budgetStopError(w.stageExecutor.ExecuteCustom(specName, stage, prompt))
+}
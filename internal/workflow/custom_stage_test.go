@@ -0,0 +1,144 @@
+// Package workflow tests config-defined custom stages.
+// Related: internal/workflow/custom_stage.go
+// Tags: workflow, stages, custom-stage, schema, artifacts, config
+package workflow
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomStagesFromConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Configuration{Stages: map[string]config.StageConfig{
+		"plan":         {Agent: "codex"},
+		"threat-model": {Command: "autospec.threat-model", Description: "Model threats", Requires: []string{"plan.yaml"}, Produces: []string{"threat-model.yaml"}, Schema: "threat.yaml", After: "plan"},
+		"api-contract": {Command: "autospec.api-contract", After: "threat-model"},
+		"audit":        {Command: "autospec.audit"},
+	}}
+
+	stages := CustomStagesFromConfig(cfg)
+	require.Len(t, stages, 3)
+	assert.Equal(t, CustomStage{
+		Name:        "threat-model",
+		Description: "Model threats",
+		Command:     "autospec.threat-model",
+		Requires:    []string{"plan.yaml"},
+		Produces:    []string{"threat-model.yaml"},
+		Schema:      "threat.yaml",
+		After:       []Stage{StagePlan},
+	}, stages["threat-model"])
+	assert.Equal(t, []Stage{"threat-model", StagePlan}, stages["api-contract"].After)
+	assert.Empty(t, stages["audit"].After)
+}
+
+func TestCustomStageValidator(t *testing.T) {
+	t.Parallel()
+
+	schema := &validation.Schema{Fields: []validation.SchemaField{
+		{Name: "threats", Type: validation.FieldTypeArray, Required: true},
+	}}
+	stage := CustomStage{Name: "threat-model", Produces: []string{"threat-model.yaml", "threats.md"}}
+
+	tests := map[string]struct {
+		files       map[string]string
+		schema      *validation.Schema
+		errContains string
+	}{
+		"all artifacts present": {
+			files: map[string]string{"threat-model.yaml": "threats: []\n", "threats.md": "# Threats\n"},
+		},
+		"missing artifact": {
+			files:       map[string]string{"threat-model.yaml": "threats: []\n"},
+			errContains: "threat-model stage did not create threats.md",
+		},
+		"schema valid": {
+			files:  map[string]string{"threat-model.yaml": "threats: []\n", "threats.md": ""},
+			schema: schema,
+		},
+		"schema invalid": {
+			files:       map[string]string{"threat-model.yaml": "risks: []\n", "threats.md": ""},
+			schema:      schema,
+			errContains: "schema validation failed for threat-model.yaml",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			specDir := t.TempDir()
+			for file, content := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(specDir, file), []byte(content), 0o644))
+			}
+
+			err := customStageValidator(stage, tt.schema)(specDir)
+			if tt.errContains == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+func TestInjectExpectedStructure(t *testing.T) {
+	t.Parallel()
+
+	stage := CustomStage{Name: "threat-model", Produces: []string{"threat-model.yaml"}}
+	schema := &validation.Schema{Fields: []validation.SchemaField{
+		{Name: "threats", Type: validation.FieldTypeArray, Required: true},
+	}}
+
+	assert.Equal(t, "Model threats.", injectExpectedStructure("Model threats.", stage, nil))
+
+	got := injectExpectedStructure("Model threats.", stage, schema)
+	assert.Contains(t, got, "## Expected Structure")
+	assert.Contains(t, got, "`threat-model.yaml`")
+	assert.Contains(t, got, "threats: array (required)")
+}
+
+func TestExecuteCustomStage(t *testing.T) {
+	t.Parallel()
+
+	stage := CustomStage{Name: "threat-model", Command: "autospec.threat-model"}
+
+	tests := map[string]struct {
+		stageErr error
+		wantErr  bool
+	}{
+		"delegates to stage executor": {},
+		"propagates failure":          {stageErr: errors.New("agent failed"), wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStage := NewMockStageExecutor()
+			mockStage.CustomError = tt.stageErr
+			cfg := &config.Configuration{
+				CustomAgent: &cliagent.CustomAgentConfig{Command: "echo", Args: []string{"{{PROMPT}}"}},
+				SpecsDir:    t.TempDir(),
+				MaxRetries:  3,
+				StateDir:    filepath.Join(t.TempDir(), "state"),
+			}
+			orch := NewWorkflowOrchestratorWithExecutors(cfg, ExecutorOptions{StageExecutor: mockStage})
+
+			err := orch.ExecuteCustomStage("001-test", stage, "Focus on auth")
+			assert.Equal(t, tt.wantErr, err != nil)
+			require.Len(t, mockStage.CustomCalls, 1)
+			assert.Equal(t, CustomCall{SpecName: "001-test", Stage: stage, Prompt: "Focus on auth"}, mockStage.CustomCalls[0])
+		})
+	}
+}
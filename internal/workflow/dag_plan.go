@@ -0,0 +1,54 @@
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/ariel-frischer/autospec/internal/commands"
+	"github.com/ariel-frischer/autospec/internal/prereqs"
+	"github.com/ariel-frischer/autospec/internal/retry"
+)
+
+// ExecuteDAGPlan runs the dag-plan stage: an agent session that turns a
+// roadmap into a DAG file. validateFunc checks the written file after each
+// attempt, and its errors are injected as retry context until the file passes
+// or retries are exhausted. planName scopes the retry state, which starts
+// fresh on every call since each call regenerates the file.
+func (w *WorkflowOrchestrator) ExecuteDAGPlan(planName, input string, validateFunc func() error) error {
+	if err := retry.ResetRetryCount(w.Executor.StateDir, planName, string(StageDAGPlan)); err != nil {
+		return fmt.Errorf("resetting retry state: %w", err)
+	}
+
+	command, err := renderDAGPlanCommand(w.SpecsDir, input)
+	if err != nil {
+		return fmt.Errorf("building dag-plan command: %w", err)
+	}
+	fmt.Println("Executing: /autospec.dag-plan")
+
+	result, err := w.Executor.ExecuteStage(planName, StageDAGPlan, command, func(string) error {
+		return validateFunc()
+	})
+	if err != nil {
+		if result != nil && result.Exhausted {
+			err = fmt.Errorf("dag plan exhausted retries after %d total attempts: %w", result.RetryCount+1, err)
+		}
+		return w.budgetStopError(err)
+	}
+	return nil
+}
+
+// renderDAGPlanCommand renders the dag-plan template with the user input appended.
+// The template needs no feature context, so it renders outside a spec.
+func renderDAGPlanCommand(specsDir, input string) (string, error) {
+	const commandName = "autospec.dag-plan"
+
+	ctx, err := prereqs.ComputeContext(prereqs.Options{SpecsDir: specsDir, PathsOnly: true})
+	if err != nil {
+		return "", fmt.Errorf("computing prereqs context: %w", err)
+	}
+
+	rendered, err := commands.RenderCommand(commandName, ctx)
+	if err != nil {
+		return "", fmt.Errorf("rendering template: %w", err)
+	}
+	return fmt.Sprintf("%s\n\n## User Input\n\n%s", rendered, input), nil
+}
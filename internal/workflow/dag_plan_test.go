@@ -0,0 +1,75 @@
+package workflow
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteDAGPlan(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		failures     int
+		wantCalls    int
+		wantErr      string
+		wantRetryMsg bool
+	}{
+		"valid on first attempt": {
+			wantCalls: 1,
+		},
+		"retries with validation errors": {
+			failures:     1,
+			wantCalls:    2,
+			wantRetryMsg: true,
+		},
+		"exhausts retries": {
+			failures:  5,
+			wantCalls: 3,
+			wantErr:   "dag plan exhausted retries after 3 total attempts",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &config.Configuration{
+				SpecsDir:   t.TempDir(),
+				StateDir:   t.TempDir(),
+				MaxRetries: 2,
+			}
+			orch := NewWorkflowOrchestrator(cfg)
+			runner := &mockClaudeExecutor{}
+			orch.Executor.Claude = runner
+
+			validations := 0
+			validate := func() error {
+				validations++
+				if validations <= tt.failures {
+					return errors.New("DAG plan validation failed for q3.yaml:\n- line 4: feature \"001-auth\" already exists as a spec folder\n")
+				}
+				return nil
+			}
+
+			err := orch.ExecuteDAGPlan("dag-q3", "Output file: .autospec/dags/q3.yaml\n\nRoadmap:\n\nAdd auth", validate)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Len(t, runner.executeCalls, tt.wantCalls)
+			assert.Contains(t, runner.executeCalls[0], "## User Input\n\nOutput file: .autospec/dags/q3.yaml")
+			assert.NotContains(t, runner.executeCalls[0], "{{.AutospecVersion}}")
+			if tt.wantRetryMsg {
+				assert.Contains(t, runner.executeCalls[1], "RETRY 1/2")
+				assert.Contains(t, runner.executeCalls[1], `- line 4: feature "001-auth" already exists as a spec folder`)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Package workflow provides EARS test task generation for the tasks stage.
+// Related: internal/validation/ears_traceability.go, internal/workflow/ears_instructions.go
+// Tags: workflow, ears, tasks, test-generation, traceability
+package workflow
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/ariel-frischer/autospec/internal/verification"
+)
+
+const earsTestTasksInstructions = `## EARS Requirement Test Tasks
+
+For each EARS requirement in spec.yaml whose test kind is enabled, include a test task that references it:
+
+` + "```yaml" + `
+- id: "T020"
+  title: "Write property test for EARS-002"
+  status: "Pending"
+  type: "test"
+  requirement_id: "EARS-002"
+  test_kind: "property"
+` + "```" + `
+
+Enabled test kinds: %s
+
+| test_type | test_kind |
+|-----------|-----------|
+| invariant, exception | contract |
+| property, state-machine | property |
+| feature-flag | metamorphic |
+
+Requirements without a matching test task are added to a final "EARS Requirement Tests" phase after this stage.
+`
+
+// EarsTestKinds returns the EARS test kinds enabled by the contracts,
+// property_tests, and metamorphic_tests verification toggles.
+func EarsTestKinds(cfg *verification.VerificationConfig) []validation.EarsTestKind {
+	var kinds []validation.EarsTestKind
+	if cfg.IsEnabled(verification.FeatureContracts) {
+		kinds = append(kinds, validation.TestKindContract)
+	}
+	if cfg.IsEnabled(verification.FeaturePropertyTests) {
+		kinds = append(kinds, validation.TestKindProperty)
+	}
+	if cfg.IsEnabled(verification.FeatureMetamorphicTests) {
+		kinds = append(kinds, validation.TestKindMetamorphic)
+	}
+	return kinds
+}
+
+func BuildEarsTestTaskInstructions(kinds []validation.EarsTestKind) InjectableInstruction {
+	names := make([]string, len(kinds))
+	for i, k := range kinds {
+		names[i] = string(k)
+	}
+	return InjectableInstruction{
+		Name:        "EarsTestTasks",
+		DisplayHint: "add a test task per EARS requirement",
+		Content:     fmt.Sprintf(earsTestTasksInstructions, strings.Join(names, ", ")),
+	}
+}
+
+func InjectEarsTestTaskInstructions(command string, kinds []validation.EarsTestKind) string {
+	if len(kinds) == 0 {
+		return command
+	}
+	instruction := BuildEarsTestTaskInstructions(kinds)
+	return InjectInstructions(command, []InjectableInstruction{instruction})
+}
+
+// emitEarsTestTasks adds a test task to tasks.yaml for each enabled EARS
+// requirement the agent did not cover, so every requirement is traceable to
+// a test regardless of what the agent produced.
+func (s *StageExecutor) emitEarsTestTasks(specName string) error {
+	if len(s.earsTestKinds) == 0 {
+		return nil
+	}
+	specDir := filepath.Join(s.specsDir, specName)
+	tasksPath := filepath.Join(specDir, "tasks.yaml")
+
+	ids, err := validation.AddEarsTestTasks(tasksPath, validation.GetSpecFilePath(specDir), s.earsTestKinds)
+	if err != nil {
+		return fmt.Errorf("adding EARS test tasks: %w", err)
+	}
+	if len(ids) > 0 {
+		fmt.Printf("Added %d EARS test task(s): %s\n", len(ids), strings.Join(ids, ", "))
+	}
+	return nil
+}
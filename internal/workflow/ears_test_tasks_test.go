@@ -0,0 +1,155 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/ariel-frischer/autospec/internal/verification"
+)
+
+func TestEarsTestKinds(t *testing.T) {
+	t.Parallel()
+
+	disabled := false
+	enabled := true
+
+	tests := map[string]struct {
+		cfg  verification.VerificationConfig
+		want []validation.EarsTestKind
+	}{
+		"basic level": {
+			cfg:  verification.VerificationConfig{Level: verification.LevelBasic},
+			want: nil,
+		},
+		"enhanced level": {
+			cfg:  verification.VerificationConfig{Level: verification.LevelEnhanced},
+			want: []validation.EarsTestKind{validation.TestKindContract},
+		},
+		"full level": {
+			cfg:  verification.VerificationConfig{Level: verification.LevelFull},
+			want: validation.ValidTestKinds,
+		},
+		"toggles override level": {
+			cfg: verification.VerificationConfig{
+				Level:            verification.LevelFull,
+				Contracts:        &disabled,
+				MetamorphicTests: &disabled,
+			},
+			want: []validation.EarsTestKind{validation.TestKindProperty},
+		},
+		"explicit toggle on basic level": {
+			cfg:  verification.VerificationConfig{Level: verification.LevelBasic, MetamorphicTests: &enabled},
+			want: []validation.EarsTestKind{validation.TestKindMetamorphic},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := EarsTestKinds(&tt.cfg)
+			if len(got) != len(tt.want) {
+				t.Fatalf("EarsTestKinds() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("EarsTestKinds()[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestInjectEarsTestTaskInstructions(t *testing.T) {
+	t.Parallel()
+
+	command := "/autospec.tasks"
+
+	if got := InjectEarsTestTaskInstructions(command, nil); got != command {
+		t.Errorf("no kinds should leave command unchanged, got %q", got)
+	}
+
+	got := InjectEarsTestTaskInstructions(command, []validation.EarsTestKind{validation.TestKindContract, validation.TestKindProperty})
+	for _, want := range []string{command, "requirement_id", "test_kind", "Enabled test kinds: contract, property"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("injected command should contain %q", want)
+		}
+	}
+}
+
+func TestStageExecutor_EmitEarsTestTasks(t *testing.T) {
+	t.Parallel()
+
+	specYAML := `ears_requirements:
+  - id: "EARS-001"
+    pattern: "unwanted"
+    text: "If the database is down, then the system shall retry"
+    test_type: "exception"
+    condition: "database is down"
+`
+	tasksYAML := `tasks:
+  branch: "001-ears"
+summary:
+  total_tasks: 1
+phases:
+  - number: 1
+    title: "Core"
+    tasks:
+      - id: "T001"
+        title: "Implement retry"
+        status: "Pending"
+        type: "implementation"
+`
+
+	tests := map[string]struct {
+		kinds     []validation.EarsTestKind
+		wantTasks int
+	}{
+		"contract kind adds test task": {
+			kinds:     []validation.EarsTestKind{validation.TestKindContract},
+			wantTasks: 2,
+		},
+		"other kinds leave tasks unchanged": {
+			kinds:     []validation.EarsTestKind{validation.TestKindProperty},
+			wantTasks: 1,
+		},
+		"no kinds leave tasks unchanged": {
+			wantTasks: 1,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			specsDir := t.TempDir()
+			specDir := filepath.Join(specsDir, "001-ears")
+			if err := os.MkdirAll(specDir, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(specDir, "spec.yaml"), []byte(specYAML), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			tasksPath := filepath.Join(specDir, "tasks.yaml")
+			if err := os.WriteFile(tasksPath, []byte(tasksYAML), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			se := NewStageExecutorWithOptions(&Executor{}, specsDir, StageExecutorOptions{EarsTestKinds: tt.kinds})
+			if err := se.emitEarsTestTasks("001-ears"); err != nil {
+				t.Fatalf("emitEarsTestTasks() error = %v", err)
+			}
+
+			tasks, err := validation.GetAllTasks(tasksPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(tasks) != tt.wantTasks {
+				t.Errorf("len(tasks) = %d, want %d", len(tasks), tt.wantTasks)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"time"
+
+	"github.com/ariel-frischer/autospec/internal/budget"
 )
 
 // TimeoutError represents a command timeout failure
@@ -31,3 +33,21 @@ func NewTimeoutError(timeout time.Duration, command string) *TimeoutError {
 		Err:     context.DeadlineExceeded,
 	}
 }
+
+// BudgetError reports that a stage was stopped because a spending limit was reached.
+// Retry state is left untouched so the stage can be resumed once the limit is raised.
+type BudgetError struct {
+	Stage Stage                 // The stage that was about to start another agent session
+	Spec  string                // Spec name (empty for specify before the spec exists)
+	Err   *budget.ExceededError // The limit that was reached
+}
+
+// Error returns the exceeded limit along with the stopped stage
+func (e *BudgetError) Error() string {
+	return fmt.Sprintf("%v (stopped before next %s session)", e.Err, e.Stage)
+}
+
+// Unwrap returns the underlying budget error for errors.Is/As compatibility
+func (e *BudgetError) Unwrap() error {
+	return e.Err
+}
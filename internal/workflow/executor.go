@@ -3,14 +3,19 @@ package workflow
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/ariel-frischer/autospec/internal/budget"
+	"github.com/ariel-frischer/autospec/internal/cliagent"
 	"github.com/ariel-frischer/autospec/internal/lifecycle"
 	"github.com/ariel-frischer/autospec/internal/notify"
 	"github.com/ariel-frischer/autospec/internal/output"
 	"github.com/ariel-frischer/autospec/internal/progress"
 	"github.com/ariel-frischer/autospec/internal/retry"
 	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/ariel-frischer/autospec/internal/yaml"
 )
 
 // Executor handles command execution with retry logic.
@@ -26,12 +31,18 @@ type Executor struct {
 	SpecsDir            string                    // Directory for spec files
 	MaxRetries          int                       // Maximum retry attempts (1-10 range)
 	TotalStages         int                       // Total stages in workflow
+	StageNumbers        map[Stage]int             // Positions of this run's stages (overrides canonical numbers)
 	Debug               bool                      // Enable debug logging
 	AutoCommit          bool                      // Enable auto-commit instruction injection
 	Progress            *ProgressController       // Optional progress display controller
 	Notify              *NotifyDispatcher         // Optional notification dispatcher
 	ProgressDisplay     *progress.ProgressDisplay // Deprecated: use Progress instead
 	NotificationHandler *notify.Handler           // Deprecated: use Notify instead
+	Budget              budget.Config             // Spending limits checked before each agent session
+	StageRunners        map[Stage]ClaudeRunner    // Per-stage overrides of Claude (from stages config)
+	SchemaModeline      bool                      // Add yaml-language-server modelines to produced artifacts
+
+	runUsage cliagent.Usage // Usage across all stages run by this executor (per-run budget)
 }
 
 // Stage represents a workflow stage (specify, plan, tasks, implement)
@@ -49,6 +60,13 @@ const (
 	StageClarify      Stage = "clarify"
 	StageChecklist    Stage = "checklist"
 	StageAnalyze      Stage = "analyze"
+
+	// Post-implement stages
+	StageVerify Stage = "verify" // Quality gates (see verification.RunGates)
+	StageReview Stage = "review" // Adversarial review of the implementation diff
+
+	// Multi-spec stages
+	StageDAGPlan Stage = "dag-plan" // DAG file generation from a roadmap
 )
 
 // debugLog prints a debug message if debug mode is enabled
@@ -60,8 +78,12 @@ func (e *Executor) debugLog(format string, args ...interface{}) {
 
 // getStageNumber returns the sequential number for a stage (1-based)
 // For optional stages, this returns their position in the canonical order:
-// constitution(1) -> specify(2) -> clarify(3) -> plan(4) -> tasks(5) -> checklist(6) -> analyze(7) -> implement(8)
+// constitution(1) -> specify(2) -> clarify(3) -> plan(4) -> tasks(5) -> checklist(6) -> analyze(7) -> implement(8) -> verify(9) -> review(10)
+// StageNumbers takes precedence, which is how custom stages get a number.
 func (e *Executor) getStageNumber(stage Stage) int {
+	if number, ok := e.StageNumbers[stage]; ok {
+		return number
+	}
 	switch stage {
 	case StageConstitution:
 		return 1
@@ -79,6 +101,10 @@ func (e *Executor) getStageNumber(stage Stage) int {
 		return 7
 	case StageImplement:
 		return 8
+	case StageVerify:
+		return 9
+	case StageReview:
+		return 10
 	default:
 		return 0
 	}
@@ -103,7 +129,9 @@ type StageResult struct {
 	Error            error
 	RetryCount       int
 	Exhausted        bool
-	ValidationErrors []string // Schema validation errors for retry context
+	ValidationErrors []string       // Schema validation errors for retry context
+	Usage            cliagent.Usage // Token and cost usage summed across all attempts
+	Agent            string         // Agent that completed the final attempt (empty if unknown)
 }
 
 // ExecuteStage executes a workflow stage with validation and retry logic.
@@ -120,7 +148,23 @@ type StageResult struct {
 //
 // The retry mechanism injects validation errors into subsequent commands,
 // allowing Claude to self-correct based on previous failures.
+// Token and cost usage is summed across attempts into StageResult.Usage and
+// persisted per spec stage next to the retry state.
 func (e *Executor) ExecuteStage(specName string, stage Stage, command string, validateFunc func(string) error) (*StageResult, error) {
+	return e.executeStage(specName, stage, command, nil, validateFunc)
+}
+
+// ExecuteStageWithFailures executes a stage whose output already failed a check
+// made outside the stage (e.g., quality gates run after implement).
+// The failures count as a failed validation: the first attempt is a retry with
+// the failures injected via FormatRetryContext, and later attempts are driven
+// by validateFunc exactly as in ExecuteStage.
+func (e *Executor) ExecuteStageWithFailures(specName string, stage Stage, command string, failures []string, validateFunc func(string) error) (*StageResult, error) {
+	return e.executeStage(specName, stage, command, failures, validateFunc)
+}
+
+// executeStage implements ExecuteStage, seeding the retry loop with prior failures if any.
+func (e *Executor) executeStage(specName string, stage Stage, command string, priorFailures []string, validateFunc func(string) error) (*StageResult, error) {
 	e.debugLog("ExecuteStage called - spec: %s, stage: %s, command: %s", specName, stage, command)
 	result := &StageResult{Stage: stage, Success: false}
 
@@ -142,9 +186,23 @@ func (e *Executor) ExecuteStage(specName string, stage Stage, command string, va
 		result:         result,
 		retryState:     retryState,
 		interactive:    IsInteractive(stage),
+		runner:         e.runnerFor(stage),
 	}
 
-	return e.executeStageLoop(ctx)
+	e.publishStageStarted(ctx)
+	start := time.Now()
+	if len(priorFailures) > 0 {
+		result, err = e.retryAfterFailures(ctx, priorFailures)
+	} else {
+		result, err = e.executeStageLoop(ctx)
+	}
+	e.publishStageCompleted(ctx, err, time.Since(start))
+	e.recordUsage(specName, retry.StageUsageScope(string(stage)), result.Usage, result.Agent)
+	if result.Success {
+		e.stampGenerator(specName, stage, result.Agent)
+		e.stampSchemaModeline(specName, stage)
+	}
+	return result, err
 }
 
 // stageExecutionContext holds state for stage execution loop
@@ -157,7 +215,21 @@ type stageExecutionContext struct {
 	result               *StageResult
 	retryState           *retry.RetryState
 	lastValidationErrors []string
-	interactive          bool // When true, skip retry loop and use interactive mode
+	interactive          bool         // When true, skip retry loop and use interactive mode
+	runner               ClaudeRunner // Runner for this stage (see runnerFor)
+}
+
+// runnerFor returns the runner configured for stage in StageRunners,
+// falling back to Claude. The verify stage re-runs the implement command,
+// so it uses the implement runner.
+func (e *Executor) runnerFor(stage Stage) ClaudeRunner {
+	if stage == StageVerify {
+		stage = StageImplement
+	}
+	if runner, ok := e.StageRunners[stage]; ok && runner != nil {
+		return runner
+	}
+	return e.Claude
 }
 
 // executeStageLoop runs the retry loop for stage execution.
@@ -165,11 +237,20 @@ type stageExecutionContext struct {
 func (e *Executor) executeStageLoop(ctx *stageExecutionContext) (*StageResult, error) {
 	// Interactive mode: execute once, no retry loop
 	if ctx.interactive {
+		if err := e.checkBudget(ctx); err != nil {
+			ctx.result.Error = err
+			return ctx.result, err
+		}
 		return e.executeInteractiveStage(ctx)
 	}
 
 	for {
 		stageInfo := e.buildStageInfo(ctx.stage, ctx.retryState.Count)
+		if err := e.checkBudget(ctx); err != nil {
+			ctx.result.Error = err
+			e.failStageProgress(stageInfo, err)
+			return ctx.result, err
+		}
 		e.startProgressDisplay(stageInfo)
 
 		stageErr, validationErr := e.executeStageAttempt(ctx, stageInfo)
@@ -187,13 +268,27 @@ func (e *Executor) executeStageLoop(ctx *stageExecutionContext) (*StageResult, e
 	}
 }
 
+// retryAfterFailures records failures as the last validation errors and enters
+// the retry loop as if an attempt had just failed validation with them.
+func (e *Executor) retryAfterFailures(ctx *stageExecutionContext, failures []string) (*StageResult, error) {
+	ctx.result.ValidationErrors = failures
+	ctx.lastValidationErrors = failures
+
+	failureErr := fmt.Errorf("%s checks failed:\n- %s", ctx.stage, strings.Join(failures, "\n- "))
+	stageInfo := e.buildStageInfo(ctx.stage, ctx.retryState.Count)
+	if done, err := e.handleStageRetry(ctx, stageInfo, failureErr); done {
+		return ctx.result, err
+	}
+	return e.executeStageLoop(ctx)
+}
+
 // executeInteractiveStage runs a stage in interactive mode without retry loop.
 // Interactive stages skip validation and rely on user conversation.
 func (e *Executor) executeInteractiveStage(ctx *stageExecutionContext) (*StageResult, error) {
 	e.debugLog("Executing interactive stage: %s", ctx.stage)
 
-	e.displayInteractiveCommandExecution(ctx.currentCommand)
-	if err := e.Claude.ExecuteInteractive(ctx.currentCommand); err != nil {
+	e.displayInteractiveCommandExecution(ctx.runner, ctx.currentCommand)
+	if err := ctx.runner.ExecuteInteractive(ctx.currentCommand); err != nil {
 		output.PrintAgentOutputEnd(os.Stdout)
 		ctx.result.Error = fmt.Errorf("interactive session failed: %w", err)
 		return ctx.result, ctx.result.Error
@@ -208,8 +303,13 @@ func (e *Executor) executeInteractiveStage(ctx *stageExecutionContext) (*StageRe
 // executeStageAttempt executes a single attempt of a stage
 func (e *Executor) executeStageAttempt(ctx *stageExecutionContext, stageInfo progress.StageInfo) (stageErr, validationErr error) {
 	_ = lifecycle.RunStage(e.NotificationHandler, string(ctx.stage), func() error {
-		e.displayCommandExecution(ctx.currentCommand)
-		if err := e.Claude.Execute(ctx.currentCommand); err != nil {
+		e.displayCommandExecution(ctx.runner, ctx.currentCommand)
+		err := ctx.runner.Execute(ctx.currentCommand)
+		usage := lastUsage(ctx.runner)
+		ctx.result.Usage.Add(usage)
+		e.runUsage.Add(usage)
+		ctx.result.Agent = lastAgent(ctx.runner)
+		if err != nil {
 			output.PrintAgentOutputEnd(os.Stdout)
 			stageErr = e.handleExecutionFailure(ctx.result, ctx.retryState, stageInfo, err)
 			return stageErr
@@ -222,6 +322,7 @@ func (e *Executor) executeStageAttempt(ctx *stageExecutionContext, stageInfo pro
 			validationErr = err
 			ctx.result.ValidationErrors = ExtractValidationErrors(err)
 			ctx.lastValidationErrors = ctx.result.ValidationErrors
+			e.publishValidationFailed(ctx)
 			e.debugLog("Validation failed: %v", err)
 			return err
 		}
@@ -233,6 +334,140 @@ func (e *Executor) executeStageAttempt(ctx *stageExecutionContext, stageInfo pro
 	return stageErr, validationErr
 }
 
+// lastUsage returns usage from the runner's most recent execution, if reported.
+func lastUsage(runner ClaudeRunner) cliagent.Usage {
+	if reporter, ok := runner.(UsageReporter); ok {
+		return reporter.LastUsage()
+	}
+	return cliagent.Usage{}
+}
+
+// configuredAgent returns the runner's primary agent, if reported.
+func configuredAgent(runner ClaudeRunner) string {
+	if reporter, ok := runner.(AgentReporter); ok {
+		return reporter.ConfiguredAgent()
+	}
+	return ""
+}
+
+// lastAgent returns the agent that completed the runner's most recent execution, if reported.
+func lastAgent(runner ClaudeRunner) string {
+	if reporter, ok := runner.(AgentReporter); ok {
+		return reporter.LastAgent()
+	}
+	return ""
+}
+
+// stampGenerator records the producing agent in _meta.generator of each
+// artifact the stage produces when a fallback agent took over from the
+// configured one. Failures are non-fatal.
+func (e *Executor) stampGenerator(specName string, stage Stage, agent string) {
+	if specName == "" || agent == "" || agent == configuredAgent(e.runnerFor(stage)) {
+		return
+	}
+	for _, artifact := range GetProducedArtifacts(stage) {
+		path := filepath.Join(e.SpecsDir, specName, artifact)
+		if err := yaml.SetMetaGenerator(path, GeneratorName(agent)); err != nil {
+			e.debugLog("Failed to stamp generator on %s: %v", path, err)
+		}
+	}
+}
+
+// stampSchemaModeline points the yaml-language-server modeline of the
+// artifacts a stage produced at their JSON Schemas, writing the schemas to
+// validation.JSONSchemaDir. Failures are logged, not returned: the modeline is
+// an editor convenience.
+func (e *Executor) stampSchemaModeline(specName string, stage Stage) {
+	if !e.SchemaModeline || specName == "" {
+		return
+	}
+	for _, artifact := range GetProducedArtifacts(stage) {
+		artType, err := validation.InferArtifactTypeFromFilename(artifact)
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(e.SpecsDir, specName, artifact)
+		if err := validation.StampSchemaModeline(path, artType); err != nil {
+			e.debugLog("Failed to add schema modeline to %s: %v", path, err)
+		}
+	}
+}
+
+// GeneratorName returns the _meta.generator value for artifacts produced by agent.
+func GeneratorName(agent string) string {
+	return "autospec/" + agent
+}
+
+// checkBudget returns a BudgetError if stage, spec, or run spending has reached
+// its configured limit. It runs before every agent session so a runaway retry
+// loop stops before spending more; retry state is not consumed.
+func (e *Executor) checkBudget(ctx *stageExecutionContext) error {
+	// The current stage is persisted only when it finishes, so its usage is unrecorded
+	return e.checkBudgetLimits(ctx.stage, ctx.specName, ctx.result.Usage, ctx.result.Usage)
+}
+
+// checkBudgetLimits checks stage, spec, and run spending against the budget.
+// stageUsage is what the stage has spent so far; unrecorded is the part of it
+// not yet persisted to the spec's usage state.
+func (e *Executor) checkBudgetLimits(stage Stage, specName string, stageUsage, unrecorded cliagent.Usage) error {
+	if !e.Budget.Enabled() {
+		return nil
+	}
+
+	err := e.Budget.CheckStage(string(stage), stageUsage)
+	if err == nil && specName != "" && e.Budget.HasSpecLimit() {
+		spent := unrecorded
+		if total, loadErr := retry.SpecUsageTotal(e.StateDir, specName); loadErr == nil && total != nil {
+			spent.Add(usageFromState(total))
+		}
+		err = e.Budget.CheckSpec(specName, spent)
+	}
+	if err == nil {
+		err = e.Budget.CheckRun("", e.runUsage)
+	}
+	if err == nil {
+		return nil
+	}
+
+	exceeded, _ := budget.AsExceeded(err)
+	e.debugLog("Budget limit reached: %v", exceeded)
+	return &BudgetError{Stage: stage, Spec: specName, Err: exceeded}
+}
+
+// usageFromState converts persisted usage counters to cliagent.Usage.
+func usageFromState(u *retry.UsageState) cliagent.Usage {
+	return cliagent.Usage{
+		InputTokens:              u.InputTokens,
+		OutputTokens:             u.OutputTokens,
+		CacheCreationInputTokens: u.CacheCreationInputTokens,
+		CacheReadInputTokens:     u.CacheReadInputTokens,
+		CostUSD:                  u.CostUSD,
+	}
+}
+
+// recordUsage persists usage and the producing agent for a spec scope alongside
+// retry state. Skips unnamed specs and executions that reported neither;
+// persistence errors are non-fatal.
+func (e *Executor) recordUsage(specName, scope string, usage cliagent.Usage, agent string) {
+	if specName == "" || (usage.IsZero() && agent == "") {
+		return
+	}
+	delta := &retry.UsageState{
+		SpecName:                 specName,
+		Scope:                    scope,
+		InputTokens:              usage.InputTokens,
+		OutputTokens:             usage.OutputTokens,
+		CacheCreationInputTokens: usage.CacheCreationInputTokens,
+		CacheReadInputTokens:     usage.CacheReadInputTokens,
+		CostUSD:                  usage.CostUSD,
+		Sessions:                 1,
+		Agent:                    agent,
+	}
+	if err := retry.RecordUsage(e.StateDir, delta); err != nil {
+		e.debugLog("Failed to record usage: %v", err)
+	}
+}
+
 // handleStageRetry handles retry logic after validation failure
 // Returns (done bool, err error) - done=true means stop the loop
 func (e *Executor) handleStageRetry(ctx *stageExecutionContext, stageInfo progress.StageInfo, validationErr error) (bool, error) {
@@ -254,6 +489,7 @@ func (e *Executor) handleStageRetry(ctx *stageExecutionContext, stageInfo progre
 	retryContext := FormatRetryContext(ctx.retryState.Count, e.MaxRetries, ctx.lastValidationErrors)
 	ctx.currentCommand = BuildRetryCommand(ctx.command, retryContext, "")
 	ctx.result.RetryCount = ctx.retryState.Count
+	e.publishStageRetried(ctx)
 
 	e.debugLog("Retrying (attempt %d/%d) with error context", ctx.retryState.Count, e.MaxRetries)
 	fmt.Printf("\n⟳ Retry %d/%d - injecting validation errors into command\n", ctx.retryState.Count, e.MaxRetries)
@@ -297,13 +533,13 @@ func (e *Executor) startProgressDisplay(stageInfo progress.StageInfo) {
 // Compact tags [+Name] are shown for injected instructions.
 // In debug mode, shows [+Name: hint] if a DisplayHint is present and full prompt is shown.
 // In normal mode, long prompts are truncated for display (full content is still sent to agent).
-func (e *Executor) displayCommandExecution(command string) {
+func (e *Executor) displayCommandExecution(runner ClaudeRunner, command string) {
 	compactedCommand := CompactInstructionsForDisplay(command, e.Debug)
 	displayCommand := compactedCommand
 	if !e.Debug {
 		displayCommand = TruncatePromptForDisplay(compactedCommand)
 	}
-	fullCommand := e.Claude.FormatCommand(displayCommand)
+	fullCommand := runner.FormatCommand(displayCommand)
 	output.PrintExecutingCommand(os.Stdout, fullCommand)
 	e.debugLog("About to call Claude.Execute()")
 }
@@ -312,26 +548,26 @@ func (e *Executor) displayCommandExecution(command string) {
 // Interactive mode uses positional argument without -p flag for multi-turn conversation.
 // In normal mode, long prompts are truncated for display (full content is still sent to agent).
 // In debug mode, full prompt is shown.
-func (e *Executor) displayInteractiveCommandExecution(command string) {
+func (e *Executor) displayInteractiveCommandExecution(runner ClaudeRunner, command string) {
 	compactedCommand := CompactInstructionsForDisplay(command, e.Debug)
 	displayCommand := compactedCommand
 	if !e.Debug {
 		displayCommand = TruncatePromptForDisplay(compactedCommand)
 	}
-	fullCommand := e.formatInteractiveCommand(displayCommand)
+	fullCommand := formatInteractiveCommand(runner, displayCommand)
 	fmt.Printf("\n→ Executing (interactive): %s\n\n", fullCommand)
 	e.debugLog("About to call Claude.ExecuteInteractive()")
 }
 
 // formatInteractiveCommand returns the command string for interactive mode.
 // Interactive mode uses positional argument (no -p, no --output-format stream-json).
-func (e *Executor) formatInteractiveCommand(prompt string) string {
-	if e.Claude == nil {
+func formatInteractiveCommand(runner ClaudeRunner, prompt string) string {
+	if runner == nil {
 		return "[no agent configured]"
 	}
 	// For Claude, interactive mode is: claude <prompt> (positional, no -p flag)
 	// We get the agent name and append the prompt directly
-	ce, ok := e.Claude.(*ClaudeExecutor)
+	ce, ok := runner.(*ClaudeExecutor)
 	if !ok || ce.Agent == nil {
 		return fmt.Sprintf("claude %s", prompt)
 	}
@@ -468,13 +704,14 @@ func (e *Executor) completeStageSuccessNoNotify(result *StageResult, stageInfo p
 	e.debugLog("ExecuteStage completed successfully - returning")
 }
 
-// ExecuteWithRetry executes a command and automatically retries on failure
-// This is a simplified version that doesn't require stage tracking
-func (e *Executor) ExecuteWithRetry(command string, maxAttempts int) error {
+// ExecuteWithRetry executes a command on the stage's runner and automatically
+// retries on failure. This is a simplified version without retry state tracking.
+func (e *Executor) ExecuteWithRetry(stage Stage, command string, maxAttempts int) error {
 	var lastErr error
 
+	runner := e.runnerFor(stage)
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
-		err := e.Claude.Execute(command)
+		err := runner.Execute(command)
 		if err == nil {
 			return nil
 		}
@@ -518,6 +755,11 @@ func (e *Executor) ValidateConstitution(projectDir string) error {
 	return validation.ValidateConstitutionFile(projectDir)
 }
 
+// ValidateReviewResolved checks that review.yaml has no open critical findings
+func (e *Executor) ValidateReviewResolved(reviewPath string) error {
+	return validation.ValidateReviewResolved(reviewPath)
+}
+
 // ValidateTasksComplete checks if all tasks are completed
 // Supports both YAML (status field) and Markdown (checkbox) formats
 func (e *Executor) ValidateTasksComplete(tasksPath string) error {
@@ -2,14 +2,19 @@ package workflow
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/ariel-frischer/autospec/internal/budget"
 	"github.com/ariel-frischer/autospec/internal/cliagent"
 	"github.com/ariel-frischer/autospec/internal/progress"
 	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/ariel-frischer/autospec/internal/yaml"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -286,7 +291,7 @@ func TestExecuteWithRetry_Success(t *testing.T) {
 		Claude: testClaudeExecutor(t, "success"),
 	}
 
-	err := executor.ExecuteWithRetry("/test.command", 3)
+	err := executor.ExecuteWithRetry(StagePlan, "/test.command", 3)
 	assert.NoError(t, err)
 }
 
@@ -295,12 +300,30 @@ func TestExecuteWithRetry_AllAttemptsFail(t *testing.T) {
 		Claude: testClaudeExecutorWithCmd(t, "false"), // Command that always fails
 	}
 
-	err := executor.ExecuteWithRetry("/test.command", 2)
+	err := executor.ExecuteWithRetry(StagePlan, "/test.command", 2)
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "all 2 attempts failed")
 }
 
+// TestExecuteWithRetry_UsesStageRunner verifies ExecuteWithRetry runs on the
+// stage's runner from StageRunners instead of the default runner.
+func TestExecuteWithRetry_UsesStageRunner(t *testing.T) {
+	base := NewMockClaudeExecutor()
+	planRunner := NewMockClaudeExecutor()
+	executor := &Executor{
+		Claude:       base,
+		StageRunners: map[Stage]ClaudeRunner{StagePlan: planRunner},
+	}
+
+	require.NoError(t, executor.ExecuteWithRetry(StagePlan, "/autospec.plan", 1))
+	assert.Len(t, planRunner.ExecuteCalls, 1)
+	assert.Empty(t, base.ExecuteCalls)
+
+	require.NoError(t, executor.ExecuteWithRetry(StageTasks, "/autospec.tasks", 1))
+	assert.Len(t, base.ExecuteCalls, 1)
+}
+
 func TestGetRetryState(t *testing.T) {
 	stateDir := t.TempDir()
 
@@ -1579,7 +1602,7 @@ func TestExecuteWithRetry_MockClaudeRunner(t *testing.T) {
 				},
 			}
 
-			err := executor.ExecuteWithRetry("/test.command", tc.maxAttempts)
+			err := executor.ExecuteWithRetry(StagePlan, "/test.command", tc.maxAttempts)
 
 			if tc.wantErr {
 				require.Error(t, err)
@@ -2006,3 +2029,314 @@ func TestTruncatePromptForDisplay(t *testing.T) {
 		})
 	}
 }
+
+// usageReportingRunner wraps MockClaudeExecutor and reports fixed usage per call.
+type usageReportingRunner struct {
+	*MockClaudeExecutor
+	usage cliagent.Usage
+}
+
+func (r *usageReportingRunner) LastUsage() cliagent.Usage {
+	return r.usage
+}
+
+// TestExecuteStage_RollsUpUsage verifies usage is summed across retry attempts
+// and persisted per spec stage.
+func TestExecuteStage_RollsUpUsage(t *testing.T) {
+	stateDir := t.TempDir()
+	specsDir := t.TempDir()
+
+	runner := &usageReportingRunner{
+		MockClaudeExecutor: NewMockClaudeExecutor(),
+		usage:              cliagent.Usage{InputTokens: 100, OutputTokens: 10, CostUSD: 0.5},
+	}
+	executor := &Executor{
+		Claude:     runner,
+		StateDir:   stateDir,
+		SpecsDir:   specsDir,
+		MaxRetries: 3,
+	}
+
+	// Fail validation once, then succeed
+	attempts := 0
+	validateFunc := func(dir string) error {
+		attempts++
+		if attempts == 1 {
+			return errors.New("validation failed")
+		}
+		return nil
+	}
+
+	result, err := executor.ExecuteStage("001-test", StagePlan, "/autospec.plan", validateFunc)
+	require.NoError(t, err)
+	assert.Equal(t, 220, result.Usage.TotalTokens())
+	assert.InDelta(t, 1.0, result.Usage.CostUSD, 1e-9)
+
+	total, err := retry.SpecUsageTotal(stateDir, "001-test")
+	require.NoError(t, err)
+	assert.Equal(t, 220, total.TotalTokens())
+	assert.Equal(t, 1, total.Sessions)
+}
+
+// TestExecuteStage_NoUsageReporter verifies runners without usage support record nothing.
+func TestExecuteStage_NoUsageReporter(t *testing.T) {
+	stateDir := t.TempDir()
+
+	executor := &Executor{
+		Claude:     NewMockClaudeExecutor(),
+		StateDir:   stateDir,
+		SpecsDir:   t.TempDir(),
+		MaxRetries: 1,
+	}
+
+	result, err := executor.ExecuteStage("001-test", StagePlan, "/autospec.plan", func(string) error { return nil })
+	require.NoError(t, err)
+	assert.True(t, result.Usage.IsZero())
+
+	entries, err := retry.LoadUsage(stateDir, "001-test")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+// agentReportingRunner wraps MockClaudeExecutor and reports a fixed producing
+// agent; configured defaults to agent (no fallback).
+type agentReportingRunner struct {
+	*MockClaudeExecutor
+	agent      string
+	configured string
+}
+
+func (r *agentReportingRunner) LastAgent() string {
+	return r.agent
+}
+
+func (r *agentReportingRunner) ConfiguredAgent() string {
+	if r.configured == "" {
+		return r.agent
+	}
+	return r.configured
+}
+
+// TestExecuteStage_RecordsProducingAgent verifies the fallback agent that
+// produced a stage is persisted with usage and stamped into the artifact's
+// _meta.generator.
+func TestExecuteStage_RecordsProducingAgent(t *testing.T) {
+	stateDir := t.TempDir()
+	specsDir := t.TempDir()
+	specDir := filepath.Join(specsDir, "001-test")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	planPath := filepath.Join(specDir, "plan.yaml")
+	require.NoError(t, os.WriteFile(planPath, []byte("_meta:\n  version: \"1.0.0\"\n  generator: \"autospec\"\nplan:\n  branch: test\n"), 0o644))
+
+	executor := &Executor{
+		Claude:     &agentReportingRunner{MockClaudeExecutor: NewMockClaudeExecutor(), agent: "codex", configured: "claude"},
+		StateDir:   stateDir,
+		SpecsDir:   specsDir,
+		MaxRetries: 1,
+	}
+
+	result, err := executor.ExecuteStage("001-test", StagePlan, "/autospec.plan", func(string) error { return nil })
+	require.NoError(t, err)
+	assert.Equal(t, "codex", result.Agent)
+
+	agents := retry.StageAgentsSince(stateDir, "001-test", time.Time{})
+	assert.Equal(t, map[string]string{"plan": "codex"}, agents)
+
+	data, err := os.ReadFile(planPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `generator: "autospec/codex"`)
+	assert.Contains(t, string(data), "plan:\n  branch: test")
+}
+
+// TestExecuteStage_GeneratorUnchangedWithoutFallback verifies _meta.generator
+// is left as written when the configured agent produced the artifact.
+func TestExecuteStage_GeneratorUnchangedWithoutFallback(t *testing.T) {
+	specsDir := t.TempDir()
+	specDir := filepath.Join(specsDir, "001-test")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	planPath := filepath.Join(specDir, "plan.yaml")
+	content := "_meta:\n  version: \"1.0.0\"\n  generator: \"autospec\"\nplan:\n  branch: test\n"
+	require.NoError(t, os.WriteFile(planPath, []byte(content), 0o644))
+
+	executor := &Executor{
+		Claude:     &agentReportingRunner{MockClaudeExecutor: NewMockClaudeExecutor(), agent: "claude"},
+		StateDir:   t.TempDir(),
+		SpecsDir:   specsDir,
+		MaxRetries: 1,
+	}
+
+	result, err := executor.ExecuteStage("001-test", StagePlan, "/autospec.plan", func(string) error { return nil })
+	require.NoError(t, err)
+	assert.Equal(t, "claude", result.Agent)
+
+	data, err := os.ReadFile(planPath)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+}
+
+// TestExecuteStage_BudgetStopsRetries verifies that a budget limit stops the
+// retry loop before another agent session starts and leaves retry state intact.
+func TestExecuteStage_BudgetStopsRetries(t *testing.T) {
+	tests := map[string]struct {
+		budget    budget.Config
+		priorCost float64
+		wantCalls int
+		wantScope budget.Scope
+	}{
+		"stage cost limit stops retry": {
+			budget:    budget.Config{MaxStageCostUSD: 1.0},
+			wantCalls: 2,
+			wantScope: budget.ScopeStage,
+		},
+		"stage token limit stops retry": {
+			budget:    budget.Config{MaxStageTokens: 150},
+			wantCalls: 2,
+			wantScope: budget.ScopeStage,
+		},
+		"spec limit includes previously recorded usage": {
+			budget:    budget.Config{MaxSpecCostUSD: 2.0},
+			priorCost: 1.5,
+			wantCalls: 1,
+			wantScope: budget.ScopeSpec,
+		},
+		"run limit stops retry": {
+			budget:    budget.Config{MaxRunCostUSD: 0.5},
+			wantCalls: 1,
+			wantScope: budget.ScopeRun,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			stateDir := t.TempDir()
+			if tt.priorCost > 0 {
+				require.NoError(t, retry.RecordUsage(stateDir, &retry.UsageState{
+					SpecName: "001-test", Scope: retry.StageUsageScope("specify"), CostUSD: tt.priorCost,
+				}))
+			}
+
+			runner := &usageReportingRunner{
+				MockClaudeExecutor: NewMockClaudeExecutor(),
+				usage:              cliagent.Usage{InputTokens: 100, CostUSD: 0.5},
+			}
+			executor := &Executor{
+				Claude:     runner,
+				StateDir:   stateDir,
+				SpecsDir:   t.TempDir(),
+				MaxRetries: 5,
+				Budget:     tt.budget,
+			}
+
+			// Validation always fails so only the budget can stop the loop
+			result, err := executor.ExecuteStage("001-test", StagePlan, "/autospec.plan", func(string) error {
+				return errors.New("validation failed")
+			})
+			require.Error(t, err)
+
+			var budgetErr *BudgetError
+			require.ErrorAs(t, err, &budgetErr)
+			assert.Equal(t, StagePlan, budgetErr.Stage)
+			assert.Equal(t, tt.wantScope, budgetErr.Err.Scope)
+			assert.Len(t, runner.ExecuteCalls, tt.wantCalls)
+			assert.False(t, result.Exhausted, "budget stop should not exhaust retries")
+
+			// Usage for the attempts that ran is still recorded
+			total, loadErr := retry.SpecUsageTotal(stateDir, "001-test")
+			require.NoError(t, loadErr)
+			assert.InDelta(t, tt.priorCost+0.5*float64(tt.wantCalls), total.CostUSD, 1e-9)
+		})
+	}
+}
+
+// TestExecuteStage_RunBudgetSpansStages verifies the per-run budget accumulates
+// across stages executed by the same executor.
+func TestExecuteStage_RunBudgetSpansStages(t *testing.T) {
+	runner := &usageReportingRunner{
+		MockClaudeExecutor: NewMockClaudeExecutor(),
+		usage:              cliagent.Usage{OutputTokens: 600},
+	}
+	executor := &Executor{
+		Claude:     runner,
+		StateDir:   t.TempDir(),
+		SpecsDir:   t.TempDir(),
+		MaxRetries: 1,
+		Budget:     budget.Config{MaxRunTokens: 1000},
+	}
+	pass := func(string) error { return nil }
+
+	_, err := executor.ExecuteStage("001-test", StagePlan, "/autospec.plan", pass)
+	require.NoError(t, err)
+	_, err = executor.ExecuteStage("001-test", StageTasks, "/autospec.tasks", pass)
+	require.NoError(t, err)
+
+	_, err = executor.ExecuteStage("001-test", StageImplement, "/autospec.implement", pass)
+	var budgetErr *BudgetError
+	require.ErrorAs(t, err, &budgetErr)
+	assert.Equal(t, StageImplement, budgetErr.Stage)
+	assert.Equal(t, budget.ScopeRun, budgetErr.Err.Scope)
+	assert.Len(t, runner.ExecuteCalls, 2)
+}
+
+// TestExecuteStage_UsesStageRunner verifies stages with a StageRunners entry
+// run on that runner while other stages use Claude.
+func TestExecuteStage_UsesStageRunner(t *testing.T) {
+	base := NewMockClaudeExecutor()
+	tasksRunner := &agentReportingRunner{MockClaudeExecutor: NewMockClaudeExecutor(), agent: "gemini"}
+	executor := &Executor{
+		Claude:       base,
+		StageRunners: map[Stage]ClaudeRunner{StageTasks: tasksRunner},
+		StateDir:     t.TempDir(),
+		SpecsDir:     t.TempDir(),
+		MaxRetries:   1,
+	}
+	validate := func(string) error { return nil }
+
+	result, err := executor.ExecuteStage("001-test", StageTasks, "/autospec.tasks", validate)
+	require.NoError(t, err)
+	assert.Equal(t, "gemini", result.Agent)
+
+	_, err = executor.ExecuteStage("001-test", StagePlan, "/autospec.plan", validate)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"/autospec.tasks"}, tasksRunner.ExecuteCalls)
+	assert.Equal(t, []string{"/autospec.plan"}, base.ExecuteCalls)
+}
+
+// TestExecuteStage_SchemaModeline verifies produced artifacts get a
+// yaml-language-server modeline pointing at a JSON Schema in the project.
+func TestExecuteStage_SchemaModeline(t *testing.T) {
+	tmpDir := t.TempDir()
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	specDir := filepath.Join("specs", "001-test")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	planPath := filepath.Join(specDir, "plan.yaml")
+	require.NoError(t, os.WriteFile(planPath, []byte("plan:\n  branch: test\n"), 0o644))
+
+	// Disabled runs first so the artifact is still untouched
+	for _, enabled := range []bool{false, true} {
+		t.Run(fmt.Sprintf("enabled=%v", enabled), func(t *testing.T) {
+			executor := &Executor{
+				Claude:         NewMockClaudeExecutor(),
+				StateDir:       t.TempDir(),
+				SpecsDir:       "specs",
+				MaxRetries:     1,
+				SchemaModeline: enabled,
+			}
+			_, err := executor.ExecuteStage("001-test", StagePlan, "/autospec.plan", func(string) error { return nil })
+			require.NoError(t, err)
+
+			data, err := os.ReadFile(planPath)
+			require.NoError(t, err)
+			if !enabled {
+				assert.Equal(t, "plan:\n  branch: test\n", string(data))
+				return
+			}
+			assert.Equal(t, yaml.SchemaModelinePrefix+"../../.autospec/schemas/json/plan.schema.json\nplan:\n  branch: test\n", string(data))
+			assert.FileExists(t, validation.JSONSchemaPath("plan"))
+		})
+	}
+}
@@ -3,7 +3,10 @@
 // Tags: workflow, interfaces, dependency-injection, executors
 package workflow
 
-import "github.com/ariel-frischer/autospec/internal/validation"
+import (
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/ariel-frischer/autospec/internal/validation"
+)
 
 // ClaudeRunner abstracts Claude command execution for testability.
 // This interface enables mocking Claude commands in unit tests without
@@ -38,10 +41,30 @@ type ClaudeRunner interface {
 	FormatCommand(prompt string) string
 }
 
+// UsageReporter is optionally implemented by ClaudeRunner implementations
+// that can report token and cost usage for the most recent execution.
+// Executor type-asserts for this interface to roll usage up per stage.
+type UsageReporter interface {
+	// LastUsage returns usage reported by the most recent Execute call.
+	LastUsage() cliagent.Usage
+}
+
+// AgentReporter is optionally implemented by ClaudeRunner implementations
+// that can fall back to other agents. Executor type-asserts for this interface
+// to record which agent produced each stage's artifacts.
+type AgentReporter interface {
+	// LastAgent returns the name of the agent that completed the most recent
+	// Execute call, or empty if it failed.
+	LastAgent() string
+	// ConfiguredAgent returns the name of the agent that runs when no
+	// fallback takes over.
+	ConfiguredAgent() string
+}
+
 // StageExecutorInterface defines the contract for stage execution (specify, plan, tasks).
 // Implementations handle the core workflow stages that transform feature descriptions into
 // specifications, plans, and task breakdowns. Also handles auxiliary stages like constitution,
-// clarify, checklist, and analyze.
+// clarify, checklist, analyze, and review.
 //
 // Design rationale: Narrow interface following Go idiom "accept interfaces, return concrete types"
 // to enable focused mocking in unit tests without coupling to implementation details.
@@ -76,6 +99,16 @@ type StageExecutorInterface interface {
 	// ExecuteAnalyze runs the analyze stage with optional prompt.
 	// Analyze performs cross-artifact consistency and quality analysis.
 	ExecuteAnalyze(specName string, prompt string) error
+
+	// ExecuteReview runs the review stage with optional prompt.
+	// Review adversarially checks the implementation diff against spec acceptance
+	// criteria and writes a schema-validated review.yaml.
+	ExecuteReview(specName string, prompt string) error
+
+	// ExecuteCustom runs a config-defined custom stage with optional prompt.
+	// The stage's produced artifacts are checked, and validated against its
+	// schema when one is configured.
+	ExecuteCustom(specName string, stage CustomStage, prompt string) error
 }
 
 // PhaseExecutorInterface defines the contract for phase-based implementation execution.
@@ -153,6 +186,9 @@ var (
 	// Verify ClaudeExecutor satisfies ClaudeRunner
 	_ ClaudeRunner = (*ClaudeExecutor)(nil)
 
+	// Verify ClaudeExecutor satisfies UsageReporter
+	_ UsageReporter = (*ClaudeExecutor)(nil)
+
 	// Verify StageExecutor satisfies StageExecutorInterface
 	_ StageExecutorInterface = (*StageExecutor)(nil)
 
@@ -270,6 +270,8 @@ type MockStageExecutor struct {
 	ClarifyError      error
 	ChecklistError    error
 	AnalyzeError      error
+	ReviewError       error
+	CustomError       error
 
 	// Call tracking
 	SpecifyCalls      []string // Feature descriptions
@@ -279,6 +281,8 @@ type MockStageExecutor struct {
 	ClarifyCalls      []ClarifyCall
 	ChecklistCalls    []ChecklistCall
 	AnalyzeCalls      []AnalyzeCall
+	ReviewCalls       []ReviewCall
+	CustomCalls       []CustomCall
 }
 
 // PlanCall records a call to ExecutePlan.
@@ -311,6 +315,19 @@ type AnalyzeCall struct {
 	Prompt   string
 }
 
+// ReviewCall records a call to ExecuteReview.
+type ReviewCall struct {
+	SpecName string
+	Prompt   string
+}
+
+// CustomCall records a call to ExecuteCustom.
+type CustomCall struct {
+	SpecName string
+	Stage    CustomStage
+	Prompt   string
+}
+
 // NewMockStageExecutor creates a new MockStageExecutor with default success behavior.
 func NewMockStageExecutor() *MockStageExecutor {
 	return &MockStageExecutor{
@@ -322,6 +339,8 @@ func NewMockStageExecutor() *MockStageExecutor {
 		ClarifyCalls:      make([]ClarifyCall, 0),
 		ChecklistCalls:    make([]ChecklistCall, 0),
 		AnalyzeCalls:      make([]AnalyzeCall, 0),
+		ReviewCalls:       make([]ReviewCall, 0),
+		CustomCalls:       make([]CustomCall, 0),
 	}
 }
 
@@ -367,6 +386,18 @@ func (m *MockStageExecutor) ExecuteAnalyze(specName string, prompt string) error
 	return m.AnalyzeError
 }
 
+// ExecuteReview implements StageExecutorInterface.
+func (m *MockStageExecutor) ExecuteReview(specName string, prompt string) error {
+	m.ReviewCalls = append(m.ReviewCalls, ReviewCall{SpecName: specName, Prompt: prompt})
+	return m.ReviewError
+}
+
+// ExecuteCustom implements StageExecutorInterface.
+func (m *MockStageExecutor) ExecuteCustom(specName string, stage CustomStage, prompt string) error {
+	m.CustomCalls = append(m.CustomCalls, CustomCall{SpecName: specName, Stage: stage, Prompt: prompt})
+	return m.CustomError
+}
+
 // Compile-time interface compliance check.
 var _ StageExecutorInterface = (*MockStageExecutor)(nil)
 
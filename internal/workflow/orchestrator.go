@@ -11,16 +11,20 @@ package workflow
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
 	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
 	"github.com/ariel-frischer/autospec/internal/output"
 	"github.com/ariel-frischer/autospec/internal/spec"
 	"github.com/ariel-frischer/autospec/internal/taskgraph"
 	"github.com/ariel-frischer/autospec/internal/validation"
 	"github.com/ariel-frischer/autospec/internal/verification"
+	"github.com/ariel-frischer/autospec/internal/worktree"
 )
 
 // WorkflowOrchestrator manages the complete specify → plan → tasks workflow.
@@ -74,6 +78,7 @@ func (w *WorkflowOrchestrator) debugLog(format string, args ...interface{}) {
 // Agent resolution priority:
 // 1. cfg.GetAgent() - uses agent abstraction (agent_preset or custom_agent)
 // 2. Falls back to default "claude" agent from registry
+// Stages with a stages.<name> entry get their own ClaudeExecutor in Executor.StageRunners.
 //
 // Note: CLI commands typically set Executor.NotificationHandler after construction.
 // The Executor methods support both new controllers and deprecated fields via fallback.
@@ -88,15 +93,18 @@ func NewWorkflowOrchestrator(cfg *config.Configuration) *WorkflowOrchestrator {
 	notifyDispatch := NewNotifyDispatcher(nil)
 
 	executor := &Executor{
-		Claude:      claude,
-		StateDir:    cfg.StateDir,
-		SpecsDir:    cfg.SpecsDir,
-		MaxRetries:  cfg.MaxRetries,
-		TotalStages: 3,     // Default to 3 stages (specify, plan, tasks)
-		Debug:       false, // Will be set by CLI command
-		AutoCommit:  cfg.AutoCommit,
-		Progress:    progressCtrl,
-		Notify:      notifyDispatch,
+		Claude:         claude,
+		StateDir:       cfg.StateDir,
+		SpecsDir:       cfg.SpecsDir,
+		MaxRetries:     cfg.MaxRetries,
+		TotalStages:    3,     // Default to 3 stages (specify, plan, tasks)
+		Debug:          false, // Will be set by CLI command
+		AutoCommit:     cfg.AutoCommit,
+		Progress:       progressCtrl,
+		Notify:         notifyDispatch,
+		Budget:         cfg.Budget,
+		StageRunners:   newStageRunnersFromConfig(cfg, claude),
+		SchemaModeline: cfg.SchemaModeline,
 	}
 
 	// Create default executor implementations
@@ -104,6 +112,7 @@ func NewWorkflowOrchestrator(cfg *config.Configuration) *WorkflowOrchestrator {
 		Debug:                  false,
 		EnableRiskAssessment:   cfg.EnableRiskAssessment,
 		EnableEarsRequirements: cfg.Verification.IsEnabled(verification.FeatureEarsRequirements),
+		EarsTestKinds:          EarsTestKinds(&cfg.Verification),
 	})
 	phaseExec := NewPhaseExecutor(executor, cfg.SpecsDir, false)
 	taskExec := NewTaskExecutor(executor, cfg.SpecsDir, false)
@@ -160,7 +169,7 @@ func (w *WorkflowOrchestrator) RunCompleteWorkflow(featureDescription string) er
 
 	specName, err := w.executeSpecifyPlanTasks(featureDescription, 3)
 	if err != nil {
-		return fmt.Errorf("executing specify-plan-tasks workflow: %w", err)
+		return w.budgetStopError(fmt.Errorf("executing specify-plan-tasks workflow: %w", err))
 	}
 
 	fmt.Println("Workflow completed successfully!")
@@ -182,12 +191,12 @@ func (w *WorkflowOrchestrator) RunFullWorkflow(featureDescription string, resume
 	// Execute specify → plan → tasks stages
 	specName, err := w.executeSpecifyPlanTasks(featureDescription, 4)
 	if err != nil {
-		return fmt.Errorf("executing specify-plan-tasks workflow: %w", err)
+		return w.budgetStopError(fmt.Errorf("executing specify-plan-tasks workflow: %w", err))
 	}
 
 	// Execute implement stage
 	if err := w.executeImplementStage(specName, featureDescription, resume); err != nil {
-		return fmt.Errorf("executing implement stage: %w", err)
+		return w.budgetStopError(fmt.Errorf("executing implement stage: %w", err))
 	}
 
 	// Print success summary
@@ -237,12 +246,24 @@ func (w *WorkflowOrchestrator) executeSpecifyPlanTasks(featureDescription string
 	return specName, nil
 }
 
-// executeImplementStage runs the implement stage with resume support.
-// Delegates to PhaseExecutor.ExecuteDefault for execution.
+// executeImplementStage runs the implement stage with resume support, followed
+// by the post-implement checks. Delegates to PhaseExecutor.ExecuteDefault for execution.
 func (w *WorkflowOrchestrator) executeImplementStage(specName, featureDescription string, resume bool) error {
 	output.PrintStageHeader(os.Stdout, 4, 4, "Implement")
 	specDir := filepath.Join(w.SpecsDir, specName)
-	return w.phaseExecutor.ExecuteDefault(specName, specDir, "", resume)
+	if err := w.phaseExecutor.ExecuteDefault(specName, specDir, "", resume); err != nil {
+		return err
+	}
+	return w.runPostImplementChecks(specName)
+}
+
+// runPostImplementChecks runs the verification quality gates, then the
+// adversarial review when verification.adversarial_review is enabled.
+func (w *WorkflowOrchestrator) runPostImplementChecks(specName string) error {
+	if err := w.runQualityGates(specName); err != nil {
+		return err
+	}
+	return w.runAutoReview(specName)
 }
 
 // printFullWorkflowSummary prints the completion summary for full workflow
@@ -358,7 +379,7 @@ func (w *WorkflowOrchestrator) ExecuteSpecify(featureDescription string) (string
 
 	specName, err := w.stageExecutor.ExecuteSpecify(featureDescription)
 	if err != nil {
-		return "", err
+		return "", w.budgetStopError(err)
 	}
 
 	output.PrintStageSuccess(os.Stdout, fmt.Sprintf("Created specs/%s/spec.yaml (schema valid)", specName))
@@ -382,7 +403,7 @@ func (w *WorkflowOrchestrator) ExecutePlan(specNameArg string, prompt string) er
 	}
 
 	if err := w.stageExecutor.ExecutePlan(specName, prompt); err != nil {
-		return fmt.Errorf("executing plan stage: %w", err)
+		return w.budgetStopError(fmt.Errorf("executing plan stage: %w", err))
 	}
 
 	output.PrintStageSuccess(os.Stdout, fmt.Sprintf("Created specs/%s/plan.yaml (schema valid)", specName))
@@ -406,7 +427,7 @@ func (w *WorkflowOrchestrator) ExecuteTasks(specNameArg string, prompt string) e
 	}
 
 	if err := w.stageExecutor.ExecuteTasks(specName, prompt); err != nil {
-		return fmt.Errorf("executing tasks stage: %w", err)
+		return w.budgetStopError(fmt.Errorf("executing tasks stage: %w", err))
 	}
 
 	output.PrintStageSuccess(os.Stdout, fmt.Sprintf("Created specs/%s/tasks.yaml (schema valid)", specName))
@@ -438,7 +459,14 @@ func (w *WorkflowOrchestrator) ExecuteImplement(specNameArg string, prompt strin
 		specName = fmt.Sprintf("%s-%s", metadata.Number, metadata.Name)
 	}
 
-	// Dispatch to appropriate execution mode based on phase options
+	if err := w.dispatchImplement(specName, metadata, prompt, resume, phaseOpts); err != nil {
+		return w.budgetStopError(err)
+	}
+	return w.budgetStopError(w.runPostImplementChecks(specName))
+}
+
+// dispatchImplement runs the implementation mode selected by phase options.
+func (w *WorkflowOrchestrator) dispatchImplement(specName string, metadata *spec.Metadata, prompt string, resume bool, phaseOpts PhaseExecutionOptions) error {
 	switch phaseOpts.Mode() {
 	case ModeParallel:
 		return w.ExecuteImplementParallel(specName, metadata, prompt, phaseOpts)
@@ -573,19 +601,33 @@ func (w *WorkflowOrchestrator) ExecuteImplementParallel(specName string, metadat
 		}
 	}
 
+	runner, err := w.newAgentTaskRunner(specName, prompt)
+	if err != nil {
+		return err
+	}
+
+	state, startWave, err := w.prepareParallelState(specName, graph, phaseOpts)
+	if err != nil {
+		return err
+	}
+
 	// Create parallel executor
 	opts := []ParallelExecutorOption{
 		WithMaxParallel(phaseOpts.MaxParallel),
 		WithParallelDebug(w.Debug),
 		WithProgressCallback(w.defaultProgressCallback),
+		WithTaskRunner(runner),
+		WithParallelState(w.Config.StateDir, state),
+		WithStartWave(startWave),
 	}
 
 	// Add worktree support when --worktrees is set
 	if phaseOpts.UseWorktrees {
-		// TODO: Create worktree manager and add it
-		// worktreeConfig := worktree.DefaultConfig()
-		// wm := worktree.NewManager(worktreeConfig, w.Config.StateDir, metadata.Directory)
-		// opts = append(opts, WithWorktreeManager(wm), WithRepoRoot(metadata.Directory))
+		worktreeOpts, err := w.parallelWorktreeOptions()
+		if err != nil {
+			return err
+		}
+		opts = append(opts, worktreeOpts...)
 		fmt.Println("Note: Worktree isolation enabled (each task runs in isolated worktree)")
 	}
 
@@ -593,7 +635,8 @@ func (w *WorkflowOrchestrator) ExecuteImplementParallel(specName string, metadat
 
 	// Execute waves
 	fmt.Printf("Executing %d tasks in parallel (max %d concurrent)\n", graph.Size(), phaseOpts.MaxParallel)
-	fmt.Printf("Wave structure: %s\n\n", graph.RenderCompact())
+	fmt.Printf("Wave structure: %s\n", graph.RenderCompact())
+	fmt.Printf("Task session logs: %s\n\n", runner.logDir)
 
 	results, err := executor.ExecuteWaves(context.Background(), specName, tasksPath)
 	if err != nil {
@@ -601,7 +644,92 @@ func (w *WorkflowOrchestrator) ExecuteImplementParallel(specName string, metadat
 	}
 
 	// Print summary
-	return w.printParallelSummary(results, executor)
+	summaryErr := w.printParallelSummary(results, executor)
+	if budgetErr := runner.budgetStop(); budgetErr != nil {
+		return budgetErr
+	}
+	return summaryErr
+}
+
+// newAgentTaskRunner creates the task runner used by parallel execution,
+// based on the implement stage's agent executor.
+func (w *WorkflowOrchestrator) newAgentTaskRunner(specName, prompt string) (*agentTaskRunner, error) {
+	claude, ok := w.Executor.runnerFor(StageImplement).(*ClaudeExecutor)
+	if !ok {
+		return nil, fmt.Errorf("parallel execution requires an agent executor for the implement stage")
+	}
+	return &agentTaskRunner{
+		claude: claude,
+		tasks:  NewTaskExecutor(w.Executor, w.SpecsDir, w.Debug),
+		usage:  w.Executor,
+		prompt: prompt,
+		logDir: filepath.Join(w.Config.StateDir, specName, "parallel-logs"),
+	}, nil
+}
+
+// prepareParallelState loads the persisted state of an unfinished parallel
+// execution and asks how to resume it (resuming by default with --yes).
+// Returns the state to record progress in and the wave to start from.
+func (w *WorkflowOrchestrator) prepareParallelState(specName string, graph *taskgraph.DependencyGraph, phaseOpts PhaseExecutionOptions) (*ParallelExecutionState, int, error) {
+	fresh := NewParallelExecutionState(specName, graph.GetWaveStats().TotalWaves, phaseOpts.MaxParallel, phaseOpts.UseWorktrees)
+
+	state, err := LoadParallelState(w.Config.StateDir, specName)
+	if err != nil {
+		return nil, 0, fmt.Errorf("loading parallel state: %w", err)
+	}
+	if !ShouldPromptResume(state) {
+		return fresh, 1, nil
+	}
+
+	option := ResumeRetry
+	if !phaseOpts.SkipConfirmation {
+		if option, err = PromptResumeOption(state); err != nil {
+			return nil, 0, err
+		}
+	}
+	startWave, err := ApplyResumeOption(option, state, w.Config.StateDir)
+	if err != nil {
+		return nil, 0, err
+	}
+	if option == ResumeReset {
+		return fresh, startWave, nil
+	}
+
+	state.Interrupted = false
+	state.TotalWaves = fresh.TotalWaves
+	state.MaxParallel = phaseOpts.MaxParallel
+	state.UseWorktrees = phaseOpts.UseWorktrees
+	fmt.Printf("Resuming parallel execution from wave %d\n", startWave)
+	return state, startWave, nil
+}
+
+// parallelWorktreeOptions configures per-task worktrees that merge back into
+// the branch the repository is currently on.
+func (w *WorkflowOrchestrator) parallelWorktreeOptions() ([]ParallelExecutorOption, error) {
+	repoRoot, err := worktree.GetRepoRoot(".")
+	if err != nil {
+		return nil, fmt.Errorf("worktree isolation requires a git repository: %w", err)
+	}
+	dagRoot, err := runGit(repoRoot, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("getting current branch: %w", err)
+	}
+	if dagRoot == "HEAD" {
+		return nil, fmt.Errorf("worktree isolation requires a checked-out branch to merge into (HEAD is detached)")
+	}
+
+	wtConfig := w.Config.Worktree
+	if wtConfig == nil {
+		wtConfig = worktree.DefaultConfig()
+	}
+	wm := worktree.NewManager(wtConfig, w.Config.StateDir, repoRoot, worktree.WithStdout(io.Discard))
+
+	return []ParallelExecutorOption{
+		WithWorktreeManager(wm),
+		WithRepoRoot(repoRoot),
+		WithDAGRoot(dagRoot),
+		WithCommitExcludes(wtConfig.CopyDirs...),
+	}, nil
 }
 
 // defaultProgressCallback prints single-line progress updates.
@@ -707,7 +835,7 @@ func markSpecCompletedAndPrint(specDir string) {
 // ExecuteConstitution runs the constitution stage with optional prompt.
 // Delegates to StageExecutor for execution.
 func (w *WorkflowOrchestrator) ExecuteConstitution(prompt string) error {
-	return w.stageExecutor.ExecuteConstitution(prompt)
+	return w.budgetStopError(w.stageExecutor.ExecuteConstitution(prompt))
 }
 
 // ExecuteClarify runs the clarify stage with optional prompt.
@@ -717,7 +845,7 @@ func (w *WorkflowOrchestrator) ExecuteClarify(specNameArg string, prompt string)
 	if err != nil {
 		return fmt.Errorf("resolving spec name: %w", err)
 	}
-	return w.stageExecutor.ExecuteClarify(specName, prompt)
+	return w.budgetStopError(w.stageExecutor.ExecuteClarify(specName, prompt))
 }
 
 // ExecuteChecklist runs the checklist stage with optional prompt.
@@ -727,7 +855,7 @@ func (w *WorkflowOrchestrator) ExecuteChecklist(specNameArg string, prompt strin
 	if err != nil {
 		return fmt.Errorf("resolving spec name: %w", err)
 	}
-	return w.stageExecutor.ExecuteChecklist(specName, prompt)
+	return w.budgetStopError(w.stageExecutor.ExecuteChecklist(specName, prompt))
 }
 
 // ExecuteAnalyze runs the analyze stage with optional prompt.
@@ -737,7 +865,42 @@ func (w *WorkflowOrchestrator) ExecuteAnalyze(specNameArg string, prompt string)
 	if err != nil {
 		return fmt.Errorf("resolving spec name: %w", err)
 	}
-	return w.stageExecutor.ExecuteAnalyze(specName, prompt)
+	return w.budgetStopError(w.stageExecutor.ExecuteAnalyze(specName, prompt))
+}
+
+// ExecuteReview runs the review stage with optional prompt.
+// With verification.review_blocking, open critical findings fail the review.
+func (w *WorkflowOrchestrator) ExecuteReview(specNameArg string, prompt string) error {
+	specName, err := w.resolveSpecName(specNameArg)
+	if err != nil {
+		return fmt.Errorf("resolving spec name: %w", err)
+	}
+	return w.budgetStopError(w.runReview(specName, prompt))
+}
+
+// budgetStopError converts a BudgetError anywhere in err's chain into a CLIError
+// with remediation; the CLI prints it. Other errors (including nil) are returned unchanged.
+func (w *WorkflowOrchestrator) budgetStopError(err error) error {
+	var budgetErr *BudgetError
+	if !errors.As(err, &budgetErr) {
+		return err
+	}
+	return clierrors.BudgetExceeded(budgetErr.Error(), budgetErr.Err.ConfigKey(), budgetResumeCommand(budgetErr.Stage))
+}
+
+// budgetResumeCommand returns the command that continues a stage stopped by a budget limit.
+func budgetResumeCommand(stage Stage) string {
+	switch stage {
+	case StageImplement, StageVerify:
+		return "autospec implement --resume"
+	case StageSpecify:
+		return "autospec specify \"<feature description>\""
+	default:
+		if IsBuiltinStage(stage) || stage == StageReview || stage == StageDAGPlan {
+			return "autospec " + string(stage)
+		}
+		return "autospec run --stages " + string(stage) // Custom stages have no subcommand
+	}
 }
 
 // newClaudeExecutorFromConfig creates a ClaudeExecutor from configuration.
@@ -754,8 +917,12 @@ func newClaudeExecutorFromConfig(cfg *config.Configuration) *ClaudeExecutor {
 		}
 	}
 
+	// Fallback names are validated at config load; unknown names cannot reach here
+	fallbacks, _ := cfg.GetFallbackAgents()
+
 	return &ClaudeExecutor{
 		Agent:                        agent,
+		Fallbacks:                    fallbacks,
 		Timeout:                      cfg.Timeout,
 		CcleanConfig:                 cfg.Cclean,
 		UseSubscription:              cfg.UseSubscription,
@@ -764,16 +931,56 @@ func newClaudeExecutorFromConfig(cfg *config.Configuration) *ClaudeExecutor {
 	}
 }
 
-// SetOutputStyle sets the output style on the underlying ClaudeExecutor.
-// CLI flag value takes precedence over config file when called.
-// Uses type assertion to access ClaudeExecutor through ClaudeRunner interface.
-func (w *WorkflowOrchestrator) SetOutputStyle(style config.OutputStyle) {
-	if w.Executor == nil || w.Executor.Claude == nil {
-		return
+// newStageRunnersFromConfig creates a ClaudeExecutor for each stage with a
+// stages.<name> override. Each copies base and replaces agent, extra args, and
+// timeout where set. Stages without overrides are omitted and use base.
+func newStageRunnersFromConfig(cfg *config.Configuration, base *ClaudeExecutor) map[Stage]ClaudeRunner {
+	runners := make(map[Stage]ClaudeRunner)
+	for name, sc := range cfg.Stages {
+		if sc.IsZero() {
+			continue
+		}
+		stageExec := *base
+		if sc.Agent != "" {
+			// Stage agents are validated at config load; unknown names cannot reach here
+			if agent, err := cfg.GetStageAgent(name); err == nil {
+				stageExec.Agent = agent
+			}
+		}
+		if len(sc.ExtraArgs) > 0 {
+			stageExec.ExtraArgs = sc.ExtraArgs
+		}
+		if sc.Timeout > 0 {
+			stageExec.Timeout = sc.Timeout
+		}
+		runners[Stage(name)] = &stageExec
 	}
+	return runners
+}
 
-	// Type assert to access ClaudeExecutor fields through the interface
+// claudeExecutors returns the ClaudeExecutor behind Executor.Claude and every
+// stage runner, for settings that apply to all agent sessions.
+// Uses type assertion to access ClaudeExecutor through ClaudeRunner interface.
+func (w *WorkflowOrchestrator) claudeExecutors() []*ClaudeExecutor {
+	if w.Executor == nil {
+		return nil
+	}
+	var executors []*ClaudeExecutor
 	if claude, ok := w.Executor.Claude.(*ClaudeExecutor); ok {
+		executors = append(executors, claude)
+	}
+	for _, runner := range w.Executor.StageRunners {
+		if claude, ok := runner.(*ClaudeExecutor); ok {
+			executors = append(executors, claude)
+		}
+	}
+	return executors
+}
+
+// SetOutputStyle sets the output style on the underlying ClaudeExecutors.
+// CLI flag value takes precedence over config file when called.
+func (w *WorkflowOrchestrator) SetOutputStyle(style config.OutputStyle) {
+	for _, claude := range w.claudeExecutors() {
 		claude.CcleanConfig.Style = string(style)
 	}
 }
@@ -782,11 +989,7 @@ func (w *WorkflowOrchestrator) SetOutputStyle(style config.OutputStyle) {
 // Use this for multi-stage runs where we need to continue after interactive stages.
 // Without this, interactive stages would replace the process and prevent continuation.
 func (w *WorkflowOrchestrator) DisableProcessReplacement() {
-	if w.Executor == nil || w.Executor.Claude == nil {
-		return
-	}
-
-	if claude, ok := w.Executor.Claude.(*ClaudeExecutor); ok {
+	for _, claude := range w.claudeExecutors() {
 		claude.ReplaceProcessForInteractive = false
 	}
 }
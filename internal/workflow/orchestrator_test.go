@@ -8,8 +8,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ariel-frischer/autospec/internal/budget"
 	"github.com/ariel-frischer/autospec/internal/cliagent"
 	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
 	"github.com/ariel-frischer/autospec/internal/spec"
 	"github.com/ariel-frischer/autospec/internal/validation"
 )
@@ -71,6 +73,47 @@ func TestWorkflowOrchestrator_Configuration(t *testing.T) {
 	}
 }
 
+func TestNewWorkflowOrchestrator_StageRunners(t *testing.T) {
+	cfg := testConfigWithAgent("./specs", "~/.autospec/state", "claude")
+	cfg.Timeout = 600
+	cfg.Stages = map[string]config.StageConfig{
+		"tasks":     {Agent: "gemini"},
+		"implement": {ExtraArgs: []string{"--model", "opus"}, Timeout: 3600},
+		"plan":      {},
+	}
+
+	orchestrator := NewWorkflowOrchestrator(cfg)
+	orchestrator.DisableProcessReplacement()
+
+	runners := orchestrator.Executor.StageRunners
+	if len(runners) != 2 {
+		t.Fatalf("StageRunners has %d entries, want 2 (empty overrides skipped)", len(runners))
+	}
+
+	tasks, ok := runners[StageTasks].(*ClaudeExecutor)
+	if !ok {
+		t.Fatalf("tasks runner = %T, want *ClaudeExecutor", runners[StageTasks])
+	}
+	if tasks.Agent.Name() != "gemini" || tasks.Timeout != 600 {
+		t.Errorf("tasks runner = %s/%ds, want gemini/600s", tasks.Agent.Name(), tasks.Timeout)
+	}
+	if tasks.ReplaceProcessForInteractive {
+		t.Error("DisableProcessReplacement should apply to stage runners")
+	}
+
+	implement := runners[StageImplement].(*ClaudeExecutor)
+	if implement.Agent.Name() != "claude" || implement.Timeout != 3600 {
+		t.Errorf("implement runner = %s/%ds, want claude/3600s", implement.Agent.Name(), implement.Timeout)
+	}
+	if got := strings.Join(implement.ExtraArgs, " "); got != "--model opus" {
+		t.Errorf("implement ExtraArgs = %q, want %q", got, "--model opus")
+	}
+
+	if orchestrator.Executor.runnerFor(StagePlan) != orchestrator.Executor.Claude {
+		t.Error("stage without overrides should use Executor.Claude")
+	}
+}
+
 // TestExecutePlanWithPrompt tests that plan commands properly format prompts
 func TestExecutePlanWithPrompt(t *testing.T) {
 	tests := map[string]struct {
@@ -5097,3 +5140,54 @@ _meta:
 		t.Fatalf("Failed to write tasks.yaml: %v", err)
 	}
 }
+
+func TestExecutePlan_BudgetErrorBecomesCLIError(t *testing.T) {
+	t.Parallel()
+
+	budgetErr := &BudgetError{
+		Stage: StagePlan,
+		Spec:  "001-test",
+		Err:   &budget.ExceededError{Scope: budget.ScopeSpec, Subject: "001-test", Metric: budget.MetricCost, Limit: 1, Spent: 1.2},
+	}
+	mockStage := &MockStageExecutor{PlanError: fmt.Errorf("plan failed: %w", budgetErr)}
+	cfg := testConfigWithAgent(t.TempDir(), t.TempDir(), "claude")
+	orch := NewWorkflowOrchestratorWithExecutors(cfg, ExecutorOptions{StageExecutor: mockStage})
+
+	err := orch.ExecutePlan("001-test", "")
+
+	cliErr := clierrors.AsCLIError(err)
+	if cliErr == nil {
+		t.Fatalf("ExecutePlan() error = %T %v, want *CLIError", err, err)
+	}
+	remediation := strings.Join(cliErr.Remediation, "\n")
+	if !strings.Contains(remediation, "budget.max_spec_cost_usd") {
+		t.Errorf("remediation should name the exceeded key, got %q", remediation)
+	}
+	if !strings.Contains(remediation, "autospec plan") {
+		t.Errorf("remediation should include resume command, got %q", remediation)
+	}
+}
+
+func TestBudgetResumeCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		stage Stage
+		want  string
+	}{
+		"implement resumes":     {stage: StageImplement, want: "autospec implement --resume"},
+		"specify needs prompt":  {stage: StageSpecify, want: `autospec specify "<feature description>"`},
+		"other stages re-run":   {stage: StageTasks, want: "autospec tasks"},
+		"optional stage re-run": {stage: StageChecklist, want: "autospec checklist"},
+		"custom stage re-run":   {stage: Stage("threat-model"), want: "autospec run --stages threat-model"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if got := budgetResumeCommand(tt.stage); got != tt.want {
+				t.Errorf("budgetResumeCommand(%s) = %q, want %q", tt.stage, got, tt.want)
+			}
+		})
+	}
+}
@@ -4,9 +4,12 @@ package workflow
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -30,11 +33,20 @@ type ParallelExecutor struct {
 	skippedTasks    map[string]string          // Tasks skipped due to failed dependencies
 	worktreePaths   map[string]string          // TaskID -> worktree path mapping
 	progressCb      ProgressCallback           // Callback for progress updates
-	mu              sync.Mutex                 // Protects failedTasks, skippedTasks, worktreePaths
+	mu              sync.Mutex                 // Protects failedTasks, skippedTasks, worktreePaths, mergedStatuses
+	createMu        sync.Mutex                 // Serializes worktree creation (manager state is file-based)
+	specName        string                     // Spec being executed (set by ExecuteWaves)
+	tasksRel        string                     // tasks.yaml path relative to repoRoot (set by ExecuteWaves)
+	commitExcludes  []string                   // Paths never committed from task worktrees (e.g. copied dirs)
+	mergedStatuses  map[string]string          // TaskID -> status read from its worktree at merge time
+	stateDir        string                     // Directory for persisted parallel state (empty = no persistence)
+	state           *ParallelExecutionState    // Persisted execution state (optional)
+	startWave       int                        // First wave to execute when resuming
 
 	// Dependencies injected for testing
-	taskRunner TaskRunner // Interface for running individual tasks
-	debug      bool       // Enable debug logging
+	taskRunner TaskRunner                                      // Interface for running individual tasks
+	mergeFn    func(taskID, branch, worktreePath string) error // Merges a task branch back (default: gitMergeWorktree)
+	debug      bool                                            // Enable debug logging
 }
 
 // TaskRunner defines the interface for executing individual tasks.
@@ -121,25 +133,55 @@ func WithProgressCallback(cb ProgressCallback) ParallelExecutorOption {
 	}
 }
 
+// WithCommitExcludes sets paths that are never committed from task worktrees,
+// such as directories copied into each worktree on creation.
+func WithCommitExcludes(paths ...string) ParallelExecutorOption {
+	return func(pe *ParallelExecutor) {
+		pe.commitExcludes = paths
+	}
+}
+
+// WithParallelState persists execution progress to state in stateDir after
+// every wave, so interrupted runs can be resumed and inspected.
+func WithParallelState(stateDir string, state *ParallelExecutionState) ParallelExecutorOption {
+	return func(pe *ParallelExecutor) {
+		pe.stateDir = stateDir
+		pe.state = state
+	}
+}
+
+// WithStartWave skips waves before n (1-indexed) when resuming an execution.
+func WithStartWave(n int) ParallelExecutorOption {
+	return func(pe *ParallelExecutor) {
+		pe.startWave = n
+	}
+}
+
 // NewParallelExecutor creates a new ParallelExecutor with the given options.
 func NewParallelExecutor(graph *taskgraph.DependencyGraph, opts ...ParallelExecutorOption) *ParallelExecutor {
 	pe := &ParallelExecutor{
-		maxParallel:   4, // Default
-		graph:         graph,
-		worktreeDir:   ".worktrees",
-		failedTasks:   make(map[string]error),
-		skippedTasks:  make(map[string]string),
-		worktreePaths: make(map[string]string),
+		maxParallel:    4, // Default
+		graph:          graph,
+		worktreeDir:    ".worktrees",
+		failedTasks:    make(map[string]error),
+		skippedTasks:   make(map[string]string),
+		worktreePaths:  make(map[string]string),
+		mergedStatuses: make(map[string]string),
 	}
 
 	for _, opt := range opts {
 		opt(pe)
 	}
+	if pe.mergeFn == nil {
+		pe.mergeFn = pe.gitMergeWorktree
+	}
 
 	return pe
 }
 
 // ExecuteWaves executes all waves in order, running tasks within each wave concurrently.
+// With worktree isolation, each completed wave is merged back into DAG-ROOT and
+// task statuses are reconciled into tasks.yaml before the next wave starts.
 // Returns results for all waves and any error that occurred.
 func (pe *ParallelExecutor) ExecuteWaves(ctx context.Context, specName, tasksPath string) ([]WaveResult, error) {
 	waves := pe.graph.Waves()
@@ -147,26 +189,80 @@ func (pe *ParallelExecutor) ExecuteWaves(ctx context.Context, specName, tasksPat
 		return nil, nil
 	}
 
+	pe.specName = specName
+	pe.tasksRel = pe.relativeTasksPath(tasksPath)
 	results := make([]WaveResult, 0, len(waves))
 
 	for _, wave := range waves {
+		if wave.Number < pe.startWave {
+			continue
+		}
+
 		select {
 		case <-ctx.Done():
+			pe.saveInterrupted()
 			return results, ctx.Err()
 		default:
 		}
 
+		pe.startWaveState(wave)
 		waveResult, err := pe.executeWave(ctx, wave, specName, tasksPath)
+		if err == nil {
+			err = pe.finishWave(waveResult)
+		}
 		results = append(results, *waveResult)
 
 		if err != nil {
+			pe.saveInterrupted()
 			return results, fmt.Errorf("executing wave %d: %w", wave.Number, err)
 		}
+		if err := pe.completeWaveState(waveResult); err != nil {
+			return results, err
+		}
 	}
 
+	if err := pe.completeState(); err != nil {
+		return results, err
+	}
 	return results, nil
 }
 
+// finishWave merges a wave's task worktrees back, reconciles tasks.yaml and
+// removes the merged worktrees. A no-op without worktree isolation.
+func (pe *ParallelExecutor) finishWave(waveResult *WaveResult) error {
+	if pe.worktreeManager == nil {
+		return nil
+	}
+
+	if err := pe.MergeWaveWorktrees(waveResult); err != nil {
+		return err
+	}
+	if err := pe.reconcileTaskStatuses(waveResult.WaveNumber); err != nil {
+		return fmt.Errorf("reconciling task statuses: %w", err)
+	}
+	if err := pe.CleanupWaveWorktrees(waveResult); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: cleaning up worktrees for wave %d: %v\n", waveResult.WaveNumber, err)
+	}
+	return nil
+}
+
+// relativeTasksPath returns tasksPath relative to the repository root, or an
+// empty string when it lies outside the repository.
+func (pe *ParallelExecutor) relativeTasksPath(tasksPath string) string {
+	if pe.repoRoot == "" {
+		return ""
+	}
+	abs, err := filepath.Abs(tasksPath)
+	if err != nil {
+		return ""
+	}
+	rel, err := filepath.Rel(pe.repoRoot, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return ""
+	}
+	return rel
+}
+
 // executeWave executes all tasks in a single wave concurrently.
 func (pe *ParallelExecutor) executeWave(ctx context.Context, wave taskgraph.ExecutionWave, specName, tasksPath string) (*WaveResult, error) {
 	startTime := time.Now()
@@ -230,6 +326,11 @@ func (pe *ParallelExecutor) filterTasksToRun(taskIDs []string) ([]string, map[st
 			continue
 		}
 
+		// Tasks already completed in tasks.yaml (earlier runs or resumed waves) are not rerun
+		if node.Task != nil && strings.EqualFold(node.Task.Status, "completed") {
+			continue
+		}
+
 		// Check if any dependency failed
 		failedDep := ""
 		for _, depID := range node.Dependencies {
@@ -322,6 +423,11 @@ func (pe *ParallelExecutor) executeTask(ctx context.Context, taskID, specName, t
 	}
 	result.WorktreePath = worktreePath
 
+	// Tasks in a worktree work on the worktree's copy of tasks.yaml
+	if worktreePath != "" && pe.tasksRel != "" {
+		tasksPath = filepath.Join(worktreePath, pe.tasksRel)
+	}
+
 	// Execute the task
 	err = pe.taskRunner.RunTask(ctx, taskID, specName, tasksPath)
 	result.Duration = time.Since(startTime)
@@ -406,6 +512,8 @@ func (pe *ParallelExecutor) UseWorktrees() bool {
 }
 
 // createWorktree creates a worktree for a task if worktree mode is enabled.
+// A worktree left behind by an interrupted run is replaced; its branch (and any
+// commits on it) is reused.
 // Returns the worktree path (or empty string if not using worktrees).
 func (pe *ParallelExecutor) createWorktree(taskID string) (string, error) {
 	if pe.worktreeManager == nil {
@@ -415,9 +523,16 @@ func (pe *ParallelExecutor) createWorktree(taskID string) (string, error) {
 	// Create worktree path: .worktrees/<task-id>/
 	worktreePath := filepath.Join(pe.repoRoot, pe.worktreeDir, taskID)
 
-	// Create the worktree using the manager
-	// Branch name is the task ID for easy identification
-	wt, err := pe.worktreeManager.Create(taskID, taskID, worktreePath)
+	pe.createMu.Lock()
+	defer pe.createMu.Unlock()
+
+	if existing, _ := pe.worktreeManager.Get(taskID); existing != nil {
+		if err := pe.worktreeManager.Remove(taskID, true); err != nil {
+			return "", fmt.Errorf("removing stale worktree for task %s: %w", taskID, err)
+		}
+	}
+
+	wt, err := pe.worktreeManager.Create(taskID, pe.taskBranch(taskID), worktreePath)
 	if err != nil {
 		return "", fmt.Errorf("creating worktree for task %s: %w", taskID, err)
 	}
@@ -429,7 +544,17 @@ func (pe *ParallelExecutor) createWorktree(taskID string) (string, error) {
 	return wt.Path, nil
 }
 
+// taskBranch returns the branch a task's worktree works on.
+func (pe *ParallelExecutor) taskBranch(taskID string) string {
+	if pe.specName == "" {
+		return taskID
+	}
+	return pe.specName + "-" + taskID
+}
+
 // mergeWorktree merges changes from a task's worktree into DAG-ROOT.
+// Returns a *WorktreeMergeConflictError when the merge conflicts; the merge is
+// aborted and the worktree is left in place.
 func (pe *ParallelExecutor) mergeWorktree(taskID string) error {
 	if pe.worktreeManager == nil {
 		return nil
@@ -443,15 +568,15 @@ func (pe *ParallelExecutor) mergeWorktree(taskID string) error {
 		return nil // No worktree for this task
 	}
 
+	if err := pe.mergeFn(taskID, pe.taskBranch(taskID), wtPath); err != nil {
+		return err
+	}
+
 	// Update worktree status to merged
 	if err := pe.worktreeManager.UpdateStatus(taskID, worktree.StatusMerged); err != nil {
 		return fmt.Errorf("updating worktree status: %w", err)
 	}
 
-	// Note: Actual git merge is handled separately as it may require
-	// user intervention for conflicts. The worktree package handles this.
-	_ = wtPath // Used for potential merge operations
-
 	return nil
 }
 
@@ -469,8 +594,9 @@ func (pe *ParallelExecutor) cleanupWorktree(taskID string) error {
 		return nil
 	}
 
-	// Remove the worktree (force=false to preserve uncommitted work)
-	if err := pe.worktreeManager.Remove(taskID, false); err != nil {
+	// Force removal: the task branch is merged, and files left in the
+	// worktree are either excluded copies or ignored build output
+	if err := pe.worktreeManager.Remove(taskID, true); err != nil {
 		return fmt.Errorf("removing worktree for task %s: %w", taskID, err)
 	}
 
@@ -492,20 +618,38 @@ func (pe *ParallelExecutor) getWorktreeDir() string {
 	return filepath.Join(pe.repoRoot, pe.worktreeDir)
 }
 
-// MergeWaveWorktrees sequentially merges all worktrees from a completed wave.
-// Returns an error if any merge fails (typically due to conflicts).
+// MergeWaveWorktrees sequentially merges all worktrees from a completed wave,
+// in task ID order. A task whose merge conflicts is marked failed in
+// waveResult (so its dependents are skipped) and its worktree is kept.
+// Returns an error if a merge fails for any other reason.
 func (pe *ParallelExecutor) MergeWaveWorktrees(waveResult *WaveResult) error {
 	if pe.worktreeManager == nil {
 		return nil
 	}
 
-	for taskID, result := range waveResult.Results {
+	taskIDs := make([]string, 0, len(waveResult.Results))
+	for taskID := range waveResult.Results {
+		taskIDs = append(taskIDs, taskID)
+	}
+	sort.Strings(taskIDs)
+
+	for _, taskID := range taskIDs {
+		result := waveResult.Results[taskID]
 		// Only merge successful tasks
 		if !result.Success || result.Skipped {
 			continue
 		}
 
-		if err := pe.mergeWorktree(taskID); err != nil {
+		err := pe.mergeWorktree(taskID)
+		var conflictErr *WorktreeMergeConflictError
+		switch {
+		case errors.As(err, &conflictErr):
+			result.Success = false
+			result.Error = err
+			waveResult.Status = taskgraph.WavePartialFailed
+			pe.recordFailedTask(taskID, err)
+			_ = pe.graph.SetNodeStatus(taskID, taskgraph.StatusFailed)
+		case err != nil:
 			return fmt.Errorf("merging worktree for task %s: %w", taskID, err)
 		}
 	}
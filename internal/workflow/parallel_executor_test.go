@@ -565,6 +565,11 @@ func TestParallelExecutor_WithWorktreeManager_CreatesWorktrees(t *testing.T) {
 		WithWorktreeManager(wm),
 		WithRepoRoot("/tmp/test-repo"),
 	)
+	var merged []string
+	pe.mergeFn = func(taskID, branch, _ string) error {
+		merged = append(merged, branch)
+		return nil
+	}
 
 	ctx := context.Background()
 	results, err := pe.ExecuteWaves(ctx, "test-spec", "tasks.yaml")
@@ -572,6 +577,10 @@ func TestParallelExecutor_WithWorktreeManager_CreatesWorktrees(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, results, 1)
 
+	// Verify each task branch was merged back and its worktree removed
+	assert.Equal(t, []string{"test-spec-T001", "test-spec-T002"}, merged)
+	assert.ElementsMatch(t, []string{"T001", "T002"}, wm.removeCalls)
+
 	// Verify worktrees were created for each task
 	assert.Len(t, wm.createCalls, 2)
 	assert.Contains(t, wm.createCalls, "T001")
@@ -628,13 +637,18 @@ func TestParallelExecutor_MergeWaveWorktrees(t *testing.T) {
 
 	wm := &mockWorktreeManager{}
 	pe := NewParallelExecutor(g, WithWorktreeManager(wm))
+	var merged []string
+	pe.mergeFn = func(taskID, _, worktreePath string) error {
+		merged = append(merged, worktreePath)
+		return nil
+	}
 
 	// Simulate completed wave
 	waveResult := &WaveResult{
 		WaveNumber: 1,
 		Results: map[string]*ParallelTaskResult{
-			"T001": {TaskID: "T001", Success: true},
 			"T002": {TaskID: "T002", Success: true},
+			"T001": {TaskID: "T001", Success: true},
 		},
 	}
 
@@ -645,6 +659,9 @@ func TestParallelExecutor_MergeWaveWorktrees(t *testing.T) {
 	err = pe.MergeWaveWorktrees(waveResult)
 	require.NoError(t, err)
 
+	// Worktrees are merged in task order
+	assert.Equal(t, []string{"/tmp/worktree-T001", "/tmp/worktree-T002"}, merged)
+
 	// Verify status was updated for both tasks
 	assert.Len(t, wm.statusCalls, 2)
 	assert.Contains(t, wm.statusCalls, "T001")
@@ -0,0 +1,201 @@
+// Package workflow provides worktree merge-back for parallel execution.
+// Each task runs on its own branch in its own worktree; after a wave the task
+// branches are merged into DAG-ROOT (the branch the repository root is on) and
+// the task statuses recorded in each worktree's tasks.yaml are reconciled into
+// the repository's tasks.yaml.
+package workflow
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+)
+
+// WorktreeMergeConflictError reports a task branch that could not be merged
+// into DAG-ROOT because of conflicts outside tasks.yaml. The merge is aborted
+// and the task worktree is kept for manual resolution.
+type WorktreeMergeConflictError struct {
+	TaskID       string   // Task whose branch conflicted
+	Branch       string   // Task branch
+	WorktreePath string   // Worktree kept for resolution
+	Files        []string // Conflicting files, relative to the repository root
+}
+
+func (e *WorktreeMergeConflictError) Error() string {
+	return fmt.Sprintf("merge conflict in %s merging task %s (branch %s); merge the branch manually, then re-run to continue (worktree kept at %s)",
+		strings.Join(e.Files, ", "), e.TaskID, e.Branch, e.WorktreePath)
+}
+
+// gitMergeWorktree commits a task's worktree changes and merges its branch
+// into DAG-ROOT with a merge commit. Conflicts limited to tasks.yaml are
+// resolved in favor of DAG-ROOT, since task statuses are reconciled after the
+// wave. On success the task branch is deleted.
+func (pe *ParallelExecutor) gitMergeWorktree(taskID, branch, worktreePath string) error {
+	if err := pe.checkDAGRoot(); err != nil {
+		return err
+	}
+
+	status := pe.worktreeTaskStatus(taskID, worktreePath)
+	if err := pe.commitWorktree(taskID, worktreePath); err != nil {
+		return fmt.Errorf("committing task %s: %w", taskID, err)
+	}
+
+	message := fmt.Sprintf("Merge task %s", taskID)
+	if _, err := runGit(pe.repoRoot, "merge", "--no-ff", "--no-edit", "-m", message, branch); err != nil {
+		conflicts := conflictedFiles(pe.repoRoot)
+		if len(conflicts) == 0 {
+			return fmt.Errorf("merging %s: %w", branch, err)
+		}
+		if err := pe.resolveTasksFileConflict(taskID, branch, worktreePath, conflicts); err != nil {
+			return err
+		}
+	}
+
+	if status != "" {
+		pe.mu.Lock()
+		pe.mergedStatuses[taskID] = status
+		pe.mu.Unlock()
+	}
+
+	// Detach the worktree so the merged branch can be deleted
+	if _, err := runGit(worktreePath, "checkout", "-q", "--detach"); err != nil {
+		return fmt.Errorf("detaching worktree for task %s: %w", taskID, err)
+	}
+	if _, err := runGit(pe.repoRoot, "branch", "-d", branch); err != nil {
+		return fmt.Errorf("deleting merged branch %s: %w", branch, err)
+	}
+	return nil
+}
+
+// checkDAGRoot verifies the repository root is on the DAG-ROOT branch, when
+// one is configured.
+func (pe *ParallelExecutor) checkDAGRoot() error {
+	if pe.dagRoot == "" {
+		return nil
+	}
+	current, err := runGit(pe.repoRoot, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return fmt.Errorf("getting current branch: %w", err)
+	}
+	if current != pe.dagRoot {
+		return fmt.Errorf("repository is on branch %s, but task worktrees merge into %s", current, pe.dagRoot)
+	}
+	return nil
+}
+
+// commitWorktree commits all changes in a task worktree, except excluded
+// paths. Does nothing when the task left no uncommitted changes.
+func (pe *ParallelExecutor) commitWorktree(taskID, worktreePath string) error {
+	args := []string{"add", "-A", "--", "."}
+	for _, path := range pe.commitExcludes {
+		args = append(args, ":(exclude)"+path)
+	}
+	if _, err := runGit(worktreePath, args...); err != nil {
+		return err
+	}
+	if _, err := runGit(worktreePath, "diff", "--cached", "--quiet"); err == nil {
+		return nil // Nothing staged
+	}
+	_, err := runGit(worktreePath, "commit", "-q", "-m", fmt.Sprintf("%s: implement task", taskID))
+	return err
+}
+
+// resolveTasksFileConflict completes a merge whose only conflict is
+// tasks.yaml by keeping DAG-ROOT's copy. Any other conflict aborts the merge
+// and returns a *WorktreeMergeConflictError.
+func (pe *ParallelExecutor) resolveTasksFileConflict(taskID, branch, worktreePath string, conflicts []string) error {
+	tasksFile := filepath.ToSlash(pe.tasksRel)
+	if pe.tasksRel != "" && len(conflicts) == 1 && conflicts[0] == tasksFile {
+		if _, err := runGit(pe.repoRoot, "checkout", "--ours", "--", tasksFile); err != nil {
+			return fmt.Errorf("resolving %s: %w", tasksFile, err)
+		}
+		if _, err := runGit(pe.repoRoot, "add", "--", tasksFile); err != nil {
+			return fmt.Errorf("staging %s: %w", tasksFile, err)
+		}
+		if _, err := runGit(pe.repoRoot, "commit", "-q", "--no-edit"); err != nil {
+			return fmt.Errorf("committing merge of %s: %w", branch, err)
+		}
+		return nil
+	}
+
+	if _, err := runGit(pe.repoRoot, "merge", "--abort"); err != nil {
+		return fmt.Errorf("aborting conflicted merge of %s: %w", branch, err)
+	}
+	return &WorktreeMergeConflictError{
+		TaskID:       taskID,
+		Branch:       branch,
+		WorktreePath: worktreePath,
+		Files:        conflicts,
+	}
+}
+
+// worktreeTaskStatus returns a task's status from its worktree's tasks.yaml,
+// or an empty string when it cannot be read.
+func (pe *ParallelExecutor) worktreeTaskStatus(taskID, worktreePath string) string {
+	if pe.tasksRel == "" {
+		return ""
+	}
+	tasks, err := validation.GetAllTasks(filepath.Join(worktreePath, pe.tasksRel))
+	if err != nil {
+		return ""
+	}
+	task, err := validation.GetTaskByID(tasks, taskID)
+	if err != nil {
+		return ""
+	}
+	return task.Status
+}
+
+// reconcileTaskStatuses writes the statuses collected from merged worktrees
+// into the repository's tasks.yaml and commits the result.
+func (pe *ParallelExecutor) reconcileTaskStatuses(waveNum int) error {
+	pe.mu.Lock()
+	statuses := pe.mergedStatuses
+	pe.mergedStatuses = make(map[string]string)
+	pe.mu.Unlock()
+
+	if len(statuses) == 0 || pe.tasksRel == "" {
+		return nil
+	}
+
+	changed, err := validation.UpdateTaskStatuses(filepath.Join(pe.repoRoot, pe.tasksRel), statuses)
+	if err != nil {
+		return err
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	tasksFile := filepath.ToSlash(pe.tasksRel)
+	if _, err := runGit(pe.repoRoot, "add", "--", tasksFile); err != nil {
+		return err
+	}
+	message := fmt.Sprintf("Update task statuses after wave %d", waveNum)
+	if _, err := runGit(pe.repoRoot, "commit", "-q", "-m", message, "--", tasksFile); err != nil {
+		return err
+	}
+	return nil
+}
+
+// conflictedFiles lists unmerged files in dir.
+func conflictedFiles(dir string) []string {
+	out, err := runGit(dir, "diff", "--name-only", "--diff-filter=U")
+	if err != nil || out == "" {
+		return nil
+	}
+	return strings.Split(out, "\n")
+}
+
+// runGit runs a git command in dir and returns its trimmed output.
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
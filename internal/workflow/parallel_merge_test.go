@@ -0,0 +1,172 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/taskgraph"
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/ariel-frischer/autospec/internal/worktree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const parallelTasksYAML = `phases:
+  - number: 1
+    title: Build
+    tasks:
+      - id: T001
+        title: Add a
+        status: Pending
+        dependencies: []
+      - id: T002
+        title: Edit shared
+        status: Pending
+        dependencies: []
+      - id: T003
+        title: Also edit shared
+        status: Pending
+        dependencies: []
+      - id: T004
+        title: Depends on T003
+        status: Pending
+        dependencies: [T003]
+`
+
+// worktreeTaskRunner writes files into the repository containing tasksPath
+// and marks the task completed there, like an agent working in a worktree.
+type worktreeTaskRunner struct {
+	files map[string]map[string]string // TaskID -> file -> content
+}
+
+func (r *worktreeTaskRunner) RunTask(_ context.Context, taskID, _, tasksPath string) error {
+	root, err := worktree.GetRepoRoot(filepath.Dir(tasksPath))
+	if err != nil {
+		return err
+	}
+	for name, content := range r.files[taskID] {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0o644); err != nil {
+			return err
+		}
+	}
+	_, err = validation.UpdateTaskStatuses(tasksPath, map[string]string{taskID: "Completed"})
+	return err
+}
+
+func TestParallelExecutor_WorktreeMergeBack(t *testing.T) {
+	repo := setupParallelRepo(t)
+	stateDir := t.TempDir()
+	tasksPath := filepath.Join(repo, "specs", "001-feat", "tasks.yaml")
+
+	tasks, err := validation.GetAllTasks(tasksPath)
+	require.NoError(t, err)
+	g, err := taskgraph.BuildFromTasks(tasks)
+	require.NoError(t, err)
+	_, err = g.ComputeWaves()
+	require.NoError(t, err)
+
+	runner := &worktreeTaskRunner{files: map[string]map[string]string{
+		"T001": {"a.txt": "a\n"},
+		"T002": {"shared.txt": "from T002\n"},
+		"T003": {"shared.txt": "from T003\n"},
+	}}
+	wm := worktree.NewManager(worktree.DefaultConfig(), stateDir, repo, worktree.WithStdout(io.Discard))
+	state := NewParallelExecutionState("001-feat", g.GetWaveStats().TotalWaves, 2, true)
+	pe := NewParallelExecutor(g,
+		WithTaskRunner(runner),
+		WithWorktreeManager(wm),
+		WithRepoRoot(repo),
+		WithDAGRoot("main"),
+		WithMaxParallel(2),
+		WithParallelState(stateDir, state),
+	)
+
+	results, err := pe.ExecuteWaves(context.Background(), "001-feat", tasksPath)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	// T003 conflicts with T002 in shared.txt; its dependent is skipped
+	var conflictErr *WorktreeMergeConflictError
+	require.True(t, errors.As(results[0].Results["T003"].Error, &conflictErr), "T003 error = %v", results[0].Results["T003"].Error)
+	assert.Contains(t, conflictErr.Files, "shared.txt")
+	assert.Equal(t, taskgraph.WavePartialFailed, results[0].Status)
+	assert.True(t, results[1].Results["T004"].Skipped)
+
+	// Merged changes are on main, and no merge is left in progress
+	content, err := os.ReadFile(filepath.Join(repo, "shared.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "from T002\n", string(content))
+	assert.FileExists(t, filepath.Join(repo, "a.txt"))
+	assert.Empty(t, conflictedFiles(repo))
+	assert.Empty(t, gitOut(t, repo, "status", "--porcelain", "--untracked-files=no"))
+
+	// Statuses from merged worktrees are reconciled into tasks.yaml
+	tasks, err = validation.GetAllTasks(tasksPath)
+	require.NoError(t, err)
+	statuses := map[string]string{}
+	for _, task := range tasks {
+		statuses[task.ID] = task.Status
+	}
+	assert.Equal(t, map[string]string{"T001": "Completed", "T002": "Completed", "T003": "Pending", "T004": "Pending"}, statuses)
+
+	// Merged branches are deleted; the conflicting worktree and branch are kept
+	assert.Equal(t, "001-feat-T003", gitOut(t, repo, "branch", "--list", "001-feat-*", "--format=%(refname:short)"))
+	assert.DirExists(t, conflictErr.WorktreePath)
+	assert.NoDirExists(t, filepath.Join(repo, ".worktrees", "T001"))
+
+	// Wave progress is persisted for resume and status
+	saved, err := LoadParallelState(stateDir, "001-feat")
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.Equal(t, "partial_failed", saved.WaveResults[1].Status)
+	assert.Equal(t, 2, saved.WaveResults[1].Completed)
+	assert.Equal(t, 1, saved.WaveResults[1].Failed)
+	assert.Equal(t, "failed", saved.TaskStatuses["T003"])
+	assert.Equal(t, conflictErr.WorktreePath, saved.WorktreePaths["T003"])
+	assert.False(t, saved.IsComplete())
+}
+
+func TestParallelExecutor_MergeWorktreeRequiresDAGRoot(t *testing.T) {
+	repo := setupParallelRepo(t)
+	pe := NewParallelExecutor(taskgraph.NewDependencyGraph(), WithRepoRoot(repo), WithDAGRoot("release"))
+
+	err := pe.gitMergeWorktree("T001", "001-feat-T001", repo)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "merge into release")
+}
+
+// setupParallelRepo creates a repository on main with a spec tasks.yaml and a
+// shared file committed.
+func setupParallelRepo(t *testing.T) string {
+	t.Helper()
+	repo := t.TempDir()
+	gitOut(t, repo, "init", "-q", "-b", "main")
+	gitOut(t, repo, "config", "user.email", "test@example.com")
+	gitOut(t, repo, "config", "user.name", "Test")
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, "specs", "001-feat"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "specs", "001-feat", "tasks.yaml"), []byte(parallelTasksYAML), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "shared.txt"), []byte("base\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ".gitignore"), []byte(".worktrees/\n"), 0o644))
+	gitOut(t, repo, "add", ".")
+	gitOut(t, repo, "commit", "-q", "-m", "init")
+
+	// Resolve symlinks (e.g. macOS /var) so paths match git's output
+	resolved, err := filepath.EvalSymlinks(repo)
+	require.NoError(t, err)
+	return resolved
+}
+
+func gitOut(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, "git %v: %s", args, out)
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,107 @@
+// Package workflow provides the agent task runner for parallel execution.
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/ariel-frischer/autospec/internal/worktree"
+)
+
+// agentTaskRunner implements TaskRunner by running each task in a fresh agent
+// session. The session runs in the repository (or task worktree) containing
+// the tasks.yaml it is given, and its output goes to a per-task log file so
+// concurrent sessions do not interleave on the terminal.
+type agentTaskRunner struct {
+	claude  *ClaudeExecutor // Template executor; copied per task
+	tasks   *TaskExecutor   // Builds task commands and validates completion
+	usage   *Executor       // Records token and cost usage and checks budgets
+	prompt  string          // Optional custom prompt appended to every task
+	logDir  string          // Directory for per-task session logs
+	usageMu sync.Mutex      // Serializes usage recording and budget checks
+
+	stageUsage cliagent.Usage // Usage of all task sessions (implement stage budget)
+	budgetErr  error          // First budget limit reached; later tasks are not started
+}
+
+// RunTask runs one task and verifies it was marked completed in tasksPath.
+// Budgets are checked before each session, as for sequential stages.
+func (r *agentTaskRunner) RunTask(_ context.Context, taskID, specName, tasksPath string) error {
+	if err := r.checkBudget(specName); err != nil {
+		return fmt.Errorf("task %s not started: %w", taskID, err)
+	}
+
+	command, err := r.tasks.buildTaskCommand(taskID, r.prompt)
+	if err != nil {
+		return fmt.Errorf("building task command: %w", err)
+	}
+
+	workDir, err := worktree.GetRepoRoot(filepath.Dir(tasksPath))
+	if err != nil {
+		return fmt.Errorf("resolving work directory for task %s: %w", taskID, err)
+	}
+
+	if err := os.MkdirAll(r.logDir, 0o755); err != nil {
+		return fmt.Errorf("creating task log directory: %w", err)
+	}
+	logPath := filepath.Join(r.logDir, taskID+".log")
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		return fmt.Errorf("creating task log: %w", err)
+	}
+	defer logFile.Close()
+
+	claude := *r.claude
+	claude.WorkDir = workDir
+	runErr := claude.StreamCommand(command, logFile, logFile)
+	r.recordUsage(specName, taskID, &claude)
+	if runErr != nil {
+		return fmt.Errorf("task %s session failed (log: %s): %w", taskID, logPath, runErr)
+	}
+
+	return r.tasks.validateTaskCompleted(filepath.Dir(tasksPath), taskID)
+}
+
+// recordUsage records a task session's usage under the task's scope.
+func (r *agentTaskRunner) recordUsage(specName, taskID string, claude *ClaudeExecutor) {
+	if r.usage == nil {
+		return
+	}
+	r.usageMu.Lock()
+	defer r.usageMu.Unlock()
+	usage := claude.LastUsage()
+	r.stageUsage.Add(usage)
+	r.usage.runUsage.Add(usage)
+	agent := claude.LastAgent() // Differs from the configured agent after a fallback
+	if agent == "" {
+		agent = claude.ConfiguredAgent()
+	}
+	r.usage.recordUsage(specName, retry.TaskUsageScope(taskID), usage, agent)
+}
+
+// checkBudget returns a BudgetError once the implement stage, spec, or run
+// budget is reached. Task usage is recorded after each session, so only the
+// sessions still running are not counted.
+func (r *agentTaskRunner) checkBudget(specName string) error {
+	if r.usage == nil {
+		return nil
+	}
+	r.usageMu.Lock()
+	defer r.usageMu.Unlock()
+	if r.budgetErr == nil {
+		r.budgetErr = r.usage.checkBudgetLimits(StageImplement, specName, r.stageUsage, cliagent.Usage{})
+	}
+	return r.budgetErr
+}
+
+// budgetStop returns the budget limit that stopped task sessions, or nil.
+func (r *agentTaskRunner) budgetStop() error {
+	r.usageMu.Lock()
+	defer r.usageMu.Unlock()
+	return r.budgetErr
+}
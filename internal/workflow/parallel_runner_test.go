@@ -0,0 +1,109 @@
+// Package workflow tests the agent task runner used by parallel execution.
+// Related: internal/workflow/parallel_runner.go
+// Tags: workflow, parallel, budget, usage
+package workflow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/budget"
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentTaskRunner_BudgetStopsTasks(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		budget     budget.Config
+		stageUsage cliagent.Usage
+		priorCost  float64
+		wantScope  budget.Scope
+	}{
+		"stage limit counts earlier task sessions": {
+			budget:     budget.Config{MaxStageCostUSD: 1},
+			stageUsage: cliagent.Usage{CostUSD: 1.5},
+			wantScope:  budget.ScopeStage,
+		},
+		"spec limit counts recorded usage": {
+			budget:    budget.Config{MaxSpecCostUSD: 1},
+			priorCost: 2,
+			wantScope: budget.ScopeSpec,
+		},
+		"run limit counts usage of the executor": {
+			budget:     budget.Config{MaxRunTokens: 100},
+			stageUsage: cliagent.Usage{OutputTokens: 150},
+			wantScope:  budget.ScopeRun,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			stateDir := t.TempDir()
+			if tt.priorCost > 0 {
+				require.NoError(t, retry.RecordUsage(stateDir, &retry.UsageState{
+					SpecName: "001-test", Scope: retry.StageUsageScope("plan"), CostUSD: tt.priorCost,
+				}))
+			}
+			executor := &Executor{StateDir: stateDir, Budget: tt.budget}
+			executor.runUsage.Add(tt.stageUsage)
+			// No agent or task executor: the budget check must stop the task first
+			runner := &agentTaskRunner{usage: executor, stageUsage: tt.stageUsage}
+
+			err := runner.RunTask(context.Background(), "T001", "001-test", "tasks.yaml")
+			var budgetErr *BudgetError
+			require.ErrorAs(t, err, &budgetErr)
+			assert.Equal(t, StageImplement, budgetErr.Stage)
+			assert.Equal(t, tt.wantScope, budgetErr.Err.Scope)
+			assert.Contains(t, err.Error(), "task T001 not started")
+			assert.Same(t, budgetErr, runner.budgetStop())
+		})
+	}
+}
+
+func TestAgentTaskRunner_NoBudget(t *testing.T) {
+	t.Parallel()
+
+	runner := &agentTaskRunner{usage: &Executor{StateDir: t.TempDir()}}
+	assert.NoError(t, runner.checkBudget("001-test"))
+	assert.NoError(t, runner.budgetStop())
+}
+
+func TestAgentTaskRunner_RecordUsageAgent(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		lastAgent string
+		wantAgent string
+	}{
+		"fallback agent completed the task":        {lastAgent: "codex", wantAgent: "codex"},
+		"failed session uses the configured agent": {wantAgent: "claude"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			stateDir := t.TempDir()
+			runner := &agentTaskRunner{usage: &Executor{StateDir: stateDir}}
+			claude := &ClaudeExecutor{
+				Agent:     cliagent.NewClaude(),
+				lastAgent: tt.lastAgent,
+				lastUsage: cliagent.Usage{CostUSD: 0.25},
+			}
+
+			runner.recordUsage("001-test", "T001", claude)
+
+			entries, err := retry.LoadUsage(stateDir, "001-test")
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+			assert.Equal(t, tt.wantAgent, entries[0].Agent)
+			assert.InDelta(t, 0.25, runner.stageUsage.CostUSD, 1e-9)
+		})
+	}
+}
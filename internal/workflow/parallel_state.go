@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
+
+	"github.com/ariel-frischer/autospec/internal/taskgraph"
 )
 
 // ParallelExecutionState persists the state of a parallel execution.
@@ -215,3 +218,98 @@ func (s *ParallelExecutionState) GetCompletedTasks() []string {
 	}
 	return completed
 }
+
+// startWaveState records the start of a wave in the executor's state.
+func (pe *ParallelExecutor) startWaveState(wave taskgraph.ExecutionWave) {
+	if pe.state == nil {
+		return
+	}
+	pe.state.StartWave(wave.Number, len(wave.TaskIDs))
+	for _, taskID := range wave.TaskIDs {
+		// Tasks already completed in tasks.yaml are not run
+		if node := pe.graph.GetNode(taskID); node != nil && node.Task != nil && strings.EqualFold(node.Task.Status, "completed") {
+			pe.state.UpdateTaskStatus(taskID, "completed")
+			continue
+		}
+		pe.state.UpdateTaskStatus(taskID, "running")
+	}
+	pe.saveState()
+}
+
+// completeWaveState records a finished (and, with worktrees, merged) wave.
+func (pe *ParallelExecutor) completeWaveState(waveResult *WaveResult) error {
+	if pe.state == nil {
+		return nil
+	}
+
+	var completed, failed, skipped int
+	for taskID, result := range waveResult.Results {
+		switch {
+		case result.Skipped:
+			skipped++
+			pe.state.RecordTaskSkipped(taskID, result.SkipReason)
+		case result.Success:
+			completed++
+			delete(pe.state.FailedTasks, taskID)
+			pe.state.UpdateTaskStatus(taskID, "completed")
+		default:
+			failed++
+			pe.state.RecordTaskFailure(taskID, errorMessage(result.Error))
+		}
+		if path := result.WorktreePath; path != "" && !result.Success {
+			pe.state.WorktreePaths[taskID] = path
+		} else {
+			delete(pe.state.WorktreePaths, taskID)
+		}
+	}
+	// Tasks already completed in tasks.yaml have no result
+	if info, ok := pe.state.WaveResults[waveResult.WaveNumber]; ok && info.TaskCount > len(waveResult.Results) {
+		completed += info.TaskCount - len(waveResult.Results)
+	}
+
+	pe.state.CompleteWave(waveResult.WaveNumber, completed, failed, skipped)
+	if err := SaveParallelState(pe.stateDir, pe.state.SpecName, pe.state); err != nil {
+		return fmt.Errorf("saving parallel state: %w", err)
+	}
+	return nil
+}
+
+// completeState marks the execution completed when no task failed, so the
+// next run starts fresh; otherwise the state is kept for resuming.
+func (pe *ParallelExecutor) completeState() error {
+	if pe.state == nil {
+		return nil
+	}
+	if len(pe.state.FailedTasks) == 0 {
+		pe.state.MarkCompleted()
+	}
+	if err := SaveParallelState(pe.stateDir, pe.state.SpecName, pe.state); err != nil {
+		return fmt.Errorf("saving parallel state: %w", err)
+	}
+	return nil
+}
+
+// saveInterrupted marks the execution interrupted and persists it.
+func (pe *ParallelExecutor) saveInterrupted() {
+	if pe.state == nil {
+		return
+	}
+	pe.state.MarkInterrupted()
+	pe.saveState()
+}
+
+// saveState persists the state, warning on failure since progress tracking
+// must not stop the execution.
+func (pe *ParallelExecutor) saveState() {
+	if err := SaveParallelState(pe.stateDir, pe.state.SpecName, pe.state); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: saving parallel state: %v\n", err)
+	}
+}
+
+// errorMessage returns err's message, or an empty string for nil.
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
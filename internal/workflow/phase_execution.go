@@ -18,6 +18,10 @@ const (
 	ModeParallel
 )
 
+// DefaultMaxParallel is the default maximum number of concurrent sessions
+// for parallel implement, shared by autospec implement and autospec run.
+const DefaultMaxParallel = 4
+
 // PhaseExecutionOptions contains configuration for phase-based execution
 type PhaseExecutionOptions struct {
 	// RunAllPhases indicates --phases flag was set (run each phase in separate session)
@@ -32,7 +36,7 @@ type PhaseExecutionOptions struct {
 	FromTask string
 	// ParallelMode indicates --parallel flag was set (DAG-based concurrent execution)
 	ParallelMode bool
-	// MaxParallel is the maximum number of concurrent Claude sessions (default 4)
+	// MaxParallel is the maximum number of concurrent Claude sessions (default DefaultMaxParallel)
 	MaxParallel int
 	// UseWorktrees indicates --worktrees flag was set (git worktree isolation)
 	UseWorktrees bool
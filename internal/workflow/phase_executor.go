@@ -10,6 +10,7 @@ import (
 
 	"github.com/ariel-frischer/autospec/internal/commands"
 	"github.com/ariel-frischer/autospec/internal/prereqs"
+	"github.com/ariel-frischer/autospec/internal/retry"
 	"github.com/ariel-frischer/autospec/internal/validation"
 )
 
@@ -214,13 +215,13 @@ func (p *PhaseExecutor) buildPhaseCommand(phaseNumber int, contextFilePath, prom
 
 // computeAndRenderImplementCommand gets and renders the implement template.
 func (p *PhaseExecutor) computeAndRenderImplementCommand() (string, error) {
-	content, err := commands.GetTemplate("autospec.implement")
-	if err != nil {
-		return "", fmt.Errorf("loading template autospec.implement: %w", err)
-	}
+	return renderImplementCommand(p.specsDir)
+}
 
+// renderImplementCommand renders the autospec.implement template for specsDir.
+func renderImplementCommand(specsDir string) (string, error) {
 	opts := prereqs.Options{
-		SpecsDir:     p.specsDir,
+		SpecsDir:     specsDir,
 		RequireTasks: true,
 	}
 	ctx, err := prereqs.ComputeContext(opts)
@@ -228,7 +229,7 @@ func (p *PhaseExecutor) computeAndRenderImplementCommand() (string, error) {
 		return "", fmt.Errorf("computing prereqs context: %w", err)
 	}
 
-	rendered, err := commands.RenderAndValidate("autospec.implement", content, ctx)
+	rendered, err := commands.RenderCommand("autospec.implement", ctx)
 	if err != nil {
 		return "", fmt.Errorf("rendering template: %w", err)
 	}
@@ -254,6 +255,7 @@ func (p *PhaseExecutor) executePhaseWithValidation(specName string, phaseNumber
 			return nil
 		},
 	)
+	p.executor.recordUsage(specName, retry.PhaseUsageScope(phaseNumber), result.Usage, result.Agent)
 	if err != nil {
 		if result.Exhausted {
 			fmt.Printf("\nPhase %d paused.\n", phaseNumber)
@@ -433,7 +433,7 @@ func GeneratePrerequisiteError(stageConfig *StageConfig, missingArtifacts []stri
 	formatMissingArtifacts(&sb, missingArtifacts)
 	formatInvalidArtifacts(&sb, invalidArtifacts)
 	formatStageArtifactMapping(&sb, stageConfig, missingArtifacts, invalidArtifacts)
-	formatMissingRemediation(&sb, missingArtifacts)
+	formatMissingRemediation(&sb, stageConfig, missingArtifacts)
 	formatInvalidRemediation(&sb, invalidArtifacts)
 
 	return sb.String()
@@ -466,7 +466,7 @@ func formatStageArtifactMapping(sb *strings.Builder, stageConfig *StageConfig, m
 	allProblematic := collectProblematicArtifacts(missingArtifacts, invalidArtifacts)
 	sb.WriteString("\nThe following stages require these artifacts:\n")
 	for _, stage := range stageConfig.GetSelectedStages() {
-		for _, req := range GetRequiredArtifacts(stage) {
+		for _, req := range stageConfig.dependencyFor(stage).Requires {
 			for _, problematic := range allProblematic {
 				if req == problematic {
 					sb.WriteString(fmt.Sprintf("  - %s requires %s\n", stage, req))
@@ -487,7 +487,7 @@ func collectProblematicArtifacts(missingArtifacts []string, invalidArtifacts map
 }
 
 // formatMissingRemediation writes suggestions for generating missing artifacts
-func formatMissingRemediation(sb *strings.Builder, missingArtifacts []string) {
+func formatMissingRemediation(sb *strings.Builder, stageConfig *StageConfig, missingArtifacts []string) {
 	if len(missingArtifacts) == 0 {
 		return
 	}
@@ -501,6 +501,13 @@ func formatMissingRemediation(sb *strings.Builder, missingArtifacts []string) {
 	if containsArtifact(missingArtifacts, "tasks.yaml") {
 		sb.WriteString("  autospec run -t                         # Generate tasks.yaml\n")
 	}
+	for _, custom := range stageConfig.Custom {
+		for _, artifact := range custom.Produces {
+			if containsArtifact(missingArtifacts, artifact) {
+				sb.WriteString(fmt.Sprintf("  autospec run --stages %s  # Generate %s\n", custom.Name, artifact))
+			}
+		}
+	}
 }
 
 // formatInvalidRemediation writes suggestions for fixing invalid artifacts
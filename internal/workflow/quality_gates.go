@@ -0,0 +1,86 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/ariel-frischer/autospec/internal/verification"
+)
+
+// runQualityGates enforces the verification quality gates once implementation
+// is complete. It is a no-op when no gate commands are configured or tasks
+// remain (e.g., after implement --phase N), since partial implementations are
+// not expected to meet project-wide thresholds.
+func (w *WorkflowOrchestrator) runQualityGates(specName string) error {
+	if w.Config == nil || !w.Config.Verification.HasGates() {
+		return nil
+	}
+	tasksPath := validation.GetTasksFilePath(filepath.Join(w.SpecsDir, specName))
+	if err := w.Executor.ValidateTasksComplete(tasksPath); err != nil {
+		w.debugLog("Skipping quality gates: %v", err)
+		return nil
+	}
+
+	buildCommand := func() (string, error) {
+		return renderImplementCommand(w.SpecsDir)
+	}
+	return executeQualityGates(w.Executor, specName, "", &w.Config.Verification, buildCommand)
+}
+
+// executeQualityGates runs the gates in dir and, if any fail, runs the implement
+// command as the verify stage with the failures as retry context. The stage's
+// validation re-runs the gates, so the agent keeps retrying (up to MaxRetries)
+// until they pass. buildCommand is only called when a gate fails.
+func executeQualityGates(e *Executor, specName, dir string, cfg *verification.VerificationConfig, buildCommand func() (string, error)) error {
+	fmt.Println("\nRunning quality gates...")
+	report := verification.RunGates(context.Background(), dir, cfg)
+	printGateReport(os.Stdout, report)
+	if report.Passed() {
+		return nil
+	}
+
+	command, err := buildCommand()
+	if err != nil {
+		return fmt.Errorf("building implement command: %w", err)
+	}
+
+	validateGates := func(string) error {
+		report := verification.RunGates(context.Background(), dir, cfg)
+		printGateReport(os.Stdout, report)
+		if report.Passed() {
+			return nil
+		}
+		return fmt.Errorf("quality gates failed:\n- %s", strings.Join(report.Failures(), "\n- "))
+	}
+
+	result, err := e.ExecuteStageWithFailures(specName, StageVerify, command, report.Failures(), validateGates)
+	if err != nil {
+		if result != nil && result.Exhausted {
+			fmt.Println("\nQuality gates still failing after retries.")
+			fmt.Println("Fix the reported issues, then re-run: autospec implement")
+			return fmt.Errorf("quality gates exhausted retries: %w", err)
+		}
+		return fmt.Errorf("quality gates failed: %w", err)
+	}
+	return nil
+}
+
+// printGateReport prints one line per gate result.
+func printGateReport(out io.Writer, report *verification.GateReport) {
+	for _, res := range report.Results {
+		if !res.Passed {
+			fmt.Fprintf(out, "  ✗ %s\n", res.Failure())
+			continue
+		}
+		if res.Gate == verification.GateComplexity {
+			fmt.Fprintf(out, "  ✓ complexity: %d (max %d)\n", int(res.Value), int(res.Threshold))
+			continue
+		}
+		fmt.Fprintf(out, "  ✓ %s: %.1f%% (threshold %.1f%%)\n", res.Gate, res.Value*100, res.Threshold*100)
+	}
+}
@@ -0,0 +1,104 @@
+package workflow
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/verification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteQualityGates(t *testing.T) {
+	tests := map[string]struct {
+		initialCoverage string
+		fixedCoverage   string // written by the agent on each attempt; empty leaves the file unchanged
+		maxRetries      int
+		wantCalls       int
+		wantErr         string
+	}{
+		"gates pass without agent": {
+			initialCoverage: "total: (statements) 90.0%",
+			maxRetries:      2,
+			wantCalls:       0,
+		},
+		"agent fixes failing gate": {
+			initialCoverage: "total: (statements) 60.0%",
+			fixedCoverage:   "total: (statements) 88.0%",
+			maxRetries:      2,
+			wantCalls:       1,
+		},
+		"retries exhausted": {
+			initialCoverage: "total: (statements) 60.0%",
+			maxRetries:      2,
+			wantCalls:       2,
+			wantErr:         "quality gates exhausted retries",
+		},
+		"no retries allowed": {
+			initialCoverage: "total: (statements) 60.0%",
+			maxRetries:      0,
+			wantCalls:       0,
+			wantErr:         "quality gates exhausted retries",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			coveragePath := filepath.Join(dir, "coverage.txt")
+			require.NoError(t, os.WriteFile(coveragePath, []byte(tt.initialCoverage), 0o644))
+
+			mock := NewMockClaudeExecutor().WithExecuteFunc(func(string) error {
+				if tt.fixedCoverage == "" {
+					return nil
+				}
+				return os.WriteFile(coveragePath, []byte(tt.fixedCoverage), 0o644)
+			})
+			executor := &Executor{
+				Claude:     mock,
+				StateDir:   t.TempDir(),
+				SpecsDir:   t.TempDir(),
+				MaxRetries: tt.maxRetries,
+			}
+			cfg := &verification.VerificationConfig{
+				CoverageThreshold: 0.85,
+				CoverageCmd:       "cat coverage.txt",
+			}
+			buildCommand := func() (string, error) { return "/autospec.implement", nil }
+
+			err := executeQualityGates(executor, "001-gates", dir, cfg, buildCommand)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Len(t, mock.ExecuteCalls, tt.wantCalls)
+			if tt.wantCalls > 0 {
+				first := mock.ExecuteCalls[0]
+				assert.Contains(t, first, "/autospec.implement")
+				assert.Contains(t, first, "RETRY 1/")
+				assert.Contains(t, first, "coverage gate: 60.0% is below threshold 85.0%")
+			}
+		})
+	}
+}
+
+func TestExecuteQualityGates_BuildCommandError(t *testing.T) {
+	executor := &Executor{
+		Claude:     NewMockClaudeExecutor(),
+		StateDir:   t.TempDir(),
+		SpecsDir:   t.TempDir(),
+		MaxRetries: 1,
+	}
+	cfg := &verification.VerificationConfig{ComplexityMax: 5, ComplexityCmd: "echo '9 pkg Foo foo.go:1:1'"}
+	buildCommand := func() (string, error) { return "", errors.New("no tasks.yaml") }
+
+	err := executeQualityGates(executor, "001-gates", t.TempDir(), cfg, buildCommand)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "building implement command")
+}
@@ -0,0 +1,79 @@
+package workflow
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/ariel-frischer/autospec/internal/verification"
+)
+
+// runAutoReview runs the review stage after implementation when
+// verification.adversarial_review is enabled. Like the quality gates, it is
+// skipped while tasks remain.
+func (w *WorkflowOrchestrator) runAutoReview(specName string) error {
+	if w.Config == nil || !w.Config.Verification.IsEnabled(verification.FeatureAdversarialReview) {
+		return nil
+	}
+	tasksPath := validation.GetTasksFilePath(filepath.Join(w.SpecsDir, specName))
+	if err := w.Executor.ValidateTasksComplete(tasksPath); err != nil {
+		w.debugLog("Skipping adversarial review: %v", err)
+		return nil
+	}
+
+	fmt.Println("\nRunning adversarial review...")
+	if err := w.runReview(specName, ""); err != nil {
+		return fmt.Errorf("adversarial review: %w", err)
+	}
+	return nil
+}
+
+// runReview runs the review stage and prints its findings. With
+// verification.review_blocking, open critical findings in review.yaml
+// fail the run so the spec is not reported as complete.
+func (w *WorkflowOrchestrator) runReview(specName, prompt string) error {
+	if err := w.stageExecutor.ExecuteReview(specName, prompt); err != nil {
+		return err
+	}
+
+	reviewPath := validation.GetReviewFilePath(filepath.Join(w.SpecsDir, specName))
+	printReviewSummary(reviewPath)
+
+	if w.Config == nil || !w.Config.Verification.ReviewBlocking {
+		return nil
+	}
+	if err := w.Executor.ValidateReviewResolved(reviewPath); err != nil {
+		fmt.Println("\nResolve the critical findings (or mark them resolved/wont_fix), then re-run: autospec review")
+		return fmt.Errorf("review blocking completion: %w", err)
+	}
+	return nil
+}
+
+// printReviewSummary prints finding counts by severity and lists open
+// critical and high findings.
+func printReviewSummary(reviewPath string) {
+	findings, err := validation.GetReviewFindings(reviewPath)
+	if err != nil {
+		fmt.Printf("Warning: could not read review findings: %v\n", err)
+		return
+	}
+
+	counts := make(map[string]int)
+	var open []validation.ReviewFinding
+	for _, f := range findings {
+		if !f.IsOpen() {
+			continue
+		}
+		counts[f.Severity]++
+		if f.Severity == "CRITICAL" || f.Severity == "HIGH" {
+			open = append(open, f)
+		}
+	}
+
+	fmt.Printf("Review: %d open finding(s) (%d critical, %d high, %d medium, %d low)\n",
+		counts["CRITICAL"]+counts["HIGH"]+counts["MEDIUM"]+counts["LOW"],
+		counts["CRITICAL"], counts["HIGH"], counts["MEDIUM"], counts["LOW"])
+	for _, f := range open {
+		fmt.Printf("  %s [%s] %s: %s\n", f.ID, f.Severity, f.Location(), f.Summary)
+	}
+}
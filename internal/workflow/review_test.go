@@ -0,0 +1,175 @@
+package workflow
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/verification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const reviewTestSpec = "001-review"
+
+func newReviewTestOrchestrator(t *testing.T, verify verification.VerificationConfig, taskStatus, reviewYAML string) (*WorkflowOrchestrator, *MockStageExecutor) {
+	t.Helper()
+
+	specsDir := t.TempDir()
+	specDir := filepath.Join(specsDir, reviewTestSpec)
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+
+	tasks := `phases:
+  - number: 1
+    title: "Setup"
+    tasks:
+      - id: "T001"
+        title: "Create module"
+        status: "` + taskStatus + `"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "tasks.yaml"), []byte(tasks), 0o644))
+
+	mockStage := NewMockStageExecutor()
+	if reviewYAML != "" {
+		require.NoError(t, os.WriteFile(filepath.Join(specDir, "review.yaml"), []byte(reviewYAML), 0o644))
+	}
+
+	cfg := &config.Configuration{
+		SpecsDir:     specsDir,
+		StateDir:     t.TempDir(),
+		Verification: verify,
+	}
+	orch := NewWorkflowOrchestratorWithExecutors(cfg, ExecutorOptions{StageExecutor: mockStage})
+	orch.SkipPreflight = true
+	return orch, mockStage
+}
+
+const criticalReviewYAML = `review:
+  branch: "001-review"
+  timestamp: "2025-01-01T00:00:00Z"
+findings:
+  - id: "REV-001"
+    severity: "CRITICAL"
+    category: "acceptance_criteria"
+    file: "main.go"
+    line: 10
+    summary: "Criterion not met"
+    status: "open"
+summary:
+  verdict: "REQUEST_CHANGES"
+`
+
+func TestRunReview(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		reviewBlocking bool
+		reviewYAML     string
+		stageErr       error
+		wantErr        string
+	}{
+		"non-blocking review with critical findings succeeds": {
+			reviewYAML: criticalReviewYAML,
+		},
+		"blocking review with critical findings fails": {
+			reviewBlocking: true,
+			reviewYAML:     criticalReviewYAML,
+			wantErr:        "review blocking completion",
+		},
+		"blocking review with no findings succeeds": {
+			reviewBlocking: true,
+			reviewYAML:     "findings: []\n",
+		},
+		"stage error is returned": {
+			stageErr: errors.New("agent failed"),
+			wantErr:  "agent failed",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			verify := verification.VerificationConfig{ReviewBlocking: tt.reviewBlocking}
+			orch, mockStage := newReviewTestOrchestrator(t, verify, "Completed", tt.reviewYAML)
+			mockStage.ReviewError = tt.stageErr
+
+			err := orch.ExecuteReview(reviewTestSpec, "focus on auth")
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Len(t, mockStage.ReviewCalls, 1)
+			assert.Equal(t, ReviewCall{SpecName: reviewTestSpec, Prompt: "focus on auth"}, mockStage.ReviewCalls[0])
+		})
+	}
+}
+
+func TestRunAutoReview(t *testing.T) {
+	t.Parallel()
+
+	enabled := true
+	disabled := false
+
+	tests := map[string]struct {
+		verify     verification.VerificationConfig
+		taskStatus string
+		wantCalls  int
+		wantErr    bool
+	}{
+		"basic level skips review": {
+			verify:     verification.VerificationConfig{Level: verification.LevelBasic},
+			taskStatus: "Completed",
+			wantCalls:  0,
+		},
+		"full level runs review": {
+			verify:     verification.VerificationConfig{Level: verification.LevelFull},
+			taskStatus: "Completed",
+			wantCalls:  1,
+		},
+		"explicit toggle runs review": {
+			verify:     verification.VerificationConfig{Level: verification.LevelBasic, AdversarialReview: &enabled},
+			taskStatus: "Completed",
+			wantCalls:  1,
+		},
+		"explicit toggle overrides full level": {
+			verify:     verification.VerificationConfig{Level: verification.LevelFull, AdversarialReview: &disabled},
+			taskStatus: "Completed",
+			wantCalls:  0,
+		},
+		"incomplete tasks skip review": {
+			verify:     verification.VerificationConfig{Level: verification.LevelFull},
+			taskStatus: "Pending",
+			wantCalls:  0,
+		},
+		"blocking critical findings fail": {
+			verify:     verification.VerificationConfig{Level: verification.LevelFull, ReviewBlocking: true},
+			taskStatus: "Completed",
+			wantCalls:  1,
+			wantErr:    true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			orch, mockStage := newReviewTestOrchestrator(t, tt.verify, tt.taskStatus, criticalReviewYAML)
+
+			err := orch.runAutoReview(reviewTestSpec)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "adversarial review")
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Len(t, mockStage.ReviewCalls, tt.wantCalls)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Package workflow provides project schema extension instructions for agent prompt injection.
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+)
+
+// schemaArtifactFiles names the file each artifact type is written to, for prompts.
+var schemaArtifactFiles = map[validation.ArtifactType]string{
+	validation.ArtifactTypeSpec:         "spec.yaml",
+	validation.ArtifactTypePlan:         "plan.yaml",
+	validation.ArtifactTypeTasks:        "tasks.yaml",
+	validation.ArtifactTypeAnalysis:     "analysis.yaml",
+	validation.ArtifactTypeChecklist:    "the checklist YAML",
+	validation.ArtifactTypeConstitution: "constitution.yaml",
+	validation.ArtifactTypeReview:       "review.yaml",
+}
+
+// BuildSchemaExtensionInstructions returns an InjectableInstruction telling the
+// agent about the project-specific fields of an artifact, as defined in
+// .autospec/schemas/<type>.yaml. Only the extension is listed; the built-in
+// structure is already described by the command template.
+func BuildSchemaExtensionInstructions(artifactType validation.ArtifactType, ext *validation.Schema) InjectableInstruction {
+	file := schemaArtifactFiles[artifactType]
+	if file == "" {
+		file = string(artifactType) + ".yaml"
+	}
+
+	content := fmt.Sprintf("## Project Schema Extensions\n\n"+
+		"This project extends the %s schema (%s). In addition to the standard structure, %s must satisfy:\n\n```\n%s```\n",
+		artifactType, validation.SchemaExtensionPath(artifactType), file, validation.FormatSchemaOutline(ext))
+	if ext.Description != "" {
+		content += "\n" + ext.Description + "\n"
+	}
+
+	return InjectableInstruction{
+		Name:        "SchemaExtensions",
+		DisplayHint: "project-specific fields in " + file,
+		Content:     content,
+	}
+}
+
+// InjectSchemaExtension appends the project schema extension for artifactType
+// to a command. If the project has no extension, returns the command unchanged.
+func InjectSchemaExtension(command string, artifactType validation.ArtifactType) (string, error) {
+	ext, err := validation.LoadSchemaExtension(artifactType)
+	if err != nil {
+		return "", err
+	}
+	if ext == nil {
+		return command, nil
+	}
+	return InjectInstructions(command, []InjectableInstruction{BuildSchemaExtensionInstructions(artifactType, ext)}), nil
+}
@@ -0,0 +1,74 @@
+// Package workflow_test tests project schema extension instruction injection.
+// Related: internal/workflow/schema_instructions.go
+// Tags: schema, extension, injectable-instruction, spec, injection
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSchemaExtensionInstructions(t *testing.T) {
+	t.Parallel()
+
+	ext := &validation.Schema{
+		Description: "Every spec links a ticket.",
+		Fields: []validation.SchemaField{
+			{Name: "feature", Type: validation.FieldTypeObject, Children: []validation.SchemaField{
+				{Name: "ticket", Type: validation.FieldTypeString, Required: true, Pattern: `^PROJ-\d+$`},
+			}},
+		},
+	}
+
+	tests := map[string]struct {
+		artifactType validation.ArtifactType
+		wantHint     string
+	}{
+		"spec":      {artifactType: validation.ArtifactTypeSpec, wantHint: "project-specific fields in spec.yaml"},
+		"checklist": {artifactType: validation.ArtifactTypeChecklist, wantHint: "project-specific fields in the checklist YAML"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			inst := BuildSchemaExtensionInstructions(tt.artifactType, ext)
+			assert.Equal(t, "SchemaExtensions", inst.Name)
+			assert.Equal(t, tt.wantHint, inst.DisplayHint)
+			assert.Contains(t, inst.Content, validation.SchemaExtensionPath(tt.artifactType))
+			assert.Contains(t, inst.Content, "  ticket: string (required, pattern ^PROJ-\\d+$)")
+			assert.Contains(t, inst.Content, "Every spec links a ticket.")
+		})
+	}
+}
+
+func TestInjectSchemaExtension(t *testing.T) {
+	tmpDir := t.TempDir()
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	got, err := InjectSchemaExtension("/autospec.plan", validation.ArtifactTypePlan)
+	require.NoError(t, err)
+	assert.Equal(t, "/autospec.plan", got, "command is unchanged without an extension")
+
+	require.NoError(t, os.MkdirAll(validation.SchemaExtensionDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(validation.SchemaExtensionDir, "plan.yaml"),
+		[]byte("fields:\n  - name: rollout\n    type: string\n    required: true\n"), 0o644))
+
+	got, err = InjectSchemaExtension("/autospec.plan", validation.ArtifactTypePlan)
+	require.NoError(t, err)
+	assert.Contains(t, got, "<!-- AUTOSPEC_INJECT:SchemaExtensions:project-specific fields in plan.yaml -->")
+	assert.Contains(t, got, "rollout: string (required)")
+
+	require.NoError(t, os.WriteFile(filepath.Join(validation.SchemaExtensionDir, "plan.yaml"),
+		[]byte("fields:\n  - name: rollout\n"), 0o644))
+	_, err = InjectSchemaExtension("/autospec.plan", validation.ArtifactTypePlan)
+	assert.ErrorContains(t, err, "type is required")
+}
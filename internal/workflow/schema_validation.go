@@ -14,13 +14,16 @@ import (
 )
 
 // ValidateSpecSchema validates a spec.yaml file against its full schema.
-// It wraps the existing SpecValidator and returns an error suitable for
+// It wraps the SpecValidator (plus any project schema extension) and returns an error suitable for
 // ExecuteStage's validation callback.
 //
 // Performance contract: <10ms (delegated to existing validator)
 func ValidateSpecSchema(specDir string) error {
 	specPath := filepath.Join(specDir, "spec.yaml")
-	validator := &validation.SpecValidator{}
+	validator, err := validation.NewArtifactValidator(validation.ArtifactTypeSpec)
+	if err != nil {
+		return err
+	}
 	result := validator.Validate(specPath)
 
 	if result.Valid {
@@ -31,13 +34,16 @@ func ValidateSpecSchema(specDir string) error {
 }
 
 // ValidatePlanSchema validates a plan.yaml file against its full schema.
-// It wraps the existing PlanValidator and returns an error suitable for
+// It wraps the PlanValidator (plus any project schema extension) and returns an error suitable for
 // ExecuteStage's validation callback.
 //
 // Performance contract: <10ms (delegated to existing validator)
 func ValidatePlanSchema(specDir string) error {
 	planPath := filepath.Join(specDir, "plan.yaml")
-	validator := &validation.PlanValidator{}
+	validator, err := validation.NewArtifactValidator(validation.ArtifactTypePlan)
+	if err != nil {
+		return err
+	}
 	result := validator.Validate(planPath)
 
 	if result.Valid {
@@ -48,13 +54,16 @@ func ValidatePlanSchema(specDir string) error {
 }
 
 // ValidateTasksSchema validates a tasks.yaml file against its full schema.
-// It wraps the existing TasksValidator and returns an error suitable for
+// It wraps the TasksValidator (plus any project schema extension) and returns an error suitable for
 // ExecuteStage's validation callback.
 //
 // Performance contract: <10ms (delegated to existing validator)
 func ValidateTasksSchema(specDir string) error {
 	tasksPath := filepath.Join(specDir, "tasks.yaml")
-	validator := &validation.TasksValidator{}
+	validator, err := validation.NewArtifactValidator(validation.ArtifactTypeTasks)
+	if err != nil {
+		return err
+	}
 	result := validator.Validate(tasksPath)
 
 	if result.Valid {
@@ -64,6 +73,24 @@ func ValidateTasksSchema(specDir string) error {
 	return formatValidationErrors("tasks.yaml", result.Errors)
 }
 
+// ValidateReviewSchema validates a review.yaml file against its full schema.
+// It wraps the ReviewValidator (plus any project schema extension) and returns an error suitable for
+// ExecuteStage's validation callback.
+func ValidateReviewSchema(specDir string) error {
+	reviewPath := validation.GetReviewFilePath(specDir)
+	validator, err := validation.NewArtifactValidator(validation.ArtifactTypeReview)
+	if err != nil {
+		return err
+	}
+	result := validator.Validate(reviewPath)
+
+	if result.Valid {
+		return nil
+	}
+
+	return formatValidationErrors("review.yaml", result.Errors)
+}
+
 // MakeSpecSchemaValidatorWithDetection creates a validation function that first
 // detects the current spec directory, then validates spec.yaml against its schema.
 // This is necessary for the specify stage where the spec name is not known until
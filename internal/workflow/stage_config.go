@@ -1,9 +1,11 @@
 package workflow
 
+import "sort"
+
 // StageConfig represents the user's selected stages for execution.
-// It determines which workflow stages (specify, plan, tasks, implement)
-// and optional stages (constitution, clarify, checklist, analyze)
-// will be executed during a run.
+// It determines which workflow stages (specify, plan, tasks, implement),
+// optional stages (constitution, clarify, checklist, analyze), and
+// config-defined custom stages will be executed during a run.
 type StageConfig struct {
 	// Core workflow stages
 	Specify   bool
@@ -16,6 +18,9 @@ type StageConfig struct {
 	Clarify      bool
 	Checklist    bool
 	Analyze      bool
+
+	// Custom stages selected from config (see CustomStage)
+	Custom []CustomStage
 }
 
 // NewStageConfig creates a new StageConfig with all stages disabled.
@@ -36,12 +41,29 @@ func NewStageConfigAll() *StageConfig {
 // HasAnyStage returns true if any stage (core or optional) is selected.
 func (sc *StageConfig) HasAnyStage() bool {
 	return sc.Specify || sc.Plan || sc.Tasks || sc.Implement ||
-		sc.Constitution || sc.Clarify || sc.Checklist || sc.Analyze
+		sc.Constitution || sc.Clarify || sc.Checklist || sc.Analyze ||
+		len(sc.Custom) > 0
+}
+
+// canonicalStages lists the built-in stages in canonical order.
+var canonicalStages = []Stage{
+	StageConstitution, StageSpecify, StageClarify, StagePlan,
+	StageTasks, StageChecklist, StageAnalyze, StageImplement,
 }
 
 // GetSelectedStages returns a slice of selected stages in canonical order.
 // The canonical order is always: constitution -> specify -> clarify -> plan -> tasks -> checklist -> analyze -> implement.
+// Custom stages run directly after the stage they are configured to follow
+// (see CustomStage.After); siblings run in name order.
 func (sc *StageConfig) GetSelectedStages() []Stage {
+	if len(sc.Custom) > 0 {
+		return sc.orderWithCustomStages()
+	}
+	return sc.selectedBuiltinStages()
+}
+
+// selectedBuiltinStages returns the selected built-in stages in canonical order.
+func (sc *StageConfig) selectedBuiltinStages() []Stage {
 	stages := make([]Stage, 0, 8)
 	if sc.Constitution {
 		stages = append(stages, StageConstitution)
@@ -70,6 +92,72 @@ func (sc *StageConfig) GetSelectedStages() []Stage {
 	return stages
 }
 
+// orderWithCustomStages interleaves the selected custom stages with the
+// selected built-in stages. Each custom stage is placed after the nearest
+// stage in its After chain that is built-in or selected, so a custom stage
+// anchored to an unselected built-in keeps that built-in's position.
+func (sc *StageConfig) orderWithCustomStages() []Stage {
+	selected := make(map[Stage]bool)
+	for _, stage := range sc.selectedBuiltinStages() {
+		selected[stage] = true
+	}
+	for _, custom := range sc.Custom {
+		selected[custom.Name] = true
+	}
+
+	followers := make(map[Stage][]Stage)
+	for _, custom := range sc.Custom {
+		anchor := StageImplement
+		for _, after := range custom.After {
+			if selected[after] || IsBuiltinStage(after) {
+				anchor = after
+				break
+			}
+		}
+		followers[anchor] = append(followers[anchor], custom.Name)
+	}
+	for _, names := range followers {
+		sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	}
+
+	stages := make([]Stage, 0, len(selected))
+	var appendFollowers func(Stage)
+	appendFollowers = func(anchor Stage) {
+		for _, name := range followers[anchor] {
+			stages = append(stages, name)
+			appendFollowers(name)
+		}
+	}
+	for _, stage := range canonicalStages {
+		if selected[stage] {
+			stages = append(stages, stage)
+		}
+		appendFollowers(stage)
+	}
+	return stages
+}
+
+// IsBuiltinStage returns true if stage is one of the stages selectable with
+// run flags (constitution through implement).
+func IsBuiltinStage(stage Stage) bool {
+	for _, builtin := range canonicalStages {
+		if builtin == stage {
+			return true
+		}
+	}
+	return false
+}
+
+// CustomStage returns the selected custom stage named name.
+func (sc *StageConfig) CustomStage(name Stage) (CustomStage, bool) {
+	for _, custom := range sc.Custom {
+		if custom.Name == name {
+			return custom, true
+		}
+	}
+	return CustomStage{}, false
+}
+
 // GetCanonicalOrder is an alias for GetSelectedStages that returns stages
 // in the canonical execution order:
 // constitution -> specify -> clarify -> plan -> tasks -> checklist -> analyze -> implement
@@ -87,9 +175,9 @@ func (sc *StageConfig) SetAll() {
 	sc.Implement = true
 }
 
-// Count returns the number of selected stages (core and optional).
+// Count returns the number of selected stages (core, optional, and custom).
 func (sc *StageConfig) Count() int {
-	count := 0
+	count := len(sc.Custom)
 	// Core stages
 	if sc.Specify {
 		count++
@@ -173,6 +261,11 @@ var artifactDependencies = map[Stage]ArtifactDependency{
 		Requires: []string{"spec.yaml", "plan.yaml", "tasks.yaml"}, // Analyze validates all artifacts
 		Produces: []string{},                                       // Analyze outputs analysis report
 	},
+	StageReview: {
+		Stage:    StageReview,
+		Requires: []string{"spec.yaml", "tasks.yaml"}, // Review checks the diff against acceptance criteria
+		Produces: []string{"review.yaml"},
+	},
 }
 
 // GetArtifactDependencies returns the complete dependency map for all stages.
@@ -206,6 +299,15 @@ func GetProducedArtifacts(stage Stage) []string {
 	return []string{}
 }
 
+// dependencyFor returns the artifact dependency of a built-in or selected
+// custom stage.
+func (sc *StageConfig) dependencyFor(stage Stage) ArtifactDependency {
+	if custom, ok := sc.CustomStage(stage); ok {
+		return custom.Dependency()
+	}
+	return artifactDependencies[stage]
+}
+
 // GetAllRequiredArtifacts returns all artifacts required by the selected stages,
 // excluding artifacts that will be produced by earlier selected stages.
 func (sc *StageConfig) GetAllRequiredArtifacts() []string {
@@ -214,7 +316,7 @@ func (sc *StageConfig) GetAllRequiredArtifacts() []string {
 
 	// Iterate through stages in canonical order
 	for _, stage := range sc.GetCanonicalOrder() {
-		dep := artifactDependencies[stage]
+		dep := sc.dependencyFor(stage)
 
 		// Add requirements that won't be produced by earlier stages
 		for _, req := range dep.Requires {
@@ -323,9 +323,9 @@ func TestCount(t *testing.T) {
 func TestGetArtifactDependencies(t *testing.T) {
 	deps := GetArtifactDependencies()
 
-	// 4 core stages + 4 optional stages = 8 total
-	if len(deps) != 8 {
-		t.Errorf("GetArtifactDependencies() returned %d entries, want 8", len(deps))
+	// 4 core stages + 4 optional stages + review = 9 total
+	if len(deps) != 9 {
+		t.Errorf("GetArtifactDependencies() returned %d entries, want 9", len(deps))
 	}
 
 	// Verify each stage has a dependency entry
@@ -334,6 +334,8 @@ func TestGetArtifactDependencies(t *testing.T) {
 		StageSpecify, StagePlan, StageTasks, StageImplement,
 		// Optional stages
 		StageConstitution, StageClarify, StageChecklist, StageAnalyze,
+		// Post-implement stages
+		StageReview,
 	}
 	for _, stage := range stages {
 		if _, ok := deps[stage]; !ok {
@@ -423,6 +425,8 @@ func TestGetRequiredArtifacts(t *testing.T) {
 		{StageClarify, []string{"spec.yaml"}},
 		{StageChecklist, []string{"spec.yaml"}},
 		{StageAnalyze, []string{"spec.yaml", "plan.yaml", "tasks.yaml"}},
+		// Post-implement stages
+		{StageReview, []string{"spec.yaml", "tasks.yaml"}},
 	}
 
 	for _, tt := range tests {
@@ -450,6 +454,8 @@ func TestGetProducedArtifacts(t *testing.T) {
 		{StageClarify, []string{}},
 		{StageChecklist, []string{}},
 		{StageAnalyze, []string{}},
+		// Post-implement stages
+		{StageReview, []string{"review.yaml"}},
 	}
 
 	for _, tt := range tests {
@@ -564,3 +570,112 @@ func TestGetAllRequiredArtifacts(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSelectedStages_CustomStages(t *testing.T) {
+	threat := CustomStage{Name: "threat-model", After: []Stage{StagePlan}}
+	contract := CustomStage{Name: "api-contract", After: []Stage{"threat-model", StagePlan}}
+	audit := CustomStage{Name: "audit"}
+	lint := CustomStage{Name: "lint", After: []Stage{StagePlan}}
+
+	tests := map[string]struct {
+		config   StageConfig
+		expected []Stage
+	}{
+		"custom stage after its anchor": {
+			config:   StageConfig{Plan: true, Tasks: true, Custom: []CustomStage{threat}},
+			expected: []Stage{StagePlan, "threat-model", StageTasks},
+		},
+		"anchor not selected keeps its position": {
+			config:   StageConfig{Specify: true, Tasks: true, Custom: []CustomStage{threat}},
+			expected: []Stage{StageSpecify, "threat-model", StageTasks},
+		},
+		"chained custom stages": {
+			config:   StageConfig{Plan: true, Tasks: true, Custom: []CustomStage{contract, threat}},
+			expected: []Stage{StagePlan, "threat-model", "api-contract", StageTasks},
+		},
+		"unselected custom anchor falls back along the chain": {
+			config:   StageConfig{Plan: true, Tasks: true, Custom: []CustomStage{contract}},
+			expected: []Stage{StagePlan, "api-contract", StageTasks},
+		},
+		"siblings in name order": {
+			config:   StageConfig{Plan: true, Custom: []CustomStage{threat, lint}},
+			expected: []Stage{StagePlan, "lint", "threat-model"},
+		},
+		"no after runs after implement": {
+			config:   StageConfig{Tasks: true, Implement: true, Custom: []CustomStage{audit}},
+			expected: []Stage{StageTasks, StageImplement, "audit"},
+		},
+		"custom stage only": {
+			config:   StageConfig{Custom: []CustomStage{threat}},
+			expected: []Stage{"threat-model"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tt.config.GetSelectedStages()
+			if len(got) != len(tt.expected) {
+				t.Fatalf("GetSelectedStages() = %v, want %v", got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("GetSelectedStages() = %v, want %v", got, tt.expected)
+					break
+				}
+			}
+			if tt.config.Count() != len(tt.expected) {
+				t.Errorf("Count() = %d, want %d", tt.config.Count(), len(tt.expected))
+			}
+			if !tt.config.HasAnyStage() {
+				t.Error("HasAnyStage() = false, want true")
+			}
+		})
+	}
+}
+
+func TestGetAllRequiredArtifacts_CustomStages(t *testing.T) {
+	threat := CustomStage{Name: "threat-model", Requires: []string{"plan.yaml"}, Produces: []string{"threat-model.yaml"}, After: []Stage{StagePlan}}
+	contract := CustomStage{Name: "api-contract", Requires: []string{"threat-model.yaml"}, After: []Stage{StageTasks}}
+
+	tests := map[string]struct {
+		config   StageConfig
+		expected []string
+	}{
+		"requirements of custom stage": {
+			config:   StageConfig{Custom: []CustomStage{threat}},
+			expected: []string{"plan.yaml"},
+		},
+		"produced by earlier built-in stage": {
+			config:   StageConfig{Plan: true, Custom: []CustomStage{threat}},
+			expected: []string{"spec.yaml"},
+		},
+		"produced by earlier custom stage": {
+			config:   StageConfig{Custom: []CustomStage{threat, contract}},
+			expected: []string{"plan.yaml"},
+		},
+		"produced by unselected custom stage": {
+			config:   StageConfig{Custom: []CustomStage{contract}},
+			expected: []string{"threat-model.yaml"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tt.config.GetAllRequiredArtifacts()
+			if len(got) != len(tt.expected) {
+				t.Fatalf("GetAllRequiredArtifacts() = %v, want %v", got, tt.expected)
+			}
+			for _, want := range tt.expected {
+				found := false
+				for _, artifact := range got {
+					if artifact == want {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("GetAllRequiredArtifacts() = %v, missing %s", got, want)
+				}
+			}
+		})
+	}
+}
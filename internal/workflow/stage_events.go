@@ -0,0 +1,63 @@
+package workflow
+
+import (
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/events"
+)
+
+// publishStageStarted publishes a stage.started event for the stage's first attempt.
+func (e *Executor) publishStageStarted(ctx *stageExecutionContext) {
+	events.Publish(events.Event{
+		Type:        events.StageStarted,
+		Spec:        ctx.specName,
+		Stage:       string(ctx.stage),
+		Attempt:     ctx.retryState.Count + 1,
+		MaxAttempts: e.MaxRetries + 1,
+	})
+}
+
+// publishStageCompleted publishes a stage.completed event with the stage outcome.
+func (e *Executor) publishStageCompleted(ctx *stageExecutionContext, err error, duration time.Duration) {
+	evt := events.Event{
+		Type:        events.StageCompleted,
+		Spec:        ctx.specName,
+		Stage:       string(ctx.stage),
+		Status:      events.StatusCompleted,
+		Attempt:     ctx.retryState.Count + 1,
+		MaxAttempts: e.MaxRetries + 1,
+		Agent:       ctx.result.Agent,
+		DurationMS:  duration.Milliseconds(),
+	}
+	if err != nil || !ctx.result.Success {
+		evt.Status = events.StatusFailed
+	}
+	if err != nil {
+		evt.Error = err.Error()
+	}
+	events.Publish(evt)
+}
+
+// publishValidationFailed publishes a validation.failed event for the current attempt.
+func (e *Executor) publishValidationFailed(ctx *stageExecutionContext) {
+	events.Publish(events.Event{
+		Type:        events.ValidationFailed,
+		Spec:        ctx.specName,
+		Stage:       string(ctx.stage),
+		Attempt:     ctx.retryState.Count + 1,
+		MaxAttempts: e.MaxRetries + 1,
+		Errors:      ctx.result.ValidationErrors,
+	})
+}
+
+// publishStageRetried publishes a stage.retried event for the attempt about to run.
+func (e *Executor) publishStageRetried(ctx *stageExecutionContext) {
+	events.Publish(events.Event{
+		Type:        events.StageRetried,
+		Spec:        ctx.specName,
+		Stage:       string(ctx.stage),
+		Attempt:     ctx.retryState.Count + 1,
+		MaxAttempts: e.MaxRetries + 1,
+		Errors:      ctx.lastValidationErrors,
+	})
+}
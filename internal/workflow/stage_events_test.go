@@ -0,0 +1,102 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureSpecEvents records events published for specName until the test ends.
+// The bus is process-wide, so filtering by spec keeps parallel tests apart.
+func captureSpecEvents(t *testing.T, specName string) func() []events.Event {
+	t.Helper()
+	var mu sync.Mutex
+	var got []events.Event
+	unsubscribe := events.Subscribe(func(e events.Event) {
+		if e.Spec != specName {
+			return
+		}
+		mu.Lock()
+		got = append(got, e)
+		mu.Unlock()
+	})
+	t.Cleanup(unsubscribe)
+	return func() []events.Event {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]events.Event(nil), got...)
+	}
+}
+
+func TestExecuteStage_PublishesEvents(t *testing.T) {
+	tests := map[string]struct {
+		failures   int
+		maxRetries int
+		want       []string
+		wantStatus string
+	}{
+		"passes first attempt": {
+			failures:   0,
+			maxRetries: 1,
+			want:       []string{"stage.started:1", "stage.completed:1"},
+			wantStatus: events.StatusCompleted,
+		},
+		"retries after validation failure": {
+			failures:   1,
+			maxRetries: 1,
+			want:       []string{"stage.started:1", "validation.failed:1", "stage.retried:2", "stage.completed:2"},
+			wantStatus: events.StatusCompleted,
+		},
+		"retries exhausted": {
+			failures:   2,
+			maxRetries: 1,
+			want:       []string{"stage.started:1", "validation.failed:1", "stage.retried:2", "validation.failed:2", "stage.completed:2"},
+			wantStatus: events.StatusFailed,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			specName := "001-events-" + name
+			collected := captureSpecEvents(t, specName)
+
+			executor := &Executor{
+				Claude:     testClaudeExecutor(t),
+				StateDir:   t.TempDir(),
+				SpecsDir:   t.TempDir(),
+				MaxRetries: tt.maxRetries,
+			}
+			calls := 0
+			validateFunc := func(string) error {
+				calls++
+				if calls <= tt.failures {
+					return errors.New("missing field: summary")
+				}
+				return nil
+			}
+
+			_, _ = executor.ExecuteStage(specName, StagePlan, "/autospec.plan", validateFunc)
+
+			got := collected()
+			var seq []string
+			for _, e := range got {
+				assert.Equal(t, "plan", e.Stage)
+				assert.Equal(t, tt.maxRetries+1, e.MaxAttempts)
+				seq = append(seq, fmt.Sprintf("%s:%d", e.Type, e.Attempt))
+			}
+			assert.Equal(t, tt.want, seq)
+
+			require.NotEmpty(t, got)
+			last := got[len(got)-1]
+			assert.Equal(t, tt.wantStatus, last.Status)
+			if tt.failures > 0 {
+				assert.Equal(t, []string{"missing field: summary"}, got[1].Errors)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/ariel-frischer/autospec/internal/prereqs"
 	"github.com/ariel-frischer/autospec/internal/retry"
 	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/validation"
 )
 
 // StageExecutor handles specify, plan, and tasks stage execution.
@@ -19,18 +20,20 @@ import (
 // Each stage transforms artifacts: specify creates spec.yaml, plan creates plan.yaml,
 // tasks creates tasks.yaml.
 type StageExecutor struct {
-	executor               *Executor // Underlying executor for Claude command execution
-	specsDir               string    // Base directory for spec storage (e.g., "specs/")
-	debug                  bool      // Enable debug logging
-	enableRiskAssessment   bool      // Inject risk assessment instructions in plan command
-	enableEarsRequirements bool      // Inject EARS requirements instructions in specify command
+	executor               *Executor                 // Underlying executor for Claude command execution
+	specsDir               string                    // Base directory for spec storage (e.g., "specs/")
+	debug                  bool                      // Enable debug logging
+	enableRiskAssessment   bool                      // Inject risk assessment instructions in plan command
+	enableEarsRequirements bool                      // Inject EARS requirements instructions in specify command
+	earsTestKinds          []validation.EarsTestKind // Emit test tasks for EARS requirements of these kinds
 }
 
 // StageExecutorOptions holds optional configuration for StageExecutor.
 type StageExecutorOptions struct {
-	Debug                  bool // Enable debug logging
-	EnableRiskAssessment   bool // Inject risk assessment instructions in plan command
-	EnableEarsRequirements bool // Inject EARS requirements instructions in specify command
+	Debug                  bool                      // Enable debug logging
+	EnableRiskAssessment   bool                      // Inject risk assessment instructions in plan command
+	EnableEarsRequirements bool                      // Inject EARS requirements instructions in specify command
+	EarsTestKinds          []validation.EarsTestKind // Emit test tasks for EARS requirements of these kinds
 }
 
 // NewStageExecutor creates a new StageExecutor with the given dependencies.
@@ -53,6 +56,7 @@ func NewStageExecutorWithOptions(executor *Executor, specsDir string, opts Stage
 		debug:                  opts.Debug,
 		enableRiskAssessment:   opts.EnableRiskAssessment,
 		enableEarsRequirements: opts.EnableEarsRequirements,
+		earsTestKinds:          opts.EarsTestKinds,
 	}
 }
 
@@ -70,12 +74,25 @@ func (s *StageExecutor) ExecuteSpecify(featureDescription string) (string, error
 	s.debugLog("ExecuteSpecify called with description: %s", featureDescription)
 	s.resetSpecifyRetryState()
 
-	result, err := s.runSpecifyStage(featureDescription)
+	command, err := buildSpecifyCommand(featureDescription, s.enableEarsRequirements)
+	if err != nil {
+		return "", fmt.Errorf("building specify command: %w", err)
+	}
+	result, err := s.runSpecifyStage(command)
 	if err != nil {
 		return "", s.formatSpecifyError(result, err)
 	}
 
-	return s.detectAndValidateSpec()
+	specName, err := s.detectAndValidateSpec()
+	if err != nil {
+		return "", err
+	}
+
+	// Specify runs before the spec exists, so attribute usage once it is known
+	s.executor.recordUsage(specName, retry.StageUsageScope(string(StageSpecify)), result.Usage, result.Agent)
+	s.executor.stampGenerator(specName, StageSpecify, result.Agent)
+	s.executor.stampSchemaModeline(specName, StageSpecify)
+	return specName, nil
 }
 
 // resetSpecifyRetryState clears retry state before a new specify run
@@ -85,10 +102,15 @@ func (s *StageExecutor) resetSpecifyRetryState() {
 	}
 }
 
-// runSpecifyStage executes the specify stage command
-func (s *StageExecutor) runSpecifyStage(featureDescription string) (*StageResult, error) {
+// buildSpecifyCommand builds the specify command with EARS and project schema instructions.
+func buildSpecifyCommand(featureDescription string, enableEars bool) (string, error) {
 	command := fmt.Sprintf("/autospec.specify \"%s\"", featureDescription)
-	command = InjectEarsInstructions(command, s.enableEarsRequirements)
+	command = InjectEarsInstructions(command, enableEars)
+	return InjectSchemaExtension(command, validation.ArtifactTypeSpec)
+}
+
+// runSpecifyStage executes the specify stage command
+func (s *StageExecutor) runSpecifyStage(command string) (*StageResult, error) {
 	validateFunc := MakeSpecSchemaValidatorWithDetection(s.specsDir)
 	return s.executor.ExecuteStage("", StageSpecify, command, validateFunc)
 }
@@ -167,6 +189,10 @@ func (s *StageExecutor) ExecuteTasks(specNameArg string, prompt string) error {
 		return s.formatStageError("tasks", result, err)
 	}
 
+	if err := s.emitEarsTestTasks(specName); err != nil {
+		return err
+	}
+
 	s.debugLog("ExecuteTasks completed successfully")
 	return nil
 }
@@ -193,6 +219,10 @@ func (s *StageExecutor) buildRenderedPlanCommand(prompt string) (string, error)
 		return "", err
 	}
 	command := InjectRiskAssessment(rendered, s.enableRiskAssessment)
+	command, err = InjectSchemaExtension(command, validation.ArtifactTypePlan)
+	if err != nil {
+		return "", err
+	}
 	if prompt != "" {
 		command = fmt.Sprintf("%s\n\n## User Input\n\n%s", command, prompt)
 	}
@@ -205,10 +235,15 @@ func (s *StageExecutor) buildRenderedTasksCommand(prompt string) (string, error)
 	if err != nil {
 		return "", err
 	}
+	command := InjectEarsTestTaskInstructions(rendered, s.earsTestKinds)
+	command, err = InjectSchemaExtension(command, validation.ArtifactTypeTasks)
+	if err != nil {
+		return "", err
+	}
 	if prompt != "" {
-		return fmt.Sprintf("%s\n\n## User Input\n\n%s", rendered, prompt), nil
+		return fmt.Sprintf("%s\n\n## User Input\n\n%s", command, prompt), nil
 	}
-	return rendered, nil
+	return command, nil
 }
 
 // formatStageError formats an error from a stage execution.
@@ -222,12 +257,26 @@ func (s *StageExecutor) formatStageError(stageName string, result *StageResult,
 		stageName, totalAttempts, result.RetryCount, err)
 }
 
-// buildRenderedAuxCommand renders an auxiliary command template (clarify, analyze, checklist).
+// auxCommandArtifacts maps auxiliary commands to the artifact type they write,
+// for project schema extension instructions.
+var auxCommandArtifacts = map[string]validation.ArtifactType{
+	"autospec.clarify":   validation.ArtifactTypeSpec,
+	"autospec.analyze":   validation.ArtifactTypeAnalysis,
+	"autospec.checklist": validation.ArtifactTypeChecklist,
+	"autospec.review":    validation.ArtifactTypeReview,
+}
+
+// buildRenderedAuxCommand renders an auxiliary command template (clarify, analyze, checklist, review).
 func (s *StageExecutor) buildRenderedAuxCommand(commandName, prompt string) (string, error) {
 	rendered, err := s.computeAndRenderCommand(commandName)
 	if err != nil {
 		return "", err
 	}
+	if artifactType, ok := auxCommandArtifacts[commandName]; ok {
+		if rendered, err = InjectSchemaExtension(rendered, artifactType); err != nil {
+			return "", err
+		}
+	}
 	if prompt != "" {
 		return fmt.Sprintf("%s\n\n## User Input\n\n%s", rendered, prompt), nil
 	}
@@ -239,7 +288,10 @@ func (s *StageExecutor) buildRenderedAuxCommand(commandName, prompt string) (str
 func (s *StageExecutor) ExecuteConstitution(prompt string) error {
 	s.debugLog("ExecuteConstitution called with prompt: %s", prompt)
 
-	command := s.buildCommand("/autospec.constitution", prompt)
+	command, err := InjectSchemaExtension(s.buildCommand("/autospec.constitution", prompt), validation.ArtifactTypeConstitution)
+	if err != nil {
+		return fmt.Errorf("building constitution command: %w", err)
+	}
 	s.printExecuting("/autospec.constitution", prompt)
 
 	// Derive project directory from specsDir (parent of specs/)
@@ -342,6 +394,27 @@ func (s *StageExecutor) ExecuteAnalyze(specName string, prompt string) error {
 	return nil
 }
 
+// ExecuteReview runs the review stage with optional prompt.
+// Review runs a separate agent session against the implementation diff and
+// spec acceptance criteria, retrying until review.yaml passes schema validation.
+func (s *StageExecutor) ExecuteReview(specName string, prompt string) error {
+	s.debugLog("ExecuteReview called for spec: %s, prompt: %s", specName, prompt)
+
+	command, err := s.buildRenderedAuxCommand("autospec.review", prompt)
+	if err != nil {
+		return fmt.Errorf("building review command: %w", err)
+	}
+	s.printExecuting("/autospec.review", prompt)
+
+	result, err := s.executor.ExecuteStage(specName, StageReview, command, ValidateReviewSchema)
+	if err != nil {
+		return s.formatStageError("review", result, err)
+	}
+
+	fmt.Printf("\n✓ Review written to specs/%s/review.yaml\n", specName)
+	return nil
+}
+
 // buildCommand constructs a command with optional prompt.
 func (s *StageExecutor) buildCommand(baseCmd, prompt string) string {
 	if prompt != "" {
@@ -388,18 +461,13 @@ func (s *StageExecutor) getOptionsForStage(stageName string) prereqs.Options {
 // computeAndRenderCommand gets a command template and renders it with prereqs context.
 // Returns the rendered command string ready for execution.
 func (s *StageExecutor) computeAndRenderCommand(commandName string) (string, error) {
-	content, err := commands.GetTemplate(commandName)
-	if err != nil {
-		return "", fmt.Errorf("loading template %s: %w", commandName, err)
-	}
-
 	opts := s.getOptionsForStage(commandName)
 	ctx, err := prereqs.ComputeContext(opts)
 	if err != nil {
 		return "", fmt.Errorf("computing prereqs context: %w", err)
 	}
 
-	rendered, err := commands.RenderAndValidate(commandName, content, ctx)
+	rendered, err := commands.RenderCommand(commandName, ctx)
 	if err != nil {
 		return "", fmt.Errorf("rendering template: %w", err)
 	}
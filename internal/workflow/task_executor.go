@@ -10,6 +10,7 @@ import (
 
 	"github.com/ariel-frischer/autospec/internal/commands"
 	"github.com/ariel-frischer/autospec/internal/prereqs"
+	"github.com/ariel-frischer/autospec/internal/retry"
 	"github.com/ariel-frischer/autospec/internal/validation"
 )
 
@@ -137,11 +138,6 @@ func (te *TaskExecutor) buildTaskCommand(taskID, prompt string) (string, error)
 
 // computeAndRenderImplementCommand gets and renders the implement template.
 func (te *TaskExecutor) computeAndRenderImplementCommand() (string, error) {
-	content, err := commands.GetTemplate("autospec.implement")
-	if err != nil {
-		return "", fmt.Errorf("loading template autospec.implement: %w", err)
-	}
-
 	opts := prereqs.Options{
 		SpecsDir:     te.specsDir,
 		RequireTasks: true,
@@ -151,7 +147,7 @@ func (te *TaskExecutor) computeAndRenderImplementCommand() (string, error) {
 		return "", fmt.Errorf("computing prereqs context: %w", err)
 	}
 
-	rendered, err := commands.RenderAndValidate("autospec.implement", content, ctx)
+	rendered, err := commands.RenderCommand("autospec.implement", ctx)
 	if err != nil {
 		return "", fmt.Errorf("rendering template: %w", err)
 	}
@@ -170,6 +166,7 @@ func (te *TaskExecutor) executeTaskWithValidation(specName, taskID, command stri
 			return te.validateTaskCompleted(specDir, taskID)
 		},
 	)
+	te.executor.recordUsage(specName, retry.TaskUsageScope(taskID), result.Usage, result.Agent)
 	if err != nil {
 		if result.Exhausted {
 			fmt.Printf("\nTask %s paused.\n", taskID)
@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/ariel-frischer/autospec/internal/events"
 )
 
 // Manager defines the interface for worktree CRUD operations.
@@ -182,7 +184,17 @@ func (m *DefaultManager) CreateWithOptions(
 		return nil, fmt.Errorf("setup failed: %w", outcome.Error)
 	}
 
-	return m.saveWorktreeToState(state, name, worktreePath, branch, outcome)
+	wt, err := m.saveWorktreeToState(state, name, worktreePath, branch, outcome)
+	if err != nil {
+		return nil, err
+	}
+
+	events.Publish(events.Event{
+		Type:   events.WorktreeCreated,
+		Path:   worktreePath,
+		Branch: branch,
+	})
+	return wt, nil
 }
 
 // copyDirsToWorktree copies configured directories to the worktree.
@@ -3,6 +3,7 @@ package yaml
 import (
 	"fmt"
 	"io"
+	"os"
 	"strconv"
 	"strings"
 
@@ -124,6 +125,7 @@ var ValidArtifactTypes = []string{
 	"checklist",
 	"analysis",
 	"constitution",
+	"review",
 }
 
 // IsValidArtifactType returns true if the artifact type is valid.
@@ -135,3 +137,63 @@ func IsValidArtifactType(artifactType string) bool {
 	}
 	return false
 }
+
+// SetMetaGenerator sets _meta.generator in a YAML artifact file.
+// The file is edited line by line so comments and formatting are preserved.
+// Adds the generator key if missing; files without a top-level _meta section
+// are left unchanged.
+func SetMetaGenerator(path, generator string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	updated, ok := setMetaGenerator(string(data), generator)
+	if !ok {
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(updated), 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// setMetaGenerator returns content with _meta.generator set to generator.
+// Returns false if content has no top-level _meta mapping.
+func setMetaGenerator(content, generator string) (string, bool) {
+	lines := strings.Split(content, "\n")
+	metaIdx := -1
+	for i, line := range lines {
+		// Only block-style mappings are edited; flow style (_meta: {...}) is skipped
+		rest, found := strings.CutPrefix(line, "_meta:")
+		if rest = strings.TrimSpace(rest); found && (rest == "" || strings.HasPrefix(rest, "#")) {
+			metaIdx = i
+			break
+		}
+	}
+	if metaIdx < 0 {
+		return content, false
+	}
+
+	value := strconv.Quote(generator)
+	indent := "  "
+	for i := metaIdx + 1; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if len(trimmed) == len(line) {
+			break // Next top-level key: end of _meta block
+		}
+		indent = line[:len(line)-len(trimmed)]
+		if strings.HasPrefix(trimmed, "generator:") {
+			lines[i] = indent + "generator: " + value
+			return strings.Join(lines, "\n"), true
+		}
+	}
+
+	// No generator key: insert it as the first entry of the block
+	lines = append(lines[:metaIdx+1], append([]string{indent + "generator: " + value}, lines[metaIdx+1:]...)...)
+	return strings.Join(lines, "\n"), true
+}
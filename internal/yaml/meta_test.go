@@ -4,6 +4,8 @@
 package yaml
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -346,6 +348,7 @@ func TestValidArtifactTypes_Coverage(t *testing.T) {
 		"checklist",
 		"analysis",
 		"constitution",
+		"review",
 	}
 
 	assert.Len(t, ValidArtifactTypes, len(expectedTypes), "ValidArtifactTypes length mismatch")
@@ -370,3 +373,64 @@ func TestExtractMeta_EmptyInput(t *testing.T) {
 	assert.Empty(t, meta.Version)
 	assert.Empty(t, meta.ArtifactType)
 }
+
+func TestSetMetaGenerator(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		content string
+		want    string
+		wantOK  bool
+	}{
+		"inserts generator when missing": {
+			content: "_meta:\n  version: \"1.0.0\"\nfeature:\n  branch: x\n",
+			want:    "_meta:\n  generator: \"autospec/codex\"\n  version: \"1.0.0\"\nfeature:\n  branch: x\n",
+			wantOK:  true,
+		},
+		"replaces existing generator": {
+			content: "_meta:\n    version: \"1.0.0\"\n    generator: \"autospec\"\n",
+			want:    "_meta:\n    version: \"1.0.0\"\n    generator: \"autospec/codex\"\n",
+			wantOK:  true,
+		},
+		"ignores generator outside _meta": {
+			content: "_meta:\n  version: \"1.0.0\"\nother:\n  generator: keep\n",
+			want:    "_meta:\n  generator: \"autospec/codex\"\n  version: \"1.0.0\"\nother:\n  generator: keep\n",
+			wantOK:  true,
+		},
+		"no _meta section": {
+			content: "feature:\n  branch: x\n",
+			want:    "feature:\n  branch: x\n",
+		},
+		"flow style _meta is skipped": {
+			content: "_meta: {version: \"1.0.0\"}\n",
+			want:    "_meta: {version: \"1.0.0\"}\n",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := setMetaGenerator(tt.content, "autospec/codex")
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSetMetaGenerator_File(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "plan.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("_meta:\n  version: \"1.0.0\"\n"), 0o644))
+
+	require.NoError(t, SetMetaGenerator(path, "autospec/gemini"))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	meta, err := ExtractMetaFromBytes(data)
+	require.NoError(t, err)
+	assert.Equal(t, "autospec/gemini", meta.Generator)
+	assert.Equal(t, "1.0.0", meta.Version)
+
+	assert.Error(t, SetMetaGenerator(filepath.Join(t.TempDir(), "missing.yaml"), "autospec/gemini"))
+}
@@ -0,0 +1,67 @@
+package yaml
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// SchemaModelinePrefix starts the comment yaml-language-server reads to find
+// the JSON Schema of a document.
+const SchemaModelinePrefix = "# yaml-language-server: $schema="
+
+// SetSchemaModeline sets the yaml-language-server modeline of a YAML file to
+// schemaRef (a URL or a path relative to the file). An existing modeline in
+// the leading comment block is replaced; otherwise one is added as the first
+// line. The rest of the file is left untouched.
+func SetSchemaModeline(path, schemaRef string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	updated := WithSchemaModeline(string(data), schemaRef)
+	if updated == string(data) {
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(updated), 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// WithSchemaModeline returns content with its modeline set to schemaRef.
+func WithSchemaModeline(content, schemaRef string) string {
+	modeline := SchemaModelinePrefix + schemaRef
+	lines := strings.Split(content, "\n")
+	if i := schemaModelineIndex(lines); i >= 0 {
+		lines[i] = modeline
+		return strings.Join(lines, "\n")
+	}
+	return modeline + "\n" + content
+}
+
+// SchemaModelineRef returns the schema reference of the modeline in the
+// leading comment block of content, or "" if there is none.
+func SchemaModelineRef(content string) string {
+	lines := strings.Split(content, "\n")
+	if i := schemaModelineIndex(lines); i >= 0 {
+		return strings.TrimPrefix(strings.TrimSpace(lines[i]), SchemaModelinePrefix)
+	}
+	return ""
+}
+
+// schemaModelineIndex returns the line of the modeline, or -1. Only the
+// leading comment block is searched.
+func schemaModelineIndex(lines []string) int {
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			break
+		}
+		if strings.HasPrefix(trimmed, SchemaModelinePrefix) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,74 @@
+// Package yaml_test tests yaml-language-server schema modelines.
+// Related: internal/yaml/modeline.go
+// Tags: yaml, modeline, json-schema, editor
+package yaml
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetSchemaModeline(t *testing.T) {
+	t.Parallel()
+
+	const ref = "../../.autospec/schemas/json/spec.schema.json"
+	modeline := SchemaModelinePrefix + ref
+
+	tests := map[string]struct {
+		content string
+		want    string
+	}{
+		"adds modeline": {
+			content: "feature:\n  branch: x\n",
+			want:    modeline + "\nfeature:\n  branch: x\n",
+		},
+		"keeps leading comments": {
+			content: "# Feature spec\nfeature: {}\n",
+			want:    modeline + "\n# Feature spec\nfeature: {}\n",
+		},
+		"replaces existing modeline": {
+			content: "# Feature spec\n" + SchemaModelinePrefix + "old.json\nfeature: {}\n",
+			want:    "# Feature spec\n" + modeline + "\nfeature: {}\n",
+		},
+		"ignores modeline-like text after the header": {
+			content: "feature: {}\nnotes: |\n  " + SchemaModelinePrefix + "x.json\n",
+			want:    modeline + "\nfeature: {}\nnotes: |\n  " + SchemaModelinePrefix + "x.json\n",
+		},
+		"up to date": {
+			content: modeline + "\nfeature: {}\n",
+			want:    modeline + "\nfeature: {}\n",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, WithSchemaModeline(tt.content, ref))
+		})
+	}
+}
+
+func TestSchemaModelineRef(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "../dag.schema.json", SchemaModelineRef("# DAG\n"+SchemaModelinePrefix+"../dag.schema.json\nlayers: []\n"))
+	assert.Empty(t, SchemaModelineRef("layers: []\n# "+SchemaModelinePrefix+"x.json\n"))
+}
+
+func TestSetSchemaModeline_File(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "plan.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("plan:\n  summary: x\n"), 0o644))
+
+	require.NoError(t, SetSchemaModeline(path, "plan.schema.json"))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, SchemaModelinePrefix+"plan.schema.json\nplan:\n  summary: x\n", string(data))
+
+	assert.Error(t, SetSchemaModeline(filepath.Join(t.TempDir(), "missing.yaml"), "x.json"))
+}